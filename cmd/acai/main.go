@@ -10,23 +10,38 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	annotationapp "github.com/felixgeelhaar/acai/internal/application/annotation"
 	authapp "github.com/felixgeelhaar/acai/internal/application/auth"
 	embeddingapp "github.com/felixgeelhaar/acai/internal/application/embedding"
 	exportapp "github.com/felixgeelhaar/acai/internal/application/export"
 	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 	infraauth "github.com/felixgeelhaar/acai/internal/infrastructure/auth"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/backup"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/cache"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/config"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/events"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventstream"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/granola"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/hybrid"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/llm"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/localcache"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/migrate"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/notepush"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
 	infraPolicy "github.com/felixgeelhaar/acai/internal/infrastructure/policy"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/projection"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/resilience"
+	syncmgr "github.com/felixgeelhaar/acai/internal/infrastructure/sync"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/taskpush"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/tasksink"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
 	"github.com/felixgeelhaar/acai/internal/interfaces/cli"
 	mcpiface "github.com/felixgeelhaar/acai/internal/interfaces/mcp"
 	_ "github.com/mattn/go-sqlite3"
@@ -63,17 +78,41 @@ func main() {
 
 	var repo domain.Repository
 	var granolaClient *granola.Client
+	var granolaRepo *granola.Repository
+	var localCacheRepo *localcache.Repository
 
-	switch dataSource {
-	case "local_cache":
-		cachePath := resolveLocalCachePath(cfg, homeDir)
+	// Read-side SQLite database: the API response cache and the projection
+	var cacheDB *sql.DB
+	var databases []*migrate.Migrator
+	if cfg.Cache.Enabled || cfg.Projection.Enabled {
+		cacheDBPath := filepath.Join(cfg.Cache.Dir, "cache.db")
+		if err := os.MkdirAll(cfg.Cache.Dir, 0o700); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: cannot create cache dir: %v\n", err)
+		} else if db, err := sql.Open("sqlite3", cacheDBPath); err == nil {
+			cacheDB = db
+			defer func() { _ = cacheDB.Close() }()
+			migrator := migrate.NewMigrator("cache.db", cacheDBPath, cacheDB, cache.Migrations, projection.Migrations)
+			migrateOnStartup(migrator)
+			databases = append(databases, migrator)
+		}
+	}
+
+	// "hybrid" builds both repositories below and merges them.
+	if dataSource == "local_cache" || dataSource == "hybrid" {
+		cachePath, err := resolveLocalCachePath(cfg, homeDir)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: %v\nSet ACAI_GRANOLA_CACHE_PATH or ACAI_GRANOLA_CACHE_ROOTS to point acai at the Granola desktop cache.\n", err)
+			cachePath = filepath.Join(localCacheRoots(cfg, homeDir)[0], "cache-v3.json")
+		}
 		reader := localcache.NewReader(cachePath)
-		repo = localcache.NewRepository(reader)
+		localCacheRepo = localcache.NewRepository(reader)
+		repo = localCacheRepo
+	}
 
-	default: // "api"
+	if dataSource != "local_cache" { // "api" or "hybrid"
 		httpClient := &http.Client{Timeout: cfg.Resilience.Timeout}
 		granolaClient = granola.NewClient(cfg.Granola.APIURL, httpClient, cfg.Granola.APIToken)
-		granolaRepo := granola.NewRepository(granolaClient)
+		granolaRepo = granola.NewRepository(granolaClient)
 
 		resilientRepo := resilience.NewResilientRepository(granolaRepo, resilience.Config{
 			Timeout:          cfg.Resilience.Timeout,
@@ -90,20 +129,12 @@ func main() {
 		defer func() { _ = resilientRepo.Close() }()
 
 		repo = resilientRepo
-		if cfg.Cache.Enabled {
-			cacheDir := cfg.Cache.Dir
-			if err := os.MkdirAll(cacheDir, 0o700); err != nil {
-				_, _ = fmt.Fprintf(os.Stderr, "Warning: cannot create cache dir: %v\n", err)
-			} else {
-				dbPath := filepath.Join(cacheDir, "cache.db")
-				db, err := sql.Open("sqlite3", dbPath)
-				if err == nil {
-					cachedRepo, cacheErr := cache.NewCachedRepository(resilientRepo, db, cfg.Cache.TTL)
-					if cacheErr == nil {
-						repo = cachedRepo
-						defer func() { _ = db.Close() }()
-					}
-				}
+		// The TTL cache also backs the projection, which falls back to it
+		// while unbuilt or stale.
+		if cfg.Cache.Enabled && cacheDB != nil {
+			cachedRepo, cacheErr := cache.NewCachedRepository(resilientRepo, cacheDB, cfg.Cache.TTL)
+			if cacheErr == nil {
+				repo = cachedRepo
 			}
 		}
 
@@ -113,6 +144,22 @@ func main() {
 		}
 	}
 
+	if dataSource == "hybrid" {
+		repo = hybrid.NewRepository(repo, localCacheRepo, hybridPrecedence(cfg))
+	}
+
+	// Read model: a SQLite projection kept current by sync answers list,
+	// search and stats queries once built
+	var projectionRepo *projection.Repository
+	if cfg.Projection.Enabled && cacheDB != nil {
+		if projected, err := projection.NewRepository(repo, cacheDB, cfg.Projection.MaxAge); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: cannot init projection: %v\n", err)
+		} else {
+			projectionRepo = projected
+			repo = projected
+		}
+	}
+
 	// Local store (SQLite for write-side: notes, action item overrides, outbox)
 	localDir := cfg.Cache.Dir // Reuse cache dir for local store
 	if err := os.MkdirAll(localDir, 0o700); err != nil {
@@ -125,26 +172,93 @@ func main() {
 		localDB = nil
 	} else {
 		defer func() { _ = localDB.Close() }()
-		if err := localstore.InitSchema(localDB); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Warning: cannot init local store schema: %v\n", err)
-		}
+		migrator := migrate.NewMigrator("local.db", localDBPath, localDB, localstore.Migrations)
+		migrateOnStartup(migrator)
+		databases = append(databases, migrator)
+	}
+
+	// Content hashes let API sync detect transcript and summary changes, and
+	// the synced document list lets cache sync detect deletions across runs
+	if granolaRepo != nil && localDB != nil {
+		granolaRepo.SetHashStore(granola.NewSQLiteHashStore(localDB))
+	}
+	if localCacheRepo != nil && localDB != nil {
+		localCacheRepo.SetDocStore(localcache.NewSQLiteDocStore(localDB))
 	}
 
 	// Local store repositories (guarded against nil db)
 	var noteRepo *localstore.NoteRepository
 	var writeRepo *localstore.WriteRepository
+	var summaryRepo *localstore.SummaryRepository
 	if localDB != nil {
 		noteRepo = localstore.NewNoteRepository(localDB)
 		writeRepo = localstore.NewWriteRepository(localDB)
+		// Locally generated summaries fill in for meetings Granola has none for
+		summaryRepo = localstore.NewSummaryRepository(localDB)
+		repo = localstore.NewSummaryOverlay(repo, summaryRepo)
 	}
 
-	// Event infrastructure: notifier → dispatcher → outbox decorator
+	// Event infrastructure: notifier → dispatcher → event store → outbox → webhook → note push → task push decorators
+	taskSinks := buildTaskSinks(cfg.Tasks)
 	notifier := events.NewMCPNotifier()
 	innerDispatcher := events.NewDispatcher(notifier)
 	var dispatcher domain.EventDispatcher = innerDispatcher
+	var eventStore *eventstore.SQLiteStore
+	var eventStream http.Handler
+	var outboxRelay *outbox.Relay
+	var outboxInspector outbox.Inspector
+	var webhookSubscriptions *webhook.SQLiteRepository
+	var webhookSink *webhook.Sink
+	var pushConflicts annotation.PushConflictReader
 	if localDB != nil {
+		eventStore = eventstore.NewSQLiteStore(localDB, eventcodec.Default())
 		outboxStore := outbox.NewSQLiteStore(localDB)
-		dispatcher = outbox.NewDispatcher(innerDispatcher, outboxStore)
+		broker := eventstream.NewBroker()
+		webhookSubscriptions = webhook.NewSQLiteRepository(localDB)
+		webhookSink = webhook.NewSink(webhookSubscriptions, nil)
+		dispatcher = webhook.NewDispatcher(
+			outbox.NewDispatcher(eventstore.NewDispatcher(innerDispatcher, eventStore, broker), outboxStore),
+			webhookSubscriptions, outboxStore, eventcodec.Default(),
+		)
+		if cfg.MCP.EventsToken != "" {
+			eventStream = eventstream.NewHandler(broker, eventStore, cfg.MCP.EventsToken)
+		}
+		outboxInspector = outboxStore
+		outboxRelay = outbox.NewRelay(outboxStore, buildOutboxSinks(cfg, granolaClient), outbox.RelayConfig{
+			Interval:       cfg.Outbox.RelayInterval,
+			BatchSize:      100,
+			MaxAttempts:    cfg.Outbox.MaxAttempts,
+			InitialBackoff: cfg.Outbox.InitialBackoff,
+			MaxBackoff:     cfg.Outbox.MaxBackoff,
+		})
+		outboxRelay.Route(webhookSink)
+		if cfg.Outbox.GranolaNotesPath != "" {
+			if granolaClient != nil {
+				pushStore := notepush.NewSQLiteStore(localDB)
+				pushConflicts = pushStore
+				dispatcher = notepush.NewDispatcher(dispatcher, outboxStore)
+				outboxRelay.Route(notepush.NewSink(granolaClient, cfg.Outbox.GranolaNotesPath, noteRepo, pushStore))
+			} else {
+				_, _ = fmt.Fprintln(os.Stderr, "Warning: pushing notes to Granola requires the API data source; skipping")
+			}
+		}
+		if len(taskSinks) > 0 {
+			dispatcher = taskpush.NewDispatcher(dispatcher, outboxStore)
+		}
+	}
+
+	// Live reloads of the desktop cache file while serving
+	var cacheWatcher *localcache.Watcher
+	if localCacheRepo != nil && cfg.Granola.CacheWatch > 0 {
+		// The watcher bypasses repo.Sync, so its events are projected here.
+		var watchDispatcher domain.EventDispatcher = dispatcher
+		if projectionRepo != nil {
+			watchDispatcher = projection.NewDispatcher(dispatcher, projectionRepo)
+		}
+		cacheWatcher = localcache.NewWatcher(localCacheRepo, watchDispatcher, localcache.WatchConfig{
+			Interval: cfg.Granola.CacheWatch,
+			Debounce: localcache.DefaultWatchConfig().Debounce,
+		})
 	}
 
 	// --- Application Layer (Use Cases) ---
@@ -155,27 +269,74 @@ func main() {
 	searchTranscripts := meetingapp.NewSearchTranscripts(repo)
 	getActionItems := meetingapp.NewGetActionItems(repo)
 	getMeetingStats := meetingapp.NewGetMeetingStats(repo)
-	syncMeetings := meetingapp.NewSyncMeetings(repo)
-	exportMeeting := exportapp.NewExportMeeting(repo)
+	var localState domain.LocalStateCounter
+	if localDB != nil {
+		localState = localstore.NewLocalStateCounter(localDB)
+	}
+	syncMeetings := meetingapp.NewSyncMeetings(repo, localState)
+	// While serving, sync twice per projection max age so reads keep coming
+	// from the projection instead of falling through to the source.
+	var syncManager *syncmgr.Manager
+	if projectionRepo != nil && cfg.Projection.MaxAge > 0 {
+		syncManager = syncmgr.NewManager(syncMeetings, dispatcher, cfg.Projection.MaxAge/2)
+	}
+	var backupService *backup.Service
+	if localDB != nil {
+		configPath, _ := config.DefaultConfigPath() // empty: config is not archived
+		backupService = backup.NewService(localDB, configPath)
+	}
+	var getMeetingHistory *meetingapp.GetMeetingHistory
+	if eventStore != nil {
+		getMeetingHistory = meetingapp.NewGetMeetingHistory(eventStore)
+	}
+	// Transcript exports render agent notes inline when available
+	var exportNotes annotation.NoteRepository
+	if noteRepo != nil {
+		exportNotes = noteRepo
+	}
+	exportMeeting := exportapp.NewExportMeeting(repo, exportNotes)
 	login := authapp.NewLogin(authService)
 	checkStatus := authapp.NewCheckStatus(authService)
 	logout := authapp.NewLogout(authService)
 
 	// Write use cases (require local DB)
 	var addNote *annotationapp.AddNote
+	var updateNote *annotationapp.UpdateNote
+	var getNoteHistory *annotationapp.GetNoteHistory
 	var listNotes *annotationapp.ListNotes
 	var deleteNote *annotationapp.DeleteNote
 	var completeActionItem *meetingapp.CompleteActionItem
 	var updateActionItem *meetingapp.UpdateActionItem
 	var exportEmbeddings *embeddingapp.ExportEmbeddings
+	var pushActionItem *meetingapp.PushActionItem
+	var generateSummary *meetingapp.GenerateSummary
 	if localDB != nil {
 		addNote = annotationapp.NewAddNote(noteRepo, repo, dispatcher)
-		listNotes = annotationapp.NewListNotes(noteRepo)
+		updateNote = annotationapp.NewUpdateNote(noteRepo, dispatcher)
+		getNoteHistory = annotationapp.NewGetNoteHistory(noteRepo)
+		listNotes = annotationapp.NewListNotes(noteRepo, pushConflicts)
 		deleteNote = annotationapp.NewDeleteNote(noteRepo, dispatcher)
 		completeActionItem = meetingapp.NewCompleteActionItem(repo, writeRepo, dispatcher)
 		updateActionItem = meetingapp.NewUpdateActionItem(repo, writeRepo, dispatcher)
 		exportEmbeddings = embeddingapp.NewExportEmbeddings(repo, noteRepo)
+		taskLinks := localstore.NewTaskLinkRepository(localDB)
+		pushActionItem = meetingapp.NewPushActionItem(repo, writeRepo, taskLinks, taskSinks)
+		if len(taskSinks) > 0 {
+			outboxRelay.Route(taskpush.NewSink(pushActionItem))
+		}
+		if cfg.LLM.Enabled() {
+			provider := llm.NewOpenAIProvider(cfg.LLM.BaseURL, cfg.LLM.Model, cfg.LLM.APIKey, nil)
+			generateSummary = meetingapp.NewGenerateSummary(repo, summaryRepo, provider, dispatcher)
+		}
+	}
+
+	// Cross-meeting action item inbox (applies local overrides when available)
+	var actionItemOverrides domain.WriteRepository
+	if writeRepo != nil {
+		actionItemOverrides = writeRepo
 	}
+	listActionItems := meetingapp.NewListActionItems(repo, actionItemOverrides, cfg.User.Name)
+	exportActionItems := exportapp.NewExportActionItems(listActionItems)
 
 	// --- Interfaces Layer ---
 
@@ -197,13 +358,19 @@ func main() {
 		GetTranscript:      getTranscript,
 		SearchTranscripts:  searchTranscripts,
 		GetActionItems:     getActionItems,
+		ListActionItems:    listActionItems,
+		ExportActionItems:  exportActionItems,
 		GetMeetingStats:    getMeetingStats,
+		GetMeetingHistory:  getMeetingHistory,
 		AddNote:            addNote,
+		UpdateNote:         updateNote,
 		ListNotes:          listNotes,
 		DeleteNote:         deleteNote,
 		CompleteActionItem: completeActionItem,
 		UpdateActionItem:   updateActionItem,
 		ExportEmbeddings:   exportEmbeddings,
+		GenerateSummary:    generateSummary,
+		Outbox:             outboxInspector,
 		PolicyEngine:       policyEngine,
 	})
 
@@ -214,22 +381,39 @@ func main() {
 		GetTranscript:      getTranscript,
 		SearchTranscripts:  searchTranscripts,
 		GetActionItems:     getActionItems,
+		ListActionItems:    listActionItems,
 		GetMeetingStats:    getMeetingStats,
+		GetMeetingHistory:  getMeetingHistory,
 		SyncMeetings:       syncMeetings,
 		ExportMeeting:      exportMeeting,
+		ExportActionItems:  exportActionItems,
 		Login:              login,
 		CheckStatus:        checkStatus,
 		Logout:             logout,
 		EventDispatcher:    dispatcher,
 		MCPServer:          mcpServer,
 		AddNote:            addNote,
+		UpdateNote:         updateNote,
+		GetNoteHistory:     getNoteHistory,
 		ListNotes:          listNotes,
 		DeleteNote:         deleteNote,
 		CompleteActionItem: completeActionItem,
 		UpdateActionItem:   updateActionItem,
+		PushActionItem:     pushActionItem,
 		ExportEmbeddings:   exportEmbeddings,
+		GenerateSummary:    generateSummary,
+		OutboxRelay:        outboxRelay,
+		EventStream:        eventStream,
+		Outbox:             outboxInspector,
 		GranolaAPIToken:    cfg.Granola.APIToken,
 		Out:                os.Stdout,
+
+		WebhookSubscriptions: webhookSubscriptions,
+		WebhookSink:          webhookSink,
+		SyncManager:          syncManager,
+		CacheWatcher:         cacheWatcher,
+		Backup:               backupService,
+		Databases:            databases,
 	}
 
 	// Execute CLI
@@ -239,6 +423,53 @@ func main() {
 	}
 }
 
+// migrateOnStartup applies pending schema migrations, reporting the backup
+// it took. A failure is a warning: "acai db migrate" retries it.
+func migrateOnStartup(migrator *migrate.Migrator) {
+	result, err := migrator.Migrate(context.Background())
+	if result.Backup != "" {
+		_, _ = fmt.Fprintf(os.Stderr, "Backed up %s to %s before migrating its schema\n", migrator.Name(), result.Backup)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: cannot migrate %s: %v\nRun \"acai db migrate\" to retry.\n", migrator.Name(), err)
+	}
+}
+
+// buildOutboxSinks returns the configured outbox relay sinks. The Granola
+// sink requires the API data source, since it reuses the authenticated client.
+func buildOutboxSinks(cfg *config.Config, granolaClient *granola.Client) []outbox.Sink {
+	var sinks []outbox.Sink
+	if cfg.Outbox.WebhookURL != "" {
+		sinks = append(sinks, outbox.NewWebhookSink(cfg.Outbox.WebhookURL, nil))
+	}
+	if cfg.Outbox.FilePath != "" {
+		sinks = append(sinks, outbox.NewFileSink(cfg.Outbox.FilePath))
+	}
+	if cfg.Outbox.GranolaPath != "" {
+		if granolaClient != nil {
+			sinks = append(sinks, outbox.NewGranolaSink(granolaClient, cfg.Outbox.GranolaPath))
+		} else {
+			_, _ = fmt.Fprintln(os.Stderr, "Warning: outbox Granola sink requires the API data source; skipping")
+		}
+	}
+	return sinks
+}
+
+// buildTaskSinks returns an adapter for every external tracker with complete configuration.
+func buildTaskSinks(cfg config.TasksConfig) []domain.TaskSink {
+	var sinks []domain.TaskSink
+	if cfg.GitHub.Enabled() {
+		sinks = append(sinks, tasksink.NewGitHubSink(cfg.GitHub.APIURL, cfg.GitHub.Repo, cfg.GitHub.Token, nil))
+	}
+	if cfg.Linear.Enabled() {
+		sinks = append(sinks, tasksink.NewLinearSink(cfg.Linear.APIURL, cfg.Linear.APIKey, cfg.Linear.TeamID, cfg.Linear.DoneStateID, cfg.Linear.OpenStateID, nil))
+	}
+	if cfg.Jira.Enabled() {
+		sinks = append(sinks, tasksink.NewJiraSink(cfg.Jira.BaseURL, cfg.Jira.Email, cfg.Jira.APIToken, cfg.Jira.ProjectKey, cfg.Jira.IssueType, nil))
+	}
+	return sinks
+}
+
 // resolveDataSource determines which data source to use based on configuration.
 // Priority: explicit DataSource setting > API token presence > local cache file existence.
 func resolveDataSource(cfg *config.Config, homeDir string) string {
@@ -247,11 +478,16 @@ func resolveDataSource(cfg *config.Config, homeDir string) string {
 		return "api"
 	case "local_cache":
 		return "local_cache"
+	case "hybrid":
+		return "hybrid"
 	default: // "auto"
 		if cfg.Granola.APIToken != "" {
 			return "api"
 		}
-		cachePath := resolveLocalCachePath(cfg, homeDir)
+		cachePath, err := resolveLocalCachePath(cfg, homeDir)
+		if err != nil {
+			return "api"
+		}
 		if _, err := os.Stat(cachePath); err == nil {
 			return "local_cache"
 		}
@@ -259,11 +495,43 @@ func resolveDataSource(cfg *config.Config, homeDir string) string {
 	}
 }
 
+// hybridPrecedence reads the per-field precedence for the hybrid data
+// source, falling back to the default for invalid values.
+func hybridPrecedence(cfg *config.Config) hybrid.Precedence {
+	precedence := hybrid.DefaultPrecedence()
+	for _, field := range []struct {
+		name  string
+		value string
+		dest  *hybrid.Preference
+	}{
+		{"transcript", cfg.Granola.Hybrid.Transcript, &precedence.Transcript},
+		{"summary", cfg.Granola.Hybrid.Summary, &precedence.Summary},
+		{"participants", cfg.Granola.Hybrid.Participants, &precedence.Participants},
+	} {
+		p, err := hybrid.ParsePreference(field.value)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: hybrid %s precedence: %v\n", field.name, err)
+			continue
+		}
+		*field.dest = p
+	}
+	return precedence
+}
+
 // resolveLocalCachePath returns the path to the Granola local cache file.
-// Uses the configured path if set, otherwise defaults to the standard macOS location.
-func resolveLocalCachePath(cfg *config.Config, homeDir string) string {
+// Uses the configured path if set, otherwise the newest cache-v*.json found
+// under the configured or platform-default roots.
+func resolveLocalCachePath(cfg *config.Config, homeDir string) (string, error) {
 	if cfg.Granola.LocalCachePath != "" {
-		return cfg.Granola.LocalCachePath
+		return cfg.Granola.LocalCachePath, nil
+	}
+	return localcache.Locate(localCacheRoots(cfg, homeDir))
+}
+
+// localCacheRoots returns the directories searched for the desktop cache.
+func localCacheRoots(cfg *config.Config, homeDir string) []string {
+	if len(cfg.Granola.CacheRoots) > 0 {
+		return cfg.Granola.CacheRoots
 	}
-	return filepath.Join(homeDir, "Library", "Application Support", "Granola", "cache-v3.json")
+	return localcache.DefaultRoots(homeDir, runtime.GOOS, os.Getenv)
 }
@@ -3,6 +3,7 @@ package annotation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,13 +12,26 @@ import (
 )
 
 type AddNoteInput struct {
-	MeetingID string
+	MeetingID string // may be empty for a reply; it defaults to the parent's meeting
 	Author    string
 	Content   string
+	Kind      string // note (default), insight, risk, decision, question
+
+	// Optional transcript anchor: a time range, or one utterance by index.
+	AnchorStart    *time.Time
+	AnchorEnd      *time.Time
+	UtteranceIndex *int
+
+	ParentID string // the note this one replies to
+
+	// IdempotencyKey, when set, makes the call safe to retry: a later call
+	// with the same key returns the note the first one created.
+	IdempotencyKey string
 }
 
 type AddNoteOutput struct {
-	Note *annotation.AgentNote
+	Note     *annotation.AgentNote
+	Replayed bool // the note was created by an earlier call with the same idempotency key
 }
 
 type AddNote struct {
@@ -31,6 +45,34 @@ func NewAddNote(noteRepo annotation.NoteRepository, meetingRepo domain.Repositor
 }
 
 func (uc *AddNote) Execute(ctx context.Context, input AddNoteInput) (*AddNoteOutput, error) {
+	kind, err := annotation.ParseNoteKind(input.Kind)
+	if err != nil {
+		return nil, err
+	}
+	anchor, err := parseAnchor(input)
+	if err != nil {
+		return nil, err
+	}
+	if input.IdempotencyKey != "" {
+		existing, err := uc.noteRepo.FindByIdempotencyKey(ctx, input.Author, input.IdempotencyKey)
+		if err == nil {
+			return uc.replay(ctx, existing, input)
+		}
+		if !errors.Is(err, annotation.ErrNoteNotFound) {
+			return nil, err
+		}
+	}
+
+	var parent *annotation.AgentNote
+	if input.ParentID != "" {
+		if parent, err = uc.noteRepo.FindByID(ctx, annotation.NoteID(input.ParentID)); err != nil {
+			return nil, fmt.Errorf("parent note %s: %w", input.ParentID, err)
+		}
+		if input.MeetingID == "" {
+			input.MeetingID = parent.MeetingID()
+		}
+	}
+
 	// Verify meeting exists
 	if input.MeetingID == "" {
 		return nil, annotation.ErrInvalidMeetingID
@@ -39,20 +81,41 @@ func (uc *AddNote) Execute(ctx context.Context, input AddNoteInput) (*AddNoteOut
 		return nil, err
 	}
 
-	// Generate note ID
-	noteID := annotation.NoteID(fmt.Sprintf("note-%d", time.Now().UnixNano()))
-
+	noteID, err := newNoteID(time.Now())
+	if err != nil {
+		return nil, err
+	}
 	note, err := annotation.NewAgentNote(noteID, input.MeetingID, input.Author, input.Content)
 	if err != nil {
 		return nil, err
 	}
+	if err := note.SetIdempotencyKey(input.IdempotencyKey); err != nil {
+		return nil, err
+	}
+	if err := note.Classify(kind); err != nil {
+		return nil, err
+	}
+	note.AnchorTo(anchor)
+	if parent != nil {
+		if err := note.ReplyTo(parent); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := uc.noteRepo.Save(ctx, note); err != nil {
+		// A concurrent call with the same key created the note first.
+		if errors.Is(err, annotation.ErrDuplicateIdempotencyKey) {
+			existing, findErr := uc.noteRepo.FindByIdempotencyKey(ctx, input.Author, input.IdempotencyKey)
+			if findErr != nil {
+				return nil, findErr
+			}
+			return uc.replay(ctx, existing, input)
+		}
 		return nil, err
 	}
 
 	// Dispatch event (annotation events satisfy meeting.DomainEvent via structural typing)
-	event := annotation.NewNoteAddedEvent(string(note.ID()), note.MeetingID(), note.Author())
+	event := annotation.NewNoteAddedEventFor(note)
 	if uc.dispatcher != nil {
 		if err := uc.dispatcher.Dispatch(ctx, []domain.DomainEvent{event}); err != nil {
 			return nil, err
@@ -61,3 +124,48 @@ func (uc *AddNote) Execute(ctx context.Context, input AddNoteInput) (*AddNoteOut
 
 	return &AddNoteOutput{Note: note}, nil
 }
+
+// replay returns the note an earlier call created with the input's
+// idempotency key, provided that call asked for the same note. The note may
+// have been edited since, so its first revision is compared.
+func (uc *AddNote) replay(ctx context.Context, note *annotation.AgentNote, input AddNoteInput) (*AddNoteOutput, error) {
+	content := note.Content()
+	if note.Revision() > 1 {
+		revisions, err := uc.noteRepo.ListRevisions(ctx, note.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range revisions {
+			if r.Number() == 1 {
+				content = r.Content()
+				break
+			}
+		}
+	}
+	if content != input.Content ||
+		(input.MeetingID != "" && note.MeetingID() != input.MeetingID) ||
+		(input.ParentID != "" && string(note.ParentID()) != input.ParentID) {
+		return nil, annotation.ErrIdempotencyKeyReused
+	}
+	return &AddNoteOutput{Note: note, Replayed: true}, nil
+}
+
+// parseAnchor builds the note's anchor from the input; a note is anchored
+// to a time range or to an utterance, not both.
+func parseAnchor(input AddNoteInput) (annotation.Anchor, error) {
+	switch {
+	case input.AnchorStart != nil && input.UtteranceIndex != nil:
+		return annotation.Anchor{}, annotation.ErrInvalidAnchor
+	case input.AnchorStart != nil:
+		var end time.Time
+		if input.AnchorEnd != nil {
+			end = *input.AnchorEnd
+		}
+		return annotation.NewTimeRangeAnchor(*input.AnchorStart, end)
+	case input.AnchorEnd != nil:
+		return annotation.Anchor{}, annotation.ErrInvalidAnchor
+	case input.UtteranceIndex != nil:
+		return annotation.NewUtteranceAnchor(*input.UtteranceIndex)
+	}
+	return annotation.Anchor{}, nil
+}
@@ -2,6 +2,9 @@ package annotation_test
 
 import (
 	"context"
+	"errors"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -110,3 +113,160 @@ func TestAddNote_EmptyAuthor(t *testing.T) {
 		t.Errorf("got error %v, want %v", err, annotatn.ErrInvalidAuthor)
 	}
 }
+
+func TestAddNote_KindAnchorAndReply(t *testing.T) {
+	noteRepo := newMockNoteRepository()
+	meetingRepo := newMockMeetingRepository()
+	dispatcher := &mockDispatcher{}
+
+	mtg, _ := domain.New("m-1", "Sprint Planning", time.Now(), domain.SourceZoom, nil)
+	mtg.ClearDomainEvents()
+	meetingRepo.addMeeting(mtg)
+
+	uc := app.NewAddNote(noteRepo, meetingRepo, dispatcher)
+	index := 3
+	question, err := uc.Execute(context.Background(), app.AddNoteInput{
+		MeetingID:      "m-1",
+		Author:         "claude",
+		Content:        "Who owns the migration?",
+		Kind:           "question",
+		UtteranceIndex: &index,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if question.Note.Kind() != annotatn.KindQuestion {
+		t.Errorf("got kind %q", question.Note.Kind())
+	}
+	if i, ok := question.Note.Anchor().UtteranceIndex(); !ok || i != 3 {
+		t.Errorf("got anchor index %d, %v", i, ok)
+	}
+
+	// A reply needs only the parent; the meeting is the parent's.
+	answer, err := uc.Execute(context.Background(), app.AddNoteInput{
+		Author:   "gpt",
+		Content:  "Alice, per the summary",
+		ParentID: string(question.Note.ID()),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if answer.Note.MeetingID() != "m-1" || answer.Note.ParentID() != question.Note.ID() {
+		t.Errorf("reply = meeting %q, parent %q", answer.Note.MeetingID(), answer.Note.ParentID())
+	}
+	added, ok := dispatcher.events[1].(annotatn.NoteAdded)
+	if !ok || added.ParentID() != string(question.Note.ID()) || added.Kind() != "note" {
+		t.Errorf("reply event = %+v", dispatcher.events[1])
+	}
+}
+
+func TestAddNote_RejectsInvalidKindAnchorAndParent(t *testing.T) {
+	meetingRepo := newMockMeetingRepository()
+	mtg, _ := domain.New("m-1", "Sprint Planning", time.Now(), domain.SourceZoom, nil)
+	meetingRepo.addMeeting(mtg)
+	uc := app.NewAddNote(newMockNoteRepository(), meetingRepo, nil)
+
+	start := time.Now()
+	index := 0
+	cases := map[string]struct {
+		input app.AddNoteInput
+		want  error
+	}{
+		"kind":           {app.AddNoteInput{MeetingID: "m-1", Author: "a", Content: "c", Kind: "todo"}, annotatn.ErrInvalidNoteKind},
+		"both anchors":   {app.AddNoteInput{MeetingID: "m-1", Author: "a", Content: "c", AnchorStart: &start, UtteranceIndex: &index}, annotatn.ErrInvalidAnchor},
+		"missing parent": {app.AddNoteInput{Author: "a", Content: "c", ParentID: "n-404"}, annotatn.ErrNoteNotFound},
+	}
+	for name, tc := range cases {
+		if _, err := uc.Execute(context.Background(), tc.input); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got error %v, want %v", name, err, tc.want)
+		}
+	}
+}
+
+func TestAddNote_GeneratesUniqueTimeOrderedIDs(t *testing.T) {
+	noteRepo := newMockNoteRepository()
+	meetingRepo := newMockMeetingRepository()
+	mtg, _ := domain.New("m-1", "Sprint Planning", time.Now(), domain.SourceZoom, nil)
+	meetingRepo.addMeeting(mtg)
+	uc := app.NewAddNote(noteRepo, meetingRepo, nil)
+
+	uuidv7 := regexp.MustCompile(`^note-[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	var prev annotatn.NoteID
+	for i := 0; i < 100; i++ {
+		out, err := uc.Execute(context.Background(), app.AddNoteInput{MeetingID: "m-1", Author: "claude", Content: "Note"})
+		if err != nil {
+			t.Fatalf("add %d: %v", i, err)
+		}
+		id := out.Note.ID()
+		if !uuidv7.MatchString(string(id)) {
+			t.Fatalf("id %q is not note-<UUIDv7>", id)
+		}
+		// The leading 48 bits are a millisecond timestamp.
+		if prev != "" && string(id)[:18] < string(prev)[:18] {
+			t.Errorf("id %q sorts before earlier id %q", id, prev)
+		}
+		prev = id
+	}
+	if len(noteRepo.notes) != 100 {
+		t.Errorf("stored %d notes, want 100: ids collided", len(noteRepo.notes))
+	}
+}
+
+func TestAddNote_IdempotencyKeyReplaysOriginalNote(t *testing.T) {
+	noteRepo := newMockNoteRepository()
+	meetingRepo := newMockMeetingRepository()
+	dispatcher := &mockDispatcher{}
+	mtg, _ := domain.New("m-1", "Sprint Planning", time.Now(), domain.SourceZoom, nil)
+	meetingRepo.addMeeting(mtg)
+	uc := app.NewAddNote(noteRepo, meetingRepo, dispatcher)
+	ctx := context.Background()
+
+	input := app.AddNoteInput{MeetingID: "m-1", Author: "claude", Content: "Budget at risk", IdempotencyKey: "req-1"}
+	first, err := uc.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("first add: %v", err)
+	}
+	if first.Replayed {
+		t.Error("first add reported as replayed")
+	}
+
+	second, err := uc.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("replayed add: %v", err)
+	}
+	if !second.Replayed || second.Note.ID() != first.Note.ID() {
+		t.Errorf("replay = (%s, %v), want (%s, true)", second.Note.ID(), second.Replayed, first.Note.ID())
+	}
+	if len(noteRepo.notes) != 1 {
+		t.Errorf("stored %d notes, want 1", len(noteRepo.notes))
+	}
+	if len(dispatcher.events) != 1 {
+		t.Errorf("dispatched %d events, want 1", len(dispatcher.events))
+	}
+
+	input.Content = "Something else"
+	if _, err := uc.Execute(ctx, input); !errors.Is(err, annotatn.ErrIdempotencyKeyReused) {
+		t.Errorf("reused key: got %v, want ErrIdempotencyKeyReused", err)
+	}
+
+	// A retry after the note was edited still replays it.
+	input.Content = "Budget at risk"
+	prior, _ := first.Note.Edit("Budget approved after all", "alice", 1)
+	if err := noteRepo.Update(ctx, first.Note, prior); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if out, err := uc.Execute(ctx, input); err != nil || !out.Replayed {
+		t.Errorf("retry after edit: got %v, %v; want the replayed note", out, err)
+	}
+
+	// Keys are scoped to the author.
+	input.Author = "gpt"
+	if out, err := uc.Execute(ctx, input); err != nil || out.Replayed {
+		t.Errorf("same key, other author: got %v, %v; want a new note", out, err)
+	}
+
+	input.IdempotencyKey = strings.Repeat("k", 256)
+	if _, err := uc.Execute(ctx, input); !errors.Is(err, annotatn.ErrInvalidIdempotencyKey) {
+		t.Errorf("long key: got %v, want ErrInvalidIdempotencyKey", err)
+	}
+}
@@ -0,0 +1,41 @@
+package annotation
+
+import (
+	"context"
+
+	annotatn "github.com/felixgeelhaar/acai/internal/domain/annotation"
+)
+
+type GetNoteHistoryInput struct {
+	NoteID string
+}
+
+type GetNoteHistoryOutput struct {
+	Note      *annotatn.AgentNote
+	Revisions []annotatn.NoteRevision // oldest first, ending with the current revision
+}
+
+type GetNoteHistory struct {
+	noteRepo annotatn.NoteRepository
+}
+
+func NewGetNoteHistory(noteRepo annotatn.NoteRepository) *GetNoteHistory {
+	return &GetNoteHistory{noteRepo: noteRepo}
+}
+
+func (uc *GetNoteHistory) Execute(ctx context.Context, input GetNoteHistoryInput) (*GetNoteHistoryOutput, error) {
+	if input.NoteID == "" {
+		return nil, annotatn.ErrInvalidNoteID
+	}
+
+	note, err := uc.noteRepo.FindByID(ctx, annotatn.NoteID(input.NoteID))
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := uc.noteRepo.ListRevisions(ctx, note.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetNoteHistoryOutput{Note: note, Revisions: append(revisions, note.CurrentRevision())}, nil
+}
@@ -2,38 +2,87 @@ package annotation
 
 import (
 	"context"
+	"time"
 
 	annotatn "github.com/felixgeelhaar/acai/internal/domain/annotation"
 )
 
 type ListNotesInput struct {
 	MeetingID string
+
+	// Optional filters; with any of them set, notes are searched across
+	// meetings (or within MeetingID) and returned newest first.
+	Author string
+	Kind   string // note, insight, risk, decision, question
+	Since  *time.Time
+	Until  *time.Time
+	Query  string // full-text match on content
+	Limit  int
+	Offset int
 }
 
 type ListNotesOutput struct {
 	Notes []*annotatn.AgentNote
+	// PushConflicts holds, for listed notes whose local edits lost to a
+	// human edit in Granola, the time of the latest conflict.
+	PushConflicts map[annotatn.NoteID]time.Time
 }
 
 type ListNotes struct {
-	noteRepo annotatn.NoteRepository
+	noteRepo  annotatn.NoteRepository
+	conflicts annotatn.PushConflictReader
 }
 
-func NewListNotes(noteRepo annotatn.NoteRepository) *ListNotes {
-	return &ListNotes{noteRepo: noteRepo}
+// NewListNotes creates a new ListNotes use case. conflicts is optional; it
+// is set when notes are pushed to Granola.
+func NewListNotes(noteRepo annotatn.NoteRepository, conflicts annotatn.PushConflictReader) *ListNotes {
+	return &ListNotes{noteRepo: noteRepo, conflicts: conflicts}
 }
 
 func (uc *ListNotes) Execute(ctx context.Context, input ListNotesInput) (*ListNotesOutput, error) {
+	var kind annotatn.NoteKind
+	if input.Kind != "" {
+		var err error
+		if kind, err = annotatn.ParseNoteKind(input.Kind); err != nil {
+			return nil, err
+		}
+	}
+	filter := annotatn.NoteFilter{
+		MeetingID: input.MeetingID,
+		Author:    input.Author,
+		Kind:      kind,
+		Since:     input.Since,
+		Until:     input.Until,
+		Query:     input.Query,
+		Limit:     input.Limit,
+		Offset:    input.Offset,
+	}
+
 	var notes []*annotatn.AgentNote
 	var err error
 
-	if input.MeetingID == "" {
+	filtered := filter != (annotatn.NoteFilter{MeetingID: input.MeetingID})
+	switch {
+	case filtered:
+		notes, err = uc.noteRepo.Search(ctx, filter)
+	case input.MeetingID == "":
 		notes, err = uc.noteRepo.ListAll(ctx)
-	} else {
+	default:
 		notes, err = uc.noteRepo.ListByMeeting(ctx, input.MeetingID)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &ListNotesOutput{Notes: notes}, nil
+	out := &ListNotesOutput{Notes: notes}
+	if uc.conflicts != nil && len(notes) > 0 {
+		ids := make([]annotatn.NoteID, len(notes))
+		for i, n := range notes {
+			ids[i] = n.ID()
+		}
+		if out.PushConflicts, err = uc.conflicts.PushConflicts(ctx, ids); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
 }
@@ -3,6 +3,7 @@ package annotation_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	app "github.com/felixgeelhaar/acai/internal/application/annotation"
 	annotatn "github.com/felixgeelhaar/acai/internal/domain/annotation"
@@ -13,7 +14,7 @@ func TestListNotes_Found(t *testing.T) {
 	note, _ := annotatn.NewAgentNote("n-1", "m-1", "claude", "observation")
 	noteRepo.notes[note.ID()] = note
 
-	uc := app.NewListNotes(noteRepo)
+	uc := app.NewListNotes(noteRepo, nil)
 	out, err := uc.Execute(context.Background(), app.ListNotesInput{MeetingID: "m-1"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -23,10 +24,28 @@ func TestListNotes_Found(t *testing.T) {
 	}
 }
 
+func TestListNotes_ReportsPushConflicts(t *testing.T) {
+	noteRepo := newMockNoteRepository()
+	for _, id := range []annotatn.NoteID{"n-1", "n-2"} {
+		note, _ := annotatn.NewAgentNote(id, "m-1", "claude", "observation")
+		noteRepo.notes[note.ID()] = note
+	}
+	at := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	uc := app.NewListNotes(noteRepo, mockPushConflicts{"n-2": at, "n-9": at})
+	out, err := uc.Execute(context.Background(), app.ListNotesInput{MeetingID: "m-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.PushConflicts) != 1 || !out.PushConflicts["n-2"].Equal(at) {
+		t.Errorf("got conflicts %v, want n-2 only", out.PushConflicts)
+	}
+}
+
 func TestListNotes_Empty(t *testing.T) {
 	noteRepo := newMockNoteRepository()
 
-	uc := app.NewListNotes(noteRepo)
+	uc := app.NewListNotes(noteRepo, nil)
 	out, err := uc.Execute(context.Background(), app.ListNotesInput{MeetingID: "m-1"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -43,7 +62,7 @@ func TestListNotes_AllNotes(t *testing.T) {
 	noteRepo.notes[note1.ID()] = note1
 	noteRepo.notes[note2.ID()] = note2
 
-	uc := app.NewListNotes(noteRepo)
+	uc := app.NewListNotes(noteRepo, nil)
 	out, err := uc.Execute(context.Background(), app.ListNotesInput{MeetingID: ""})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -52,3 +71,28 @@ func TestListNotes_AllNotes(t *testing.T) {
 		t.Errorf("got %d notes, want 2", len(out.Notes))
 	}
 }
+
+func TestListNotes_SearchesAcrossMeetings(t *testing.T) {
+	noteRepo := newMockNoteRepository()
+	risk, _ := annotatn.NewAgentNote("n-1", "m-1", "claude", "Budget is blocked")
+	_ = risk.Classify(annotatn.KindRisk)
+	decision, _ := annotatn.NewAgentNote("n-2", "m-2", "gpt", "Budget approved")
+	_ = decision.Classify(annotatn.KindDecision)
+	other, _ := annotatn.NewAgentNote("n-3", "m-2", "claude", "Ship the beta")
+	for _, n := range []*annotatn.AgentNote{risk, decision, other} {
+		noteRepo.notes[n.ID()] = n
+	}
+
+	uc := app.NewListNotes(noteRepo, nil)
+	out, err := uc.Execute(context.Background(), app.ListNotesInput{Query: "budget", Kind: "decision"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Notes) != 1 || out.Notes[0].ID() != "n-2" {
+		t.Errorf("got %d notes, want n-2 only", len(out.Notes))
+	}
+
+	if _, err := uc.Execute(context.Background(), app.ListNotesInput{Kind: "todo"}); err != annotatn.ErrInvalidNoteKind {
+		t.Errorf("got error %v, want %v", err, annotatn.ErrInvalidNoteKind)
+	}
+}
@@ -2,6 +2,7 @@ package annotation_test
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	annotatn "github.com/felixgeelhaar/acai/internal/domain/annotation"
@@ -10,7 +11,8 @@ import (
 
 // mockNoteRepository implements annotation.NoteRepository for tests.
 type mockNoteRepository struct {
-	notes map[annotatn.NoteID]*annotatn.AgentNote
+	notes     map[annotatn.NoteID]*annotatn.AgentNote
+	revisions map[annotatn.NoteID][]annotatn.NoteRevision
 }
 
 func newMockNoteRepository() *mockNoteRepository {
@@ -30,6 +32,15 @@ func (m *mockNoteRepository) FindByID(_ context.Context, id annotatn.NoteID) (*a
 	return note, nil
 }
 
+func (m *mockNoteRepository) FindByIdempotencyKey(_ context.Context, author, key string) (*annotatn.AgentNote, error) {
+	for _, note := range m.notes {
+		if note.Author() == author && note.IdempotencyKey() == key {
+			return note, nil
+		}
+	}
+	return nil, annotatn.ErrNoteNotFound
+}
+
 func (m *mockNoteRepository) ListByMeeting(_ context.Context, meetingID string) ([]*annotatn.AgentNote, error) {
 	var result []*annotatn.AgentNote
 	for _, note := range m.notes {
@@ -54,6 +65,19 @@ func (m *mockNoteRepository) ListAll(_ context.Context) ([]*annotatn.AgentNote,
 	return result, nil
 }
 
+func (m *mockNoteRepository) Search(_ context.Context, filter annotatn.NoteFilter) ([]*annotatn.AgentNote, error) {
+	result := []*annotatn.AgentNote{}
+	for _, note := range m.notes {
+		if (filter.MeetingID == "" || note.MeetingID() == filter.MeetingID) &&
+			(filter.Author == "" || note.Author() == filter.Author) &&
+			(filter.Kind == "" || note.Kind() == filter.Kind) &&
+			strings.Contains(strings.ToLower(note.Content()), strings.ToLower(filter.Query)) {
+			result = append(result, note)
+		}
+	}
+	return result, nil
+}
+
 func (m *mockNoteRepository) Delete(_ context.Context, id annotatn.NoteID) error {
 	if _, ok := m.notes[id]; !ok {
 		return annotatn.ErrNoteNotFound
@@ -62,7 +86,36 @@ func (m *mockNoteRepository) Delete(_ context.Context, id annotatn.NoteID) error
 	return nil
 }
 
+func (m *mockNoteRepository) Update(_ context.Context, note *annotatn.AgentNote, prior annotatn.NoteRevision) error {
+	if _, ok := m.notes[note.ID()]; !ok {
+		return annotatn.ErrNoteNotFound
+	}
+	if m.revisions == nil {
+		m.revisions = make(map[annotatn.NoteID][]annotatn.NoteRevision)
+	}
+	m.revisions[note.ID()] = append(m.revisions[note.ID()], prior)
+	m.notes[note.ID()] = note
+	return nil
+}
+
+func (m *mockNoteRepository) ListRevisions(_ context.Context, id annotatn.NoteID) ([]annotatn.NoteRevision, error) {
+	return m.revisions[id], nil
+}
+
 // mockMeetingRepository implements domain.Repository for verifying meeting existence.
+// mockPushConflicts reports fixed push conflicts.
+type mockPushConflicts map[annotatn.NoteID]time.Time
+
+func (m mockPushConflicts) PushConflicts(_ context.Context, ids []annotatn.NoteID) (map[annotatn.NoteID]time.Time, error) {
+	conflicts := make(map[annotatn.NoteID]time.Time)
+	for _, id := range ids {
+		if at, ok := m[id]; ok {
+			conflicts[id] = at
+		}
+	}
+	return conflicts, nil
+}
+
 type mockMeetingRepository struct {
 	meetings map[domain.MeetingID]*domain.Meeting
 }
@@ -0,0 +1,26 @@
+package annotation
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+)
+
+// newNoteID returns "note-" followed by a UUIDv7 (RFC 9562): a millisecond
+// timestamp then 74 random bits, so IDs sort by creation time and notes
+// created in the same instant, by any process, do not collide.
+func newNoteID(now time.Time) (annotation.NoteID, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[6:]); err != nil {
+		return "", fmt.Errorf("generate note id: %w", err)
+	}
+	ms := uint64(now.UnixMilli())
+	for i := 0; i < 6; i++ {
+		u[i] = byte(ms >> (40 - 8*i))
+	}
+	u[6] = u[6]&0x0f | 0x70 // version 7
+	u[8] = u[8]&0x3f | 0x80 // RFC 9562 variant
+	return annotation.NoteID(fmt.Sprintf("note-%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])), nil
+}
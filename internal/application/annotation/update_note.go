@@ -0,0 +1,61 @@
+package annotation
+
+import (
+	"context"
+
+	annotatn "github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+type UpdateNoteInput struct {
+	NoteID           string
+	Author           string // who is editing; the note keeps its original author
+	Content          string
+	ExpectedRevision int // the revision the edit is based on
+}
+
+type UpdateNoteOutput struct {
+	Note *annotatn.AgentNote
+}
+
+// UpdateNote edits a note's content with optimistic concurrency: the edit
+// applies only if nobody has edited the note since ExpectedRevision. The
+// replaced content is kept as a revision.
+type UpdateNote struct {
+	noteRepo   annotatn.NoteRepository
+	dispatcher domain.EventDispatcher
+}
+
+func NewUpdateNote(noteRepo annotatn.NoteRepository, dispatcher domain.EventDispatcher) *UpdateNote {
+	return &UpdateNote{noteRepo: noteRepo, dispatcher: dispatcher}
+}
+
+func (uc *UpdateNote) Execute(ctx context.Context, input UpdateNoteInput) (*UpdateNoteOutput, error) {
+	if input.NoteID == "" {
+		return nil, annotatn.ErrInvalidNoteID
+	}
+	if input.ExpectedRevision < 1 {
+		return nil, annotatn.ErrInvalidRevision
+	}
+
+	note, err := uc.noteRepo.FindByID(ctx, annotatn.NoteID(input.NoteID))
+	if err != nil {
+		return nil, err
+	}
+	prior, err := note.Edit(input.Content, input.Author, input.ExpectedRevision)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.noteRepo.Update(ctx, note, prior); err != nil {
+		return nil, err
+	}
+
+	event := annotatn.NewNoteUpdatedEvent(string(note.ID()), note.MeetingID(), note.UpdatedBy(), note.Revision())
+	if uc.dispatcher != nil {
+		if err := uc.dispatcher.Dispatch(ctx, []domain.DomainEvent{event}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &UpdateNoteOutput{Note: note}, nil
+}
@@ -0,0 +1,88 @@
+package annotation_test
+
+import (
+	"context"
+	"testing"
+
+	app "github.com/felixgeelhaar/acai/internal/application/annotation"
+	annotatn "github.com/felixgeelhaar/acai/internal/domain/annotation"
+)
+
+func TestUpdateNote_EditsAndKeepsHistory(t *testing.T) {
+	noteRepo := newMockNoteRepository()
+	dispatcher := &mockDispatcher{}
+
+	note, _ := annotatn.NewAgentNote("n-1", "m-1", "claude", "Budget is blocked")
+	noteRepo.notes[note.ID()] = note
+
+	uc := app.NewUpdateNote(noteRepo, dispatcher)
+	out, err := uc.Execute(context.Background(), app.UpdateNoteInput{
+		NoteID: "n-1", Author: "alice", Content: "Budget approved on Friday", ExpectedRevision: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Note.Content() != "Budget approved on Friday" || out.Note.Revision() != 2 {
+		t.Errorf("got %q at revision %d", out.Note.Content(), out.Note.Revision())
+	}
+
+	if len(dispatcher.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(dispatcher.events))
+	}
+	event, ok := dispatcher.events[0].(annotatn.NoteUpdated)
+	if !ok || event.Editor() != "alice" || event.Revision() != 2 {
+		t.Errorf("got event %+v", dispatcher.events[0])
+	}
+
+	history, err := app.NewGetNoteHistory(noteRepo).Execute(context.Background(), app.GetNoteHistoryInput{NoteID: "n-1"})
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history.Revisions) != 2 {
+		t.Fatalf("got %d revisions, want 2", len(history.Revisions))
+	}
+	if r := history.Revisions[0]; r.Number() != 1 || r.Content() != "Budget is blocked" || r.Author() != "claude" {
+		t.Errorf("first revision = %d %q by %q", r.Number(), r.Content(), r.Author())
+	}
+	if r := history.Revisions[1]; r.Number() != 2 || r.Author() != "alice" {
+		t.Errorf("current revision = %d by %q", r.Number(), r.Author())
+	}
+}
+
+func TestUpdateNote_RejectsStaleRevision(t *testing.T) {
+	noteRepo := newMockNoteRepository()
+	dispatcher := &mockDispatcher{}
+
+	note, _ := annotatn.NewAgentNote("n-1", "m-1", "claude", "Budget is blocked")
+	noteRepo.notes[note.ID()] = note
+
+	uc := app.NewUpdateNote(noteRepo, dispatcher)
+	input := app.UpdateNoteInput{NoteID: "n-1", Author: "alice", Content: "Approved", ExpectedRevision: 1}
+	if _, err := uc.Execute(context.Background(), input); err != nil {
+		t.Fatalf("first edit: %v", err)
+	}
+	input.Author, input.Content = "bob", "Cut"
+	if _, err := uc.Execute(context.Background(), input); err != annotatn.ErrRevisionConflict {
+		t.Errorf("got error %v, want %v", err, annotatn.ErrRevisionConflict)
+	}
+	if len(dispatcher.events) != 1 {
+		t.Errorf("rejected edit dispatched an event")
+	}
+}
+
+func TestUpdateNote_ValidatesInput(t *testing.T) {
+	uc := app.NewUpdateNote(newMockNoteRepository(), nil)
+	cases := []struct {
+		input app.UpdateNoteInput
+		want  error
+	}{
+		{app.UpdateNoteInput{Content: "x", Author: "a", ExpectedRevision: 1}, annotatn.ErrInvalidNoteID},
+		{app.UpdateNoteInput{NoteID: "n-1", Content: "x", Author: "a"}, annotatn.ErrInvalidRevision},
+		{app.UpdateNoteInput{NoteID: "missing", Content: "x", Author: "a", ExpectedRevision: 1}, annotatn.ErrNoteNotFound},
+	}
+	for _, c := range cases {
+		if _, err := uc.Execute(context.Background(), c.input); err != c.want {
+			t.Errorf("Execute(%+v) = %v, want %v", c.input, err, c.want)
+		}
+	}
+}
@@ -39,7 +39,7 @@ func (s *BySpeakerTurn) ChunkTranscript(meetingID domain.MeetingID, utterances [
 		}
 
 		content := strings.Join(texts, " ")
-		c, err := domain.NewChunk(meetingID, idx, content, speaker, startTime, endTime, domain.ChunkSourceTranscript, estimateTokens(content))
+		c, err := domain.NewChunk(meetingID, idx, content, speaker, startTime, endTime, domain.ChunkSourceTranscript, EstimateTokens(content))
 		if err != nil {
 			return nil, err
 		}
@@ -85,7 +85,7 @@ func (s *ByTimeWindow) ChunkTranscript(meetingID domain.MeetingID, utterances []
 
 		content := strings.Join(texts, " ")
 		speaker := strings.Join(speakers, ", ")
-		c, err := domain.NewChunk(meetingID, idx, content, speaker, windowStart, endTime, domain.ChunkSourceTranscript, estimateTokens(content))
+		c, err := domain.NewChunk(meetingID, idx, content, speaker, windowStart, endTime, domain.ChunkSourceTranscript, EstimateTokens(content))
 		if err != nil {
 			return nil, err
 		}
@@ -124,7 +124,7 @@ func (s *ByTokenLimit) ChunkTranscript(meetingID domain.MeetingID, utterances []
 		}
 		content := strings.Join(texts, " ")
 		speaker := strings.Join(speakers, ", ")
-		c, err := domain.NewChunk(meetingID, idx, content, speaker, startTime, endTime, domain.ChunkSourceTranscript, estimateTokens(content))
+		c, err := domain.NewChunk(meetingID, idx, content, speaker, startTime, endTime, domain.ChunkSourceTranscript, EstimateTokens(content))
 		if err != nil {
 			return err
 		}
@@ -137,7 +137,7 @@ func (s *ByTokenLimit) ChunkTranscript(meetingID domain.MeetingID, utterances []
 	}
 
 	for _, u := range utterances {
-		uTokens := estimateTokens(u.Text())
+		uTokens := EstimateTokens(u.Text())
 		if tokenCount+uTokens > maxTokens && len(texts) > 0 {
 			if err := flush(); err != nil {
 				return nil, err
@@ -157,8 +157,8 @@ func (s *ByTokenLimit) ChunkTranscript(meetingID domain.MeetingID, utterances []
 	return chunks, nil
 }
 
-// estimateTokens provides a rough token count approximation (~0.75 words per token).
-func estimateTokens(text string) int {
+// EstimateTokens provides a rough token count approximation (~0.75 words per token).
+func EstimateTokens(text string) int {
 	words := len(strings.Fields(text))
 	tokens := int(float64(words) / 0.75)
 	if tokens == 0 && words > 0 {
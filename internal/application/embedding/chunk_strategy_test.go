@@ -198,9 +198,9 @@ func TestEstimateTokens(t *testing.T) {
 		{"hello world how are you", 3}, // 5 words / 0.75 ≈ 6.67
 	}
 	for _, tt := range tests {
-		got := estimateTokens(tt.text)
+		got := EstimateTokens(tt.text)
 		if got < tt.min {
-			t.Errorf("estimateTokens(%q) = %d, want >= %d", tt.text, got, tt.min)
+			t.Errorf("EstimateTokens(%q) = %d, want >= %d", tt.text, got, tt.min)
 		}
 	}
 }
@@ -39,7 +39,7 @@ func (uc *ExportEmbeddings) Execute(ctx context.Context, input ExportEmbeddingsI
 		return nil, ErrNoMeetings
 	}
 
-	strategy, err := resolveStrategy(input.Strategy, input.MaxTokens)
+	strategy, err := ResolveStrategy(input.Strategy, input.MaxTokens)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +67,7 @@ func (uc *ExportEmbeddings) Execute(ctx context.Context, input ExportEmbeddingsI
 		}
 		if summary := meeting.Summary(); summary != nil && summary.Content() != "" {
 			chunkIdx := len(allChunks)
-			c, err := domain.NewChunk(mid, chunkIdx, summary.Content(), "", meeting.Datetime(), meeting.Datetime(), domain.ChunkSourceSummary, estimateTokens(summary.Content()))
+			c, err := domain.NewChunk(mid, chunkIdx, summary.Content(), "", meeting.Datetime(), meeting.Datetime(), domain.ChunkSourceSummary, EstimateTokens(summary.Content()))
 			if err != nil {
 				return nil, err
 			}
@@ -82,7 +82,7 @@ func (uc *ExportEmbeddings) Execute(ctx context.Context, input ExportEmbeddingsI
 			}
 			for _, n := range notes {
 				chunkIdx := len(allChunks)
-				c, err := domain.NewChunk(mid, chunkIdx, n.Content(), n.Author(), n.CreatedAt(), n.CreatedAt(), domain.ChunkSourceNote, estimateTokens(n.Content()))
+				c, err := domain.NewChunk(mid, chunkIdx, n.Content(), n.Author(), n.CreatedAt(), n.CreatedAt(), domain.ChunkSourceNote, EstimateTokens(n.Content()))
 				if err != nil {
 					return nil, err
 				}
@@ -103,7 +103,9 @@ func (uc *ExportEmbeddings) Execute(ctx context.Context, input ExportEmbeddingsI
 	}, nil
 }
 
-func resolveStrategy(name string, maxTokens int) (ChunkStrategy, error) {
+// ResolveStrategy returns the chunking strategy named name; the empty name
+// is speaker_turn. maxTokens applies to token_limit.
+func ResolveStrategy(name string, maxTokens int) (ChunkStrategy, error) {
 	switch name {
 	case "", "speaker_turn":
 		return &BySpeakerTurn{}, nil
@@ -52,6 +52,9 @@ func (m *mockNoteRepo) Save(_ context.Context, _ *annotation.AgentNote) error {
 func (m *mockNoteRepo) FindByID(_ context.Context, _ annotation.NoteID) (*annotation.AgentNote, error) {
 	return nil, annotation.ErrNoteNotFound
 }
+func (m *mockNoteRepo) FindByIdempotencyKey(_ context.Context, _, _ string) (*annotation.AgentNote, error) {
+	return nil, annotation.ErrNoteNotFound
+}
 func (m *mockNoteRepo) ListByMeeting(_ context.Context, meetingID string) ([]*annotation.AgentNote, error) {
 	return m.notes[meetingID], nil
 }
@@ -62,7 +65,16 @@ func (m *mockNoteRepo) ListAll(_ context.Context) ([]*annotation.AgentNote, erro
 	}
 	return all, nil
 }
+func (m *mockNoteRepo) Search(_ context.Context, _ annotation.NoteFilter) ([]*annotation.AgentNote, error) {
+	return nil, nil
+}
 func (m *mockNoteRepo) Delete(_ context.Context, _ annotation.NoteID) error { return nil }
+func (m *mockNoteRepo) Update(_ context.Context, _ *annotation.AgentNote, _ annotation.NoteRevision) error {
+	return nil
+}
+func (m *mockNoteRepo) ListRevisions(_ context.Context, _ annotation.NoteID) ([]annotation.NoteRevision, error) {
+	return nil, nil
+}
 
 // --- Tests ---
 
@@ -149,7 +161,7 @@ func TestExportEmbeddings_WithNotes(t *testing.T) {
 	mtg, _ := domain.New("m-1", "Sprint", now, domain.SourceZoom, nil)
 	mtg.ClearDomainEvents()
 
-	note := annotation.ReconstructAgentNote("n-1", "m-1", "agent", "Agent observation", now)
+	note := annotation.ReconstructAgentNote("n-1", "m-1", "agent", "Agent observation", now, annotation.KindNote, annotation.Anchor{}, "", 1, "agent", now)
 
 	repo := &mockMeetingRepo{
 		meetings:    map[domain.MeetingID]*domain.Meeting{"m-1": mtg},
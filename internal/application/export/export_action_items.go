@@ -0,0 +1,206 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+)
+
+const (
+	FormatICS Format = "ics"
+	FormatCSV Format = "csv"
+)
+
+// icsProductID identifies acai as the producer of exported calendars (RFC 5545 §3.7.3).
+const icsProductID = "-//acai//Action Items//EN"
+
+type ExportActionItemsInput struct {
+	Query  meetingapp.ListActionItemsInput
+	Format Format
+}
+
+type ExportActionItemsOutput struct {
+	Content string
+	Format  Format
+	Count   int
+}
+
+// ExportActionItems renders the action item inbox in formats consumed by
+// external task trackers: iCalendar VTODO entries and CSV.
+type ExportActionItems struct {
+	inbox *meetingapp.ListActionItems
+	now   func() time.Time
+}
+
+func NewExportActionItems(inbox *meetingapp.ListActionItems) *ExportActionItems {
+	return &ExportActionItems{inbox: inbox, now: time.Now}
+}
+
+func (uc *ExportActionItems) Execute(ctx context.Context, input ExportActionItemsInput) (*ExportActionItemsOutput, error) {
+	f := input.Format
+	if f == "" {
+		f = FormatICS
+	}
+	if f != FormatICS && f != FormatCSV {
+		return nil, ErrUnsupportedFormat
+	}
+
+	out, err := uc.inbox.Execute(ctx, input.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var content string
+	switch f {
+	case FormatICS:
+		content = formatICS(out.Items, uc.now().UTC())
+	case FormatCSV:
+		content, err = formatCSV(out.Items)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ExportActionItemsOutput{
+		Content: content,
+		Format:  f,
+		Count:   len(out.Items),
+	}, nil
+}
+
+// meetingLink returns the first external link recorded for the meeting,
+// falling back to the MCP resource URI.
+func meetingLink(entry meetingapp.InboxItem) string {
+	if len(entry.MeetingLinks) > 0 {
+		return entry.MeetingLinks[0]
+	}
+	return fmt.Sprintf("meeting://%s", entry.Item.MeetingID())
+}
+
+func formatICS(items []meetingapp.InboxItem, now time.Time) string {
+	const utcStamp = "20060102T150405Z"
+
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:"+icsProductID)
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+
+	for _, entry := range items {
+		item := entry.Item
+		writeICSLine(&b, "BEGIN:VTODO")
+		writeICSLine(&b, fmt.Sprintf("UID:%s@acai", escapeICSText(string(item.ID()))))
+		writeICSLine(&b, "DTSTAMP:"+now.Format(utcStamp))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(item.Text()))
+		if item.DueDate() != nil {
+			writeICSLine(&b, "DUE:"+item.DueDate().UTC().Format(utcStamp))
+		}
+		if item.IsCompleted() {
+			writeICSLine(&b, "STATUS:COMPLETED")
+		} else {
+			writeICSLine(&b, "STATUS:NEEDS-ACTION")
+		}
+		if item.Owner() != "" {
+			writeICSLine(&b, icsAttendee(item.Owner()))
+		}
+
+		link := meetingLink(entry)
+		description := fmt.Sprintf("From meeting: %s (%s)\n%s",
+			entry.MeetingTitle, entry.MeetingDatetime.Format("2006-01-02"), link)
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(description))
+		if strings.Contains(link, "://") && !strings.HasPrefix(link, "meeting://") {
+			writeICSLine(&b, "URL:"+link)
+		}
+		writeICSLine(&b, "END:VTODO")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// icsAttendee renders the owner as an ATTENDEE property. Owners that look like
+// email addresses become mailto URIs; other names are carried in CN with a
+// placeholder URI since ATTENDEE requires a cal-address value.
+func icsAttendee(owner string) string {
+	if strings.Contains(owner, "@") && !strings.ContainsAny(owner, " \t") {
+		return "ATTENDEE;ROLE=REQ-PARTICIPANT:mailto:" + owner
+	}
+	return fmt.Sprintf("ATTENDEE;CN=%s;ROLE=REQ-PARTICIPANT:mailto:unknown@invalid", quoteICSParam(owner))
+}
+
+// escapeICSText escapes TEXT values per RFC 5545 §3.3.11.
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// quoteICSParam quotes a parameter value, dropping characters that cannot
+// appear inside a quoted-string (RFC 5545 §3.1).
+func quoteICSParam(s string) string {
+	s = strings.NewReplacer(`"`, "", "\r", "", "\n", " ").Replace(s)
+	return `"` + s + `"`
+}
+
+// writeICSLine writes a content line terminated by CRLF, folding lines longer
+// than 75 octets without splitting UTF-8 sequences (RFC 5545 §3.1).
+func writeICSLine(b *strings.Builder, line string) {
+	const maxOctets = 75
+	limit := maxOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = maxOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
+
+func formatCSV(items []meetingapp.InboxItem) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	header := []string{"id", "meeting_id", "meeting_title", "meeting_date", "owner", "text", "due_date", "status", "meeting_link"}
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	for _, entry := range items {
+		item := entry.Item
+		due := ""
+		if item.DueDate() != nil {
+			due = item.DueDate().Format(time.RFC3339)
+		}
+		status := "open"
+		if item.IsCompleted() {
+			status = "completed"
+		}
+		record := []string{
+			string(item.ID()),
+			string(item.MeetingID()),
+			entry.MeetingTitle,
+			entry.MeetingDatetime.Format(time.RFC3339),
+			item.Owner(),
+			item.Text(),
+			due,
+			status,
+			meetingLink(entry),
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return b.String(), w.Error()
+}
@@ -0,0 +1,132 @@
+package export_test
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/felixgeelhaar/acai/internal/application/export"
+	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+func seedActionItemRepo(t *testing.T) *mockRepo {
+	t.Helper()
+	mtg, _ := domain.New("m-1", "Sprint Planning", time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC), domain.SourceZoom, nil)
+	mtg.SetMetadata(domain.NewMetadata(nil, []string{"https://notes.example.com/m-1"}, nil))
+
+	due := time.Date(2025, 1, 17, 17, 0, 0, 0, time.UTC)
+	open, _ := domain.NewActionItem("ai-1", "m-1", "alice@example.com", "Ship release; update changelog, notify team", &due)
+	done, _ := domain.NewActionItem("ai-2", "m-1", "Bob", "Book venue", nil)
+	done.Complete()
+
+	return &mockRepo{
+		meetings:    map[domain.MeetingID]*domain.Meeting{"m-1": mtg},
+		actionItems: map[domain.MeetingID][]*domain.ActionItem{"m-1": {open, done}},
+	}
+}
+
+func TestExportActionItems_ICS(t *testing.T) {
+	uc := export.NewExportActionItems(meetingapp.NewListActionItems(seedActionItemRepo(t), nil, ""))
+
+	out, err := uc.Execute(context.Background(), export.ExportActionItemsInput{Format: export.FormatICS})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Count != 2 {
+		t.Errorf("got count %d, want 2", out.Count)
+	}
+
+	unfolded := strings.ReplaceAll(out.Content, "\r\n ", "")
+	wants := []string{
+		"BEGIN:VCALENDAR\r\n",
+		"VERSION:2.0\r\n",
+		"BEGIN:VTODO\r\n",
+		"UID:ai-1@acai\r\n",
+		"DUE:20250117T170000Z\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"STATUS:COMPLETED\r\n",
+		`SUMMARY:Ship release\; update changelog\, notify team`,
+		"ATTENDEE;ROLE=REQ-PARTICIPANT:mailto:alice@example.com\r\n",
+		`ATTENDEE;CN="Bob"`,
+		"URL:https://notes.example.com/m-1\r\n",
+		"END:VCALENDAR\r\n",
+	}
+	for _, want := range wants {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("ics missing %q\n%s", want, unfolded)
+		}
+	}
+	if !strings.Contains(unfolded, "DESCRIPTION:From meeting: Sprint Planning (2025-01-10)\\nhttps://notes.example.com/m-1") {
+		t.Errorf("ics description should reference the meeting link\n%s", unfolded)
+	}
+}
+
+func TestExportActionItems_ICSFoldsLongLines(t *testing.T) {
+	mtg, _ := domain.New("m-1", "Meeting", time.Now().UTC(), domain.SourceZoom, nil)
+	item, _ := domain.NewActionItem("ai-1", "m-1", "", strings.Repeat("é", 100), nil)
+	repo := &mockRepo{
+		meetings:    map[domain.MeetingID]*domain.Meeting{"m-1": mtg},
+		actionItems: map[domain.MeetingID][]*domain.ActionItem{"m-1": {item}},
+	}
+	uc := export.NewExportActionItems(meetingapp.NewListActionItems(repo, nil, ""))
+
+	out, err := uc.Execute(context.Background(), export.ExportActionItemsInput{Format: export.FormatICS})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out.Content, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets (%d): %q", len(line), line)
+		}
+		if !utf8.ValidString(strings.TrimPrefix(line, " ")) {
+			t.Errorf("fold split a UTF-8 sequence: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(out.Content, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("é", 100)+"\r\n") {
+		t.Error("unfolded content should contain the full summary")
+	}
+}
+
+func TestExportActionItems_CSV(t *testing.T) {
+	uc := export.NewExportActionItems(meetingapp.NewListActionItems(seedActionItemRepo(t), nil, ""))
+
+	out, err := uc.Execute(context.Background(), export.ExportActionItemsInput{
+		Format: export.FormatCSV,
+		Query:  meetingapp.ListActionItemsInput{Status: meetingapp.ActionItemStatusOpen},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(out.Content)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want header + 1", len(records))
+	}
+	if records[0][0] != "id" {
+		t.Errorf("unexpected header %v", records[0])
+	}
+	row := records[1]
+	if row[0] != "ai-1" || row[5] != "Ship release; update changelog, notify team" || row[7] != "open" {
+		t.Errorf("unexpected row %v", row)
+	}
+	if row[8] != "https://notes.example.com/m-1" {
+		t.Errorf("got link %q", row[8])
+	}
+}
+
+func TestExportActionItems_UnsupportedFormat(t *testing.T) {
+	uc := export.NewExportActionItems(meetingapp.NewListActionItems(seedActionItemRepo(t), nil, ""))
+
+	_, err := uc.Execute(context.Background(), export.ExportActionItemsInput{Format: export.FormatMarkdown})
+	if err != export.ErrUnsupportedFormat {
+		t.Errorf("got error %v, want %v", err, export.ErrUnsupportedFormat)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 )
 
@@ -23,19 +24,29 @@ const (
 type ExportMeetingInput struct {
 	MeetingID domain.MeetingID
 	Format    Format
+	// Transcript appends the transcript to markdown and text exports, with
+	// notes anchored to it inline. It is fetched from the source on demand.
+	Transcript bool
 }
 
 type ExportMeetingOutput struct {
 	Content string
 	Format  Format
+	// TranscriptErr is set when the transcript was requested but could not
+	// be loaded; the export is rendered without it.
+	TranscriptErr error
 }
 
 type ExportMeeting struct {
-	repo domain.Repository
+	repo     domain.Repository
+	noteRepo annotation.NoteRepository
 }
 
-func NewExportMeeting(repo domain.Repository) *ExportMeeting {
-	return &ExportMeeting{repo: repo}
+// NewExportMeeting creates the use case. With a note repository, markdown
+// and text exports include agent notes, inline after the transcript
+// utterances they are anchored to when the transcript is exported.
+func NewExportMeeting(repo domain.Repository, noteRepo annotation.NoteRepository) *ExportMeeting {
+	return &ExportMeeting{repo: repo, noteRepo: noteRepo}
 }
 
 func (uc *ExportMeeting) Execute(ctx context.Context, input ExportMeetingInput) (*ExportMeetingOutput, error) {
@@ -49,11 +60,26 @@ func (uc *ExportMeeting) Execute(ctx context.Context, input ExportMeetingInput)
 	}
 
 	var content string
+	var transcriptErr error
 	switch input.Format {
-	case FormatMarkdown:
-		content = formatMarkdown(mtg)
-	case FormatText:
-		content = formatText(mtg)
+	case FormatMarkdown, FormatText:
+		var transcript *domain.Transcript
+		if input.Transcript {
+			transcript, transcriptErr = uc.transcript(ctx, mtg)
+		}
+		layout, err := uc.layoutNotes(ctx, mtg, transcript)
+		if err != nil {
+			return nil, err
+		}
+		var b strings.Builder
+		if input.Format == FormatMarkdown {
+			b.WriteString(formatMarkdown(mtg))
+			formatTranscriptMarkdown(&b, transcript, layout)
+		} else {
+			b.WriteString(formatText(mtg))
+			formatTranscriptText(&b, transcript, layout)
+		}
+		content = b.String()
 	case FormatJSON, "":
 		content = formatJSON(mtg)
 	default:
@@ -66,11 +92,37 @@ func (uc *ExportMeeting) Execute(ctx context.Context, input ExportMeetingInput)
 	}
 
 	return &ExportMeetingOutput{
-		Content: content,
-		Format:  f,
+		Content:       content,
+		Format:        f,
+		TranscriptErr: transcriptErr,
 	}, nil
 }
 
+// transcript loads the meeting's transcript. A meeting without one yet
+// exports without a transcript section.
+func (uc *ExportMeeting) transcript(ctx context.Context, mtg *domain.Meeting) (*domain.Transcript, error) {
+	if t := mtg.Transcript(); t != nil {
+		return t, nil
+	}
+	t, err := uc.repo.GetTranscript(ctx, mtg.ID())
+	if errors.Is(err, domain.ErrTranscriptNotReady) {
+		return nil, nil
+	}
+	return t, err
+}
+
+// layoutNotes lays out the meeting's notes around the transcript, if any.
+func (uc *ExportMeeting) layoutNotes(ctx context.Context, mtg *domain.Meeting, transcript *domain.Transcript) (noteLayout, error) {
+	var notes []*annotation.AgentNote
+	if uc.noteRepo != nil {
+		var err error
+		if notes, err = uc.noteRepo.ListByMeeting(ctx, string(mtg.ID())); err != nil {
+			return noteLayout{}, err
+		}
+	}
+	return layoutNotes(transcript, notes), nil
+}
+
 func formatMarkdown(m *domain.Meeting) string {
 	var b strings.Builder
 	_, _ = fmt.Fprintf(&b, "# %s\n\n", m.Title())
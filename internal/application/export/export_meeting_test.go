@@ -2,16 +2,21 @@ package export_test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/felixgeelhaar/acai/internal/application/export"
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 )
 
 type mockRepo struct {
-	meetings map[domain.MeetingID]*domain.Meeting
+	meetings        map[domain.MeetingID]*domain.Meeting
+	actionItems     map[domain.MeetingID][]*domain.ActionItem
+	transcriptErr   error
+	transcriptCalls int
 }
 
 func (m *mockRepo) FindByID(_ context.Context, id domain.MeetingID) (*domain.Meeting, error) {
@@ -23,16 +28,21 @@ func (m *mockRepo) FindByID(_ context.Context, id domain.MeetingID) (*domain.Mee
 }
 
 func (m *mockRepo) List(_ context.Context, _ domain.ListFilter) ([]*domain.Meeting, error) {
-	return nil, nil
+	result := make([]*domain.Meeting, 0, len(m.meetings))
+	for _, mtg := range m.meetings {
+		result = append(result, mtg)
+	}
+	return result, nil
 }
 func (m *mockRepo) GetTranscript(_ context.Context, _ domain.MeetingID) (*domain.Transcript, error) {
-	return nil, nil
+	m.transcriptCalls++
+	return nil, m.transcriptErr
 }
 func (m *mockRepo) SearchTranscripts(_ context.Context, _ string, _ domain.ListFilter) ([]*domain.Meeting, error) {
 	return nil, nil
 }
-func (m *mockRepo) GetActionItems(_ context.Context, _ domain.MeetingID) ([]*domain.ActionItem, error) {
-	return nil, nil
+func (m *mockRepo) GetActionItems(_ context.Context, id domain.MeetingID) ([]*domain.ActionItem, error) {
+	return m.actionItems[id], nil
 }
 func (m *mockRepo) Sync(_ context.Context, _ *time.Time) ([]domain.DomainEvent, error) {
 	return nil, nil
@@ -46,7 +56,7 @@ func TestExportMeeting_Markdown(t *testing.T) {
 	mtg.ClearDomainEvents()
 
 	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{"m-1": mtg}}
-	uc := export.NewExportMeeting(repo)
+	uc := export.NewExportMeeting(repo, nil)
 
 	out, err := uc.Execute(context.Background(), export.ExportMeetingInput{
 		MeetingID: "m-1",
@@ -71,7 +81,7 @@ func TestExportMeeting_JSON(t *testing.T) {
 	mtg.ClearDomainEvents()
 
 	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{"m-1": mtg}}
-	uc := export.NewExportMeeting(repo)
+	uc := export.NewExportMeeting(repo, nil)
 
 	out, err := uc.Execute(context.Background(), export.ExportMeetingInput{
 		MeetingID: "m-1",
@@ -87,7 +97,7 @@ func TestExportMeeting_JSON(t *testing.T) {
 
 func TestExportMeeting_NotFound(t *testing.T) {
 	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{}}
-	uc := export.NewExportMeeting(repo)
+	uc := export.NewExportMeeting(repo, nil)
 
 	_, err := uc.Execute(context.Background(), export.ExportMeetingInput{
 		MeetingID: "nonexistent",
@@ -103,7 +113,7 @@ func TestExportMeeting_UnsupportedFormat(t *testing.T) {
 	mtg.ClearDomainEvents()
 
 	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{"m-1": mtg}}
-	uc := export.NewExportMeeting(repo)
+	uc := export.NewExportMeeting(repo, nil)
 
 	_, err := uc.Execute(context.Background(), export.ExportMeetingInput{
 		MeetingID: "m-1",
@@ -116,10 +126,244 @@ func TestExportMeeting_UnsupportedFormat(t *testing.T) {
 
 func TestExportMeeting_EmptyID(t *testing.T) {
 	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{}}
-	uc := export.NewExportMeeting(repo)
+	uc := export.NewExportMeeting(repo, nil)
 
 	_, err := uc.Execute(context.Background(), export.ExportMeetingInput{MeetingID: ""})
 	if err != domain.ErrInvalidMeetingID {
 		t.Errorf("got error %v, want %v", err, domain.ErrInvalidMeetingID)
 	}
 }
+
+type mockNoteRepo struct {
+	notes []*annotation.AgentNote
+}
+
+func (m *mockNoteRepo) Save(_ context.Context, note *annotation.AgentNote) error {
+	m.notes = append(m.notes, note)
+	return nil
+}
+func (m *mockNoteRepo) FindByID(_ context.Context, _ annotation.NoteID) (*annotation.AgentNote, error) {
+	return nil, annotation.ErrNoteNotFound
+}
+func (m *mockNoteRepo) FindByIdempotencyKey(_ context.Context, _, _ string) (*annotation.AgentNote, error) {
+	return nil, annotation.ErrNoteNotFound
+}
+func (m *mockNoteRepo) ListByMeeting(_ context.Context, _ string) ([]*annotation.AgentNote, error) {
+	return m.notes, nil
+}
+func (m *mockNoteRepo) ListAll(_ context.Context) ([]*annotation.AgentNote, error) {
+	return m.notes, nil
+}
+func (m *mockNoteRepo) Search(_ context.Context, _ annotation.NoteFilter) ([]*annotation.AgentNote, error) {
+	return m.notes, nil
+}
+func (m *mockNoteRepo) Delete(_ context.Context, _ annotation.NoteID) error {
+	return nil
+}
+func (m *mockNoteRepo) Update(_ context.Context, _ *annotation.AgentNote, _ annotation.NoteRevision) error {
+	return nil
+}
+func (m *mockNoteRepo) ListRevisions(_ context.Context, _ annotation.NoteID) ([]annotation.NoteRevision, error) {
+	return nil, nil
+}
+
+func TestExportMeeting_RendersAnchoredNotesInline(t *testing.T) {
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	mtg, _ := domain.New("m-1", "Sprint Planning", start, domain.SourceZoom, nil)
+	mtg.AttachTranscript(domain.NewTranscript("m-1", []domain.Utterance{
+		domain.NewUtterance("Alice", "Let's review the budget.", start, 0.9),
+		domain.NewUtterance("Bob", "We're over by ten percent.", start.Add(time.Minute), 0.9),
+	}))
+	mtg.ClearDomainEvents()
+
+	risk, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Budget overrun")
+	_ = risk.Classify(annotation.KindRisk)
+	anchor, _ := annotation.NewTimeRangeAnchor(start.Add(time.Minute), time.Time{})
+	risk.AnchorTo(anchor)
+	reply, _ := annotation.NewAgentNote("n-2", "m-1", "alice", "Finance is aware")
+	_ = reply.ReplyTo(risk)
+	loose, _ := annotation.NewAgentNote("n-3", "m-1", "claude", "Follow up next week")
+
+	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{"m-1": mtg}}
+	notes := &mockNoteRepo{notes: []*annotation.AgentNote{risk, reply, loose}}
+	uc := export.NewExportMeeting(repo, notes)
+
+	out, err := uc.Execute(context.Background(), export.ExportMeetingInput{
+		MeetingID:  "m-1",
+		Format:     export.FormatMarkdown,
+		Transcript: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "**[10:01:00] Bob:** We're over by ten percent.\n\n" +
+		"> - [risk] claude: Budget overrun\n" +
+		">   - [↳ note] alice: Finance is aware\n"
+	if !strings.Contains(out.Content, want) {
+		t.Errorf("anchored note and reply not rendered after their utterance:\n%s", out.Content)
+	}
+	if !strings.Contains(out.Content, "## Notes\n\n- [note] claude: Follow up next week\n") {
+		t.Errorf("unanchored note not listed under Notes:\n%s", out.Content)
+	}
+
+	out, err = uc.Execute(context.Background(), export.ExportMeetingInput{
+		MeetingID:  "m-1",
+		Format:     export.FormatText,
+		Transcript: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.Content, "[10:01:00] Bob: We're over by ten percent.\n    [risk] claude: Budget overrun\n      [↳ note] alice: Finance is aware\n") {
+		t.Errorf("text export missing inline notes:\n%s", out.Content)
+	}
+}
+
+func TestExportMeeting_PlacesRangeBetweenUtterancesInline(t *testing.T) {
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	mtg, _ := domain.New("m-1", "Sprint Planning", start, domain.SourceZoom, nil)
+	mtg.AttachTranscript(domain.NewTranscript("m-1", []domain.Utterance{
+		domain.NewUtterance("Alice", "Let's review the budget.", start.Add(5*time.Second), 0.9),
+		domain.NewUtterance("Bob", "We're over by ten percent.", start.Add(20*time.Second), 0.9),
+	}))
+	mtg.ClearDomainEvents()
+
+	between, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Silence here")
+	anchor, _ := annotation.NewTimeRangeAnchor(start.Add(10*time.Second), start.Add(12*time.Second))
+	between.AnchorTo(anchor)
+	early, _ := annotation.NewAgentNote("n-2", "m-1", "claude", "Before anyone spoke")
+	anchor, _ = annotation.NewTimeRangeAnchor(start, start.Add(time.Second))
+	early.AnchorTo(anchor)
+
+	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{"m-1": mtg}}
+	uc := export.NewExportMeeting(repo, &mockNoteRepo{notes: []*annotation.AgentNote{between, early}})
+
+	out, err := uc.Execute(context.Background(), export.ExportMeetingInput{MeetingID: "m-1", Format: export.FormatMarkdown, Transcript: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "**[10:00:05] Alice:** Let's review the budget.\n\n" +
+		"> - [note] claude: Silence here\n"
+	if !strings.Contains(out.Content, want) {
+		t.Errorf("range between utterances not placed after the earlier one:\n%s", out.Content)
+	}
+	if !strings.Contains(out.Content, "## Notes\n\n- [note] claude: Before anyone spoke\n") {
+		t.Errorf("range before the transcript not listed under Notes:\n%s", out.Content)
+	}
+}
+
+func TestExportMeeting_KeepsMultiLineNotesTogether(t *testing.T) {
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	mtg, _ := domain.New("m-1", "Sprint Planning", start, domain.SourceZoom, nil)
+	mtg.AttachTranscript(domain.NewTranscript("m-1", []domain.Utterance{
+		domain.NewUtterance("Alice", "Let's review the budget.", start, 0.9),
+		domain.NewUtterance("Bob", "We're over by ten percent.", start.Add(time.Minute), 0.9),
+	}))
+	mtg.ClearDomainEvents()
+
+	note, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Budget overrun:\n\ncut travel first")
+	anchor, _ := annotation.NewUtteranceAnchor(0)
+	note.AnchorTo(anchor)
+
+	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{"m-1": mtg}}
+	uc := export.NewExportMeeting(repo, &mockNoteRepo{notes: []*annotation.AgentNote{note}})
+
+	out, err := uc.Execute(context.Background(), export.ExportMeetingInput{MeetingID: "m-1", Format: export.FormatMarkdown, Transcript: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "> - [note] claude: Budget overrun:\n" +
+		">\n" +
+		">   cut travel first\n\n" +
+		"**[10:01:00] Bob:**"
+	if !strings.Contains(out.Content, want) {
+		t.Errorf("multi-line note left its blockquote:\n%s", out.Content)
+	}
+
+	out, err = uc.Execute(context.Background(), export.ExportMeetingInput{MeetingID: "m-1", Format: export.FormatText, Transcript: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = "[10:00:00] Alice: Let's review the budget.\n" +
+		"    [note] claude: Budget overrun:\n" +
+		"\n" +
+		"      cut travel first\n" +
+		"[10:01:00] Bob:"
+	if !strings.Contains(out.Content, want) {
+		t.Errorf("multi-line note not indented under its utterance:\n%s", out.Content)
+	}
+}
+
+func TestExportMeeting_TranscriptIsOptIn(t *testing.T) {
+	mtg, _ := domain.New("m-1", "Sprint Planning", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC), domain.SourceZoom, nil)
+	mtg.ClearDomainEvents()
+	note, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Follow up next week")
+
+	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{"m-1": mtg}}
+	uc := export.NewExportMeeting(repo, &mockNoteRepo{notes: []*annotation.AgentNote{note}})
+
+	out, err := uc.Execute(context.Background(), export.ExportMeetingInput{MeetingID: "m-1", Format: export.FormatMarkdown})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.transcriptCalls != 0 {
+		t.Errorf("transcript fetched %d time(s) without being requested", repo.transcriptCalls)
+	}
+	if !strings.Contains(out.Content, "## Notes\n\n- [note] claude: Follow up next week\n") {
+		t.Errorf("note not listed under Notes:\n%s", out.Content)
+	}
+}
+
+func TestExportMeeting_ExportsWithoutTranscriptWhenFetchFails(t *testing.T) {
+	mtg, _ := domain.New("m-1", "Sprint Planning", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC), domain.SourceZoom, nil)
+	mtg.ClearDomainEvents()
+	fetchErr := errors.New("granola: service unavailable")
+
+	repo := &mockRepo{meetings: map[domain.MeetingID]*domain.Meeting{"m-1": mtg}, transcriptErr: fetchErr}
+	uc := export.NewExportMeeting(repo, nil)
+
+	out, err := uc.Execute(context.Background(), export.ExportMeetingInput{MeetingID: "m-1", Format: export.FormatText, Transcript: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !errors.Is(out.TranscriptErr, fetchErr) {
+		t.Errorf("got transcript error %v, want %v", out.TranscriptErr, fetchErr)
+	}
+	if !strings.HasPrefix(out.Content, "Sprint Planning\n") || strings.Contains(out.Content, "Transcript:") {
+		t.Errorf("unexpected export:\n%s", out.Content)
+	}
+
+	// A transcript that is not ready yet is no failure.
+	repo.transcriptErr = domain.ErrTranscriptNotReady
+	out, err = uc.Execute(context.Background(), export.ExportMeetingInput{MeetingID: "m-1", Format: export.FormatText, Transcript: true})
+	if err != nil || out.TranscriptErr != nil {
+		t.Errorf("got %v, %v; want an export without error", err, out.TranscriptErr)
+	}
+}
+
+func TestTranscriptText_RendersNotesInline(t *testing.T) {
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	transcript := domain.NewTranscript("m-1", []domain.Utterance{
+		domain.NewUtterance("Alice", "Let's review the budget.", start, 0.9),
+		domain.NewUtterance("Bob", "We're over by ten percent.", start.Add(time.Minute), 0.9),
+	})
+	risk, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Budget overrun")
+	anchor, _ := annotation.NewUtteranceAnchor(1)
+	risk.AnchorTo(anchor)
+	reply, _ := annotation.NewAgentNote("n-2", "m-1", "alice", "Finance is aware")
+	_ = reply.ReplyTo(risk)
+
+	got := export.TranscriptText(&transcript, []*annotation.AgentNote{risk, reply})
+	want := "[10:00:00] Alice: Let's review the budget.\n" +
+		"[10:01:00] Bob: We're over by ten percent.\n" +
+		"    [note] claude: Budget overrun\n" +
+		"      [↳ note] alice: Finance is aware\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	threads := export.PlaceNotes(&transcript, []*annotation.AgentNote{risk, reply})
+	if len(threads.Inline[1]) != 2 || threads.Inline[1][1] != reply || len(threads.Unplaced) != 0 {
+		t.Errorf("got %+v, want the thread of n-1 at utterance 1", threads)
+	}
+}
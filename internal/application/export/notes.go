@@ -0,0 +1,186 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// noteLayout places a meeting's notes for rendering alongside its transcript.
+type noteLayout struct {
+	inline   map[int][]*annotation.AgentNote // by utterance index: notes anchored there
+	unplaced []*annotation.AgentNote         // unanchored, or anchored outside the transcript
+	replies  map[annotation.NoteID][]*annotation.AgentNote
+}
+
+// layoutNotes places each anchored note after the last utterance its anchor
+// covers; a time range is placed after the last utterance at or before its
+// end, so a range falling between two utterances still lands inline. Replies are rendered under their parent; a reply whose parent is
+// gone is placed like a top-level note.
+func layoutNotes(t *domain.Transcript, notes []*annotation.AgentNote) noteLayout {
+	layout := noteLayout{
+		inline:  make(map[int][]*annotation.AgentNote),
+		replies: make(map[annotation.NoteID][]*annotation.AgentNote),
+	}
+	ids := make(map[annotation.NoteID]bool, len(notes))
+	for _, n := range notes {
+		ids[n.ID()] = true
+	}
+
+	var utterances []domain.Utterance
+	if t != nil {
+		utterances = t.Utterances()
+	}
+	for _, n := range notes {
+		if n.IsReply() && ids[n.ParentID()] {
+			layout.replies[n.ParentID()] = append(layout.replies[n.ParentID()], n)
+			continue
+		}
+		at := -1
+		for i, u := range utterances {
+			if placesAfter(n.Anchor(), i, u.Timestamp()) {
+				at = i
+			}
+		}
+		if at < 0 {
+			layout.unplaced = append(layout.unplaced, n)
+			continue
+		}
+		layout.inline[at] = append(layout.inline[at], n)
+	}
+	return layout
+}
+
+// placesAfter reports whether a note anchored at a may follow the utterance
+// at index, spoken at ts.
+func placesAfter(a annotation.Anchor, index int, ts time.Time) bool {
+	if _, end, ok := a.TimeRange(); ok {
+		return !ts.After(end)
+	}
+	return a.Covers(index, ts)
+}
+
+// writeNote writes a note and, nested one level deeper, its replies. Every
+// line of the note carries prefix, and lines after the first are indented
+// under the bullet, so a multi-line note stays within its quote and item.
+func (l noteLayout) writeNote(b *strings.Builder, n *annotation.AgentNote, prefix, bullet string, depth int) {
+	label := string(n.Kind())
+	if n.IsReply() {
+		label = "↳ " + label
+	}
+	indent := strings.Repeat("  ", depth)
+	first, rest, multiline := strings.Cut(n.Content(), "\n")
+	_, _ = fmt.Fprintf(b, "%s%s%s[%s] %s: %s\n", prefix, indent, bullet, label, n.Author(), first)
+	if multiline {
+		b.WriteString(prefixLines(rest, prefix+indent+"  ", strings.TrimRight(prefix, " ")))
+		b.WriteString("\n")
+	}
+	for _, reply := range l.replies[n.ID()] {
+		l.writeNote(b, reply, prefix, bullet, depth+1)
+	}
+}
+
+// prefixLines prefixes each line of s, and empty lines with emptyPrefix.
+func prefixLines(s, prefix, emptyPrefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+			continue
+		}
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatTranscriptMarkdown(b *strings.Builder, t *domain.Transcript, l noteLayout) {
+	if t != nil && len(t.Utterances()) > 0 {
+		b.WriteString("## Transcript\n\n")
+		for i, u := range t.Utterances() {
+			_, _ = fmt.Fprintf(b, "**[%s] %s:** %s\n\n", u.Timestamp().Format("15:04:05"), u.Speaker(), u.Text())
+			for _, n := range l.inline[i] {
+				l.writeNote(b, n, "> ", "- ", 0)
+			}
+			if len(l.inline[i]) > 0 {
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	if len(l.unplaced) > 0 {
+		b.WriteString("## Notes\n\n")
+		for _, n := range l.unplaced {
+			l.writeNote(b, n, "", "- ", 0)
+		}
+		b.WriteString("\n")
+	}
+}
+
+func formatTranscriptText(b *strings.Builder, t *domain.Transcript, l noteLayout) {
+	if t != nil && len(t.Utterances()) > 0 {
+		b.WriteString("\nTranscript:\n")
+	}
+	writeTranscriptText(b, t, l)
+}
+
+// writeTranscriptText writes one utterance per line, each followed by the
+// notes anchored to it, then the notes that fit no utterance.
+func writeTranscriptText(b *strings.Builder, t *domain.Transcript, l noteLayout) {
+	if t != nil {
+		for i, u := range t.Utterances() {
+			_, _ = fmt.Fprintf(b, "[%s] %s: %s\n", u.Timestamp().Format("15:04:05"), u.Speaker(), u.Text())
+			for _, n := range l.inline[i] {
+				l.writeNote(b, n, "    ", "", 0)
+			}
+		}
+	}
+
+	if len(l.unplaced) > 0 {
+		b.WriteString("\nNotes:\n")
+		for _, n := range l.unplaced {
+			l.writeNote(b, n, "  ", "", 0)
+		}
+	}
+}
+
+// TranscriptText renders a transcript as `acai transcript show` prints it:
+// one utterance per line, each followed by the notes anchored to it. Notes
+// that fit no utterance are listed after the transcript.
+func TranscriptText(t *domain.Transcript, notes []*annotation.AgentNote) string {
+	var b strings.Builder
+	writeTranscriptText(&b, t, layoutNotes(t, notes))
+	return b.String()
+}
+
+// NoteThreads places a meeting's notes along its transcript for structured
+// views. A thread is a note followed by its replies, depth first.
+type NoteThreads struct {
+	Inline   map[int][]*annotation.AgentNote // by utterance index: the threads anchored there
+	Unplaced []*annotation.AgentNote         // threads that fit no utterance
+}
+
+// PlaceNotes places notes the way transcript exports render them.
+func PlaceNotes(t *domain.Transcript, notes []*annotation.AgentNote) NoteThreads {
+	l := layoutNotes(t, notes)
+	threads := NoteThreads{Inline: make(map[int][]*annotation.AgentNote, len(l.inline))}
+	for i, top := range l.inline {
+		for _, n := range top {
+			threads.Inline[i] = l.appendThread(threads.Inline[i], n)
+		}
+	}
+	for _, n := range l.unplaced {
+		threads.Unplaced = l.appendThread(threads.Unplaced, n)
+	}
+	return threads
+}
+
+func (l noteLayout) appendThread(dst []*annotation.AgentNote, n *annotation.AgentNote) []*annotation.AgentNote {
+	dst = append(dst, n)
+	for _, reply := range l.replies[n.ID()] {
+		dst = l.appendThread(dst, reply)
+	}
+	return dst
+}
@@ -0,0 +1,214 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/felixgeelhaar/acai/internal/application/embedding"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// DefaultSummaryMaxTokens bounds the transcript text, or the partial
+// summaries, sent to the model in one prompt. It suits the context window
+// of small local models.
+const DefaultSummaryMaxTokens = 2000
+
+// minReplyTokens keeps the reply bound usable for very small budgets.
+const minReplyTokens = 128
+
+const summarySystemPrompt = "You summarize meetings from their transcripts. " +
+	"Be factual and concise. Use only what the text says; never invent names, decisions or dates."
+
+// ErrEmptyCompletion is returned when the model replies with no text.
+var ErrEmptyCompletion = errors.New("language model returned an empty reply")
+
+type GenerateSummaryInput struct {
+	MeetingID domain.MeetingID
+	Strategy  string // chunking strategy: speaker_turn (default), time_window, token_limit
+	MaxTokens int    // per prompt; 0 uses DefaultSummaryMaxTokens. Replies are capped at half of it
+}
+
+type GenerateSummaryOutput struct {
+	Summary    domain.Summary
+	Model      string
+	ChunkCount int // transcript chunks the summary was built from
+}
+
+// GenerateSummary writes a summary for a meeting Granola has none for, using
+// a language model. The transcript is chunked, consecutive chunks are packed
+// into prompts that fit MaxTokens and summarized one by one (map), and the
+// partial summaries are merged the same way until one remains (reduce).
+// The result is stored locally as a SummaryGenerated summary; running it
+// again replaces it.
+type GenerateSummary struct {
+	repo       domain.Repository
+	summaries  domain.SummaryStore
+	llm        domain.LLMProvider
+	dispatcher domain.EventDispatcher
+}
+
+// NewGenerateSummary creates a new GenerateSummary use case.
+// dispatcher is optional.
+func NewGenerateSummary(repo domain.Repository, summaries domain.SummaryStore, llm domain.LLMProvider, dispatcher domain.EventDispatcher) *GenerateSummary {
+	return &GenerateSummary{repo: repo, summaries: summaries, llm: llm, dispatcher: dispatcher}
+}
+
+func (uc *GenerateSummary) Execute(ctx context.Context, input GenerateSummaryInput) (*GenerateSummaryOutput, error) {
+	if input.MeetingID == "" {
+		return nil, domain.ErrInvalidMeetingID
+	}
+	maxTokens := input.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultSummaryMaxTokens
+	}
+	strategy, err := embedding.ResolveStrategy(input.Strategy, maxTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	mtg, err := uc.repo.FindByID(ctx, input.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	if s := mtg.Summary(); s != nil && s.Kind() != domain.SummaryGenerated {
+		return nil, domain.ErrSummaryExists
+	}
+
+	transcript := mtg.Transcript()
+	if transcript == nil {
+		if transcript, err = uc.repo.GetTranscript(ctx, input.MeetingID); err != nil {
+			return nil, err
+		}
+	}
+	if transcript == nil || len(transcript.Utterances()) == 0 {
+		return nil, domain.ErrTranscriptNotReady
+	}
+
+	chunks, err := strategy.ChunkTranscript(mtg.ID(), transcript.Utterances())
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]string, len(chunks))
+	for i, c := range chunks {
+		parts[i] = formatSummaryChunk(c)
+	}
+
+	content, err := uc.mapReduce(ctx, mtg.Title(), parts, maxTokens, max(maxTokens/2, minReplyTokens))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := domain.NewSummary(mtg.ID(), content, domain.SummaryGenerated)
+	if err := uc.summaries.SaveSummary(ctx, summary); err != nil {
+		return nil, err
+	}
+	events := mtg.AttachSummary(summary)
+	if uc.dispatcher != nil {
+		if err := uc.dispatcher.Dispatch(ctx, events); err != nil {
+			return nil, err
+		}
+	}
+
+	return &GenerateSummaryOutput{Summary: summary, Model: uc.llm.Model(), ChunkCount: len(chunks)}, nil
+}
+
+// mapReduce condenses transcript parts into one summary. If the whole
+// transcript fits one prompt it is summarized directly; otherwise each
+// batch of parts is condensed to notes, and batches of notes are merged
+// until they fit the final prompt. Every reply is capped at replyTokens, so
+// a local model cannot run on unbounded and two replies fit one prompt.
+func (uc *GenerateSummary) mapReduce(ctx context.Context, title string, parts []string, maxTokens, replyTokens int) (string, error) {
+	batches := packBatches(parts, maxTokens)
+	if len(batches) == 1 {
+		return uc.complete(ctx, replyTokens, finalSummaryPrompt(title, "transcript", batches[0]))
+	}
+
+	notes := make([]string, len(batches))
+	for i, batch := range batches {
+		var err error
+		notes[i], err = uc.complete(ctx, replyTokens, fmt.Sprintf(
+			"Meeting: %s\nThis is part %d of %d of the transcript.\n\n%s\n\n"+
+				"List the points discussed, decisions made and action items (with owners) in this part as short bullet points.",
+			title, i+1, len(batches), strings.Join(batch, "\n\n")))
+		if err != nil {
+			return "", fmt.Errorf("summarize part %d of %d: %w", i+1, len(batches), err)
+		}
+	}
+
+	for {
+		batches = packBatches(notes, maxTokens)
+		if len(batches) == len(notes) {
+			// No two notes fit one prompt; merge pairs anyway so every
+			// round shrinks the list.
+			batches = nil
+			for i := 0; i < len(notes); i += 2 {
+				batches = append(batches, notes[i:min(i+2, len(notes))])
+			}
+		}
+		if len(batches) == 1 {
+			return uc.complete(ctx, replyTokens, finalSummaryPrompt(title, "notes on consecutive parts of the transcript", batches[0]))
+		}
+		merged := make([]string, len(batches))
+		for i, batch := range batches {
+			var err error
+			merged[i], err = uc.complete(ctx, replyTokens, fmt.Sprintf(
+				"Meeting: %s\nBelow are notes on consecutive parts of the transcript.\n\n%s\n\n"+
+					"Merge them into one list of short bullet points, keeping every decision and action item.",
+				title, strings.Join(batch, "\n\n")))
+			if err != nil {
+				return "", fmt.Errorf("merge notes: %w", err)
+			}
+		}
+		notes = merged
+	}
+}
+
+func (uc *GenerateSummary) complete(ctx context.Context, maxTokens int, prompt string) (string, error) {
+	reply, err := uc.llm.Complete(ctx, domain.CompletionRequest{System: summarySystemPrompt, Prompt: prompt, MaxTokens: maxTokens})
+	if err != nil {
+		return "", err
+	}
+	reply = strings.TrimSpace(reply)
+	if reply == "" {
+		return "", ErrEmptyCompletion
+	}
+	return reply, nil
+}
+
+func finalSummaryPrompt(title, source string, batch []string) string {
+	return fmt.Sprintf(
+		"Meeting: %s\nBelow is the meeting's %s.\n\n%s\n\n"+
+			"Write a summary of the meeting in Markdown: a short overview paragraph, "+
+			"then a \"Decisions\" list and an \"Action items\" list with owners where known. Leave out a list that would be empty.",
+		title, source, strings.Join(batch, "\n\n"))
+}
+
+// formatSummaryChunk renders a transcript chunk with its time and speakers.
+func formatSummaryChunk(c domain.Chunk) string {
+	return fmt.Sprintf("[%s] %s: %s", c.StartTime().UTC().Format("15:04:05"), c.Speaker(), c.Content())
+}
+
+// packBatches groups consecutive texts into batches of at most maxTokens.
+// A text larger than that gets a batch of its own.
+func packBatches(texts []string, maxTokens int) [][]string {
+	var (
+		batches [][]string
+		current []string
+		tokens  int
+	)
+	for _, text := range texts {
+		n := embedding.EstimateTokens(text)
+		if len(current) > 0 && tokens+n > maxTokens {
+			batches = append(batches, current)
+			current, tokens = nil, 0
+		}
+		current = append(current, text)
+		tokens += n
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
@@ -0,0 +1,197 @@
+package meeting_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/application/embedding"
+	app "github.com/felixgeelhaar/acai/internal/application/meeting"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/llm"
+)
+
+// seedSummaryRepo adds meeting m-1 with a transcript of n utterances of
+// fifteen words each, alternating between two speakers.
+func seedSummaryRepo(t *testing.T, n int) *mockRepository {
+	t.Helper()
+	repo := newMockRepository()
+	repo.addMeeting(mustNewMeeting(t, "m-1", "Sprint planning"))
+
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	utterances := make([]domain.Utterance, n)
+	for i := range utterances {
+		speaker := "Alice"
+		if i%2 == 1 {
+			speaker = "Bob"
+		}
+		text := strings.TrimSpace(strings.Repeat("we should ship the release ", 3))
+		utterances[i] = domain.NewUtterance(speaker, text, start.Add(time.Duration(i)*time.Minute), 0.9)
+	}
+	transcript := domain.NewTranscript("m-1", utterances)
+	repo.addTranscript("m-1", &transcript)
+	return repo
+}
+
+func TestGenerateSummary_ShortTranscriptInOnePrompt(t *testing.T) {
+	repo := seedSummaryRepo(t, 2)
+	store := newMockSummaryStore()
+	fake := llm.NewFake()
+	dispatcher := &mockDispatcher{}
+
+	uc := app.NewGenerateSummary(repo, store, fake, dispatcher)
+	out, err := uc.Execute(context.Background(), app.GenerateSummaryInput{MeetingID: "m-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d completions, want 1", len(requests))
+	}
+	if !strings.Contains(requests[0].Prompt, "Sprint planning") || !strings.Contains(requests[0].Prompt, "[10:01:00] Bob: we should ship") {
+		t.Errorf("prompt lacks the title or transcript:\n%s", requests[0].Prompt)
+	}
+	if requests[0].System == "" {
+		t.Error("system prompt not set")
+	}
+	if requests[0].MaxTokens != app.DefaultSummaryMaxTokens/2 {
+		t.Errorf("got reply bound %d, want %d", requests[0].MaxTokens, app.DefaultSummaryMaxTokens/2)
+	}
+
+	if out.Summary.Kind() != domain.SummaryGenerated || out.Summary.Content() != "summary 1" {
+		t.Errorf("got summary %+v", out.Summary)
+	}
+	if out.Model != "fake" || out.ChunkCount != 2 {
+		t.Errorf("got model %q, %d chunks", out.Model, out.ChunkCount)
+	}
+	if stored, ok := store.summaries["m-1"]; !ok || !stored.Equals(out.Summary) {
+		t.Errorf("summary not stored: %+v", store.summaries)
+	}
+	if len(dispatcher.events) != 1 || dispatcher.events[0].EventName() != "summary.updated" {
+		t.Errorf("got events %v, want one summary.updated", dispatcher.events)
+	}
+}
+
+func TestGenerateSummary_MapReducesLongTranscript(t *testing.T) {
+	repo := seedSummaryRepo(t, 8)
+	store := newMockSummaryStore()
+	fake := llm.NewFake()
+
+	uc := app.NewGenerateSummary(repo, store, fake, nil)
+	out, err := uc.Execute(context.Background(), app.GenerateSummaryInput{MeetingID: "m-1", MaxTokens: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Eight speaker turns of ~22 tokens pack two to a prompt: four map
+	// calls, whose short notes fit one final prompt.
+	requests := fake.Requests()
+	if len(requests) != 5 {
+		t.Fatalf("got %d completions, want 5", len(requests))
+	}
+	for _, req := range requests {
+		if req.MaxTokens != 128 {
+			t.Errorf("got reply bound %d, want the 128 token floor", req.MaxTokens)
+		}
+	}
+	if !strings.Contains(requests[0].Prompt, "part 1 of 4") {
+		t.Errorf("first prompt is not a map prompt:\n%s", requests[0].Prompt)
+	}
+	final := requests[4].Prompt
+	for _, want := range []string{"notes on consecutive parts", "summary 1", "summary 4"} {
+		if !strings.Contains(final, want) {
+			t.Errorf("final prompt lacks %q:\n%s", want, final)
+		}
+	}
+	if out.Summary.Content() != "summary 5" || out.ChunkCount != 8 {
+		t.Errorf("got %q from %d chunks", out.Summary.Content(), out.ChunkCount)
+	}
+}
+
+func TestGenerateSummary_MergesNotesThatDoNotFitOnePrompt(t *testing.T) {
+	repo := seedSummaryRepo(t, 8)
+	fake := llm.NewFake()
+	var merges int
+	fake.Reply = func(req domain.CompletionRequest) (string, error) {
+		if strings.Contains(req.Prompt, "Merge them") {
+			merges++
+		}
+		// Every reply is larger than the prompt budget.
+		return strings.Repeat("decision ", 60), nil
+	}
+
+	uc := app.NewGenerateSummary(repo, newMockSummaryStore(), fake, nil)
+	if _, err := uc.Execute(context.Background(), app.GenerateSummaryInput{MeetingID: "m-1", MaxTokens: 50}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Four map notes are merged in pairs (2), the two results once more
+	// as a final pair: 4 map + 2 merge + 1 final.
+	if got := len(fake.Requests()); got != 7 || merges != 2 {
+		t.Errorf("got %d completions with %d merges, want 7 with 2", got, merges)
+	}
+}
+
+func TestGenerateSummary_KeepsGranolaSummary(t *testing.T) {
+	repo := seedSummaryRepo(t, 2)
+	repo.meetings["m-1"].AttachSummary(domain.NewSummary("m-1", "From Granola", domain.SummaryAuto))
+	fake := llm.NewFake()
+
+	uc := app.NewGenerateSummary(repo, newMockSummaryStore(), fake, nil)
+	_, err := uc.Execute(context.Background(), app.GenerateSummaryInput{MeetingID: "m-1"})
+	if !errors.Is(err, domain.ErrSummaryExists) {
+		t.Errorf("got error %v, want ErrSummaryExists", err)
+	}
+	if len(fake.Requests()) != 0 {
+		t.Error("model called for a meeting with a Granola summary")
+	}
+}
+
+func TestGenerateSummary_ReplacesGeneratedSummary(t *testing.T) {
+	repo := seedSummaryRepo(t, 2)
+	repo.meetings["m-1"].AttachSummary(domain.NewSummary("m-1", "Old", domain.SummaryGenerated))
+	store := newMockSummaryStore()
+
+	uc := app.NewGenerateSummary(repo, store, llm.NewFake(), nil)
+	if _, err := uc.Execute(context.Background(), app.GenerateSummaryInput{MeetingID: "m-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.summaries["m-1"].Content() != "summary 1" {
+		t.Errorf("got %q, want the regenerated summary", store.summaries["m-1"].Content())
+	}
+}
+
+func TestGenerateSummary_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    *mockRepository
+		input   app.GenerateSummaryInput
+		reply   string
+		wantErr error
+	}{
+		{"empty meeting ID", seedSummaryRepo(t, 2), app.GenerateSummaryInput{}, "ok", domain.ErrInvalidMeetingID},
+		{"unknown meeting", seedSummaryRepo(t, 2), app.GenerateSummaryInput{MeetingID: "m-2"}, "ok", domain.ErrMeetingNotFound},
+		{"no transcript", seedSummaryRepo(t, 0), app.GenerateSummaryInput{MeetingID: "m-1"}, "ok", domain.ErrTranscriptNotReady},
+		{"unknown strategy", seedSummaryRepo(t, 2), app.GenerateSummaryInput{MeetingID: "m-1", Strategy: "by_topic"}, "ok", embedding.ErrInvalidStrategy},
+		{"empty reply", seedSummaryRepo(t, 2), app.GenerateSummaryInput{MeetingID: "m-1"}, "  \n", app.ErrEmptyCompletion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockSummaryStore()
+			fake := llm.NewFake()
+			fake.Reply = func(domain.CompletionRequest) (string, error) { return tt.reply, nil }
+
+			uc := app.NewGenerateSummary(tt.repo, store, fake, nil)
+			_, err := uc.Execute(context.Background(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if len(store.summaries) != 0 {
+				t.Error("summary stored despite the error")
+			}
+		})
+	}
+}
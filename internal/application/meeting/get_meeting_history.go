@@ -0,0 +1,83 @@
+package meeting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+type GetMeetingHistoryInput struct {
+	MeetingID domain.MeetingID
+	Limit     int // most recent N events; 0 means all
+}
+
+// HistoryItem is one line of a meeting's activity timeline.
+type HistoryItem struct {
+	EventID     string
+	Type        string
+	OccurredAt  time.Time
+	Description string
+}
+
+type GetMeetingHistoryOutput struct {
+	Items []HistoryItem
+}
+
+type GetMeetingHistory struct {
+	history domain.EventHistory
+}
+
+func NewGetMeetingHistory(history domain.EventHistory) *GetMeetingHistory {
+	return &GetMeetingHistory{history: history}
+}
+
+func (uc *GetMeetingHistory) Execute(ctx context.Context, input GetMeetingHistoryInput) (*GetMeetingHistoryOutput, error) {
+	if input.MeetingID == "" {
+		return nil, domain.ErrInvalidMeetingID
+	}
+
+	entries, err := uc.history.MeetingHistory(ctx, input.MeetingID, input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]HistoryItem, len(entries))
+	for i, e := range entries {
+		items[i] = HistoryItem{
+			EventID:     e.EventID,
+			Type:        e.Event.EventName(),
+			OccurredAt:  e.OccurredAt,
+			Description: describeEvent(e.Event),
+		}
+	}
+	return &GetMeetingHistoryOutput{Items: items}, nil
+}
+
+// describeEvent renders a one-line, human-readable summary of an event.
+func describeEvent(event domain.DomainEvent) string {
+	switch e := event.(type) {
+	case domain.MeetingCreated:
+		return fmt.Sprintf("Meeting created: %s", e.Title())
+	case domain.MeetingDeleted:
+		return "Meeting deleted upstream"
+	case domain.TranscriptUpdated:
+		return fmt.Sprintf("Transcript updated (%d utterances)", e.UtteranceCount())
+	case domain.SummaryUpdated:
+		return fmt.Sprintf("Summary updated (%s)", e.Kind())
+	case domain.ActionItemCompleted:
+		return fmt.Sprintf("Action item %s completed", e.ActionItemID())
+	case domain.ActionItemUpdated:
+		return fmt.Sprintf("Action item %s updated: %s", e.ActionItemID(), e.NewText())
+	case annotation.NoteAdded:
+		return fmt.Sprintf("Note %s added by %s", e.NoteID(), e.Author())
+	case annotation.NoteUpdated:
+		return fmt.Sprintf("Note %s edited by %s (revision %d)", e.NoteID(), e.Editor(), e.Revision())
+	case annotation.NoteDeleted:
+		return fmt.Sprintf("Note %s deleted", e.NoteID())
+	default:
+		return event.EventName()
+	}
+}
@@ -0,0 +1,69 @@
+package meeting_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+type mockEventHistory struct {
+	entries   []domain.HistoryEntry
+	err       error
+	lastLimit int
+}
+
+func (m *mockEventHistory) MeetingHistory(_ context.Context, _ domain.MeetingID, limit int) ([]domain.HistoryEntry, error) {
+	m.lastLimit = limit
+	return m.entries, m.err
+}
+
+func TestGetMeetingHistory_DescribesEvents(t *testing.T) {
+	now := time.Now().UTC()
+	history := &mockEventHistory{entries: []domain.HistoryEntry{
+		{EventID: "evt-1", OccurredAt: now, Event: domain.NewMeetingCreatedEvent("m-1", "Planning", now)},
+		{EventID: "evt-2", OccurredAt: now, Event: domain.NewSummaryUpdatedEvent("m-1", domain.SummaryEdited)},
+		{EventID: "evt-3", OccurredAt: now, Event: annotation.NewNoteAddedEvent("n-1", "m-1", "agent")},
+		{EventID: "evt-4", OccurredAt: now, Event: domain.NewActionItemUpdatedEvent("m-1", "ai-1", "Ship v2")},
+	}}
+	uc := meetingapp.NewGetMeetingHistory(history)
+
+	out, err := uc.Execute(context.Background(), meetingapp.GetMeetingHistoryInput{MeetingID: "m-1", Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if history.lastLimit != 10 {
+		t.Errorf("limit not passed through, got %d", history.lastLimit)
+	}
+
+	want := []string{
+		"Meeting created: Planning",
+		"Summary updated (user_edited)",
+		"Note n-1 added by agent",
+		"Action item ai-1 updated: Ship v2",
+	}
+	if len(out.Items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(out.Items), len(want))
+	}
+	for i, w := range want {
+		if out.Items[i].Description != w {
+			t.Errorf("item %d: got %q, want %q", i, out.Items[i].Description, w)
+		}
+	}
+	if out.Items[2].Type != "note.added" || out.Items[2].EventID != "evt-3" {
+		t.Errorf("unexpected item %+v", out.Items[2])
+	}
+}
+
+func TestGetMeetingHistory_RequiresMeetingID(t *testing.T) {
+	uc := meetingapp.NewGetMeetingHistory(&mockEventHistory{})
+
+	_, err := uc.Execute(context.Background(), meetingapp.GetMeetingHistoryInput{})
+	if !errors.Is(err, domain.ErrInvalidMeetingID) {
+		t.Errorf("expected ErrInvalidMeetingID, got %v", err)
+	}
+}
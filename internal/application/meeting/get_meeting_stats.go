@@ -2,6 +2,7 @@ package meeting
 
 import (
 	"context"
+	"errors"
 	"sort"
 	"strings"
 	"time"
@@ -90,7 +91,9 @@ const (
 	maxSpeakers         = 15
 )
 
-// Execute computes meeting statistics from repository data.
+// Execute computes meeting statistics from repository data. Repositories
+// implementing domain.StatsReader aggregate them in storage; the others are
+// listed and each transcript is read.
 func (uc *GetMeetingStats) Execute(ctx context.Context, input GetMeetingStatsInput) (*GetMeetingStatsOutput, error) {
 	filter := domain.ListFilter{
 		Since: input.Since,
@@ -98,6 +101,16 @@ func (uc *GetMeetingStats) Execute(ctx context.Context, input GetMeetingStatsInp
 		Limit: maxMeetingsForStats,
 	}
 
+	if reader, ok := uc.repo.(domain.StatsReader); ok {
+		stats, err := reader.MeetingStats(ctx, filter)
+		if err == nil {
+			return statsOutput(stats), nil
+		}
+		if !errors.Is(err, domain.ErrStatsUnavailable) {
+			return nil, err
+		}
+	}
+
 	meetings, err := uc.repo.List(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -129,6 +142,49 @@ func (uc *GetMeetingStats) Execute(ctx context.Context, input GetMeetingStatsInp
 	return out, nil
 }
 
+// statsOutput maps statistics aggregated by a read model.
+func statsOutput(stats *domain.Stats) *GetMeetingStatsOutput {
+	out := &GetMeetingStatsOutput{
+		GeneratedAt:          time.Now().UTC(),
+		TotalMeetings:        stats.Meetings,
+		MeetingFrequency:     make([]FrequencyEntry, 0, len(stats.PerDay)),
+		PlatformDistribution: make([]PlatformEntry, 0, len(stats.PerSource)),
+		TopParticipants:      make([]ParticipantStatsEntry, 0, min(len(stats.Participants), maxParticipants)),
+		DayOfWeekHeatmap:     make([]HeatmapEntry, 0, len(stats.PerWeekdayHour)),
+		SpeakerTalkTime:      make([]SpeakerEntry, 0, min(len(stats.Speakers), maxSpeakers)),
+	}
+	if stats.Meetings == 0 {
+		return out
+	}
+
+	out.DateRange = DateRange{Earliest: stats.EarliestDay, Latest: stats.LatestDay}
+	for _, c := range stats.PerDay {
+		out.MeetingFrequency = append(out.MeetingFrequency, FrequencyEntry{Date: c.Day, Count: c.Count})
+	}
+	for _, c := range stats.PerSource {
+		out.PlatformDistribution = append(out.PlatformDistribution, PlatformEntry{Source: string(c.Source), Count: c.Count})
+	}
+	for _, c := range stats.Participants[:min(len(stats.Participants), maxParticipants)] {
+		out.TopParticipants = append(out.TopParticipants, ParticipantStatsEntry{Name: c.Name, Email: c.Email, MeetingCount: c.Meetings})
+	}
+	out.ActionItems = ActionItemStats{Total: stats.ActionItems, Completed: stats.CompletedActionItems}
+	if stats.ActionItems > 0 {
+		out.ActionItems.CompletionRate = float64(stats.CompletedActionItems) / float64(stats.ActionItems)
+	}
+	for _, c := range stats.PerWeekdayHour {
+		out.DayOfWeekHeatmap = append(out.DayOfWeekHeatmap, HeatmapEntry{Day: c.Weekday, Hour: c.Hour, Count: c.Count})
+	}
+	for _, c := range stats.Speakers[:min(len(stats.Speakers), maxSpeakers)] {
+		out.SpeakerTalkTime = append(out.SpeakerTalkTime, SpeakerEntry{Speaker: c.Speaker, WordCount: c.Words, UtteranceCount: c.Utterances})
+	}
+	out.SummaryCoverage = SummaryCoverageStats{
+		WithSummary:    stats.WithSummary,
+		WithoutSummary: stats.Meetings - stats.WithSummary,
+		CoverageRate:   float64(stats.WithSummary) / float64(stats.Meetings),
+	}
+	return out
+}
+
 func computeDateRange(meetings []*domain.Meeting) DateRange {
 	earliest := meetings[0].Datetime()
 	latest := meetings[0].Datetime()
@@ -259,10 +315,12 @@ func computeHeatmap(meetings []*domain.Meeting) []HeatmapEntry {
 	return entries
 }
 
+// computeSummaryCoverage counts summaries from Granola; a locally generated
+// summary fills a gap rather than closing it.
 func computeSummaryCoverage(meetings []*domain.Meeting) SummaryCoverageStats {
 	var withSummary, withoutSummary int
 	for _, m := range meetings {
-		if m.Summary() != nil {
+		if s := m.Summary(); s != nil && s.Kind() != domain.SummaryGenerated {
 			withSummary++
 		} else {
 			withoutSummary++
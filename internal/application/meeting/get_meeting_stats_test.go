@@ -356,7 +356,9 @@ func TestGetMeetingStats_SummaryCoverage(t *testing.T) {
 	m1.ClearDomainEvents()
 	repo.addMeeting(m1)
 
+	// A locally generated summary does not count as coverage.
 	m2, _ := domain.New("m-2", "Without Summary", time.Now().UTC(), domain.SourceZoom, nil)
+	m2.AttachSummary(domain.NewSummary("m-2", "Generated", domain.SummaryGenerated))
 	m2.ClearDomainEvents()
 	repo.addMeeting(m2)
 
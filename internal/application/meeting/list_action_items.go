@@ -0,0 +1,223 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+var (
+	ErrInvalidActionItemStatus = errors.New("action item status must be open, completed, or empty")
+	ErrCurrentUserUnknown      = errors.New(`owner "me" requires ACAI_USER_NAME or user.name in ~/.acai/config.yaml`)
+)
+
+// OwnerMe is the owner filter that matches the configured local user.
+const OwnerMe = "me"
+
+// ActionItemStatus filters action items by completion state.
+type ActionItemStatus string
+
+const (
+	ActionItemStatusAny       ActionItemStatus = ""
+	ActionItemStatusOpen      ActionItemStatus = "open"
+	ActionItemStatusCompleted ActionItemStatus = "completed"
+)
+
+// ListActionItemsInput describes a cross-meeting action item query.
+// All criteria are optional and combined with AND semantics.
+type ListActionItemsInput struct {
+	Owner     *string // case-insensitive substring match on the owner, or OwnerMe
+	Status    ActionItemStatus
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	Overdue   bool       // open items whose due date has passed
+	Since     *time.Time // meeting date lower bound
+	Until     *time.Time // meeting date upper bound
+	Tag       *string    // meeting metadata tag (case-insensitive exact match)
+	Limit     int
+}
+
+// InboxItem pairs an action item with the meeting it came from.
+type InboxItem struct {
+	Item            *domain.ActionItem
+	MeetingTitle    string
+	MeetingDatetime time.Time
+	MeetingLinks    []string
+}
+
+type ListActionItemsOutput struct {
+	Items []InboxItem
+	Total int
+}
+
+// ListActionItems answers cross-meeting action item queries ("what's outstanding
+// for Alice?") by scanning meetings in the requested date range and applying
+// local action item overrides before filtering.
+type ListActionItems struct {
+	repo        domain.Repository
+	writeRepo   domain.WriteRepository
+	currentUser string
+}
+
+// NewListActionItems creates a new ListActionItems use case.
+// writeRepo is optional; when nil, local overrides are not applied.
+// currentUser is the configured local user name an OwnerMe filter resolves
+// to; when empty, such a filter fails with ErrCurrentUserUnknown.
+func NewListActionItems(repo domain.Repository, writeRepo domain.WriteRepository, currentUser string) *ListActionItems {
+	return &ListActionItems{repo: repo, writeRepo: writeRepo, currentUser: currentUser}
+}
+
+const maxMeetingsForInbox = 10000
+
+func (uc *ListActionItems) Execute(ctx context.Context, input ListActionItemsInput) (*ListActionItemsOutput, error) {
+	switch input.Status {
+	case ActionItemStatusAny, ActionItemStatusOpen, ActionItemStatusCompleted:
+	default:
+		return nil, ErrInvalidActionItemStatus
+	}
+	if input.DueBefore != nil && input.DueAfter != nil && input.DueBefore.Before(*input.DueAfter) {
+		return nil, domain.ErrInvalidFilter
+	}
+	if input.Owner != nil && *input.Owner == OwnerMe {
+		if uc.currentUser == "" {
+			return nil, ErrCurrentUserUnknown
+		}
+		owner := uc.currentUser
+		input.Owner = &owner
+	}
+
+	meetings, err := uc.repo.List(ctx, domain.ListFilter{
+		Since: input.Since,
+		Until: input.Until,
+		Limit: maxMeetingsForInbox,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	items := make([]InboxItem, 0)
+	for _, mtg := range meetings {
+		if input.Tag != nil && !hasTag(mtg, *input.Tag) {
+			continue
+		}
+
+		actionItems, err := uc.repo.GetActionItems(ctx, mtg.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range actionItems {
+			if item, err = applyLocalOverride(ctx, uc.writeRepo, item); err != nil {
+				return nil, err
+			}
+			if !matchesActionItem(item, input, now) {
+				continue
+			}
+			items = append(items, InboxItem{
+				Item:            item,
+				MeetingTitle:    mtg.Title(),
+				MeetingDatetime: mtg.Datetime(),
+				MeetingLinks:    mtg.Metadata().Links(),
+			})
+		}
+	}
+
+	sortInboxItems(items)
+
+	total := len(items)
+	if input.Limit > 0 && input.Limit < len(items) {
+		items = items[:input.Limit]
+	}
+
+	return &ListActionItemsOutput{Items: items, Total: total}, nil
+}
+
+// applyLocalOverride merges the locally stored completion state and text into item.
+// Items without a local override (or a nil writeRepo) are returned unchanged.
+func applyLocalOverride(ctx context.Context, writeRepo domain.WriteRepository, item *domain.ActionItem) (*domain.ActionItem, error) {
+	if writeRepo == nil {
+		return item, nil
+	}
+	local, err := writeRepo.GetLocalActionItemState(ctx, item.ID())
+	if errors.Is(err, domain.ErrMeetingNotFound) {
+		return item, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if local.IsCompleted() {
+		item.Complete()
+	} else {
+		item.Uncomplete()
+	}
+	if local.Text() != "" {
+		_ = item.UpdateText(local.Text())
+	}
+	return item, nil
+}
+
+func matchesActionItem(item *domain.ActionItem, input ListActionItemsInput, now time.Time) bool {
+	switch input.Status {
+	case ActionItemStatusOpen:
+		if item.IsCompleted() {
+			return false
+		}
+	case ActionItemStatusCompleted:
+		if !item.IsCompleted() {
+			return false
+		}
+	}
+
+	if input.Owner != nil {
+		if !strings.Contains(strings.ToLower(item.Owner()), strings.ToLower(*input.Owner)) {
+			return false
+		}
+	}
+
+	due := item.DueDate()
+	if input.DueBefore != nil && (due == nil || !due.Before(*input.DueBefore)) {
+		return false
+	}
+	if input.DueAfter != nil && (due == nil || due.Before(*input.DueAfter)) {
+		return false
+	}
+	if input.Overdue && (item.IsCompleted() || due == nil || !due.Before(now)) {
+		return false
+	}
+
+	return true
+}
+
+func hasTag(mtg *domain.Meeting, tag string) bool {
+	for _, t := range mtg.Metadata().Tags() {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortInboxItems orders items by due date (earliest first, undated last),
+// then by meeting date (newest first) so the most pressing work surfaces first.
+func sortInboxItems(items []InboxItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		di, dj := items[i].Item.DueDate(), items[j].Item.DueDate()
+		switch {
+		case di != nil && dj != nil && !di.Equal(*dj):
+			return di.Before(*dj)
+		case di != nil && dj == nil:
+			return true
+		case di == nil && dj != nil:
+			return false
+		}
+		if !items[i].MeetingDatetime.Equal(items[j].MeetingDatetime) {
+			return items[i].MeetingDatetime.After(items[j].MeetingDatetime)
+		}
+		return items[i].Item.ID() < items[j].Item.ID()
+	})
+}
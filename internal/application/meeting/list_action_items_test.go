@@ -0,0 +1,227 @@
+package meeting_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	app "github.com/felixgeelhaar/acai/internal/application/meeting"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+func seedInboxRepo(t *testing.T) *mockRepository {
+	t.Helper()
+	repo := newMockRepository()
+
+	past := time.Now().UTC().Add(-48 * time.Hour)
+	future := time.Now().UTC().Add(72 * time.Hour)
+
+	m1, _ := domain.New("m-1", "Standup", time.Now().UTC().Add(-24*time.Hour), domain.SourceZoom, nil)
+	m1.SetMetadata(domain.NewMetadata([]string{"Engineering"}, nil, nil))
+	m2, _ := domain.New("m-2", "Planning", time.Now().UTC().Add(-240*time.Hour), domain.SourceMeet, nil)
+	repo.addMeeting(m1)
+	repo.addMeeting(m2)
+
+	overdue, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Ship release", &past)
+	upcoming, _ := domain.NewActionItem("ai-2", "m-1", "Bob", "Write docs", &future)
+	done, _ := domain.NewActionItem("ai-3", "m-2", "Alice Smith", "Book venue", &past)
+	done.Complete()
+	undated, _ := domain.NewActionItem("ai-4", "m-2", "alice", "Follow up", nil)
+
+	repo.addActionItems("m-1", []*domain.ActionItem{overdue, upcoming})
+	repo.addActionItems("m-2", []*domain.ActionItem{done, undated})
+	return repo
+}
+
+func inboxIDs(out *app.ListActionItemsOutput) []string {
+	ids := make([]string, len(out.Items))
+	for i, it := range out.Items {
+		ids[i] = string(it.Item.ID())
+	}
+	return ids
+}
+
+func TestListActionItems_AllAcrossMeetings(t *testing.T) {
+	uc := app.NewListActionItems(seedInboxRepo(t), nil, "")
+
+	out, err := uc.Execute(context.Background(), app.ListActionItemsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Total != 4 {
+		t.Fatalf("got %d items, want 4", out.Total)
+	}
+	// Dated items first (earliest due), undated last
+	if ids := inboxIDs(out); ids[3] != "ai-4" {
+		t.Errorf("undated item should sort last, got order %v", ids)
+	}
+	if out.Items[0].MeetingTitle == "" {
+		t.Error("expected meeting title on inbox item")
+	}
+}
+
+func TestListActionItems_OwnerAndOpen(t *testing.T) {
+	uc := app.NewListActionItems(seedInboxRepo(t), nil, "")
+	owner := "alice"
+
+	out, err := uc.Execute(context.Background(), app.ListActionItemsInput{
+		Owner:  &owner,
+		Status: app.ActionItemStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := inboxIDs(out)
+	if len(ids) != 2 || ids[0] != "ai-1" || ids[1] != "ai-4" {
+		t.Errorf("got %v, want [ai-1 ai-4]", ids)
+	}
+}
+
+func TestListActionItems_Overdue(t *testing.T) {
+	uc := app.NewListActionItems(seedInboxRepo(t), nil, "")
+
+	out, err := uc.Execute(context.Background(), app.ListActionItemsInput{Overdue: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := inboxIDs(out)
+	if len(ids) != 1 || ids[0] != "ai-1" {
+		t.Errorf("got %v, want [ai-1]", ids)
+	}
+}
+
+func TestListActionItems_DueWindow(t *testing.T) {
+	uc := app.NewListActionItems(seedInboxRepo(t), nil, "")
+	after := time.Now().UTC()
+	before := time.Now().UTC().Add(7 * 24 * time.Hour)
+
+	out, err := uc.Execute(context.Background(), app.ListActionItemsInput{
+		DueAfter:  &after,
+		DueBefore: &before,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := inboxIDs(out)
+	if len(ids) != 1 || ids[0] != "ai-2" {
+		t.Errorf("got %v, want [ai-2]", ids)
+	}
+}
+
+func TestListActionItems_Tag(t *testing.T) {
+	uc := app.NewListActionItems(seedInboxRepo(t), nil, "")
+	tag := "engineering"
+
+	out, err := uc.Execute(context.Background(), app.ListActionItemsInput{Tag: &tag})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Total != 2 {
+		t.Errorf("got %d items, want 2", out.Total)
+	}
+	for _, it := range out.Items {
+		if it.Item.MeetingID() != "m-1" {
+			t.Errorf("unexpected meeting %q", it.Item.MeetingID())
+		}
+	}
+}
+
+func TestListActionItems_PassesMeetingDateRange(t *testing.T) {
+	repo := seedInboxRepo(t)
+	uc := app.NewListActionItems(repo, nil, "")
+	since := time.Now().UTC().Add(-72 * time.Hour)
+
+	if _, err := uc.Execute(context.Background(), app.ListActionItemsInput{Since: &since}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.listFilter == nil || repo.listFilter.Since == nil || !repo.listFilter.Since.Equal(since) {
+		t.Error("expected meeting date range to be passed to repository")
+	}
+}
+
+func TestListActionItems_AppliesLocalOverrides(t *testing.T) {
+	repo := seedInboxRepo(t)
+	writeRepo := newMockWriteRepository()
+	override, _ := domain.NewActionItem("ai-1", "m-1", "", "Ship release v2", nil)
+	override.Complete()
+	_ = writeRepo.SaveActionItemState(context.Background(), override)
+
+	uc := app.NewListActionItems(repo, writeRepo, "")
+	out, err := uc.Execute(context.Background(), app.ListActionItemsInput{Status: app.ActionItemStatusCompleted})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := inboxIDs(out)
+	if len(ids) != 2 {
+		t.Fatalf("got %v, want ai-1 and ai-3", ids)
+	}
+	for _, it := range out.Items {
+		if it.Item.ID() == "ai-1" && it.Item.Text() != "Ship release v2" {
+			t.Errorf("override text not applied: %q", it.Item.Text())
+		}
+	}
+}
+
+func TestListActionItems_OverrideErrorIsReturned(t *testing.T) {
+	writeRepo := newMockWriteRepository()
+	writeRepo.getErr = errors.New("database is locked")
+	uc := app.NewListActionItems(seedInboxRepo(t), writeRepo, "")
+
+	_, err := uc.Execute(context.Background(), app.ListActionItemsInput{})
+	if !errors.Is(err, writeRepo.getErr) {
+		t.Errorf("got error %v, want the override lookup error", err)
+	}
+}
+
+func TestListActionItems_OwnerMe(t *testing.T) {
+	me := app.OwnerMe
+	uc := app.NewListActionItems(seedInboxRepo(t), nil, "alice")
+	out, err := uc.Execute(context.Background(), app.ListActionItemsInput{Owner: &me})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := inboxIDs(out); len(ids) != 3 {
+		t.Errorf("got %v, want alice's ai-1, ai-3 and ai-4", ids)
+	}
+
+	uc = app.NewListActionItems(seedInboxRepo(t), nil, "")
+	if _, err := uc.Execute(context.Background(), app.ListActionItemsInput{Owner: &me}); !errors.Is(err, app.ErrCurrentUserUnknown) {
+		t.Errorf("got error %v, want ErrCurrentUserUnknown", err)
+	}
+}
+
+func TestListActionItems_Limit(t *testing.T) {
+	uc := app.NewListActionItems(seedInboxRepo(t), nil, "")
+
+	out, err := uc.Execute(context.Background(), app.ListActionItemsInput{Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Items) != 1 {
+		t.Errorf("got %d items, want 1", len(out.Items))
+	}
+	if out.Total != 4 {
+		t.Errorf("got total %d, want 4", out.Total)
+	}
+}
+
+func TestListActionItems_InvalidStatus(t *testing.T) {
+	uc := app.NewListActionItems(newMockRepository(), nil, "")
+
+	_, err := uc.Execute(context.Background(), app.ListActionItemsInput{Status: "bogus"})
+	if err != app.ErrInvalidActionItemStatus {
+		t.Errorf("got error %v, want %v", err, app.ErrInvalidActionItemStatus)
+	}
+}
+
+func TestListActionItems_InvertedDueWindow(t *testing.T) {
+	uc := app.NewListActionItems(newMockRepository(), nil, "")
+	before := time.Now().UTC()
+	after := before.Add(time.Hour)
+
+	_, err := uc.Execute(context.Background(), app.ListActionItemsInput{DueBefore: &before, DueAfter: &after})
+	if err != domain.ErrInvalidFilter {
+		t.Errorf("got error %v, want %v", err, domain.ErrInvalidFilter)
+	}
+}
@@ -2,6 +2,7 @@ package meeting_test
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
@@ -114,7 +115,8 @@ func (m *mockRepository) addActionItems(id domain.MeetingID, items []*domain.Act
 
 // mockWriteRepository implements domain.WriteRepository for tests.
 type mockWriteRepository struct {
-	items map[domain.ActionItemID]*domain.ActionItem
+	items  map[domain.ActionItemID]*domain.ActionItem
+	getErr error
 }
 
 func newMockWriteRepository() *mockWriteRepository {
@@ -127,6 +129,9 @@ func (m *mockWriteRepository) SaveActionItemState(_ context.Context, item *domai
 }
 
 func (m *mockWriteRepository) GetLocalActionItemState(_ context.Context, id domain.ActionItemID) (*domain.ActionItem, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
 	item, ok := m.items[id]
 	if !ok {
 		return nil, domain.ErrMeetingNotFound
@@ -143,3 +148,90 @@ func (m *mockDispatcher) Dispatch(_ context.Context, events []domain.DomainEvent
 	m.events = append(m.events, events...)
 	return nil
 }
+
+// mockTaskSink records calls made to an external tracker.
+type mockTaskSink struct {
+	name      string
+	nextID    int
+	created   []domain.ActionItemID
+	updated   []string
+	closed    []string
+	reopened  []string
+	createErr error
+	reopenErr error
+}
+
+func (m *mockTaskSink) Name() string { return m.name }
+
+func (m *mockTaskSink) CreateTask(_ context.Context, item *domain.ActionItem) (string, error) {
+	if m.createErr != nil {
+		return "", m.createErr
+	}
+	m.nextID++
+	m.created = append(m.created, item.ID())
+	return fmt.Sprintf("%s-%d", m.name, m.nextID), nil
+}
+
+func (m *mockTaskSink) UpdateTask(_ context.Context, externalID string, _ *domain.ActionItem) error {
+	m.updated = append(m.updated, externalID)
+	return nil
+}
+
+func (m *mockTaskSink) CloseTask(_ context.Context, externalID string) error {
+	m.closed = append(m.closed, externalID)
+	return nil
+}
+
+func (m *mockTaskSink) ReopenTask(_ context.Context, externalID string) error {
+	if m.reopenErr != nil {
+		return m.reopenErr
+	}
+	m.reopened = append(m.reopened, externalID)
+	return nil
+}
+
+// mockTaskLinkRepository implements domain.TaskLinkRepository in memory.
+type mockTaskLinkRepository struct {
+	links map[string]domain.TaskLink
+}
+
+func newMockTaskLinkRepository() *mockTaskLinkRepository {
+	return &mockTaskLinkRepository{links: make(map[string]domain.TaskLink)}
+}
+
+func (m *mockTaskLinkRepository) GetTaskLink(_ context.Context, sink string, id domain.ActionItemID) (*domain.TaskLink, error) {
+	link, ok := m.links[sink+"/"+string(id)]
+	if !ok {
+		return nil, domain.ErrTaskLinkNotFound
+	}
+	return &link, nil
+}
+
+func (m *mockTaskLinkRepository) SaveTaskLink(_ context.Context, link domain.TaskLink) error {
+	m.links[link.Sink+"/"+string(link.ActionItemID)] = link
+	return nil
+}
+
+// mockSummaryStore implements domain.SummaryStore in memory.
+type mockSummaryStore struct {
+	summaries map[domain.MeetingID]domain.Summary
+}
+
+func newMockSummaryStore() *mockSummaryStore {
+	return &mockSummaryStore{summaries: make(map[domain.MeetingID]domain.Summary)}
+}
+
+func (m *mockSummaryStore) SaveSummary(_ context.Context, summary domain.Summary) error {
+	m.summaries[summary.MeetingID()] = summary
+	return nil
+}
+
+func (m *mockSummaryStore) FindSummaries(_ context.Context, ids []domain.MeetingID) (map[domain.MeetingID]domain.Summary, error) {
+	found := make(map[domain.MeetingID]domain.Summary)
+	for _, id := range ids {
+		if s, ok := m.summaries[id]; ok {
+			found[id] = s
+		}
+	}
+	return found, nil
+}
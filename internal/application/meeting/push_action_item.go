@@ -0,0 +1,209 @@
+package meeting
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// PushOutcome describes what a push did in one external tracker.
+type PushOutcome string
+
+const (
+	PushCreated   PushOutcome = "created"
+	PushUpdated   PushOutcome = "updated"
+	PushClosed    PushOutcome = "closed"
+	PushUnchanged PushOutcome = "unchanged"
+	PushFailed    PushOutcome = "failed"
+)
+
+type PushActionItemInput struct {
+	MeetingID    domain.MeetingID
+	ActionItemID domain.ActionItemID
+}
+
+// PushResult reports the outcome for a single sink.
+type PushResult struct {
+	Sink       string
+	ExternalID string
+	Outcome    PushOutcome
+	Err        error
+}
+
+type PushActionItemOutput struct {
+	Item    *domain.ActionItem
+	Results []PushResult
+}
+
+// PushActionItem files an action item in every configured external tracker.
+// Task links make the push idempotent: an item is created once per sink,
+// later pushes update it only when its content changed, and completed items
+// are closed exactly once.
+type PushActionItem struct {
+	repo      domain.Repository
+	writeRepo domain.WriteRepository
+	links     domain.TaskLinkRepository
+	sinks     []domain.TaskSink
+}
+
+// NewPushActionItem creates a new PushActionItem use case.
+// writeRepo is optional; when nil, local overrides are not applied.
+func NewPushActionItem(repo domain.Repository, writeRepo domain.WriteRepository, links domain.TaskLinkRepository, sinks []domain.TaskSink) *PushActionItem {
+	return &PushActionItem{repo: repo, writeRepo: writeRepo, links: links, sinks: sinks}
+}
+
+// Sinks reports how many trackers are configured.
+func (uc *PushActionItem) Sinks() int { return len(uc.sinks) }
+
+// Execute pushes the item to all sinks. A failure in one sink does not stop
+// the others; the returned error joins all sink failures so callers can retry.
+func (uc *PushActionItem) Execute(ctx context.Context, input PushActionItemInput) (*PushActionItemOutput, error) {
+	if input.MeetingID == "" {
+		return nil, domain.ErrInvalidMeetingID
+	}
+	if input.ActionItemID == "" {
+		return nil, domain.ErrInvalidActionItemID
+	}
+
+	item, err := uc.resolveItem(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &PushActionItemOutput{Item: item}
+	var errs []error
+	for _, sink := range uc.sinks {
+		result := uc.pushTo(ctx, sink, item)
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), result.Err))
+		}
+		out.Results = append(out.Results, result)
+	}
+	return out, errors.Join(errs...)
+}
+
+// resolveItem reads the item upstream and applies local overrides. Items only
+// known locally (e.g. when the upstream source exposes no action items) fall
+// back to the local override.
+func (uc *PushActionItem) resolveItem(ctx context.Context, input PushActionItemInput) (*domain.ActionItem, error) {
+	items, err := uc.repo.GetActionItems(ctx, input.MeetingID)
+	if err != nil && !errors.Is(err, domain.ErrMeetingNotFound) {
+		return nil, err
+	}
+	for _, ai := range items {
+		if ai.ID() == input.ActionItemID {
+			return applyLocalOverride(ctx, uc.writeRepo, ai)
+		}
+	}
+
+	if uc.writeRepo == nil {
+		return nil, domain.ErrMeetingNotFound
+	}
+	return uc.writeRepo.GetLocalActionItemState(ctx, input.ActionItemID)
+}
+
+func (uc *PushActionItem) pushTo(ctx context.Context, sink domain.TaskSink, item *domain.ActionItem) PushResult {
+	result := PushResult{Sink: sink.Name(), Outcome: PushUnchanged}
+	fingerprint := taskFingerprint(item)
+
+	link, err := uc.links.GetTaskLink(ctx, sink.Name(), item.ID())
+	switch {
+	case errors.Is(err, domain.ErrTaskLinkNotFound):
+		externalID, err := sink.CreateTask(ctx, item)
+		if err != nil {
+			return failed(result, err)
+		}
+		link = &domain.TaskLink{
+			ActionItemID: item.ID(),
+			Sink:         sink.Name(),
+			ExternalID:   externalID,
+			Fingerprint:  fingerprint,
+		}
+		// Record the link before anything else can fail so a retry never files a duplicate.
+		if err := uc.saveLink(ctx, link); err != nil {
+			return failed(result, err)
+		}
+		result.Outcome = PushCreated
+	case err != nil:
+		return failed(result, err)
+	default:
+		result.ExternalID = link.ExternalID
+		updated, err := updateTicket(ctx, sink, link, item, fingerprint)
+		if updated {
+			if err := uc.saveLink(ctx, link); err != nil {
+				return failed(result, err)
+			}
+			result.Outcome = PushUpdated
+		}
+		if err != nil {
+			return failed(result, err)
+		}
+	}
+	result.ExternalID = link.ExternalID
+
+	if item.IsCompleted() && !link.Closed {
+		if err := sink.CloseTask(ctx, link.ExternalID); err != nil {
+			return failed(result, err)
+		}
+		link.Closed = true
+		if err := uc.saveLink(ctx, link); err != nil {
+			return failed(result, err)
+		}
+		if result.Outcome == PushUnchanged || result.Outcome == PushUpdated {
+			result.Outcome = PushClosed
+		}
+	}
+	return result
+}
+
+// updateTicket rewrites the ticket when the item changed and reopens it when
+// the item is no longer completed, keeping link in step. It reports whether
+// link changed. A sink that cannot reopen leaves the ticket closed, so the
+// link stays closed as well.
+func updateTicket(ctx context.Context, sink domain.TaskSink, link *domain.TaskLink, item *domain.ActionItem, fingerprint string) (bool, error) {
+	updated := false
+	if link.Fingerprint != fingerprint {
+		if err := sink.UpdateTask(ctx, link.ExternalID, item); err != nil {
+			return false, err
+		}
+		link.Fingerprint, updated = fingerprint, true
+	}
+	if link.Closed && !item.IsCompleted() {
+		err := sink.ReopenTask(ctx, link.ExternalID)
+		switch {
+		case errors.Is(err, domain.ErrReopenUnsupported):
+		case err != nil:
+			return updated, err
+		default:
+			link.Closed, updated = false, true
+		}
+	}
+	return updated, nil
+}
+
+func (uc *PushActionItem) saveLink(ctx context.Context, link *domain.TaskLink) error {
+	link.UpdatedAt = time.Now().UTC()
+	return uc.links.SaveTaskLink(ctx, *link)
+}
+
+func failed(result PushResult, err error) PushResult {
+	result.Outcome = PushFailed
+	result.Err = err
+	return result
+}
+
+// taskFingerprint hashes the fields a ticket mirrors, so unchanged items are
+// not re-sent. Completion is tracked separately via TaskLink.Closed.
+func taskFingerprint(item *domain.ActionItem) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\x00%s\x00", item.Text(), item.Owner())
+	if item.DueDate() != nil {
+		_, _ = fmt.Fprint(h, item.DueDate().UTC().Format(time.RFC3339))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
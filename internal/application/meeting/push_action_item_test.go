@@ -0,0 +1,173 @@
+package meeting_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	app "github.com/felixgeelhaar/acai/internal/application/meeting"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+func seedPushRepo(t *testing.T) *mockRepository {
+	t.Helper()
+	repo := newMockRepository()
+	item, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Ship release", nil)
+	repo.addActionItems("m-1", []*domain.ActionItem{item})
+	return repo
+}
+
+func TestPushActionItem_CreatesOnce(t *testing.T) {
+	sink := &mockTaskSink{name: "github"}
+	links := newMockTaskLinkRepository()
+	uc := app.NewPushActionItem(seedPushRepo(t), nil, links, []domain.TaskSink{sink})
+	input := app.PushActionItemInput{MeetingID: "m-1", ActionItemID: "ai-1"}
+
+	out, err := uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Results[0].Outcome != app.PushCreated || out.Results[0].ExternalID != "github-1" {
+		t.Errorf("unexpected result %+v", out.Results[0])
+	}
+
+	out, err = uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error on replay: %v", err)
+	}
+	if out.Results[0].Outcome != app.PushUnchanged {
+		t.Errorf("replay should be unchanged, got %q", out.Results[0].Outcome)
+	}
+	if len(sink.created) != 1 || len(sink.updated) != 0 {
+		t.Errorf("got %d creates and %d updates, want 1 and 0", len(sink.created), len(sink.updated))
+	}
+}
+
+func TestPushActionItem_UpdatesChangedTextAndClosesOnce(t *testing.T) {
+	repo := seedPushRepo(t)
+	writeRepo := newMockWriteRepository()
+	sink := &mockTaskSink{name: "jira"}
+	links := newMockTaskLinkRepository()
+	uc := app.NewPushActionItem(repo, writeRepo, links, []domain.TaskSink{sink})
+	input := app.PushActionItemInput{MeetingID: "m-1", ActionItemID: "ai-1"}
+
+	if _, err := uc.Execute(context.Background(), input); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	override, _ := domain.NewActionItem("ai-1", "m-1", "", "Ship release v2", nil)
+	override.Complete()
+	_ = writeRepo.SaveActionItemState(context.Background(), override)
+
+	out, err := uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if out.Results[0].Outcome != app.PushClosed {
+		t.Errorf("got outcome %q, want closed", out.Results[0].Outcome)
+	}
+	if len(sink.updated) != 1 || len(sink.closed) != 1 {
+		t.Fatalf("got %d updates and %d closes, want 1 and 1", len(sink.updated), len(sink.closed))
+	}
+
+	if _, err := uc.Execute(context.Background(), input); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if len(sink.closed) != 1 {
+		t.Errorf("closed ticket should not be closed again, got %d closes", len(sink.closed))
+	}
+}
+
+// closeThenReopen pushes ai-1, completes and pushes it, then marks it
+// incomplete again and pushes a third time, returning the last result and
+// the stored link.
+func closeThenReopen(t *testing.T, sink *mockTaskSink) (app.PushResult, *domain.TaskLink) {
+	t.Helper()
+	ctx := context.Background()
+	writeRepo := newMockWriteRepository()
+	links := newMockTaskLinkRepository()
+	uc := app.NewPushActionItem(seedPushRepo(t), writeRepo, links, []domain.TaskSink{sink})
+	input := app.PushActionItemInput{MeetingID: "m-1", ActionItemID: "ai-1"}
+
+	state, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Ship release", nil)
+	if _, err := uc.Execute(ctx, input); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	state.Complete()
+	_ = writeRepo.SaveActionItemState(ctx, state)
+	if _, err := uc.Execute(ctx, input); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	state.Uncomplete()
+	_ = writeRepo.SaveActionItemState(ctx, state)
+	out, err := uc.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	link, _ := links.GetTaskLink(ctx, sink.name, "ai-1")
+	return out.Results[0], link
+}
+
+func TestPushActionItem_ReopensIncompleteItem(t *testing.T) {
+	sink := &mockTaskSink{name: "github"}
+	result, link := closeThenReopen(t, sink)
+
+	if result.Outcome != app.PushUpdated || len(sink.reopened) != 1 {
+		t.Errorf("got outcome %q and %d reopens, want updated and 1", result.Outcome, len(sink.reopened))
+	}
+	if link.Closed {
+		t.Error("link should be open after the ticket was reopened")
+	}
+}
+
+func TestPushActionItem_KeepsLinkClosedWhenSinkCannotReopen(t *testing.T) {
+	sink := &mockTaskSink{name: "linear", reopenErr: fmt.Errorf("no open state: %w", domain.ErrReopenUnsupported)}
+	result, link := closeThenReopen(t, sink)
+
+	if result.Outcome != app.PushUnchanged || result.Err != nil {
+		t.Errorf("got %+v, want unchanged without error", result)
+	}
+	if !link.Closed {
+		t.Error("link should stay closed while the ticket does")
+	}
+}
+
+func TestPushActionItem_SinkFailureDoesNotBlockOthers(t *testing.T) {
+	broken := &mockTaskSink{name: "linear", createErr: errors.New("boom")}
+	healthy := &mockTaskSink{name: "github"}
+	uc := app.NewPushActionItem(seedPushRepo(t), nil, newMockTaskLinkRepository(), []domain.TaskSink{broken, healthy})
+
+	out, err := uc.Execute(context.Background(), app.PushActionItemInput{MeetingID: "m-1", ActionItemID: "ai-1"})
+	if err == nil {
+		t.Fatal("expected joined sink error")
+	}
+	if out.Results[0].Outcome != app.PushFailed || out.Results[1].Outcome != app.PushCreated {
+		t.Errorf("unexpected results %+v", out.Results)
+	}
+}
+
+func TestPushActionItem_FallsBackToLocalOverride(t *testing.T) {
+	writeRepo := newMockWriteRepository()
+	local, _ := domain.NewActionItem("ai-9", "m-1", "", "Local only", nil)
+	_ = writeRepo.SaveActionItemState(context.Background(), local)
+	sink := &mockTaskSink{name: "github"}
+	uc := app.NewPushActionItem(newMockRepository(), writeRepo, newMockTaskLinkRepository(), []domain.TaskSink{sink})
+
+	out, err := uc.Execute(context.Background(), app.PushActionItemInput{MeetingID: "m-1", ActionItemID: "ai-9"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Item.Text() != "Local only" || len(sink.created) != 1 {
+		t.Errorf("expected local item to be filed, got %+v", out.Results)
+	}
+}
+
+func TestPushActionItem_NotFound(t *testing.T) {
+	uc := app.NewPushActionItem(newMockRepository(), nil, newMockTaskLinkRepository(), nil)
+
+	_, err := uc.Execute(context.Background(), app.PushActionItemInput{MeetingID: "m-1", ActionItemID: "missing"})
+	if err != domain.ErrMeetingNotFound {
+		t.Errorf("got error %v, want %v", err, domain.ErrMeetingNotFound)
+	}
+}
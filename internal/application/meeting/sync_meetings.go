@@ -2,6 +2,7 @@ package meeting
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
@@ -11,16 +12,27 @@ type SyncMeetingsInput struct {
 	Since *time.Time
 }
 
+// OrphanedMeeting is a meeting deleted upstream that still has local notes
+// or action item overrides. The local data is kept.
+type OrphanedMeeting struct {
+	MeetingID domain.MeetingID
+	State     domain.LocalState
+}
+
 type SyncMeetingsOutput struct {
-	Events []domain.DomainEvent
+	Events  []domain.DomainEvent
+	Orphans []OrphanedMeeting
 }
 
 type SyncMeetings struct {
-	repo domain.Repository
+	repo       domain.Repository
+	localState domain.LocalStateCounter
 }
 
-func NewSyncMeetings(repo domain.Repository) *SyncMeetings {
-	return &SyncMeetings{repo: repo}
+// NewSyncMeetings creates the sync use case. localState may be nil, in
+// which case deleted meetings are not checked for orphaned local data.
+func NewSyncMeetings(repo domain.Repository, localState domain.LocalStateCounter) *SyncMeetings {
+	return &SyncMeetings{repo: repo, localState: localState}
 }
 
 func (uc *SyncMeetings) Execute(ctx context.Context, input SyncMeetingsInput) (*SyncMeetingsOutput, error) {
@@ -29,5 +41,23 @@ func (uc *SyncMeetings) Execute(ctx context.Context, input SyncMeetingsInput) (*
 		return nil, err
 	}
 
-	return &SyncMeetingsOutput{Events: events}, nil
+	out := &SyncMeetingsOutput{Events: events}
+	if uc.localState == nil {
+		return out, nil
+	}
+
+	for _, event := range events {
+		deleted, ok := event.(domain.MeetingDeleted)
+		if !ok {
+			continue
+		}
+		state, err := uc.localState.CountLocalState(ctx, deleted.MeetingID())
+		if err != nil {
+			return nil, fmt.Errorf("check local state of %s: %w", deleted.MeetingID(), err)
+		}
+		if !state.Empty() {
+			out.Orphans = append(out.Orphans, OrphanedMeeting{MeetingID: deleted.MeetingID(), State: state})
+		}
+	}
+	return out, nil
 }
@@ -16,7 +16,7 @@ func TestSyncMeetings_DelegatesToRepository(t *testing.T) {
 		domain.NewMeetingCreatedEvent("m-1", "New Meeting", time.Now().UTC()),
 	}
 
-	uc := app.NewSyncMeetings(repo)
+	uc := app.NewSyncMeetings(repo, nil)
 	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	out, err := uc.Execute(context.Background(), app.SyncMeetingsInput{Since: &since})
 	if err != nil {
@@ -34,7 +34,7 @@ func TestSyncMeetings_PropagatesErrors(t *testing.T) {
 	repo := newMockRepository()
 	repo.syncErr = errors.New("network failure")
 
-	uc := app.NewSyncMeetings(repo)
+	uc := app.NewSyncMeetings(repo, nil)
 	_, err := uc.Execute(context.Background(), app.SyncMeetingsInput{})
 	if err == nil {
 		t.Fatal("expected error")
@@ -43,3 +43,38 @@ func TestSyncMeetings_PropagatesErrors(t *testing.T) {
 		t.Errorf("got error %q", err.Error())
 	}
 }
+
+type mockLocalState struct {
+	states map[domain.MeetingID]domain.LocalState
+}
+
+func (m *mockLocalState) CountLocalState(_ context.Context, id domain.MeetingID) (domain.LocalState, error) {
+	return m.states[id], nil
+}
+
+func TestSyncMeetings_ReportsOrphanedLocalState(t *testing.T) {
+	repo := newMockRepository()
+	repo.syncEvents = []domain.DomainEvent{
+		domain.NewMeetingDeletedEvent("m-1"),
+		domain.NewMeetingDeletedEvent("m-2"),
+		domain.NewSummaryUpdatedEvent("m-3", domain.SummaryAuto),
+	}
+	local := &mockLocalState{states: map[domain.MeetingID]domain.LocalState{
+		"m-1": {Notes: 2, ActionItemOverrides: 1},
+		"m-3": {Notes: 5},
+	}}
+
+	out, err := app.NewSyncMeetings(repo, local).Execute(context.Background(), app.SyncMeetingsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Events) != 3 {
+		t.Errorf("got %d events, want 3", len(out.Events))
+	}
+	if len(out.Orphans) != 1 {
+		t.Fatalf("got %d orphans, want 1 (only deleted meetings with local data)", len(out.Orphans))
+	}
+	if o := out.Orphans[0]; o.MeetingID != "m-1" || o.State.Notes != 2 || o.State.ActionItemOverrides != 1 {
+		t.Errorf("unexpected orphan %+v", o)
+	}
+}
@@ -0,0 +1,60 @@
+package annotation
+
+import "time"
+
+// Anchor is a value object tying a note to part of a meeting's transcript:
+// either a time range of utterance timestamps or a single utterance by its
+// index. The zero Anchor anchors nothing.
+type Anchor struct {
+	start     time.Time
+	end       time.Time
+	utterance int // index + 1; 0 when the anchor is not an utterance
+}
+
+// NewTimeRangeAnchor anchors a note to the utterances spoken between start
+// and end, inclusive. A zero end anchors to the instant start.
+func NewTimeRangeAnchor(start, end time.Time) (Anchor, error) {
+	if start.IsZero() {
+		return Anchor{}, ErrInvalidAnchor
+	}
+	if end.IsZero() {
+		end = start
+	}
+	if end.Before(start) {
+		return Anchor{}, ErrInvalidAnchor
+	}
+	return Anchor{start: start.UTC(), end: end.UTC()}, nil
+}
+
+// NewUtteranceAnchor anchors a note to the utterance at index (0-based) in
+// the meeting's transcript.
+func NewUtteranceAnchor(index int) (Anchor, error) {
+	if index < 0 {
+		return Anchor{}, ErrInvalidAnchor
+	}
+	return Anchor{utterance: index + 1}, nil
+}
+
+func (a Anchor) IsZero() bool { return a.start.IsZero() && a.utterance == 0 }
+
+// TimeRange returns the anchored time range, if the anchor is one.
+func (a Anchor) TimeRange() (start, end time.Time, ok bool) {
+	return a.start, a.end, !a.start.IsZero()
+}
+
+// UtteranceIndex returns the anchored utterance index, if the anchor is one.
+func (a Anchor) UtteranceIndex() (int, bool) {
+	return a.utterance - 1, a.utterance > 0
+}
+
+// Covers reports whether the utterance at index, spoken at ts, falls within
+// the anchor.
+func (a Anchor) Covers(index int, ts time.Time) bool {
+	if i, ok := a.UtteranceIndex(); ok {
+		return i == index
+	}
+	if start, end, ok := a.TimeRange(); ok {
+		return !ts.Before(start) && !ts.After(end)
+	}
+	return false
+}
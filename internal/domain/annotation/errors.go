@@ -6,9 +6,19 @@ package annotation
 import "errors"
 
 var (
-	ErrInvalidNoteID      = errors.New("note id must not be empty")
-	ErrInvalidMeetingID   = errors.New("meeting id must not be empty")
-	ErrInvalidNoteContent = errors.New("note content must not be empty")
-	ErrInvalidAuthor      = errors.New("note author must not be empty")
-	ErrNoteNotFound       = errors.New("note not found")
+	ErrInvalidNoteID           = errors.New("note id must not be empty")
+	ErrInvalidMeetingID        = errors.New("meeting id must not be empty")
+	ErrInvalidNoteContent      = errors.New("note content must not be empty")
+	ErrInvalidAuthor           = errors.New("note author must not be empty")
+	ErrNoteNotFound            = errors.New("note not found")
+	ErrInvalidNoteKind         = errors.New("note kind must be one of note, insight, risk, decision, question")
+	ErrInvalidAnchor           = errors.New("note anchor must be a time range with start <= end, or a non-negative utterance index")
+	ErrInvalidParent           = errors.New("a note cannot reply to itself")
+	ErrParentMeetingMismatch   = errors.New("a reply must belong to the same meeting as its parent note")
+	ErrInvalidRevision         = errors.New("expected revision must be at least 1")
+	ErrRevisionConflict        = errors.New("note was edited since the expected revision")
+	ErrInvalidIdempotencyKey   = errors.New("idempotency key must be at most 255 characters")
+	ErrIdempotencyKeyReused    = errors.New("idempotency key was already used for a different note")
+	ErrDuplicateIdempotencyKey = errors.New("a note with this idempotency key already exists")
+	ErrInvalidSearchQuery      = errors.New("search query must contain at least one word")
 )
@@ -15,6 +15,8 @@ type NoteAdded struct {
 	noteID    string
 	meetingID string
 	author    string
+	kind      string
+	parentID  string
 	occurred  time.Time
 }
 
@@ -27,11 +29,48 @@ func NewNoteAddedEvent(noteID, meetingID, author string) NoteAdded {
 	}
 }
 
+// NewNoteAddedEventFor raises NoteAdded for note, including its kind and
+// the note it replies to.
+func NewNoteAddedEventFor(note *AgentNote) NoteAdded {
+	e := NewNoteAddedEvent(string(note.ID()), note.MeetingID(), note.Author())
+	e.kind = string(note.Kind())
+	e.parentID = string(note.ParentID())
+	return e
+}
+
 func (e NoteAdded) EventName() string     { return "note.added" }
 func (e NoteAdded) OccurredAt() time.Time { return e.occurred }
 func (e NoteAdded) NoteID() string        { return e.noteID }
 func (e NoteAdded) MeetingID() string     { return e.meetingID }
 func (e NoteAdded) Author() string        { return e.author }
+func (e NoteAdded) Kind() string          { return e.kind }
+func (e NoteAdded) ParentID() string      { return e.parentID }
+
+// NoteUpdated is raised when an agent note's content is edited.
+type NoteUpdated struct {
+	noteID    string
+	meetingID string
+	editor    string
+	revision  int
+	occurred  time.Time
+}
+
+func NewNoteUpdatedEvent(noteID, meetingID, editor string, revision int) NoteUpdated {
+	return NoteUpdated{
+		noteID:    noteID,
+		meetingID: meetingID,
+		editor:    editor,
+		revision:  revision,
+		occurred:  time.Now().UTC(),
+	}
+}
+
+func (e NoteUpdated) EventName() string     { return "note.updated" }
+func (e NoteUpdated) OccurredAt() time.Time { return e.occurred }
+func (e NoteUpdated) NoteID() string        { return e.noteID }
+func (e NoteUpdated) MeetingID() string     { return e.meetingID }
+func (e NoteUpdated) Editor() string        { return e.editor }
+func (e NoteUpdated) Revision() int         { return e.revision }
 
 // NoteDeleted is raised when an agent note is removed.
 type NoteDeleted struct {
@@ -0,0 +1,88 @@
+package annotation
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Events carry unexported fields, so each one defines its JSON form
+// explicitly. The field names are part of the event schema.
+
+type noteAddedJSON struct {
+	NoteID     string    `json:"note_id"`
+	MeetingID  string    `json:"meeting_id"`
+	Author     string    `json:"author"`
+	Kind       string    `json:"kind,omitempty"`
+	ParentID   string    `json:"parent_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e NoteAdded) MarshalJSON() ([]byte, error) {
+	return json.Marshal(noteAddedJSON{
+		NoteID:     e.noteID,
+		MeetingID:  e.meetingID,
+		Author:     e.author,
+		Kind:       e.kind,
+		ParentID:   e.parentID,
+		OccurredAt: e.occurred,
+	})
+}
+
+func (e *NoteAdded) UnmarshalJSON(data []byte) error {
+	var v noteAddedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = NoteAdded{noteID: v.NoteID, meetingID: v.MeetingID, author: v.Author, kind: v.Kind, parentID: v.ParentID, occurred: v.OccurredAt}
+	return nil
+}
+
+type noteUpdatedJSON struct {
+	NoteID     string    `json:"note_id"`
+	MeetingID  string    `json:"meeting_id"`
+	Editor     string    `json:"editor"`
+	Revision   int       `json:"revision"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e NoteUpdated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(noteUpdatedJSON{
+		NoteID:     e.noteID,
+		MeetingID:  e.meetingID,
+		Editor:     e.editor,
+		Revision:   e.revision,
+		OccurredAt: e.occurred,
+	})
+}
+
+func (e *NoteUpdated) UnmarshalJSON(data []byte) error {
+	var v noteUpdatedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = NoteUpdated{noteID: v.NoteID, meetingID: v.MeetingID, editor: v.Editor, revision: v.Revision, occurred: v.OccurredAt}
+	return nil
+}
+
+type noteDeletedJSON struct {
+	NoteID     string    `json:"note_id"`
+	MeetingID  string    `json:"meeting_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e NoteDeleted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(noteDeletedJSON{
+		NoteID:     e.noteID,
+		MeetingID:  e.meetingID,
+		OccurredAt: e.occurred,
+	})
+}
+
+func (e *NoteDeleted) UnmarshalJSON(data []byte) error {
+	var v noteDeletedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = NoteDeleted{noteID: v.NoteID, meetingID: v.MeetingID, occurred: v.OccurredAt}
+	return nil
+}
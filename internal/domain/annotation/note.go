@@ -5,6 +5,28 @@ import "time"
 // NoteID is a strongly-typed identifier for agent notes.
 type NoteID string
 
+// NoteKind classifies what a note records.
+type NoteKind string
+
+const (
+	KindNote     NoteKind = "note" // unclassified; the kind of notes written before kinds existed
+	KindInsight  NoteKind = "insight"
+	KindRisk     NoteKind = "risk"
+	KindDecision NoteKind = "decision"
+	KindQuestion NoteKind = "question"
+)
+
+// ParseNoteKind parses a note kind; the empty string is KindNote.
+func ParseNoteKind(s string) (NoteKind, error) {
+	switch k := NoteKind(s); k {
+	case "":
+		return KindNote, nil
+	case KindNote, KindInsight, KindRisk, KindDecision, KindQuestion:
+		return k, nil
+	}
+	return "", ErrInvalidNoteKind
+}
+
 // AgentNote is an entity representing a note added by an MCP agent to a meeting.
 // Notes belong to the annotation bounded context, not the meeting aggregate.
 // Cross-context reference is by meeting ID string only.
@@ -14,6 +36,18 @@ type AgentNote struct {
 	author    string
 	content   string
 	createdAt time.Time
+	kind      NoteKind
+	anchor    Anchor
+	parentID  NoteID // set on replies
+
+	// The current revision: 1 on creation, incremented by every edit.
+	revision  int
+	updatedBy string
+	updatedAt time.Time
+
+	// The key the creating client sent, so a retried create returns this
+	// note rather than adding a second one.
+	idempotencyKey string
 }
 
 // NewAgentNote constructs a valid AgentNote, enforcing creation invariants.
@@ -31,24 +65,111 @@ func NewAgentNote(id NoteID, meetingID, author, content string) (*AgentNote, err
 		return nil, ErrInvalidNoteContent
 	}
 
+	now := time.Now().UTC()
 	return &AgentNote{
 		id:        id,
 		meetingID: meetingID,
 		author:    author,
 		content:   content,
-		createdAt: time.Now().UTC(),
+		createdAt: now,
+		kind:      KindNote,
+		revision:  1,
+		updatedBy: author,
+		updatedAt: now,
 	}, nil
 }
 
 // ReconstructAgentNote reconstitutes a note from persistence without raising events.
-func ReconstructAgentNote(id NoteID, meetingID, author, content string, createdAt time.Time) *AgentNote {
+// A zero revision is revision 1, written by author at createdAt.
+func ReconstructAgentNote(id NoteID, meetingID, author, content string, createdAt time.Time, kind NoteKind, anchor Anchor, parentID NoteID, revision int, updatedBy string, updatedAt time.Time) *AgentNote {
+	if kind == "" {
+		kind = KindNote
+	}
+	if revision < 1 {
+		revision = 1
+	}
+	if updatedBy == "" {
+		updatedBy = author
+	}
+	if updatedAt.IsZero() {
+		updatedAt = createdAt
+	}
 	return &AgentNote{
 		id:        id,
 		meetingID: meetingID,
 		author:    author,
 		content:   content,
 		createdAt: createdAt,
+		kind:      kind,
+		anchor:    anchor,
+		parentID:  parentID,
+		revision:  revision,
+		updatedBy: updatedBy,
+		updatedAt: updatedAt,
+	}
+}
+
+// Classify sets the note's kind.
+func (n *AgentNote) Classify(kind NoteKind) error {
+	if _, err := ParseNoteKind(string(kind)); err != nil {
+		return err
+	}
+	n.kind = kind
+	return nil
+}
+
+// AnchorTo ties the note to part of the meeting's transcript.
+func (n *AgentNote) AnchorTo(anchor Anchor) { n.anchor = anchor }
+
+// ReplyTo makes the note a reply to parent, which must belong to the same
+// meeting.
+func (n *AgentNote) ReplyTo(parent *AgentNote) error {
+	if parent.id == n.id {
+		return ErrInvalidParent
+	}
+	if parent.meetingID != n.meetingID {
+		return ErrParentMeetingMismatch
 	}
+	n.parentID = parent.id
+	return nil
+}
+
+// maxIdempotencyKeyLength bounds client-supplied idempotency keys.
+const maxIdempotencyKeyLength = 255
+
+// SetIdempotencyKey records the idempotency key the note was created with.
+func (n *AgentNote) SetIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return ErrInvalidIdempotencyKey
+	}
+	n.idempotencyKey = key
+	return nil
+}
+
+// Edit replaces the note's content on behalf of editor, provided the note is
+// still at expectedRevision. It returns the revision it replaced, to be kept
+// as history.
+func (n *AgentNote) Edit(content, editor string, expectedRevision int) (NoteRevision, error) {
+	if content == "" {
+		return NoteRevision{}, ErrInvalidNoteContent
+	}
+	if editor == "" {
+		return NoteRevision{}, ErrInvalidAuthor
+	}
+	if expectedRevision != n.revision {
+		return NoteRevision{}, ErrRevisionConflict
+	}
+	prior := n.CurrentRevision()
+	n.content = content
+	n.revision++
+	n.updatedBy = editor
+	n.updatedAt = time.Now().UTC()
+	return prior, nil
+}
+
+// CurrentRevision returns the note's content as of its latest revision.
+func (n *AgentNote) CurrentRevision() NoteRevision {
+	return ReconstructNoteRevision(n.id, n.revision, n.content, n.updatedBy, n.updatedAt)
 }
 
 func (n *AgentNote) ID() NoteID       { return n.id }
@@ -56,3 +177,14 @@ func (n *AgentNote) MeetingID() string { return n.meetingID }
 func (n *AgentNote) Author() string    { return n.author }
 func (n *AgentNote) Content() string   { return n.content }
 func (n *AgentNote) CreatedAt() time.Time { return n.createdAt }
+
+func (n *AgentNote) Kind() NoteKind   { return n.kind }
+func (n *AgentNote) Anchor() Anchor   { return n.anchor }
+func (n *AgentNote) ParentID() NoteID { return n.parentID }
+func (n *AgentNote) IsReply() bool    { return n.parentID != "" }
+
+func (n *AgentNote) Revision() int        { return n.revision }
+func (n *AgentNote) UpdatedBy() string    { return n.updatedBy }
+func (n *AgentNote) UpdatedAt() time.Time { return n.updatedAt }
+
+func (n *AgentNote) IdempotencyKey() string { return n.idempotencyKey }
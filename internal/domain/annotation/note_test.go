@@ -2,6 +2,7 @@ package annotation_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/acai/internal/domain/annotation"
 )
@@ -61,6 +62,7 @@ func TestReconstructAgentNote(t *testing.T) {
 	note, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "content")
 	reconstructed := annotation.ReconstructAgentNote(
 		note.ID(), note.MeetingID(), note.Author(), note.Content(), note.CreatedAt(),
+		"", annotation.Anchor{}, "", 0, "", time.Time{},
 	)
 
 	if reconstructed.ID() != note.ID() {
@@ -69,6 +71,41 @@ func TestReconstructAgentNote(t *testing.T) {
 	if reconstructed.CreatedAt() != note.CreatedAt() {
 		t.Error("created_at mismatch")
 	}
+	if reconstructed.Kind() != annotation.KindNote {
+		t.Errorf("notes stored before kinds existed should be plain notes, got %q", reconstructed.Kind())
+	}
+	if reconstructed.Revision() != 1 || reconstructed.UpdatedBy() != "claude" || reconstructed.UpdatedAt() != note.CreatedAt() {
+		t.Errorf("notes stored before edits existed should be revision 1 by their author, got %d by %q at %v",
+			reconstructed.Revision(), reconstructed.UpdatedBy(), reconstructed.UpdatedAt())
+	}
+}
+
+func TestAgentNote_Edit(t *testing.T) {
+	note, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Budget is blocked")
+
+	prior, err := note.Edit("Budget approved on Friday", "alice", 1)
+	if err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+	if prior.Number() != 1 || prior.Content() != "Budget is blocked" || prior.Author() != "claude" || prior.CreatedAt() != note.CreatedAt() {
+		t.Errorf("prior revision = %d %q by %q at %v", prior.Number(), prior.Content(), prior.Author(), prior.CreatedAt())
+	}
+	if note.Revision() != 2 || note.Content() != "Budget approved on Friday" || note.UpdatedBy() != "alice" {
+		t.Errorf("note = revision %d %q by %q", note.Revision(), note.Content(), note.UpdatedBy())
+	}
+	if note.Author() != "claude" {
+		t.Errorf("editing changed the note's author to %q", note.Author())
+	}
+
+	if _, err := note.Edit("Stale edit", "bob", 1); err != annotation.ErrRevisionConflict {
+		t.Errorf("stale revision: got %v, want ErrRevisionConflict", err)
+	}
+	if _, err := note.Edit("", "bob", 2); err != annotation.ErrInvalidNoteContent {
+		t.Errorf("empty content: got %v, want ErrInvalidNoteContent", err)
+	}
+	if note.Revision() != 2 {
+		t.Errorf("rejected edits changed the revision to %d", note.Revision())
+	}
 }
 
 func TestNoteAdded_Event(t *testing.T) {
@@ -91,6 +128,20 @@ func TestNoteAdded_Event(t *testing.T) {
 	}
 }
 
+func TestNoteUpdated_Event(t *testing.T) {
+	event := annotation.NewNoteUpdatedEvent("n-1", "m-1", "alice", 2)
+
+	if event.EventName() != "note.updated" {
+		t.Errorf("got event name %q", event.EventName())
+	}
+	if event.NoteID() != "n-1" || event.MeetingID() != "m-1" {
+		t.Errorf("got note %q in meeting %q", event.NoteID(), event.MeetingID())
+	}
+	if event.Editor() != "alice" || event.Revision() != 2 {
+		t.Errorf("got revision %d by %q", event.Revision(), event.Editor())
+	}
+}
+
 func TestNoteDeleted_Event(t *testing.T) {
 	event := annotation.NewNoteDeletedEvent("n-1", "m-1")
 
@@ -107,3 +158,72 @@ func TestNoteDeleted_Event(t *testing.T) {
 		t.Error("occurred_at should not be zero")
 	}
 }
+
+func TestParseNoteKind(t *testing.T) {
+	if k, err := annotation.ParseNoteKind(""); err != nil || k != annotation.KindNote {
+		t.Errorf("empty kind = %q, %v; want note", k, err)
+	}
+	if k, err := annotation.ParseNoteKind("risk"); err != nil || k != annotation.KindRisk {
+		t.Errorf("risk = %q, %v", k, err)
+	}
+	if _, err := annotation.ParseNoteKind("todo"); err != annotation.ErrInvalidNoteKind {
+		t.Errorf("got error %v, want %v", err, annotation.ErrInvalidNoteKind)
+	}
+}
+
+func TestAgentNote_ReplyTo(t *testing.T) {
+	parent, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Is the budget approved?")
+	reply, _ := annotation.NewAgentNote("n-2", "m-1", "gpt", "Yes, in the second half")
+
+	if err := reply.ReplyTo(parent); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reply.IsReply() || reply.ParentID() != "n-1" {
+		t.Errorf("got parent %q", reply.ParentID())
+	}
+
+	if err := parent.ReplyTo(parent); err != annotation.ErrInvalidParent {
+		t.Errorf("self reply: got %v, want %v", err, annotation.ErrInvalidParent)
+	}
+	other, _ := annotation.NewAgentNote("n-3", "m-2", "gpt", "Elsewhere")
+	if err := other.ReplyTo(parent); err != annotation.ErrParentMeetingMismatch {
+		t.Errorf("cross-meeting reply: got %v, want %v", err, annotation.ErrParentMeetingMismatch)
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	start := time.Date(2026, 1, 15, 10, 5, 0, 0, time.UTC)
+	end := start.Add(2 * time.Minute)
+
+	rng, err := annotation.NewTimeRangeAnchor(start, end)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rng.Covers(7, start.Add(time.Minute)) || rng.Covers(7, end.Add(time.Second)) {
+		t.Error("time range anchor should cover only timestamps within [start, end]")
+	}
+	if _, ok := rng.UtteranceIndex(); ok {
+		t.Error("time range anchor should not report an utterance index")
+	}
+
+	utt, err := annotation.NewUtteranceAnchor(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if i, ok := utt.UtteranceIndex(); !ok || i != 0 || utt.IsZero() {
+		t.Errorf("utterance anchor = %d, %v", i, ok)
+	}
+	if !utt.Covers(0, start) || utt.Covers(1, start) {
+		t.Error("utterance anchor should cover only its index")
+	}
+
+	if _, err := annotation.NewTimeRangeAnchor(end, start); err != annotation.ErrInvalidAnchor {
+		t.Errorf("reversed range: got %v, want %v", err, annotation.ErrInvalidAnchor)
+	}
+	if _, err := annotation.NewUtteranceAnchor(-1); err != annotation.ErrInvalidAnchor {
+		t.Errorf("negative index: got %v, want %v", err, annotation.ErrInvalidAnchor)
+	}
+	if !(annotation.Anchor{}).IsZero() {
+		t.Error("zero anchor should be zero")
+	}
+}
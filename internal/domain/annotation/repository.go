@@ -1,13 +1,52 @@
 package annotation
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// NoteFilter defines criteria for searching notes across meetings.
+// Zero fields do not filter.
+type NoteFilter struct {
+	MeetingID string
+	Author    string
+	Kind      NoteKind
+	Since     *time.Time // created at or after
+	Until     *time.Time // created at or before
+	Query     string     // full-text match on content; every word must occur
+	Limit     int
+	Offset    int
+}
 
 // NoteRepository is the port for agent note persistence.
 // Defined in the domain layer, implemented in infrastructure (local store).
 type NoteRepository interface {
+	// Save inserts or overwrites the note. It fails with
+	// ErrDuplicateIdempotencyKey if another note by the same author holds
+	// the note's idempotency key.
 	Save(ctx context.Context, note *AgentNote) error
 	FindByID(ctx context.Context, id NoteID) (*AgentNote, error)
+	// FindByIdempotencyKey returns the note author created with key, or
+	// ErrNoteNotFound. Keys are scoped to their author.
+	FindByIdempotencyKey(ctx context.Context, author, key string) (*AgentNote, error)
 	ListByMeeting(ctx context.Context, meetingID string) ([]*AgentNote, error)
 	ListAll(ctx context.Context) ([]*AgentNote, error)
+	// Search returns the page of notes matching filter, newest first.
+	Search(ctx context.Context, filter NoteFilter) ([]*AgentNote, error)
 	Delete(ctx context.Context, id NoteID) error
+
+	// Update stores an edited note together with the revision it replaced.
+	// It fails with ErrRevisionConflict unless the stored note is still at
+	// prior's revision.
+	Update(ctx context.Context, note *AgentNote, prior NoteRevision) error
+	// ListRevisions returns a note's replaced revisions, oldest first.
+	ListRevisions(ctx context.Context, id NoteID) ([]NoteRevision, error)
+}
+
+// PushConflictReader reports agent notes whose local edits lost to a human
+// edit of the same note in Granola. Implemented by the note push store.
+type PushConflictReader interface {
+	// PushConflicts returns the time of the latest conflict for each of
+	// the given notes that had one.
+	PushConflicts(ctx context.Context, ids []NoteID) (map[NoteID]time.Time, error)
 }
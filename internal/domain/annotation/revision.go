@@ -0,0 +1,24 @@
+package annotation
+
+import "time"
+
+// NoteRevision is a value object recording one version of a note's content,
+// who wrote it and when. Revisions are numbered from 1.
+type NoteRevision struct {
+	noteID    NoteID
+	number    int
+	content   string
+	author    string
+	createdAt time.Time
+}
+
+// ReconstructNoteRevision reconstitutes a revision from persistence.
+func ReconstructNoteRevision(noteID NoteID, number int, content, author string, createdAt time.Time) NoteRevision {
+	return NoteRevision{noteID: noteID, number: number, content: content, author: author, createdAt: createdAt}
+}
+
+func (r NoteRevision) NoteID() NoteID       { return r.noteID }
+func (r NoteRevision) Number() int          { return r.number }
+func (r NoteRevision) Content() string      { return r.content }
+func (r NoteRevision) Author() string       { return r.author }
+func (r NoteRevision) CreatedAt() time.Time { return r.createdAt }
@@ -0,0 +1,158 @@
+package meeting
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Events carry unexported fields, so each one defines its JSON form
+// explicitly. The field names are part of the event schema: renaming or
+// removing one requires a schema version bump and an upcaster.
+
+type meetingCreatedJSON struct {
+	MeetingID  string    `json:"meeting_id"`
+	Title      string    `json:"title"`
+	Datetime   time.Time `json:"datetime"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e MeetingCreated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(meetingCreatedJSON{
+		MeetingID:  string(e.meetingID),
+		Title:      e.title,
+		Datetime:   e.datetime,
+		OccurredAt: e.occurred,
+	})
+}
+
+func (e *MeetingCreated) UnmarshalJSON(data []byte) error {
+	var v meetingCreatedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = MeetingCreated{meetingID: MeetingID(v.MeetingID), title: v.Title, datetime: v.Datetime, occurred: v.OccurredAt}
+	return nil
+}
+
+// Datetime returns the scheduled start of the created meeting.
+func (e MeetingCreated) Datetime() time.Time { return e.datetime }
+
+type meetingDeletedJSON struct {
+	MeetingID  string    `json:"meeting_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e MeetingDeleted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(meetingDeletedJSON{
+		MeetingID:  string(e.meetingID),
+		OccurredAt: e.occurred,
+	})
+}
+
+func (e *MeetingDeleted) UnmarshalJSON(data []byte) error {
+	var v meetingDeletedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = MeetingDeleted{meetingID: MeetingID(v.MeetingID), occurred: v.OccurredAt}
+	return nil
+}
+
+type transcriptUpdatedJSON struct {
+	MeetingID      string    `json:"meeting_id"`
+	UtteranceCount int       `json:"utterance_count"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+func (e TranscriptUpdated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transcriptUpdatedJSON{
+		MeetingID:      string(e.meetingID),
+		UtteranceCount: e.utteranceCount,
+		OccurredAt:     e.occurred,
+	})
+}
+
+func (e *TranscriptUpdated) UnmarshalJSON(data []byte) error {
+	var v transcriptUpdatedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = TranscriptUpdated{meetingID: MeetingID(v.MeetingID), utteranceCount: v.UtteranceCount, occurred: v.OccurredAt}
+	return nil
+}
+
+type summaryUpdatedJSON struct {
+	MeetingID  string    `json:"meeting_id"`
+	Kind       string    `json:"kind"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e SummaryUpdated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(summaryUpdatedJSON{
+		MeetingID:  string(e.meetingID),
+		Kind:       string(e.kind),
+		OccurredAt: e.occurred,
+	})
+}
+
+func (e *SummaryUpdated) UnmarshalJSON(data []byte) error {
+	var v summaryUpdatedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = SummaryUpdated{meetingID: MeetingID(v.MeetingID), kind: SummaryKind(v.Kind), occurred: v.OccurredAt}
+	return nil
+}
+
+type actionItemCompletedJSON struct {
+	MeetingID    string    `json:"meeting_id"`
+	ActionItemID string    `json:"action_item_id"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+func (e ActionItemCompleted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(actionItemCompletedJSON{
+		MeetingID:    string(e.meetingID),
+		ActionItemID: string(e.actionItemID),
+		OccurredAt:   e.occurred,
+	})
+}
+
+func (e *ActionItemCompleted) UnmarshalJSON(data []byte) error {
+	var v actionItemCompletedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = ActionItemCompleted{meetingID: MeetingID(v.MeetingID), actionItemID: ActionItemID(v.ActionItemID), occurred: v.OccurredAt}
+	return nil
+}
+
+type actionItemUpdatedJSON struct {
+	MeetingID    string    `json:"meeting_id"`
+	ActionItemID string    `json:"action_item_id"`
+	NewText      string    `json:"new_text"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+func (e ActionItemUpdated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(actionItemUpdatedJSON{
+		MeetingID:    string(e.meetingID),
+		ActionItemID: string(e.actionItemID),
+		NewText:      e.newText,
+		OccurredAt:   e.occurred,
+	})
+}
+
+func (e *ActionItemUpdated) UnmarshalJSON(data []byte) error {
+	var v actionItemUpdatedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = ActionItemUpdated{
+		meetingID:    MeetingID(v.MeetingID),
+		actionItemID: ActionItemID(v.ActionItemID),
+		newText:      v.NewText,
+		occurred:     v.OccurredAt,
+	}
+	return nil
+}
@@ -25,6 +25,20 @@ func TestMeetingCreated_Event(t *testing.T) {
 	}
 }
 
+func TestMeetingDeleted_Event(t *testing.T) {
+	event := meeting.NewMeetingDeletedEvent("m-1")
+
+	if event.EventName() != "meeting.deleted" {
+		t.Errorf("got event name %q", event.EventName())
+	}
+	if event.MeetingID() != "m-1" {
+		t.Errorf("got meeting id %q", event.MeetingID())
+	}
+	if event.OccurredAt().IsZero() {
+		t.Error("expected occurred at to be set")
+	}
+}
+
 func TestTranscriptUpdated_Event(t *testing.T) {
 	event := meeting.NewTranscriptUpdatedEvent("m-1", 42)
 
@@ -0,0 +1,21 @@
+package meeting
+
+import (
+	"context"
+	"time"
+)
+
+// HistoryEntry is one recorded domain event in a meeting's activity timeline.
+type HistoryEntry struct {
+	EventID    string
+	OccurredAt time.Time
+	Event      DomainEvent
+}
+
+// EventHistory is the port for reading the recorded events of a meeting.
+// Implemented by the local event store in infrastructure.
+type EventHistory interface {
+	// MeetingHistory returns up to limit of the most recent events for the
+	// meeting, oldest first. A limit <= 0 means no limit.
+	MeetingHistory(ctx context.Context, meetingID MeetingID, limit int) ([]HistoryEntry, error)
+}
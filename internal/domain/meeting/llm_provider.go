@@ -0,0 +1,19 @@
+package meeting
+
+import "context"
+
+// CompletionRequest is one prompt for a language model.
+type CompletionRequest struct {
+	System    string // instructions
+	Prompt    string
+	MaxTokens int // upper bound on the reply; 0 leaves it to the provider
+}
+
+// LLMProvider is the port for text generation by a language model, such as
+// a local server running an open-weight model. Implementations live in
+// infrastructure.
+type LLMProvider interface {
+	// Model names the model replies come from.
+	Model() string
+	Complete(ctx context.Context, req CompletionRequest) (string, error)
+}
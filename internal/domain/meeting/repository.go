@@ -37,3 +37,28 @@ type WriteRepository interface {
 	SaveActionItemState(ctx context.Context, item *ActionItem) error
 	GetLocalActionItemState(ctx context.Context, id ActionItemID) (*ActionItem, error)
 }
+
+// SummaryStore is the port for summaries generated locally. They are kept
+// apart from the read-only meeting repository and shown only for meetings
+// Granola has no summary for.
+type SummaryStore interface {
+	SaveSummary(ctx context.Context, summary Summary) error
+	// FindSummaries returns the stored summaries of the given meetings;
+	// meetings without one are absent from the map.
+	FindSummaries(ctx context.Context, ids []MeetingID) (map[MeetingID]Summary, error)
+}
+
+// LocalState counts locally authored data that references a meeting.
+type LocalState struct {
+	Notes               int
+	ActionItemOverrides int
+}
+
+// Empty reports whether nothing local references the meeting.
+func (s LocalState) Empty() bool { return s.Notes == 0 && s.ActionItemOverrides == 0 }
+
+// LocalStateCounter is the port used to find local data orphaned by a
+// meeting that was deleted upstream.
+type LocalStateCounter interface {
+	CountLocalState(ctx context.Context, id MeetingID) (LocalState, error)
+}
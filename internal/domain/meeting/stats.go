@@ -0,0 +1,63 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStatsUnavailable is returned by a StatsReader that cannot answer yet,
+// e.g. a read model that has not been built. Callers fall back to computing
+// statistics from the repository.
+var ErrStatsUnavailable = errors.New("meeting statistics unavailable from read model")
+
+// Stats are meeting statistics aggregated in storage. Counts are ordered:
+// days ascending, sources, participants and speakers most frequent first,
+// weekday/hour cells by weekday then hour.
+type Stats struct {
+	Meetings             int
+	EarliestDay          string // 2006-01-02, in the meeting's own time zone
+	LatestDay            string
+	PerDay               []DayCount
+	PerSource            []SourceCount
+	Participants         []ParticipantCount
+	ActionItems          int
+	CompletedActionItems int
+	PerWeekdayHour       []WeekdayHourCount
+	WithSummary          int
+	Speakers             []SpeakerCount
+}
+
+type DayCount struct {
+	Day   string
+	Count int
+}
+
+type SourceCount struct {
+	Source Source
+	Count  int
+}
+
+type ParticipantCount struct {
+	Name     string
+	Email    string
+	Meetings int
+}
+
+type WeekdayHourCount struct {
+	Weekday int // 0 = Sunday
+	Hour    int
+	Count   int
+}
+
+type SpeakerCount struct {
+	Speaker    string
+	Words      int
+	Utterances int
+}
+
+// StatsReader is an optional port for repositories that aggregate
+// statistics without loading every meeting and transcript. Only the Since
+// and Until bounds of the filter apply.
+type StatsReader interface {
+	MeetingStats(ctx context.Context, filter ListFilter) (*Stats, error)
+}
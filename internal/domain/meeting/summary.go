@@ -1,11 +1,19 @@
 package meeting
 
-// SummaryKind distinguishes between auto-generated and user-edited summaries.
+import "errors"
+
+// ErrSummaryExists is returned when generating a summary for a meeting that
+// already has one from Granola.
+var ErrSummaryExists = errors.New("meeting already has a summary from Granola")
+
+// SummaryKind distinguishes between auto-generated and user-edited summaries
+// from Granola, and summaries generated locally from the transcript.
 type SummaryKind string
 
 const (
-	SummaryAuto   SummaryKind = "auto"
-	SummaryEdited SummaryKind = "user_edited"
+	SummaryAuto      SummaryKind = "auto"
+	SummaryEdited    SummaryKind = "user_edited"
+	SummaryGenerated SummaryKind = "generated" // by a local language model; fills in for a missing Granola summary
 )
 
 // Summary is an immutable value object for meeting summaries.
@@ -0,0 +1,46 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTaskLinkNotFound  = errors.New("task link not found")
+	ErrReopenUnsupported = errors.New("task tracker cannot reopen the ticket")
+)
+
+// TaskSink is the port for pushing action items to an external task tracker
+// (GitHub Issues, Linear, Jira, ...). Implementations live in infrastructure.
+type TaskSink interface {
+	// Name identifies the sink in task links, e.g. "github".
+	Name() string
+	// CreateTask files a new ticket for the item and returns its external ID.
+	CreateTask(ctx context.Context, item *ActionItem) (string, error)
+	// UpdateTask overwrites the ticket's title, description, and due date.
+	UpdateTask(ctx context.Context, externalID string, item *ActionItem) error
+	// CloseTask resolves the ticket. Closing an already closed ticket is not an error.
+	CloseTask(ctx context.Context, externalID string) error
+	// ReopenTask moves a resolved ticket back to an open state. Reopening an
+	// open ticket is not an error; a sink that cannot reopen returns
+	// ErrReopenUnsupported.
+	ReopenTask(ctx context.Context, externalID string) error
+}
+
+// TaskLink records which external ticket an action item was filed as, so
+// repeated pushes update the same ticket instead of filing a new one.
+type TaskLink struct {
+	ActionItemID ActionItemID
+	Sink         string
+	ExternalID   string
+	Fingerprint  string // hash of the last pushed content; unchanged items are skipped
+	Closed       bool
+	UpdatedAt    time.Time
+}
+
+// TaskLinkRepository is the port for persisting action item → ticket mappings.
+type TaskLinkRepository interface {
+	GetTaskLink(ctx context.Context, sink string, id ActionItemID) (*TaskLink, error)
+	SaveTaskLink(ctx context.Context, link TaskLink) error
+}
@@ -0,0 +1,174 @@
+// Package backup exports the local state in local.db — agent notes and their
+// revisions, action item overrides, task links, generated summaries, note
+// push state and the outbox — together with config.yaml to a single
+// versioned archive, and restores such an archive by merging it into, or
+// replacing, the local state of another install.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/config"
+)
+
+// Format identifies acai backup archives; Version is the archive layout
+// written by this release. Restore reads every version up to Version.
+//
+//	1: notes, action item overrides, task links, outbox, config
+//	2: notes carry kind, anchor and parent
+//	3: notes carry their revision; note revision history
+//	4: notes carry their idempotency key
+//	5: generated summaries; note push state
+const (
+	Format  = "acai-backup"
+	Version = 5
+)
+
+var (
+	ErrNotArchive         = errors.New("not an acai backup archive")
+	ErrUnsupportedVersion = errors.New("unsupported backup archive version")
+)
+
+// Archive is the portable snapshot of local state. Secrets are never part of
+// it: API tokens live in credentials.json and the environment, neither of
+// which is archived, and config.yaml holds no credentials. For the same
+// reason webhook subscriptions, whose signing secrets live in local.db, are
+// left out; Restore drops queued deliveries to subscriptions that do not
+// exist on the restoring install.
+type Archive struct {
+	Format    string             `json:"format"`
+	Version   int                `json:"version"`
+	CreatedAt time.Time          `json:"created_at"`
+	Config    *config.FileConfig `json:"config,omitempty"`
+
+	Notes         []Note         `json:"notes"`
+	NoteRevisions []NoteRevision `json:"note_revisions"`
+	Overrides     []Override     `json:"action_item_overrides"`
+	TaskLinks     []TaskLink     `json:"task_links"`
+	Outbox        []Outbox       `json:"outbox"`
+
+	Summaries         []Summary          `json:"generated_summaries"`
+	NotePushBlocks    []NotePushBlock    `json:"note_push_blocks"`
+	NotePushDocuments []NotePushDocument `json:"note_push_documents"`
+}
+
+// Note is a row of agent_notes.
+type Note struct {
+	ID              string     `json:"id"`
+	MeetingID       string     `json:"meeting_id"`
+	Author          string     `json:"author"`
+	Content         string     `json:"content"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Kind            string     `json:"kind"`
+	AnchorStart     *time.Time `json:"anchor_start,omitempty"`
+	AnchorEnd       *time.Time `json:"anchor_end,omitempty"`
+	AnchorUtterance *int       `json:"anchor_utterance,omitempty"`
+	ParentID        *string    `json:"parent_id,omitempty"`
+	Revision        int        `json:"revision"`
+	UpdatedBy       string     `json:"updated_by"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	IdempotencyKey  *string    `json:"idempotency_key,omitempty"`
+}
+
+// NoteRevision is a row of note_revisions.
+type NoteRevision struct {
+	NoteID    string    `json:"note_id"`
+	Revision  int       `json:"revision"`
+	Content   string    `json:"content"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Override is a row of action_item_overrides.
+type Override struct {
+	ActionItemID string     `json:"action_item_id"`
+	MeetingID    string     `json:"meeting_id"`
+	Text         *string    `json:"text,omitempty"`
+	Completed    *bool      `json:"completed,omitempty"`
+	Owner        *string    `json:"owner,omitempty"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TaskLink is a row of task_links.
+type TaskLink struct {
+	ActionItemID string    `json:"action_item_id"`
+	Sink         string    `json:"sink"`
+	ExternalID   string    `json:"external_id"`
+	Fingerprint  string    `json:"fingerprint"`
+	Closed       bool      `json:"closed"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Outbox is a row of outbox_entries.
+type Outbox struct {
+	ID            string     `json:"id"`
+	EventType     string     `json:"event_type"`
+	Payload       []byte     `json:"payload"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SyncedAt      *time.Time `json:"synced_at,omitempty"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastError     *string    `json:"last_error,omitempty"`
+	Sink          string     `json:"sink"`
+}
+
+// Summary is a row of generated_summaries.
+type Summary struct {
+	MeetingID   string    `json:"meeting_id"`
+	Content     string    `json:"content"`
+	Kind        string    `json:"kind"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// NotePushBlock is a row of note_push_blocks.
+type NotePushBlock struct {
+	NoteID       string     `json:"note_id"`
+	MeetingID    string     `json:"meeting_id"`
+	LocalHash    string     `json:"local_hash"`
+	UpstreamHash string     `json:"upstream_hash"`
+	ConflictedAt *time.Time `json:"conflicted_at,omitempty"`
+}
+
+// NotePushDocument is a row of note_push_documents.
+type NotePushDocument struct {
+	MeetingID        string    `json:"meeting_id"`
+	UpstreamRevision string    `json:"upstream_revision"`
+	PushedAt         time.Time `json:"pushed_at"`
+}
+
+// Write encodes the archive as indented JSON.
+func Write(w io.Writer, a *Archive) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a)
+}
+
+// Read decodes and validates an archive.
+func Read(r io.Reader) (*Archive, error) {
+	var a Archive
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotArchive, err)
+	}
+	if a.Format != Format {
+		return nil, fmt.Errorf("%w: format %q", ErrNotArchive, a.Format)
+	}
+	if a.Version < 1 || a.Version > Version {
+		return nil, fmt.Errorf("%w: %d (this release reads up to %d)", ErrUnsupportedVersion, a.Version, Version)
+	}
+	for i := range a.Notes {
+		n := &a.Notes[i]
+		if n.Kind == "" { // version 1
+			n.Kind = "note"
+		}
+		if n.Revision == 0 { // versions 1 and 2
+			n.Revision, n.UpdatedBy, n.UpdatedAt = 1, n.Author, n.CreatedAt
+		}
+	}
+	return &a, nil
+}
@@ -0,0 +1,555 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/config"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
+)
+
+// Mode selects how Restore treats local state.
+type Mode string
+
+const (
+	// ModeMerge adds archived rows that are missing locally. A row present on
+	// both sides with different contents is a conflict; the local row wins.
+	// Config settings are merged the same way, one setting at a time.
+	ModeMerge Mode = "merge"
+	// ModeReplace discards the local state and restores the archive as is.
+	ModeReplace Mode = "replace"
+)
+
+var ErrInvalidMode = errors.New("invalid restore mode")
+
+// ParseMode parses "merge" or "replace".
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(s)); m {
+	case ModeMerge, ModeReplace:
+		return m, nil
+	}
+	return "", fmt.Errorf("%w %q (use merge or replace)", ErrInvalidMode, s)
+}
+
+// Config restore outcomes reported in Report.Config.
+const (
+	ConfigRestored  = "restored"
+	ConfigUnchanged = "unchanged"
+	ConfigConflict  = "conflict" // some local settings differ and were kept
+	ConfigNone      = "none"     // the archive holds no config
+)
+
+// Report describes a restore.
+type Report struct {
+	Mode      Mode
+	DryRun    bool
+	Tables    []TableReport
+	Conflicts []Conflict
+	Config    string
+}
+
+// TableReport counts the rows of one table by outcome.
+type TableReport struct {
+	Table     string
+	Restored  int
+	Unchanged int
+	Conflicts int
+	Removed   int // local rows discarded by ModeReplace
+	Dropped   int // archived rows not restored, see loadOutbox
+}
+
+// Conflict is an archived row that differs from the local row with the same
+// key; the local row was kept. Config settings are reported with Table
+// configTable and their YAML path as Key.
+type Conflict struct {
+	Table string `json:"table"`
+	Key   string `json:"key"` // primary key; "/"-joined when composite
+}
+
+const configTable = "config.yaml"
+
+// Service creates and restores archives of the local store.
+type Service struct {
+	db         *sql.DB
+	configPath string
+}
+
+// NewService creates a backup service for local.db and the config file at
+// configPath (empty: config is not archived).
+func NewService(db *sql.DB, configPath string) *Service {
+	return &Service{db: db, configPath: configPath}
+}
+
+// Create snapshots the local state in one read transaction.
+func (s *Service) Create(ctx context.Context) (*Archive, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	a := &Archive{Format: Format, Version: Version, CreatedAt: time.Now().UTC()}
+	if a.Notes, err = dump(ctx, tx, notesTable); err != nil {
+		return nil, err
+	}
+	if a.NoteRevisions, err = dump(ctx, tx, noteRevisionsTable); err != nil {
+		return nil, err
+	}
+	if a.Overrides, err = dump(ctx, tx, overridesTable); err != nil {
+		return nil, err
+	}
+	if a.TaskLinks, err = dump(ctx, tx, taskLinksTable); err != nil {
+		return nil, err
+	}
+	if a.Outbox, err = dump(ctx, tx, outboxTable); err != nil {
+		return nil, err
+	}
+	if a.Summaries, err = dump(ctx, tx, summariesTable); err != nil {
+		return nil, err
+	}
+	if a.NotePushBlocks, err = dump(ctx, tx, notePushBlocksTable); err != nil {
+		return nil, err
+	}
+	if a.NotePushDocuments, err = dump(ctx, tx, notePushDocumentsTable); err != nil {
+		return nil, err
+	}
+
+	if s.configPath != "" {
+		if _, err := os.Stat(s.configPath); err == nil {
+			if a.Config, err = config.ReadConfigFile(s.configPath); err != nil {
+				return nil, fmt.Errorf("read config: %w", err)
+			}
+		}
+	}
+	return a, nil
+}
+
+// Restore applies the archive to the local state in one transaction. With
+// dryRun the transaction is rolled back and config.yaml is left untouched,
+// so the report previews what a restore would do.
+func (s *Service) Restore(ctx context.Context, a *Archive, mode Mode, dryRun bool) (*Report, error) {
+	if _, err := ParseMode(string(mode)); err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	report := &Report{Mode: mode, DryRun: dryRun}
+	steps := []func() (TableReport, []Conflict, error){
+		func() (TableReport, []Conflict, error) { return load(ctx, tx, notesTable, a.Notes, mode) },
+		func() (TableReport, []Conflict, error) {
+			return loadNoteRevisions(ctx, tx, a.NoteRevisions, mode, report.Conflicts)
+		},
+		func() (TableReport, []Conflict, error) { return load(ctx, tx, overridesTable, a.Overrides, mode) },
+		func() (TableReport, []Conflict, error) { return load(ctx, tx, taskLinksTable, a.TaskLinks, mode) },
+		func() (TableReport, []Conflict, error) { return loadOutbox(ctx, tx, a.Outbox, mode) },
+		func() (TableReport, []Conflict, error) { return load(ctx, tx, summariesTable, a.Summaries, mode) },
+		func() (TableReport, []Conflict, error) {
+			return load(ctx, tx, notePushBlocksTable, a.NotePushBlocks, mode)
+		},
+		func() (TableReport, []Conflict, error) {
+			return load(ctx, tx, notePushDocumentsTable, a.NotePushDocuments, mode)
+		},
+	}
+	for _, step := range steps {
+		table, conflicts, err := step()
+		if err != nil {
+			return nil, err
+		}
+		report.Tables = append(report.Tables, table)
+		report.Conflicts = append(report.Conflicts, conflicts...)
+	}
+
+	if !dryRun {
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+	}
+	var conflicts []Conflict
+	if report.Config, conflicts, err = s.restoreConfig(a.Config, mode, dryRun); err != nil {
+		return nil, err
+	}
+	report.Conflicts = append(report.Conflicts, conflicts...)
+	return report, nil
+}
+
+// restoreConfig writes the archived config.yaml. In ModeMerge it fills in
+// only the settings unset locally; a setting set differently on both sides
+// is a conflict and keeps its local value.
+func (s *Service) restoreConfig(archived *config.FileConfig, mode Mode, dryRun bool) (string, []Conflict, error) {
+	if archived == nil || s.configPath == "" {
+		return ConfigNone, nil, nil
+	}
+	local, err := config.ReadConfigFile(s.configPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("read config: %w", err)
+	}
+	if reflect.DeepEqual(local, archived) {
+		return ConfigUnchanged, nil, nil
+	}
+
+	merged := *archived
+	var conflicts []Conflict
+	if mode == ModeMerge {
+		merged = *local
+		filled, keys := mergeSettings(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(archived).Elem(), "")
+		for _, k := range keys {
+			conflicts = append(conflicts, Conflict{Table: configTable, Key: k})
+		}
+		if !filled {
+			if len(conflicts) > 0 {
+				return ConfigConflict, conflicts, nil
+			}
+			return ConfigUnchanged, nil, nil
+		}
+	}
+	if !dryRun {
+		if err := config.WriteConfigFile(s.configPath, merged); err != nil {
+			return "", nil, fmt.Errorf("write config: %w", err)
+		}
+	}
+	if len(conflicts) > 0 {
+		return ConfigConflict, conflicts, nil
+	}
+	return ConfigRestored, nil, nil
+}
+
+// mergeSettings copies each setting of archived into local where local
+// leaves it unset, reporting whether any was copied and the YAML paths of
+// the settings both set to different values.
+func mergeSettings(local, archived reflect.Value, prefix string) (filled bool, conflicts []string) {
+	for i := 0; i < local.NumField(); i++ {
+		key := prefix + strings.Split(local.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		l, a := local.Field(i), archived.Field(i)
+		switch {
+		case l.Kind() == reflect.Struct:
+			f, c := mergeSettings(l, a, key+".")
+			filled, conflicts = filled || f, append(conflicts, c...)
+		case a.IsZero():
+		case l.IsZero():
+			l.Set(a)
+			filled = true
+		case !reflect.DeepEqual(l.Interface(), a.Interface()):
+			conflicts = append(conflicts, key)
+		}
+	}
+	return filled, conflicts
+}
+
+// table maps one local.db table to its archive row type.
+type table[T any] struct {
+	name    string
+	columns []string
+	keys    []string // primary key columns, a prefix of columns
+	scan    func(scanner) (T, error)
+	values  func(T) []any
+}
+
+type scanner interface{ Scan(dest ...any) error }
+
+func (t table[T]) selectSQL() string {
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(t.columns, ", "), t.name)
+}
+
+func (t table[T]) keyWhere() string {
+	conds := make([]string, len(t.keys))
+	for i, k := range t.keys {
+		conds[i] = k + " = ?"
+	}
+	return strings.Join(conds, " AND ")
+}
+
+func (t table[T]) keyOf(row T) []any { return t.values(row)[:len(t.keys)] }
+
+func dump[T any](ctx context.Context, tx *sql.Tx, t table[T]) ([]T, error) {
+	rows, err := tx.QueryContext(ctx, t.selectSQL()+" ORDER BY "+strings.Join(t.keys, ", "))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", t.name, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []T{}
+	for rows.Next() {
+		row, err := t.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", t.name, err)
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+func load[T any](ctx context.Context, tx *sql.Tx, t table[T], rows []T, mode Mode) (TableReport, []Conflict, error) {
+	report := TableReport{Table: t.name}
+	var conflicts []Conflict
+
+	if mode == ModeReplace {
+		res, err := tx.ExecContext(ctx, "DELETE FROM "+t.name)
+		if err != nil {
+			return report, nil, fmt.Errorf("clear %s: %w", t.name, err)
+		}
+		n, _ := res.RowsAffected()
+		report.Removed = int(n)
+	}
+
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES (?%s)",
+		t.name, strings.Join(t.columns, ", "), strings.Repeat(", ?", len(t.columns)-1))
+	for _, row := range rows {
+		if mode == ModeMerge {
+			local, err := t.scan(tx.QueryRowContext(ctx, t.selectSQL()+" WHERE "+t.keyWhere(), t.keyOf(row)...))
+			if err == nil {
+				if sameRow(local, row) {
+					report.Unchanged++
+				} else {
+					report.Conflicts++
+					conflicts = append(conflicts, Conflict{Table: t.name, Key: keyString(t.keyOf(row))})
+				}
+				continue
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return report, nil, fmt.Errorf("read %s: %w", t.name, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, insert, t.values(row)...); err != nil {
+			return report, nil, fmt.Errorf("restore %s %s: %w", t.name, keyString(t.keyOf(row)), err)
+		}
+		report.Restored++
+	}
+	return report, conflicts, nil
+}
+
+// loadNoteRevisions loads revision history like any table, except that the
+// history of a note whose local row was kept on conflict stays local too:
+// the archived revisions describe a different note.
+func loadNoteRevisions(ctx context.Context, tx *sql.Tx, rows []NoteRevision, mode Mode, noteConflicts []Conflict) (TableReport, []Conflict, error) {
+	kept := make(map[string]bool)
+	for _, c := range noteConflicts {
+		if c.Table == notesTable.name {
+			kept[c.Key] = true
+		}
+	}
+	var restore, skipped []NoteRevision
+	for _, r := range rows {
+		if kept[r.NoteID] {
+			skipped = append(skipped, r)
+		} else {
+			restore = append(restore, r)
+		}
+	}
+
+	report, conflicts, err := load(ctx, tx, noteRevisionsTable, restore, mode)
+	for _, r := range skipped {
+		report.Conflicts++
+		conflicts = append(conflicts, Conflict{Table: noteRevisionsTable.name, Key: keyString(noteRevisionsTable.keyOf(r))})
+	}
+	return report, conflicts, err
+}
+
+// loadOutbox loads outbox entries like any table, except for queued webhook
+// deliveries to a subscription that does not exist locally. Subscriptions
+// are not archived, so the relay could only dead-letter such a delivery; it
+// is dropped instead and counted in TableReport.Dropped.
+func loadOutbox(ctx context.Context, tx *sql.Tx, rows []Outbox, mode Mode) (TableReport, []Conflict, error) {
+	var restore []Outbox
+	dropped := 0
+	for _, e := range rows {
+		if e.Sink == webhook.SinkName {
+			ok, err := subscriptionExists(ctx, tx, e.Payload)
+			if err != nil {
+				return TableReport{Table: outboxTable.name}, nil, fmt.Errorf("read webhook_subscriptions: %w", err)
+			}
+			if !ok {
+				dropped++
+				continue
+			}
+		}
+		restore = append(restore, e)
+	}
+
+	report, conflicts, err := load(ctx, tx, outboxTable, restore, mode)
+	report.Dropped = dropped
+	return report, conflicts, err
+}
+
+// subscriptionExists reports whether the subscription a webhook delivery
+// payload is addressed to exists locally.
+func subscriptionExists(ctx context.Context, tx *sql.Tx, payload []byte) (bool, error) {
+	var delivery struct {
+		SubscriptionID string `json:"subscription_id"`
+	}
+	if err := json.Unmarshal(payload, &delivery); err != nil || delivery.SubscriptionID == "" {
+		return false, nil
+	}
+	var one int
+	err := tx.QueryRowContext(ctx, "SELECT 1 FROM webhook_subscriptions WHERE id = ?", delivery.SubscriptionID).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// sameRow compares rows by their archive encoding, which is what a
+// round trip through an archive preserves.
+func sameRow(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+func keyString(key []any) string {
+	parts := make([]string, len(key))
+	for i, k := range key {
+		parts[i] = fmt.Sprint(k)
+	}
+	return strings.Join(parts, "/")
+}
+
+// utc normalises scanned times so rows compare equal to their archived form.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+var notesTable = table[Note]{
+	name: "agent_notes",
+	columns: []string{"id", "meeting_id", "author", "content", "created_at",
+		"kind", "anchor_start", "anchor_end", "anchor_utterance", "parent_id",
+		"revision", "updated_by", "updated_at", "idempotency_key"},
+	keys: []string{"id"},
+	scan: func(s scanner) (Note, error) {
+		var n Note
+		err := s.Scan(&n.ID, &n.MeetingID, &n.Author, &n.Content, &n.CreatedAt,
+			&n.Kind, &n.AnchorStart, &n.AnchorEnd, &n.AnchorUtterance, &n.ParentID,
+			&n.Revision, &n.UpdatedBy, &n.UpdatedAt, &n.IdempotencyKey)
+		n.CreatedAt, n.AnchorStart, n.AnchorEnd = n.CreatedAt.UTC(), utc(n.AnchorStart), utc(n.AnchorEnd)
+		n.UpdatedAt = n.UpdatedAt.UTC()
+		return n, err
+	},
+	values: func(n Note) []any {
+		return []any{n.ID, n.MeetingID, n.Author, n.Content, n.CreatedAt.UTC(),
+			n.Kind, utc(n.AnchorStart), utc(n.AnchorEnd), n.AnchorUtterance, n.ParentID,
+			n.Revision, n.UpdatedBy, n.UpdatedAt.UTC(), n.IdempotencyKey}
+	},
+}
+
+var noteRevisionsTable = table[NoteRevision]{
+	name:    "note_revisions",
+	columns: []string{"note_id", "revision", "content", "author", "created_at"},
+	keys:    []string{"note_id", "revision"},
+	scan: func(s scanner) (NoteRevision, error) {
+		var r NoteRevision
+		err := s.Scan(&r.NoteID, &r.Revision, &r.Content, &r.Author, &r.CreatedAt)
+		r.CreatedAt = r.CreatedAt.UTC()
+		return r, err
+	},
+	values: func(r NoteRevision) []any {
+		return []any{r.NoteID, r.Revision, r.Content, r.Author, r.CreatedAt.UTC()}
+	},
+}
+
+var overridesTable = table[Override]{
+	name:    "action_item_overrides",
+	columns: []string{"action_item_id", "meeting_id", "text", "completed", "owner", "due_date", "updated_at"},
+	keys:    []string{"action_item_id"},
+	scan: func(s scanner) (Override, error) {
+		var o Override
+		err := s.Scan(&o.ActionItemID, &o.MeetingID, &o.Text, &o.Completed, &o.Owner, &o.DueDate, &o.UpdatedAt)
+		o.DueDate, o.UpdatedAt = utc(o.DueDate), o.UpdatedAt.UTC()
+		return o, err
+	},
+	values: func(o Override) []any {
+		return []any{o.ActionItemID, o.MeetingID, o.Text, o.Completed, o.Owner, utc(o.DueDate), o.UpdatedAt.UTC()}
+	},
+}
+
+var taskLinksTable = table[TaskLink]{
+	name:    "task_links",
+	columns: []string{"action_item_id", "sink", "external_id", "fingerprint", "closed", "updated_at"},
+	keys:    []string{"action_item_id", "sink"},
+	scan: func(s scanner) (TaskLink, error) {
+		var l TaskLink
+		err := s.Scan(&l.ActionItemID, &l.Sink, &l.ExternalID, &l.Fingerprint, &l.Closed, &l.UpdatedAt)
+		l.UpdatedAt = l.UpdatedAt.UTC()
+		return l, err
+	},
+	values: func(l TaskLink) []any {
+		return []any{l.ActionItemID, l.Sink, l.ExternalID, l.Fingerprint, l.Closed, l.UpdatedAt.UTC()}
+	},
+}
+
+var outboxTable = table[Outbox]{
+	name: "outbox_entries",
+	columns: []string{"id", "event_type", "payload", "status", "created_at", "synced_at",
+		"attempts", "next_attempt_at", "last_error", "sink"},
+	keys: []string{"id"},
+	scan: func(s scanner) (Outbox, error) {
+		var e Outbox
+		err := s.Scan(&e.ID, &e.EventType, &e.Payload, &e.Status, &e.CreatedAt, &e.SyncedAt,
+			&e.Attempts, &e.NextAttemptAt, &e.LastError, &e.Sink)
+		e.CreatedAt, e.SyncedAt, e.NextAttemptAt = e.CreatedAt.UTC(), utc(e.SyncedAt), utc(e.NextAttemptAt)
+		return e, err
+	},
+	values: func(e Outbox) []any {
+		return []any{e.ID, e.EventType, e.Payload, e.Status, e.CreatedAt.UTC(), utc(e.SyncedAt),
+			e.Attempts, utc(e.NextAttemptAt), e.LastError, e.Sink}
+	},
+}
+
+var summariesTable = table[Summary]{
+	name:    "generated_summaries",
+	columns: []string{"meeting_id", "content", "kind", "generated_at"},
+	keys:    []string{"meeting_id"},
+	scan: func(s scanner) (Summary, error) {
+		var m Summary
+		err := s.Scan(&m.MeetingID, &m.Content, &m.Kind, &m.GeneratedAt)
+		m.GeneratedAt = m.GeneratedAt.UTC()
+		return m, err
+	},
+	values: func(m Summary) []any {
+		return []any{m.MeetingID, m.Content, m.Kind, m.GeneratedAt.UTC()}
+	},
+}
+
+var notePushBlocksTable = table[NotePushBlock]{
+	name:    "note_push_blocks",
+	columns: []string{"note_id", "meeting_id", "local_hash", "upstream_hash", "conflicted_at"},
+	keys:    []string{"note_id"},
+	scan: func(s scanner) (NotePushBlock, error) {
+		var b NotePushBlock
+		err := s.Scan(&b.NoteID, &b.MeetingID, &b.LocalHash, &b.UpstreamHash, &b.ConflictedAt)
+		b.ConflictedAt = utc(b.ConflictedAt)
+		return b, err
+	},
+	values: func(b NotePushBlock) []any {
+		return []any{b.NoteID, b.MeetingID, b.LocalHash, b.UpstreamHash, utc(b.ConflictedAt)}
+	},
+}
+
+var notePushDocumentsTable = table[NotePushDocument]{
+	name:    "note_push_documents",
+	columns: []string{"meeting_id", "upstream_revision", "pushed_at"},
+	keys:    []string{"meeting_id"},
+	scan: func(s scanner) (NotePushDocument, error) {
+		var d NotePushDocument
+		err := s.Scan(&d.MeetingID, &d.UpstreamRevision, &d.PushedAt)
+		d.PushedAt = d.PushedAt.UTC()
+		return d, err
+	},
+	values: func(d NotePushDocument) []any {
+		return []any{d.MeetingID, d.UpstreamRevision, d.PushedAt.UTC()}
+	},
+}
@@ -0,0 +1,363 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/backup"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/config"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type install struct {
+	db         *sql.DB
+	configPath string
+	svc        *backup.Service
+}
+
+// newInstall opens a local.db and config path in a fresh directory, as on
+// another laptop.
+func newInstall(t *testing.T) *install {
+	t.Helper()
+	dir := t.TempDir()
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "local.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	configPath := filepath.Join(dir, "config.yaml")
+	return &install{db: db, configPath: configPath, svc: backup.NewService(db, configPath)}
+}
+
+func (in *install) addNote(t *testing.T, id, content string) {
+	t.Helper()
+	note, err := annotation.NewAgentNote(annotation.NoteID(id), "m-1", "claude", content)
+	if err != nil {
+		t.Fatalf("new note: %v", err)
+	}
+	if err := localstore.NewNoteRepository(in.db).Save(context.Background(), note); err != nil {
+		t.Fatalf("save note: %v", err)
+	}
+}
+
+func (in *install) seed(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+	in.addNote(t, "n-1", "Budget might be the blocker")
+	notes := localstore.NewNoteRepository(in.db)
+	note, _ := notes.FindByID(ctx, "n-1")
+	prior, _ := note.Edit("Budget is the blocker", "alice", 1)
+	if err := notes.Update(ctx, note, prior); err != nil {
+		t.Fatalf("edit note: %v", err)
+	}
+
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	item, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Send the deck", &due)
+	item.Complete()
+	if err := localstore.NewWriteRepository(in.db).SaveActionItemState(ctx, item); err != nil {
+		t.Fatalf("save override: %v", err)
+	}
+	if err := localstore.NewTaskLinkRepository(in.db).SaveTaskLink(ctx, domain.TaskLink{
+		ActionItemID: "ai-1", Sink: "github", ExternalID: "42", Fingerprint: "abc",
+	}); err != nil {
+		t.Fatalf("save task link: %v", err)
+	}
+	if err := outbox.NewSQLiteStore(in.db).Append(outbox.Entry{
+		ID: "evt-1", EventType: "note.added", Payload: []byte(`{"note_id":"n-1"}`), CreatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("append outbox: %v", err)
+	}
+	if err := localstore.NewSummaryRepository(in.db).SaveSummary(ctx,
+		domain.NewSummary("m-1", "Budget review", domain.SummaryGenerated)); err != nil {
+		t.Fatalf("save summary: %v", err)
+	}
+	if _, err := in.db.Exec(`INSERT INTO note_push_blocks (note_id, meeting_id, local_hash, upstream_hash) VALUES ('n-1', 'm-1', 'h1', 'h1');
+		INSERT INTO note_push_documents (meeting_id, upstream_revision, pushed_at) VALUES ('m-1', 'rev-7', '2026-03-01 10:00:00')`); err != nil {
+		t.Fatalf("save note push state: %v", err)
+	}
+	if err := config.WriteConfigFile(in.configPath, config.FileConfig{
+		DataSource: "api", User: config.UserFileConfig{Name: "Alice"},
+	}); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+// roundTrip creates an archive of in and reads it back from its encoding.
+func roundTrip(t *testing.T, in *install) *backup.Archive {
+	t.Helper()
+	archive, err := in.svc.Create(context.Background())
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := backup.Write(&buf, archive); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	read, err := backup.Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	return read
+}
+
+func TestCreate_ArchivesLocalState(t *testing.T) {
+	src := newInstall(t)
+	src.seed(t)
+
+	a := roundTrip(t, src)
+	if len(a.Notes) != 1 || len(a.NoteRevisions) != 1 || len(a.Overrides) != 1 || len(a.TaskLinks) != 1 || len(a.Outbox) != 1 ||
+		len(a.Summaries) != 1 || len(a.NotePushBlocks) != 1 || len(a.NotePushDocuments) != 1 {
+		t.Fatalf("archive = %d notes, %d revisions, %d overrides, %d links, %d outbox, %d summaries, %d push blocks, %d push documents; want one each",
+			len(a.Notes), len(a.NoteRevisions), len(a.Overrides), len(a.TaskLinks), len(a.Outbox),
+			len(a.Summaries), len(a.NotePushBlocks), len(a.NotePushDocuments))
+	}
+	if d := a.NotePushDocuments[0]; d.UpstreamRevision != "rev-7" {
+		t.Errorf("note push document = %+v", d)
+	}
+	if n := a.Notes[0]; n.Revision != 2 || n.UpdatedBy != "alice" {
+		t.Errorf("note = revision %d by %q; want revision 2 by alice", n.Revision, n.UpdatedBy)
+	}
+	if r := a.NoteRevisions[0]; r.Revision != 1 || r.Content != "Budget might be the blocker" {
+		t.Errorf("revision = %+v", r)
+	}
+	o := a.Overrides[0]
+	if o.Owner == nil || *o.Owner != "Alice" || o.DueDate == nil || o.Completed == nil || !*o.Completed {
+		t.Errorf("override = %+v; want owner, due date and completion kept", o)
+	}
+	if a.Config == nil || a.Config.User.Name != "Alice" {
+		t.Errorf("config = %+v", a.Config)
+	}
+}
+
+func TestRestore_IntoEmptyInstall(t *testing.T) {
+	src := newInstall(t)
+	src.seed(t)
+	dst := newInstall(t)
+
+	report, err := dst.svc.Restore(context.Background(), roundTrip(t, src), backup.ModeMerge, false)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	for _, table := range report.Tables {
+		if table.Restored != 1 {
+			t.Errorf("%s restored %d rows, want 1", table.Table, table.Restored)
+		}
+	}
+	if report.Config != backup.ConfigRestored {
+		t.Errorf("config = %s, want restored", report.Config)
+	}
+
+	note, err := localstore.NewNoteRepository(dst.db).FindByID(context.Background(), "n-1")
+	if err != nil || note.Content() != "Budget is the blocker" || note.Revision() != 2 {
+		t.Errorf("restored note = %v, %v", note, err)
+	}
+	if revisions, _ := localstore.NewNoteRepository(dst.db).ListRevisions(context.Background(), "n-1"); len(revisions) != 1 {
+		t.Errorf("restored %d note revisions, want 1", len(revisions))
+	}
+	item, err := localstore.NewWriteRepository(dst.db).GetLocalActionItemState(context.Background(), "ai-1")
+	if err != nil || item.Owner() != "Alice" || !item.IsCompleted() {
+		t.Errorf("restored override = %v, %v", item, err)
+	}
+
+	// Restoring the same archive again changes nothing.
+	again, err := dst.svc.Restore(context.Background(), roundTrip(t, src), backup.ModeMerge, false)
+	if err != nil {
+		t.Fatalf("second Restore: %v", err)
+	}
+	for _, table := range again.Tables {
+		if table.Restored != 0 || table.Conflicts != 0 || table.Unchanged != 1 {
+			t.Errorf("second restore %s = %+v; want one unchanged row", table.Table, table)
+		}
+	}
+	if again.Config != backup.ConfigUnchanged {
+		t.Errorf("config = %s, want unchanged", again.Config)
+	}
+}
+
+func TestRestore_MergeReportsConflictsAndKeepsLocal(t *testing.T) {
+	src := newInstall(t)
+	src.seed(t)
+	src.addNote(t, "n-2", "Only on the old laptop")
+	dst := newInstall(t)
+	dst.addNote(t, "n-1", "Edited on the new laptop")
+	if err := config.WriteConfigFile(dst.configPath, config.FileConfig{DataSource: "local_cache"}); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	report, err := dst.svc.Restore(context.Background(), roundTrip(t, src), backup.ModeMerge, false)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	// The history of the kept local note stays local as well.
+	wantConflicts := []backup.Conflict{
+		{Table: "agent_notes", Key: "n-1"}, {Table: "note_revisions", Key: "n-1/1"}, {Table: "config.yaml", Key: "data_source"},
+	}
+	if len(report.Conflicts) != len(wantConflicts) {
+		t.Fatalf("conflicts = %+v, want %+v", report.Conflicts, wantConflicts)
+	}
+	for i, want := range wantConflicts {
+		if report.Conflicts[i] != want {
+			t.Errorf("conflict %d = %+v, want %+v", i, report.Conflicts[i], want)
+		}
+	}
+	if report.Tables[0].Restored != 1 {
+		t.Errorf("notes restored = %d, want n-2 only", report.Tables[0].Restored)
+	}
+	if report.Config != backup.ConfigConflict {
+		t.Errorf("config = %s, want conflict", report.Config)
+	}
+
+	notes := localstore.NewNoteRepository(dst.db)
+	if n, _ := notes.FindByID(context.Background(), "n-1"); n == nil || n.Content() != "Edited on the new laptop" {
+		t.Errorf("local note overwritten: %v", n)
+	}
+	// Settings missing locally are filled in; the conflicting one is kept.
+	if cfg, _ := config.ReadConfigFile(dst.configPath); cfg.DataSource != "local_cache" || cfg.User.Name != "Alice" {
+		t.Errorf("merged config = %+v; want local data_source and archived user.name", cfg)
+	}
+}
+
+func TestRestore_MergeFillsUnsetConfigSettings(t *testing.T) {
+	src := newInstall(t)
+	src.seed(t)
+	dst := newInstall(t)
+	if err := config.WriteConfigFile(dst.configPath, config.FileConfig{
+		Outbox: config.OutboxFileConfig{MaxAttempts: 3},
+	}); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	report, err := dst.svc.Restore(context.Background(), roundTrip(t, src), backup.ModeMerge, false)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if report.Config != backup.ConfigRestored || len(report.Conflicts) != 0 {
+		t.Errorf("config = %s, conflicts %+v; want restored without conflicts", report.Config, report.Conflicts)
+	}
+	cfg, _ := config.ReadConfigFile(dst.configPath)
+	if cfg.DataSource != "api" || cfg.User.Name != "Alice" || cfg.Outbox.MaxAttempts != 3 {
+		t.Errorf("merged config = %+v; want archived settings plus local outbox.max_attempts", cfg)
+	}
+}
+
+func TestRestore_DropsWebhookDeliveriesWithoutLocalSubscription(t *testing.T) {
+	ctx := context.Background()
+	src := newInstall(t)
+	for i, sub := range []string{"sub-1", "sub-2"} {
+		if err := outbox.NewSQLiteStore(src.db).Append(outbox.Entry{
+			ID: fmt.Sprintf("evt-%d", i), EventType: "note.added", Sink: webhook.SinkName,
+			Payload: []byte(`{"subscription_id":"` + sub + `","envelope":{}}`), CreatedAt: time.Now(),
+		}); err != nil {
+			t.Fatalf("append outbox: %v", err)
+		}
+	}
+	dst := newInstall(t)
+	if err := webhook.NewSQLiteRepository(dst.db).Add(ctx, webhook.Subscription{
+		ID: "sub-1", URL: "https://example.com/hook", EventTypes: []string{"*"}, Secret: "s", CreatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("add subscription: %v", err)
+	}
+
+	report, err := dst.svc.Restore(ctx, roundTrip(t, src), backup.ModeReplace, false)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	for _, table := range report.Tables {
+		if table.Table == "outbox_entries" && (table.Restored != 1 || table.Dropped != 1) {
+			t.Errorf("outbox report = %+v; want sub-1 restored and sub-2 dropped", table)
+		}
+	}
+	due, err := outbox.NewSQLiteStore(dst.db).ListDue(time.Now().Add(time.Minute), 10, []string{webhook.SinkName})
+	if err != nil || len(due) != 1 || due[0].ID != "evt-0" {
+		t.Errorf("restored webhook deliveries = %+v, %v; want evt-0 only", due, err)
+	}
+}
+
+func TestRestore_ReplaceDiscardsLocalState(t *testing.T) {
+	src := newInstall(t)
+	src.seed(t)
+	dst := newInstall(t)
+	dst.addNote(t, "n-1", "Edited on the new laptop")
+	dst.addNote(t, "n-9", "Only on the new laptop")
+
+	report, err := dst.svc.Restore(context.Background(), roundTrip(t, src), backup.ModeReplace, false)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if report.Tables[0].Removed != 2 || report.Tables[0].Restored != 1 || len(report.Conflicts) != 0 {
+		t.Errorf("notes report = %+v, conflicts %+v", report.Tables[0], report.Conflicts)
+	}
+
+	all, err := localstore.NewNoteRepository(dst.db).ListAll(context.Background())
+	if err != nil || len(all) != 1 || all[0].Content() != "Budget is the blocker" {
+		t.Errorf("notes after replace = %v, %v", all, err)
+	}
+}
+
+func TestRestore_DryRunChangesNothing(t *testing.T) {
+	src := newInstall(t)
+	src.seed(t)
+	dst := newInstall(t)
+
+	report, err := dst.svc.Restore(context.Background(), roundTrip(t, src), backup.ModeReplace, true)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if !report.DryRun || report.Tables[0].Restored != 1 || report.Config != backup.ConfigRestored {
+		t.Errorf("report = %+v", report)
+	}
+	if all, _ := localstore.NewNoteRepository(dst.db).ListAll(context.Background()); len(all) != 0 {
+		t.Errorf("dry run restored %d notes", len(all))
+	}
+	if _, err := os.Stat(dst.configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("dry run wrote config.yaml (stat err %v)", err)
+	}
+}
+
+func TestRead_RejectsForeignAndNewerArchives(t *testing.T) {
+	if _, err := backup.Read(strings.NewReader(`{"format":"other","version":1}`)); !errors.Is(err, backup.ErrNotArchive) {
+		t.Errorf("foreign format: got %v, want ErrNotArchive", err)
+	}
+	if _, err := backup.Read(strings.NewReader(`{"format":"acai-backup","version":99}`)); !errors.Is(err, backup.ErrUnsupportedVersion) {
+		t.Errorf("newer version: got %v, want ErrUnsupportedVersion", err)
+	}
+	if _, err := backup.ParseMode("overwrite"); !errors.Is(err, backup.ErrInvalidMode) {
+		t.Errorf("ParseMode: got %v, want ErrInvalidMode", err)
+	}
+}
+
+func TestRead_UpgradesVersion1Notes(t *testing.T) {
+	a, err := backup.Read(strings.NewReader(`{"format":"acai-backup","version":1,
+		"notes":[{"id":"n-1","meeting_id":"m-1","author":"claude","content":"x","created_at":"2026-01-15T10:00:00Z"}]}`))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if n := a.Notes[0]; n.Kind != "note" || n.Revision != 1 || n.UpdatedBy != "claude" || !n.UpdatedAt.Equal(n.CreatedAt) {
+		t.Errorf("version 1 note = %+v, want a plain note at revision 1", n)
+	}
+
+	dst := newInstall(t)
+	if _, err := dst.svc.Restore(context.Background(), a, backup.ModeMerge, false); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	note, err := localstore.NewNoteRepository(dst.db).FindByID(context.Background(), "n-1")
+	if err != nil || note.Kind() != annotation.KindNote {
+		t.Errorf("restored note = %v, %v", note, err)
+	}
+}
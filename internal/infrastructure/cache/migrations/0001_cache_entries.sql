@@ -0,0 +1,7 @@
+-- Baseline: the schema created by releases before versioned migrations.
+CREATE TABLE IF NOT EXISTS cache_entries (
+	key        TEXT PRIMARY KEY,
+	value      BLOB NOT NULL,
+	expires_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_cache_expires ON cache_entries(expires_at);
@@ -7,11 +7,13 @@ package cache
 import (
 	"context"
 	"database/sql"
+	"embed"
 	"encoding/json"
 	"log"
 	"time"
 
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/migrate"
 )
 
 // CachedRepository decorates a domain.Repository with local SQLite caching.
@@ -30,15 +32,14 @@ func NewCachedRepository(inner domain.Repository, db *sql.DB, ttl time.Duration)
 	return &CachedRepository{inner: inner, db: db, ttl: ttl}, nil
 }
 
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations is the versioned schema of the cache tables in cache.db.
+var Migrations = migrate.MustLoad("cache", migrationFiles, "migrations")
+
 func initSchema(db *sql.DB) error {
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS cache_entries (
-			key        TEXT PRIMARY KEY,
-			value      BLOB NOT NULL,
-			expires_at DATETIME NOT NULL
-		);
-		CREATE INDEX IF NOT EXISTS idx_cache_expires ON cache_entries(expires_at);
-	`)
+	_, err := migrate.Up(context.Background(), db, Migrations)
 	return err
 }
 
@@ -138,11 +139,12 @@ func (r *CachedRepository) Sync(ctx context.Context, since *time.Time) ([]domain
 	if err != nil {
 		return nil, err
 	}
-	// Invalidate cache for any meetings referenced in events.
+	// Invalidate cache for any meetings referenced in events
+	// (created, deleted, transcript updated, summary updated).
 	for _, e := range events {
-		if mc, ok := e.(domain.MeetingCreated); ok {
-			if _, delErr := r.db.Exec("DELETE FROM cache_entries WHERE key = ?", "meeting:"+string(mc.MeetingID())); delErr != nil {
-				log.Printf("cache: invalidation failed for meeting:%s: %v", mc.MeetingID(), delErr)
+		if me, ok := e.(interface{ MeetingID() domain.MeetingID }); ok {
+			if _, delErr := r.db.Exec("DELETE FROM cache_entries WHERE key = ?", "meeting:"+string(me.MeetingID())); delErr != nil {
+				log.Printf("cache: invalidation failed for meeting:%s: %v", me.MeetingID(), delErr)
 			}
 		}
 	}
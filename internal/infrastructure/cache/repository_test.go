@@ -17,6 +17,7 @@ type mockRepo struct {
 	listCalls   int
 	syncCalls   int
 	searchCalls int
+	syncEvents  []domain.DomainEvent
 }
 
 func newMockRepo() *mockRepo {
@@ -55,7 +56,7 @@ func (m *mockRepo) GetActionItems(_ context.Context, _ domain.MeetingID) ([]*dom
 
 func (m *mockRepo) Sync(_ context.Context, _ *time.Time) ([]domain.DomainEvent, error) {
 	m.syncCalls++
-	return nil, nil
+	return m.syncEvents, nil
 }
 
 func openTestDB(t *testing.T) *sql.DB {
@@ -170,6 +171,28 @@ func TestCachedRepository_SyncDelegatesToInner(t *testing.T) {
 	}
 }
 
+func TestCachedRepository_SyncInvalidatesUpdatedMeetings(t *testing.T) {
+	db := openTestDB(t)
+	inner := newMockRepo()
+	inner.meetings["m-1"] = mustMeeting(t, "m-1", "Planning")
+
+	repo, err := cache.NewCachedRepository(inner, db, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("new cached repo: %v", err)
+	}
+
+	_, _ = repo.FindByID(context.Background(), "m-1") // populate cache
+	inner.syncEvents = []domain.DomainEvent{domain.NewSummaryUpdatedEvent("m-1", domain.SummaryAuto)}
+	if _, err := repo.Sync(context.Background(), nil); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	_, _ = repo.FindByID(context.Background(), "m-1")
+	if inner.findCalls != 2 {
+		t.Errorf("expected cache miss after summary update, got %d inner calls", inner.findCalls)
+	}
+}
+
 func TestCachedRepository_Evict(t *testing.T) {
 	db := openTestDB(t)
 	inner := newMockRepo()
@@ -13,19 +13,36 @@ type Config struct {
 	Granola    GranolaConfig
 	MCP        MCPConfig
 	Cache      CacheConfig
+	Projection ProjectionConfig
 	Resilience ResilienceConfig
 	Privacy    PrivacyConfig
 	Policy     PolicyConfig
 	Sync       SyncConfig
 	Logging    LoggingConfig
+	User       UserConfig
+	Tasks      TasksConfig
+	Outbox     OutboxConfig
+	LLM        LLMConfig
 }
 
 type GranolaConfig struct {
 	APIURL         string
 	AuthMethod     string
 	APIToken       string
-	DataSource     string // "auto" (default), "api", "local_cache"
-	LocalCachePath string // override path to cache-v3.json
+	DataSource     string        // "auto" (default), "api", "local_cache", "hybrid"
+	LocalCachePath string        // override path to the desktop cache file
+	CacheRoots     []string      // directories searched for cache-v*.json (empty: platform default)
+	CacheWatch     time.Duration // poll interval for live cache reloads during serve (0 disables)
+	Hybrid         HybridPrecedence
+}
+
+// HybridPrecedence names, per field, which side wins for meetings present in
+// both the desktop cache and the API when DataSource is "hybrid": "local" or
+// "api".
+type HybridPrecedence struct {
+	Transcript   string
+	Summary      string
+	Participants string
 }
 
 type MCPConfig struct {
@@ -33,6 +50,7 @@ type MCPConfig struct {
 	Transport        string
 	HTTPPort         int
 	EnabledResources []string
+	EventsToken      string // bearer token for the /events SSE stream (env only)
 }
 
 type CacheConfig struct {
@@ -41,6 +59,14 @@ type CacheConfig struct {
 	TTL     time.Duration
 }
 
+// ProjectionConfig controls the SQLite read model kept in the cache dir.
+// Reads are served from it while its last sync is younger than MaxAge
+// (0: no limit). Serve mode syncs every MaxAge/2 to stay within it.
+type ProjectionConfig struct {
+	Enabled bool
+	MaxAge  time.Duration
+}
+
 type ResilienceConfig struct {
 	CircuitBreaker CircuitBreakerConfig
 	RateLimit      RateLimitConfig
@@ -87,6 +113,76 @@ type LoggingConfig struct {
 	Format string
 }
 
+// UserConfig identifies the local user, e.g. to resolve "me" in owner filters.
+type UserConfig struct {
+	Name string
+}
+
+// TasksConfig configures the external trackers action items are pushed to.
+// A tracker is enabled once its required fields are set.
+type TasksConfig struct {
+	GitHub GitHubTasksConfig
+	Linear LinearTasksConfig
+	Jira   JiraTasksConfig
+}
+
+type GitHubTasksConfig struct {
+	APIURL string
+	Repo   string // "owner/name"
+	Token  string
+}
+
+func (c GitHubTasksConfig) Enabled() bool { return c.Repo != "" && c.Token != "" }
+
+type LinearTasksConfig struct {
+	APIURL      string
+	APIKey      string
+	TeamID      string
+	DoneStateID string
+	OpenStateID string // reopens issues of items marked incomplete again
+}
+
+func (c LinearTasksConfig) Enabled() bool { return c.APIKey != "" && c.TeamID != "" }
+
+type JiraTasksConfig struct {
+	BaseURL    string
+	Email      string
+	APIToken   string
+	ProjectKey string
+	IssueType  string
+}
+
+func (c JiraTasksConfig) Enabled() bool {
+	return c.BaseURL != "" && c.Email != "" && c.APIToken != "" && c.ProjectKey != ""
+}
+
+// OutboxConfig configures the relay that delivers outbox entries.
+// The relay only runs when at least one sink is configured.
+type OutboxConfig struct {
+	RelayInterval  time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	WebhookURL     string // POST each entry as JSON
+	FilePath       string // append each entry as NDJSON
+	GranolaPath    string // Granola write API path, e.g. "/v1/events"
+
+	// GranolaNotesPath is the Granola document endpoint agent notes are
+	// written into; "{id}" is the meeting ID, e.g. "/v1/notes/{id}/document".
+	GranolaNotesPath string
+}
+
+// LLMConfig points at a server speaking the OpenAI chat completions API,
+// typically a local one such as Ollama (http://localhost:11434/v1), used to
+// generate summaries Granola lacks.
+type LLMConfig struct {
+	BaseURL string
+	Model   string
+	APIKey  string // optional; local servers usually need none
+}
+
+func (c LLMConfig) Enabled() bool { return c.BaseURL != "" && c.Model != "" }
+
 func Load() *Config {
 	cfg := Default()
 
@@ -126,6 +222,91 @@ func applyFileConfig(cfg *Config, path string) {
 	if fileCfg.Granola.CachePath != "" {
 		cfg.Granola.LocalCachePath = fileCfg.Granola.CachePath
 	}
+	if len(fileCfg.Granola.CacheRoots) > 0 {
+		cfg.Granola.CacheRoots = fileCfg.Granola.CacheRoots
+	}
+	if d, err := time.ParseDuration(fileCfg.Granola.CacheWatch); err == nil && d >= 0 {
+		cfg.Granola.CacheWatch = d
+	}
+	applyHybridFileConfig(&cfg.Granola.Hybrid, fileCfg.Granola.Hybrid)
+	if fileCfg.User.Name != "" {
+		cfg.User.Name = fileCfg.User.Name
+	}
+	applyTasksFileConfig(&cfg.Tasks, fileCfg.Tasks)
+	applyOutboxFileConfig(&cfg.Outbox, fileCfg.Outbox)
+	if fileCfg.LLM.URL != "" {
+		cfg.LLM.BaseURL = fileCfg.LLM.URL
+	}
+	if fileCfg.LLM.Model != "" {
+		cfg.LLM.Model = fileCfg.LLM.Model
+	}
+}
+
+func applyHybridFileConfig(cfg *HybridPrecedence, file HybridFileConfig) {
+	if file.Transcript != "" {
+		cfg.Transcript = file.Transcript
+	}
+	if file.Summary != "" {
+		cfg.Summary = file.Summary
+	}
+	if file.Participants != "" {
+		cfg.Participants = file.Participants
+	}
+}
+
+func applyOutboxFileConfig(cfg *OutboxConfig, file OutboxFileConfig) {
+	if file.WebhookURL != "" {
+		cfg.WebhookURL = file.WebhookURL
+	}
+	if file.File != "" {
+		cfg.FilePath = file.File
+	}
+	if file.GranolaPath != "" {
+		cfg.GranolaPath = file.GranolaPath
+	}
+	if file.GranolaNotesPath != "" {
+		cfg.GranolaNotesPath = file.GranolaNotesPath
+	}
+	if file.MaxAttempts > 0 {
+		cfg.MaxAttempts = file.MaxAttempts
+	}
+	if d, err := time.ParseDuration(file.Interval); err == nil && d > 0 {
+		cfg.RelayInterval = d
+	}
+}
+
+// applyTasksFileConfig overlays tracker settings. Tokens are env-only.
+func applyTasksFileConfig(cfg *TasksConfig, file TasksFileConfig) {
+	if file.GitHub.APIURL != "" {
+		cfg.GitHub.APIURL = file.GitHub.APIURL
+	}
+	if file.GitHub.Repo != "" {
+		cfg.GitHub.Repo = file.GitHub.Repo
+	}
+	if file.Linear.APIURL != "" {
+		cfg.Linear.APIURL = file.Linear.APIURL
+	}
+	if file.Linear.TeamID != "" {
+		cfg.Linear.TeamID = file.Linear.TeamID
+	}
+	if file.Linear.DoneStateID != "" {
+		cfg.Linear.DoneStateID = file.Linear.DoneStateID
+	}
+	if file.Linear.OpenStateID != "" {
+		cfg.Linear.OpenStateID = file.Linear.OpenStateID
+	}
+	if file.Jira.URL != "" {
+		cfg.Jira.BaseURL = file.Jira.URL
+	}
+	if file.Jira.Email != "" {
+		cfg.Jira.Email = file.Jira.Email
+	}
+	if file.Jira.Project != "" {
+		cfg.Jira.ProjectKey = file.Jira.Project
+	}
+	if file.Jira.IssueType != "" {
+		cfg.Jira.IssueType = file.Jira.IssueType
+	}
 }
 
 // applyEnvOverrides applies environment variable overrides to cfg.
@@ -142,6 +323,23 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("ACAI_GRANOLA_CACHE_PATH"); v != "" {
 		cfg.Granola.LocalCachePath = v
 	}
+	if v := os.Getenv("ACAI_GRANOLA_CACHE_ROOTS"); v != "" {
+		cfg.Granola.CacheRoots = filepath.SplitList(v)
+	}
+	if v := os.Getenv("ACAI_GRANOLA_CACHE_WATCH"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.Granola.CacheWatch = d
+		}
+	}
+	if v := os.Getenv("ACAI_HYBRID_TRANSCRIPT"); v != "" {
+		cfg.Granola.Hybrid.Transcript = v
+	}
+	if v := os.Getenv("ACAI_HYBRID_SUMMARY"); v != "" {
+		cfg.Granola.Hybrid.Summary = v
+	}
+	if v := os.Getenv("ACAI_HYBRID_PARTICIPANTS"); v != "" {
+		cfg.Granola.Hybrid.Participants = v
+	}
 	if v := os.Getenv("ACAI_MCP_TRANSPORT"); v != "" {
 		cfg.MCP.Transport = v
 	}
@@ -150,17 +348,94 @@ func applyEnvOverrides(cfg *Config) {
 			cfg.MCP.HTTPPort = port
 		}
 	}
+	if v := os.Getenv("ACAI_EVENTS_TOKEN"); v != "" {
+		cfg.MCP.EventsToken = v
+	}
 	if v := os.Getenv("ACAI_CACHE_TTL"); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
 			cfg.Cache.TTL = d
 		}
 	}
+	if v := os.Getenv("ACAI_PROJECTION_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Projection.Enabled = b
+		}
+	}
+	if v := os.Getenv("ACAI_PROJECTION_MAX_AGE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.Projection.MaxAge = d
+		}
+	}
 	if v := os.Getenv("ACAI_LOGGING_LEVEL"); v != "" {
 		cfg.Logging.Level = v
 	}
 	if v := os.Getenv("ACAI_LOGGING_FORMAT"); v != "" {
 		cfg.Logging.Format = v
 	}
+	if v := os.Getenv("ACAI_USER_NAME"); v != "" {
+		cfg.User.Name = v
+	}
+	if v := os.Getenv("ACAI_GITHUB_TOKEN"); v != "" {
+		cfg.Tasks.GitHub.Token = v
+	}
+	if v := os.Getenv("ACAI_GITHUB_REPO"); v != "" {
+		cfg.Tasks.GitHub.Repo = v
+	}
+	if v := os.Getenv("ACAI_LINEAR_API_KEY"); v != "" {
+		cfg.Tasks.Linear.APIKey = v
+	}
+	if v := os.Getenv("ACAI_LINEAR_TEAM_ID"); v != "" {
+		cfg.Tasks.Linear.TeamID = v
+	}
+	if v := os.Getenv("ACAI_LINEAR_DONE_STATE_ID"); v != "" {
+		cfg.Tasks.Linear.DoneStateID = v
+	}
+	if v := os.Getenv("ACAI_LINEAR_OPEN_STATE_ID"); v != "" {
+		cfg.Tasks.Linear.OpenStateID = v
+	}
+	if v := os.Getenv("ACAI_JIRA_URL"); v != "" {
+		cfg.Tasks.Jira.BaseURL = v
+	}
+	if v := os.Getenv("ACAI_JIRA_EMAIL"); v != "" {
+		cfg.Tasks.Jira.Email = v
+	}
+	if v := os.Getenv("ACAI_JIRA_API_TOKEN"); v != "" {
+		cfg.Tasks.Jira.APIToken = v
+	}
+	if v := os.Getenv("ACAI_JIRA_PROJECT"); v != "" {
+		cfg.Tasks.Jira.ProjectKey = v
+	}
+	if v := os.Getenv("ACAI_OUTBOX_WEBHOOK_URL"); v != "" {
+		cfg.Outbox.WebhookURL = v
+	}
+	if v := os.Getenv("ACAI_OUTBOX_FILE"); v != "" {
+		cfg.Outbox.FilePath = v
+	}
+	if v := os.Getenv("ACAI_OUTBOX_GRANOLA_PATH"); v != "" {
+		cfg.Outbox.GranolaPath = v
+	}
+	if v := os.Getenv("ACAI_OUTBOX_GRANOLA_NOTES_PATH"); v != "" {
+		cfg.Outbox.GranolaNotesPath = v
+	}
+	if v := os.Getenv("ACAI_LLM_URL"); v != "" {
+		cfg.LLM.BaseURL = v
+	}
+	if v := os.Getenv("ACAI_LLM_MODEL"); v != "" {
+		cfg.LLM.Model = v
+	}
+	if v := os.Getenv("ACAI_LLM_API_KEY"); v != "" {
+		cfg.LLM.APIKey = v
+	}
+	if v := os.Getenv("ACAI_OUTBOX_MAX_ATTEMPTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Outbox.MaxAttempts = n
+		}
+	}
+	if v := os.Getenv("ACAI_OUTBOX_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.Outbox.RelayInterval = d
+		}
+	}
 	if v := os.Getenv("ACAI_POLICY_FILE"); v != "" {
 		cfg.Policy.FilePath = v
 		cfg.Policy.Enabled = true
@@ -177,6 +452,12 @@ func Default() *Config {
 			APIURL:     "https://public-api.granola.ai",
 			AuthMethod: "api_token",
 			DataSource: "auto",
+			CacheWatch: 2 * time.Second,
+			Hybrid: HybridPrecedence{
+				Transcript:   "local",
+				Summary:      "api",
+				Participants: "api",
+			},
 		},
 		MCP: MCPConfig{
 			ServerName: "acai",
@@ -191,6 +472,10 @@ func Default() *Config {
 			Dir:     filepath.Join(homeDir, ".acai", "cache"),
 			TTL:     15 * time.Minute,
 		},
+		Projection: ProjectionConfig{
+			Enabled: true,
+			MaxAge:  time.Hour,
+		},
 		Resilience: ResilienceConfig{
 			CircuitBreaker: CircuitBreakerConfig{
 				FailureThreshold: 5,
@@ -222,5 +507,11 @@ func Default() *Config {
 			Level:  "info",
 			Format: "console",
 		},
+		Outbox: OutboxConfig{
+			RelayInterval:  30 * time.Second,
+			MaxAttempts:    8,
+			InitialBackoff: 30 * time.Second,
+			MaxBackoff:     time.Hour,
+		},
 	}
 }
@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -39,6 +40,7 @@ func TestLoad_OverridesFromEnv(t *testing.T) {
 	t.Setenv("ACAI_GRANOLA_API_TOKEN", "test-token")
 	t.Setenv("ACAI_LOGGING_LEVEL", "debug")
 	t.Setenv("ACAI_MCP_HTTP_PORT", "9090")
+	t.Setenv("ACAI_EVENTS_TOKEN", "stream-token")
 
 	cfg := config.Load()
 
@@ -57,6 +59,9 @@ func TestLoad_OverridesFromEnv(t *testing.T) {
 	if cfg.MCP.HTTPPort != 9090 {
 		t.Errorf("got http port %d", cfg.MCP.HTTPPort)
 	}
+	if cfg.MCP.EventsToken != "stream-token" {
+		t.Errorf("got events token %q", cfg.MCP.EventsToken)
+	}
 }
 
 func TestLoad_PolicyFileEnv(t *testing.T) {
@@ -108,6 +113,26 @@ func TestLoad_DataSourceEnv(t *testing.T) {
 	}
 }
 
+func TestLoad_CacheWatch(t *testing.T) {
+	if got := config.Default().Granola.CacheWatch; got != 2*time.Second {
+		t.Errorf("default cache watch = %v, want 2s", got)
+	}
+
+	t.Setenv("ACAI_GRANOLA_CACHE_WATCH", "0")
+	if got := config.Load().Granola.CacheWatch; got != 0 {
+		t.Errorf("cache watch = %v, want 0 (disabled)", got)
+	}
+}
+
+func TestLoad_CacheRootsEnv(t *testing.T) {
+	t.Setenv("ACAI_GRANOLA_CACHE_ROOTS", "/mnt/c/Users/ana/AppData/Roaming/Granola"+string(os.PathListSeparator)+"/srv/granola")
+
+	got := config.Load().Granola.CacheRoots
+	if len(got) != 2 || got[0] != "/mnt/c/Users/ana/AppData/Roaming/Granola" || got[1] != "/srv/granola" {
+		t.Errorf("got cache roots %q", got)
+	}
+}
+
 func TestLoad_FileOverridesDefaults(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
@@ -183,3 +208,138 @@ func TestLoad_MissingFileUsesDefaults(t *testing.T) {
 }
 
 
+
+func TestLoad_UserNameFromFileAndEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfgPath := filepath.Join(home, ".acai", "config.yaml")
+	if err := config.WriteConfigFile(cfgPath, config.FileConfig{
+		User: config.UserFileConfig{Name: "Alice"},
+	}); err != nil {
+		t.Fatalf("WriteConfigFile: %v", err)
+	}
+
+	if got := config.Load().User.Name; got != "Alice" {
+		t.Errorf("User.Name = %q, want Alice", got)
+	}
+
+	t.Setenv("ACAI_USER_NAME", "Bob")
+	if got := config.Load().User.Name; got != "Bob" {
+		t.Errorf("User.Name = %q, want Bob (env should override file)", got)
+	}
+}
+
+func TestLoad_TasksFromFileAndEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfgPath := filepath.Join(home, ".acai", "config.yaml")
+	if err := config.WriteConfigFile(cfgPath, config.FileConfig{
+		Tasks: config.TasksFileConfig{
+			GitHub: config.GitHubTasksFileConfig{Repo: "acme/tasks"},
+			Jira:   config.JiraTasksFileConfig{URL: "https://acme.atlassian.net", Email: "me@acme.io", Project: "OPS"},
+		},
+	}); err != nil {
+		t.Fatalf("WriteConfigFile: %v", err)
+	}
+
+	cfg := config.Load()
+	if cfg.Tasks.GitHub.Repo != "acme/tasks" {
+		t.Errorf("GitHub.Repo = %q, want acme/tasks", cfg.Tasks.GitHub.Repo)
+	}
+	if cfg.Tasks.GitHub.Enabled() || cfg.Tasks.Jira.Enabled() {
+		t.Error("trackers should stay disabled without tokens")
+	}
+
+	t.Setenv("ACAI_GITHUB_TOKEN", "gh-token")
+	t.Setenv("ACAI_JIRA_API_TOKEN", "jira-token")
+	cfg = config.Load()
+	if !cfg.Tasks.GitHub.Enabled() || !cfg.Tasks.Jira.Enabled() {
+		t.Error("trackers should be enabled once tokens are set")
+	}
+	if cfg.Tasks.Linear.Enabled() {
+		t.Error("linear should stay disabled")
+	}
+}
+
+func TestLoad_OutboxFromFileAndEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfgPath := filepath.Join(home, ".acai", "config.yaml")
+	if err := config.WriteConfigFile(cfgPath, config.FileConfig{
+		Outbox: config.OutboxFileConfig{File: "/tmp/events.ndjson", MaxAttempts: 3, Interval: "1m", GranolaNotesPath: "/v1/notes/{id}/document"},
+	}); err != nil {
+		t.Fatalf("WriteConfigFile: %v", err)
+	}
+
+	cfg := config.Load()
+	if cfg.Outbox.FilePath != "/tmp/events.ndjson" || cfg.Outbox.MaxAttempts != 3 || cfg.Outbox.RelayInterval != time.Minute ||
+		cfg.Outbox.GranolaNotesPath != "/v1/notes/{id}/document" {
+		t.Errorf("file settings not applied: %+v", cfg.Outbox)
+	}
+
+	t.Setenv("ACAI_OUTBOX_WEBHOOK_URL", "https://hooks.example.com/acai")
+	t.Setenv("ACAI_OUTBOX_MAX_ATTEMPTS", "5")
+	cfg = config.Load()
+	if cfg.Outbox.WebhookURL != "https://hooks.example.com/acai" || cfg.Outbox.MaxAttempts != 5 {
+		t.Errorf("env overrides not applied: %+v", cfg.Outbox)
+	}
+}
+
+func TestLoad_LLMFromFileAndEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if config.Load().LLM.Enabled() {
+		t.Error("LLM should be disabled by default")
+	}
+
+	cfgPath := filepath.Join(home, ".acai", "config.yaml")
+	if err := config.WriteConfigFile(cfgPath, config.FileConfig{
+		LLM: config.LLMFileConfig{URL: "http://localhost:11434/v1", Model: "llama3.2"},
+	}); err != nil {
+		t.Fatalf("WriteConfigFile: %v", err)
+	}
+	cfg := config.Load()
+	if !cfg.LLM.Enabled() || cfg.LLM.BaseURL != "http://localhost:11434/v1" || cfg.LLM.Model != "llama3.2" {
+		t.Errorf("file settings not applied: %+v", cfg.LLM)
+	}
+
+	t.Setenv("ACAI_LLM_MODEL", "qwen2.5:7b")
+	t.Setenv("ACAI_LLM_API_KEY", "secret")
+	cfg = config.Load()
+	if cfg.LLM.Model != "qwen2.5:7b" || cfg.LLM.APIKey != "secret" {
+		t.Errorf("env overrides not applied: %+v", cfg.LLM)
+	}
+}
+
+func TestLoad_HybridPrecedenceFromFileAndEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg := config.Load()
+	if cfg.Granola.Hybrid.Transcript != "local" || cfg.Granola.Hybrid.Summary != "api" || cfg.Granola.Hybrid.Participants != "api" {
+		t.Errorf("default precedence = %+v", cfg.Granola.Hybrid)
+	}
+
+	cfgPath := filepath.Join(home, ".acai", "config.yaml")
+	if err := config.WriteConfigFile(cfgPath, config.FileConfig{
+		DataSource: "hybrid",
+		Granola:    config.GranolaFileConfig{Hybrid: config.HybridFileConfig{Summary: "local"}},
+	}); err != nil {
+		t.Fatalf("WriteConfigFile: %v", err)
+	}
+
+	cfg = config.Load()
+	if cfg.Granola.DataSource != "hybrid" || cfg.Granola.Hybrid.Summary != "local" {
+		t.Errorf("file settings not applied: %q %+v", cfg.Granola.DataSource, cfg.Granola.Hybrid)
+	}
+
+	t.Setenv("ACAI_HYBRID_TRANSCRIPT", "api")
+	cfg = config.Load()
+	if cfg.Granola.Hybrid.Transcript != "api" || cfg.Granola.Hybrid.Summary != "local" {
+		t.Errorf("env override not applied: %+v", cfg.Granola.Hybrid)
+	}
+}
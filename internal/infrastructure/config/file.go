@@ -13,12 +13,77 @@ import (
 type FileConfig struct {
 	DataSource string            `yaml:"data_source,omitempty"`
 	Granola    GranolaFileConfig `yaml:"granola,omitempty"`
+	User       UserFileConfig    `yaml:"user,omitempty"`
+	Tasks      TasksFileConfig   `yaml:"tasks,omitempty"`
+	Outbox     OutboxFileConfig  `yaml:"outbox,omitempty"`
+	LLM        LLMFileConfig     `yaml:"llm,omitempty"`
 }
 
 // GranolaFileConfig holds Granola-specific file configuration.
 type GranolaFileConfig struct {
-	APIURL    string `yaml:"api_url,omitempty"`
-	CachePath string `yaml:"cache_path,omitempty"`
+	APIURL     string           `yaml:"api_url,omitempty"`
+	CachePath  string           `yaml:"cache_path,omitempty"`
+	CacheRoots []string         `yaml:"cache_roots,omitempty"` // directories searched for cache-v*.json
+	CacheWatch string           `yaml:"cache_watch,omitempty"` // e.g. "2s"; "0s" disables
+	Hybrid     HybridFileConfig `yaml:"hybrid,omitempty"`
+}
+
+// HybridFileConfig sets which side ("local" or "api") wins per field in the
+// hybrid data source.
+type HybridFileConfig struct {
+	Transcript   string `yaml:"transcript,omitempty"`
+	Summary      string `yaml:"summary,omitempty"`
+	Participants string `yaml:"participants,omitempty"`
+}
+
+// UserFileConfig holds the local user's identity.
+type UserFileConfig struct {
+	Name string `yaml:"name,omitempty"`
+}
+
+// TasksFileConfig holds external tracker settings. API tokens are read from
+// the environment only.
+type TasksFileConfig struct {
+	GitHub GitHubTasksFileConfig `yaml:"github,omitempty"`
+	Linear LinearTasksFileConfig `yaml:"linear,omitempty"`
+	Jira   JiraTasksFileConfig   `yaml:"jira,omitempty"`
+}
+
+type GitHubTasksFileConfig struct {
+	APIURL string `yaml:"api_url,omitempty"`
+	Repo   string `yaml:"repo,omitempty"`
+}
+
+type LinearTasksFileConfig struct {
+	APIURL      string `yaml:"api_url,omitempty"`
+	TeamID      string `yaml:"team_id,omitempty"`
+	DoneStateID string `yaml:"done_state_id,omitempty"`
+	OpenStateID string `yaml:"open_state_id,omitempty"`
+}
+
+type JiraTasksFileConfig struct {
+	URL       string `yaml:"url,omitempty"`
+	Email     string `yaml:"email,omitempty"`
+	Project   string `yaml:"project,omitempty"`
+	IssueType string `yaml:"issue_type,omitempty"`
+}
+
+// OutboxFileConfig holds outbox relay sinks and retry settings.
+type OutboxFileConfig struct {
+	WebhookURL  string `yaml:"webhook_url,omitempty"`
+	File        string `yaml:"file,omitempty"`
+	GranolaPath string `yaml:"granola_path,omitempty"`
+	MaxAttempts int    `yaml:"max_attempts,omitempty"`
+	Interval    string `yaml:"interval,omitempty"` // Go duration, e.g. "1m"
+
+	GranolaNotesPath string `yaml:"granola_notes_path,omitempty"`
+}
+
+// LLMFileConfig names the language model server used to generate
+// summaries. The API key is read from the environment only.
+type LLMFileConfig struct {
+	URL   string `yaml:"url,omitempty"`
+	Model string `yaml:"model,omitempty"`
 }
 
 // ReadConfigFile reads a YAML config file from path.
@@ -0,0 +1,172 @@
+// Package eventcodec serialises domain events into versioned, self-describing
+// envelopes and back. Envelopes are the wire and storage format for events
+// leaving the process (outbox entries, event store rows, webhooks).
+package eventcodec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+var (
+	ErrUnknownEventType = errors.New("eventcodec: unknown event type")
+	ErrMissingUpcaster  = errors.New("eventcodec: missing upcaster")
+)
+
+// Envelope is the canonical serialised form of a domain event.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schema_version"`
+	AggregateID   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Upcaster migrates event data from one schema version to the next.
+type Upcaster func(data json.RawMessage) (json.RawMessage, error)
+
+type registration struct {
+	version   int
+	aggregate func(domain.DomainEvent) string
+	decode    func(json.RawMessage) (domain.DomainEvent, error)
+}
+
+type upcastKey struct {
+	eventType   string
+	fromVersion int
+}
+
+// Registry maps event types to their current schema version, aggregate ID
+// accessor, and decoder, plus the upcasters that bring older payloads up to
+// the current version.
+type Registry struct {
+	events    map[string]registration
+	upcasters map[upcastKey]Upcaster
+}
+
+// NewRegistry creates an empty registry. Most callers want Default.
+func NewRegistry() *Registry {
+	return &Registry{
+		events:    make(map[string]registration),
+		upcasters: make(map[upcastKey]Upcaster),
+	}
+}
+
+// Register adds event type E under eventType at the given current schema
+// version. E's JSON encoding (MarshalJSON/UnmarshalJSON) is the data schema.
+func Register[E domain.DomainEvent](r *Registry, eventType string, version int, aggregateID func(E) string) {
+	r.events[eventType] = registration{
+		version: version,
+		aggregate: func(e domain.DomainEvent) string {
+			return aggregateID(e.(E))
+		},
+		decode: func(data json.RawMessage) (domain.DomainEvent, error) {
+			var e E
+			if err := json.Unmarshal(data, &e); err != nil {
+				return nil, err
+			}
+			return e, nil
+		},
+	}
+}
+
+// RegisterUpcaster adds the migration of eventType data from fromVersion to
+// fromVersion+1.
+func (r *Registry) RegisterUpcaster(eventType string, fromVersion int, up Upcaster) {
+	r.upcasters[upcastKey{eventType, fromVersion}] = up
+}
+
+// Types returns the registered event types, sorted.
+func (r *Registry) Types() []string {
+	types := make([]string, 0, len(r.events))
+	for t := range r.events {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Encode wraps event in an envelope with the given ID.
+func (r *Registry) Encode(id string, event domain.DomainEvent) (Envelope, error) {
+	reg, ok := r.events[event.EventName()]
+	if !ok {
+		return Envelope{}, fmt.Errorf("%w: %s", ErrUnknownEventType, event.EventName())
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("eventcodec: marshal %s: %w", event.EventName(), err)
+	}
+	return Envelope{
+		ID:            id,
+		Type:          event.EventName(),
+		SchemaVersion: reg.version,
+		AggregateID:   reg.aggregate(event),
+		OccurredAt:    event.OccurredAt().UTC(),
+		Data:          data,
+	}, nil
+}
+
+// Marshal encodes event and serialises the envelope to JSON.
+func (r *Registry) Marshal(id string, event domain.DomainEvent) ([]byte, error) {
+	env, err := r.Encode(id, event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(env)
+}
+
+// Upcast brings env's data up to the current schema version of its type.
+func (r *Registry) Upcast(env Envelope) (Envelope, error) {
+	reg, ok := r.events[env.Type]
+	if !ok {
+		return env, fmt.Errorf("%w: %s", ErrUnknownEventType, env.Type)
+	}
+	if env.SchemaVersion > reg.version {
+		return env, fmt.Errorf("eventcodec: %s schema version %d is newer than supported %d", env.Type, env.SchemaVersion, reg.version)
+	}
+	for env.SchemaVersion < reg.version {
+		up, ok := r.upcasters[upcastKey{env.Type, env.SchemaVersion}]
+		if !ok {
+			return env, fmt.Errorf("%w: %s v%d", ErrMissingUpcaster, env.Type, env.SchemaVersion)
+		}
+		data, err := up(env.Data)
+		if err != nil {
+			return env, fmt.Errorf("eventcodec: upcast %s v%d: %w", env.Type, env.SchemaVersion, err)
+		}
+		env.Data = data
+		env.SchemaVersion++
+	}
+	return env, nil
+}
+
+// Decode upcasts env and reconstructs the domain event it carries.
+func (r *Registry) Decode(env Envelope) (domain.DomainEvent, error) {
+	env, err := r.Upcast(env)
+	if err != nil {
+		return nil, err
+	}
+	event, err := r.events[env.Type].decode(env.Data)
+	if err != nil {
+		return nil, fmt.Errorf("eventcodec: decode %s: %w", env.Type, err)
+	}
+	return event, nil
+}
+
+// Unmarshal parses a JSON envelope and decodes its event.
+func (r *Registry) Unmarshal(data []byte) (Envelope, domain.DomainEvent, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Envelope{}, nil, fmt.Errorf("eventcodec: invalid envelope: %w", err)
+	}
+	event, err := r.Decode(env)
+	if err != nil {
+		return env, nil, err
+	}
+	return env, event, nil
+}
@@ -0,0 +1,118 @@
+package eventcodec_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+)
+
+func TestDefault_RoundTripsEveryEvent(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	events := []domain.DomainEvent{
+		domain.NewMeetingCreatedEvent("m-1", "Planning", start),
+		domain.NewMeetingDeletedEvent("m-1"),
+		domain.NewTranscriptUpdatedEvent("m-1", 42),
+		domain.NewSummaryUpdatedEvent("m-1", domain.SummaryAuto),
+		domain.NewActionItemCompletedEvent("m-1", "ai-1"),
+		domain.NewActionItemUpdatedEvent("m-1", "ai-1", "New text"),
+		annotation.NewNoteAddedEvent("n-1", "m-1", "agent"),
+		annotation.NewNoteUpdatedEvent("n-1", "m-1", "alice", 2),
+		annotation.NewNoteDeletedEvent("n-1", "m-1"),
+	}
+
+	codec := eventcodec.Default()
+	if got := len(codec.Types()); got != len(events) {
+		t.Errorf("registry has %d types, test covers %d", got, len(events))
+	}
+
+	for _, event := range events {
+		t.Run(event.EventName(), func(t *testing.T) {
+			data, err := codec.Marshal("evt-1", event)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			env, decoded, err := codec.Unmarshal(data)
+			if err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if env.ID != "evt-1" || env.Type != event.EventName() || env.SchemaVersion != 1 || env.AggregateID == "" {
+				t.Errorf("unexpected envelope %+v", env)
+			}
+			if !env.OccurredAt.Equal(event.OccurredAt()) {
+				t.Errorf("occurred_at %v, want %v", env.OccurredAt, event.OccurredAt())
+			}
+			// Monotonic clock readings are dropped by JSON; compare re-encoded data.
+			want, _ := json.Marshal(event)
+			got, _ := json.Marshal(decoded)
+			if reflect.TypeOf(decoded) != reflect.TypeOf(event) || string(got) != string(want) {
+				t.Errorf("decoded %T %s, want %T %s", decoded, got, event, want)
+			}
+		})
+	}
+}
+
+func TestEncode_UnknownEventType(t *testing.T) {
+	_, err := eventcodec.NewRegistry().Encode("evt-1", domain.NewTranscriptUpdatedEvent("m-1", 1))
+	if !errors.Is(err, eventcodec.ErrUnknownEventType) {
+		t.Errorf("expected ErrUnknownEventType, got %v", err)
+	}
+}
+
+func TestDecode_AppliesUpcasters(t *testing.T) {
+	r := eventcodec.NewRegistry()
+	eventcodec.Register(r, "transcript.updated", 3, func(e domain.TranscriptUpdated) string { return string(e.MeetingID()) })
+	// v1 used "meeting"; v2 used "count"; v3 is the current shape.
+	r.RegisterUpcaster("transcript.updated", 1, renameField("meeting", "meeting_id"))
+	r.RegisterUpcaster("transcript.updated", 2, renameField("count", "utterance_count"))
+
+	event, err := r.Decode(eventcodec.Envelope{
+		ID:            "evt-1",
+		Type:          "transcript.updated",
+		SchemaVersion: 1,
+		Data:          json.RawMessage(`{"meeting":"m-9","count":7}`),
+	})
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	tu := event.(domain.TranscriptUpdated)
+	if tu.MeetingID() != "m-9" || tu.UtteranceCount() != 7 {
+		t.Errorf("unexpected event after upcast: %s %d", tu.MeetingID(), tu.UtteranceCount())
+	}
+}
+
+func TestDecode_MissingUpcaster(t *testing.T) {
+	r := eventcodec.NewRegistry()
+	eventcodec.Register(r, "transcript.updated", 2, func(e domain.TranscriptUpdated) string { return string(e.MeetingID()) })
+
+	_, err := r.Decode(eventcodec.Envelope{Type: "transcript.updated", SchemaVersion: 1, Data: json.RawMessage(`{}`)})
+	if !errors.Is(err, eventcodec.ErrMissingUpcaster) {
+		t.Errorf("expected ErrMissingUpcaster, got %v", err)
+	}
+}
+
+func TestDecode_RejectsNewerSchema(t *testing.T) {
+	_, err := eventcodec.Default().Decode(eventcodec.Envelope{Type: "note.added", SchemaVersion: 2, Data: json.RawMessage(`{}`)})
+	if err == nil {
+		t.Error("expected error for schema version newer than supported")
+	}
+}
+
+func renameField(from, to string) eventcodec.Upcaster {
+	return func(data json.RawMessage) (json.RawMessage, error) {
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		if v, ok := m[from]; ok {
+			m[to] = v
+			delete(m, from)
+		}
+		return json.Marshal(m)
+	}
+}
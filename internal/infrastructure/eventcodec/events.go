@@ -0,0 +1,27 @@
+package eventcodec
+
+import (
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// Default returns a registry with every event in domain/meeting and
+// domain/annotation registered at its current schema version.
+// When an event's JSON changes, bump its version here and register an
+// upcaster from the previous version.
+func Default() *Registry {
+	r := NewRegistry()
+
+	Register(r, "meeting.created", 1, func(e domain.MeetingCreated) string { return string(e.MeetingID()) })
+	Register(r, "meeting.deleted", 1, func(e domain.MeetingDeleted) string { return string(e.MeetingID()) })
+	Register(r, "transcript.updated", 1, func(e domain.TranscriptUpdated) string { return string(e.MeetingID()) })
+	Register(r, "summary.updated", 1, func(e domain.SummaryUpdated) string { return string(e.MeetingID()) })
+	Register(r, "action_item.completed", 1, func(e domain.ActionItemCompleted) string { return string(e.ActionItemID()) })
+	Register(r, "action_item.updated", 1, func(e domain.ActionItemUpdated) string { return string(e.ActionItemID()) })
+
+	Register(r, "note.added", 1, func(e annotation.NoteAdded) string { return e.NoteID() })
+	Register(r, "note.updated", 1, func(e annotation.NoteUpdated) string { return e.NoteID() })
+	Register(r, "note.deleted", 1, func(e annotation.NoteDeleted) string { return e.NoteID() })
+
+	return r
+}
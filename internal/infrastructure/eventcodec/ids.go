@@ -0,0 +1,54 @@
+package eventcodec
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+type eventIDsKey struct{}
+
+// eventIDs are the IDs minted for one dispatched batch of events.
+type eventIDs struct {
+	events []domain.DomainEvent
+	ids    []string
+}
+
+// WithEventIDs returns the envelope IDs of a dispatched batch, index-aligned
+// with events, and a context carrying them. The outermost dispatcher
+// decorator mints the IDs; the decorators it forwards the same batch to get
+// them back from the context, so the event store row, outbox entry and
+// webhook envelope of one event share its ID.
+func WithEventIDs(ctx context.Context, events []domain.DomainEvent) (context.Context, []string) {
+	if len(events) == 0 {
+		return ctx, nil
+	}
+	if batch, ok := ctx.Value(eventIDsKey{}).(*eventIDs); ok && sameBatch(batch.events, events) {
+		return ctx, batch.ids
+	}
+	ids := make([]string, len(events))
+	for i := range ids {
+		ids[i] = NewEventID()
+	}
+	return context.WithValue(ctx, eventIDsKey{}, &eventIDs{events: events, ids: ids}), ids
+}
+
+// NewEventID mints a random envelope ID.
+func NewEventID() string {
+	return RandomID("evt_", 16)
+}
+
+// RandomID returns prefix followed by n random bytes, hex-encoded. It mints
+// the IDs of events, outbox entries and webhook deliveries alike.
+func RandomID(prefix string, n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return prefix + hex.EncodeToString(b)
+}
+
+// sameBatch reports whether a and b are the same slice of events.
+func sameBatch(a, b []domain.DomainEvent) bool {
+	return len(a) == len(b) && &a[0] == &b[0]
+}
@@ -0,0 +1,75 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+)
+
+// Listener is notified of each newly recorded event, e.g. to stream it.
+type Listener interface {
+	EventRecorded(rec Record)
+}
+
+// Dispatcher decorates a domain.EventDispatcher, recording every event in
+// the event store before forwarding it.
+type Dispatcher struct {
+	inner     domain.EventDispatcher
+	store     *SQLiteStore
+	listeners []Listener
+}
+
+// NewDispatcher creates an event-recording dispatcher decorator.
+func NewDispatcher(inner domain.EventDispatcher, store *SQLiteStore, listeners ...Listener) *Dispatcher {
+	return &Dispatcher{inner: inner, store: store, listeners: listeners}
+}
+
+// Dispatch records the events, then forwards them to the inner dispatcher.
+// The event log is a secondary record: a recording failure is logged and
+// never fails the dispatch, since the use case has already changed state by
+// the time events are dispatched. Unregistered event types are logged and
+// skipped.
+func (d *Dispatcher) Dispatch(ctx context.Context, events []domain.DomainEvent) error {
+	ctx, ids := eventcodec.WithEventIDs(ctx, events)
+	for i, event := range events {
+		env, err := d.store.codec.Encode(ids[i], event)
+		if errors.Is(err, eventcodec.ErrUnknownEventType) {
+			log.Printf("event store: skipping unregistered event type %q", event.EventName())
+			continue
+		}
+		var seq int64
+		if err == nil {
+			seq, err = d.store.Append(ctx, env, meetingIDOf(event))
+		}
+		if err != nil {
+			log.Printf("event store: append %s: %v", event.EventName(), err)
+			continue
+		}
+		if seq == 0 {
+			continue // already recorded
+		}
+		rec := Record{Seq: seq, MeetingID: meetingIDOf(event), Envelope: env}
+		for _, l := range d.listeners {
+			l.EventRecorded(rec)
+		}
+	}
+
+	return d.inner.Dispatch(ctx, events)
+}
+
+// meetingIDOf returns the meeting an event belongs to. Meeting events use
+// domain.MeetingID; other bounded contexts carry it as a plain string.
+func meetingIDOf(event domain.DomainEvent) string {
+	switch e := event.(type) {
+	case interface{ MeetingID() domain.MeetingID }:
+		return string(e.MeetingID())
+	case interface{ MeetingID() string }:
+		return e.MeetingID()
+	}
+	return ""
+}
+
+var _ domain.EventDispatcher = (*Dispatcher)(nil)
@@ -0,0 +1,123 @@
+// Package eventstore persists every dispatched domain event in an append-only
+// SQLite table, so activity can be replayed per meeting or streamed from a
+// known position.
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+)
+
+// Record is a stored envelope with its position in the log.
+type Record struct {
+	Seq       int64
+	MeetingID string
+	Envelope  eventcodec.Envelope
+}
+
+// SQLiteStore is the append-only event log in the local database.
+type SQLiteStore struct {
+	db    *sql.DB
+	codec *eventcodec.Registry
+}
+
+// NewSQLiteStore creates an event store on db. The events table is created
+// by localstore.InitSchema.
+func NewSQLiteStore(db *sql.DB, codec *eventcodec.Registry) *SQLiteStore {
+	return &SQLiteStore{db: db, codec: codec}
+}
+
+// Append stores env, tagged with the meeting it belongs to, and returns its
+// sequence number. Appending an envelope ID that already exists is a no-op
+// and returns 0.
+func (s *SQLiteStore) Append(ctx context.Context, env eventcodec.Envelope, meetingID string) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO events (id, event_type, schema_version, aggregate_id, meeting_id, occurred_at, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		env.ID, env.Type, env.SchemaVersion, env.AggregateID, meetingID, env.OccurredAt.UTC(), []byte(env.Data),
+	)
+	if err != nil {
+		return 0, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// ListAfter returns up to limit records with a sequence number greater than
+// afterSeq, in log order. A limit <= 0 means no limit.
+func (s *SQLiteStore) ListAfter(ctx context.Context, afterSeq int64, limit int) ([]Record, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	return s.query(ctx,
+		"SELECT "+recordColumns+" FROM events WHERE seq > ? ORDER BY seq ASC LIMIT ?",
+		afterSeq, limit,
+	)
+}
+
+// MeetingHistory implements domain.EventHistory. Events whose type is no
+// longer registered are skipped rather than failing the whole timeline.
+func (s *SQLiteStore) MeetingHistory(ctx context.Context, meetingID domain.MeetingID, limit int) ([]domain.HistoryEntry, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	records, err := s.query(ctx,
+		`SELECT * FROM (
+			SELECT `+recordColumns+` FROM events WHERE meeting_id = ? ORDER BY seq DESC LIMIT ?
+		) ORDER BY seq ASC`,
+		string(meetingID), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.HistoryEntry, 0, len(records))
+	for _, r := range records {
+		event, err := s.codec.Decode(r.Envelope)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, domain.HistoryEntry{
+			EventID:    r.Envelope.ID,
+			OccurredAt: r.Envelope.OccurredAt,
+			Event:      event,
+		})
+	}
+	return entries, nil
+}
+
+const recordColumns = "seq, id, event_type, schema_version, aggregate_id, meeting_id, occurred_at, data"
+
+func (s *SQLiteStore) query(ctx context.Context, query string, args ...any) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []Record
+	for rows.Next() {
+		var (
+			r          Record
+			data       []byte
+			occurredAt time.Time
+		)
+		if err := rows.Scan(&r.Seq, &r.Envelope.ID, &r.Envelope.Type, &r.Envelope.SchemaVersion,
+			&r.Envelope.AggregateID, &r.MeetingID, &occurredAt, &data); err != nil {
+			return nil, err
+		}
+		r.Envelope.OccurredAt = occurredAt.UTC()
+		r.Envelope.Data = data
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+var _ domain.EventHistory = (*SQLiteStore)(nil)
@@ -0,0 +1,198 @@
+package eventstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	return db
+}
+
+type recordingDispatcher struct {
+	events []domain.DomainEvent
+	err    error
+}
+
+func (r *recordingDispatcher) Dispatch(_ context.Context, events []domain.DomainEvent) error {
+	r.events = append(r.events, events...)
+	return r.err
+}
+
+func TestDispatcher_RecordsMeetingTimeline(t *testing.T) {
+	store := eventstore.NewSQLiteStore(openTestDB(t), eventcodec.Default())
+	inner := &recordingDispatcher{}
+	d := eventstore.NewDispatcher(inner, store)
+	ctx := context.Background()
+
+	events := []domain.DomainEvent{
+		domain.NewMeetingCreatedEvent("m-1", "Planning", time.Now()),
+		domain.NewTranscriptUpdatedEvent("m-1", 12),
+		annotation.NewNoteAddedEvent("n-1", "m-1", "agent"),
+		domain.NewActionItemCompletedEvent("m-2", "ai-9"),
+	}
+	if err := d.Dispatch(ctx, events); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(inner.events) != 4 {
+		t.Errorf("inner got %d events, want 4", len(inner.events))
+	}
+
+	history, err := store.MeetingHistory(ctx, "m-1", 0)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("got %d entries, want 3", len(history))
+	}
+	wantTypes := []string{"meeting.created", "transcript.updated", "note.added"}
+	for i, want := range wantTypes {
+		if got := history[i].Event.EventName(); got != want {
+			t.Errorf("entry %d: got %q, want %q", i, got, want)
+		}
+		if history[i].EventID == "" {
+			t.Errorf("entry %d: missing event ID", i)
+		}
+	}
+	if note, ok := history[2].Event.(annotation.NoteAdded); !ok || note.NoteID() != "n-1" {
+		t.Errorf("expected decoded NoteAdded, got %#v", history[2].Event)
+	}
+}
+
+func TestMeetingHistory_LimitKeepsMostRecent(t *testing.T) {
+	store := eventstore.NewSQLiteStore(openTestDB(t), eventcodec.Default())
+	d := eventstore.NewDispatcher(&recordingDispatcher{}, store)
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		if err := d.Dispatch(ctx, []domain.DomainEvent{domain.NewTranscriptUpdatedEvent("m-1", i)}); err != nil {
+			t.Fatalf("dispatch: %v", err)
+		}
+	}
+
+	history, err := store.MeetingHistory(ctx, "m-1", 2)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("got %d entries, want 2", len(history))
+	}
+	first := history[0].Event.(domain.TranscriptUpdated)
+	last := history[1].Event.(domain.TranscriptUpdated)
+	if first.UtteranceCount() != 2 || last.UtteranceCount() != 3 {
+		t.Errorf("expected the two most recent events oldest first, got %d, %d", first.UtteranceCount(), last.UtteranceCount())
+	}
+}
+
+func TestDispatcher_ForwardsInnerError(t *testing.T) {
+	store := eventstore.NewSQLiteStore(openTestDB(t), eventcodec.Default())
+	inner := &recordingDispatcher{err: errors.New("notify failed")}
+	d := eventstore.NewDispatcher(inner, store)
+
+	err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewTranscriptUpdatedEvent("m-1", 1)})
+	if err == nil {
+		t.Fatal("expected inner error")
+	}
+
+	// The event is still recorded
+	history, _ := store.MeetingHistory(context.Background(), "m-1", 0)
+	if len(history) != 1 {
+		t.Errorf("got %d entries, want 1", len(history))
+	}
+}
+
+func TestDispatcher_AppendFailureDoesNotFailDispatch(t *testing.T) {
+	db := openTestDB(t)
+	store := eventstore.NewSQLiteStore(db, eventcodec.Default())
+	inner := &recordingDispatcher{}
+	d := eventstore.NewDispatcher(inner, store)
+	_ = db.Close()
+
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewTranscriptUpdatedEvent("m-1", 1)}); err != nil {
+		t.Fatalf("dispatch failed on a recording error: %v", err)
+	}
+	if len(inner.events) != 1 {
+		t.Errorf("inner got %d events, want 1", len(inner.events))
+	}
+}
+
+func TestAppend_DuplicateIDIsIgnored(t *testing.T) {
+	store := eventstore.NewSQLiteStore(openTestDB(t), eventcodec.Default())
+	ctx := context.Background()
+
+	env, err := eventcodec.Default().Encode("evt-1", domain.NewTranscriptUpdatedEvent("m-1", 1))
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	for i, wantSeq := range []int64{1, 0} {
+		seq, err := store.Append(ctx, env, "m-1")
+		if err != nil {
+			t.Fatalf("append: %v", err)
+		}
+		if seq != wantSeq {
+			t.Errorf("append %d: got seq %d, want %d", i, seq, wantSeq)
+		}
+	}
+
+	history, _ := store.MeetingHistory(ctx, "m-1", 0)
+	if len(history) != 1 {
+		t.Errorf("got %d entries, want 1", len(history))
+	}
+}
+
+type recordingListener struct {
+	records []eventstore.Record
+}
+
+func (l *recordingListener) EventRecorded(rec eventstore.Record) {
+	l.records = append(l.records, rec)
+}
+
+func TestDispatcher_NotifiesListenersAndListAfter(t *testing.T) {
+	store := eventstore.NewSQLiteStore(openTestDB(t), eventcodec.Default())
+	listener := &recordingListener{}
+	d := eventstore.NewDispatcher(&recordingDispatcher{}, store, listener)
+	ctx := context.Background()
+
+	events := []domain.DomainEvent{
+		domain.NewTranscriptUpdatedEvent("m-1", 1),
+		domain.NewTranscriptUpdatedEvent("m-2", 2),
+		annotation.NewNoteDeletedEvent("n-1", "m-1"),
+	}
+	if err := d.Dispatch(ctx, events); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(listener.records) != 3 || listener.records[2].Seq != 3 || listener.records[2].MeetingID != "m-1" {
+		t.Fatalf("unexpected listener records %+v", listener.records)
+	}
+
+	after, err := store.ListAfter(ctx, 1, 0)
+	if err != nil {
+		t.Fatalf("list after: %v", err)
+	}
+	if len(after) != 2 || after[0].Seq != 2 || after[1].Envelope.Type != "note.deleted" {
+		t.Errorf("unexpected records %+v", after)
+	}
+	if after[0].Envelope.ID != listener.records[1].Envelope.ID {
+		t.Errorf("stored envelope ID %q differs from notified %q", after[0].Envelope.ID, listener.records[1].Envelope.ID)
+	}
+}
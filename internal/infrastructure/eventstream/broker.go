@@ -0,0 +1,65 @@
+// Package eventstream streams recorded domain events to HTTP clients as
+// Server-Sent Events, resuming from the local event store.
+package eventstream
+
+import (
+	"sync"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventstore"
+)
+
+// subscriberBuffer is how many records a subscriber may lag behind before it
+// is dropped. Dropped clients reconnect with Last-Event-ID and catch up from
+// the event store, so a slow consumer never blocks dispatch.
+const subscriberBuffer = 64
+
+// Broker fans recorded events out to live subscribers.
+// It implements eventstore.Listener.
+type Broker struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]chan eventstore.Record
+}
+
+// NewBroker creates a broker with no subscribers.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[int]chan eventstore.Record)}
+}
+
+// Subscribe registers a subscriber. The returned channel is closed when the
+// subscriber falls too far behind or cancel is called.
+func (b *Broker) Subscribe() (<-chan eventstore.Record, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan eventstore.Record, subscriberBuffer)
+	b.subs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if c, ok := b.subs[id]; ok {
+			delete(b.subs, id)
+			close(c)
+		}
+	}
+}
+
+// EventRecorded publishes rec to every subscriber without blocking.
+func (b *Broker) EventRecorded(rec eventstore.Record) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, ch := range b.subs {
+		select {
+		case ch <- rec:
+		default:
+			delete(b.subs, id)
+			close(ch)
+		}
+	}
+}
+
+var _ eventstore.Listener = (*Broker)(nil)
@@ -0,0 +1,212 @@
+package eventstream
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventstore"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	replayPageSize    = 500
+)
+
+// Replayer reads recorded events after a sequence number.
+type Replayer interface {
+	ListAfter(ctx context.Context, afterSeq int64, limit int) ([]eventstore.Record, error)
+}
+
+// Handler serves GET /events as a Server-Sent Events stream. Each event's
+// SSE id is its event store sequence number and its data is the JSON
+// envelope.
+//
+// Query parameters:
+//   - types: comma-separated event types to include; "note.*" matches a prefix
+//   - last_event_id: resume position, for clients that cannot set the
+//     Last-Event-ID header
+type Handler struct {
+	broker   *Broker
+	replayer Replayer
+	token    string
+}
+
+// NewHandler creates the SSE handler. Requests must carry
+// "Authorization: Bearer <token>"; an empty token rejects every request.
+func NewHandler(broker *Broker, replayer Replayer, token string) *Handler {
+	return &Handler{broker: broker, replayer: replayer, token: token}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="acai"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	cursor, resume, err := lastEventID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter := newTypeFilter(r.URL.Query().Get("types"))
+
+	// Subscribe before replaying so no event falls between the two.
+	live, cancel := h.broker.Subscribe()
+	defer cancel()
+
+	rc := http.NewResponseController(w)
+	// The server's write timeout would cut long-lived streams.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprint(w, ": connected\n\n")
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	if resume {
+		for {
+			records, err := h.replayer.ListAfter(ctx, cursor, replayPageSize)
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "event: error\ndata: %q\n\n", "replay failed")
+				_ = rc.Flush()
+				return
+			}
+			for _, rec := range records {
+				cursor = rec.Seq
+				if filter.match(rec.Envelope.Type) {
+					if err := writeEvent(w, rec); err != nil {
+						return
+					}
+				}
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+			if len(records) < replayPageSize {
+				break
+			}
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case rec, ok := <-live:
+			if !ok {
+				return // fell behind; the client reconnects and resumes
+			}
+			if rec.Seq <= cursor {
+				continue // already sent during replay
+			}
+			cursor = rec.Seq
+			if !filter.match(rec.Envelope.Type) {
+				continue
+			}
+			if err := writeEvent(w, rec); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return false
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(h.token)) == 1
+}
+
+// lastEventID returns the resume position from the Last-Event-ID header or
+// the last_event_id query parameter.
+func lastEventID(r *http.Request) (int64, bool, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return 0, false, nil
+	}
+	seq, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, false, fmt.Errorf("invalid Last-Event-ID %q", v)
+	}
+	return seq, true, nil
+}
+
+func writeEvent(w http.ResponseWriter, rec eventstore.Record) error {
+	data, err := json.Marshal(rec.Envelope)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", rec.Seq, rec.Envelope.Type, data)
+	return err
+}
+
+// typeFilter matches event types against exact names and "prefix.*" patterns.
+// An empty filter matches everything.
+type typeFilter struct {
+	exact    map[string]bool
+	prefixes []string
+}
+
+func newTypeFilter(raw string) typeFilter {
+	f := typeFilter{exact: make(map[string]bool)}
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		switch {
+		case t == "":
+		case strings.HasSuffix(t, ".*"):
+			f.prefixes = append(f.prefixes, strings.TrimSuffix(t, "*"))
+		default:
+			f.exact[t] = true
+		}
+	}
+	return f
+}
+
+func (f typeFilter) match(eventType string) bool {
+	if len(f.exact) == 0 && len(f.prefixes) == 0 {
+		return true
+	}
+	if f.exact[eventType] {
+		return true
+	}
+	for _, p := range f.prefixes {
+		if strings.HasPrefix(eventType, p) {
+			return true
+		}
+	}
+	return false
+}
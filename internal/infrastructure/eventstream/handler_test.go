@@ -0,0 +1,198 @@
+package eventstream_test
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventstream"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type nopDispatcher struct{}
+
+func (nopDispatcher) Dispatch(context.Context, []domain.DomainEvent) error { return nil }
+
+type fixture struct {
+	server     *httptest.Server
+	dispatcher *eventstore.Dispatcher
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+
+	store := eventstore.NewSQLiteStore(db, eventcodec.Default())
+	broker := eventstream.NewBroker()
+	srv := httptest.NewServer(eventstream.NewHandler(broker, store, "secret"))
+	t.Cleanup(srv.Close)
+
+	return &fixture{
+		server:     srv,
+		dispatcher: eventstore.NewDispatcher(nopDispatcher{}, store, broker),
+	}
+}
+
+func (f *fixture) dispatch(t *testing.T, events ...domain.DomainEvent) {
+	t.Helper()
+	if err := f.dispatcher.Dispatch(context.Background(), events); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+}
+
+// open connects to the stream and returns a channel of "id|event" pairs.
+func (f *fixture) open(t *testing.T, query, lastEventID string) <-chan string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, f.server.URL+"/?"+query, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("got content type %q", ct)
+	}
+
+	out := make(chan string, 16)
+	go func() {
+		defer func() { _ = resp.Body.Close() }()
+		scanner := bufio.NewScanner(resp.Body)
+		var id string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				out <- id + "|" + strings.TrimPrefix(line, "event: ")
+			}
+		}
+	}()
+	return out
+}
+
+func expect(t *testing.T, ch <-chan string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		select {
+		case got := <-ch:
+			if got != w {
+				t.Fatalf("got %q, want %q", got, w)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %q", w)
+		}
+	}
+}
+
+// waitForSubscriber lets the handler register before events are dispatched.
+func waitForSubscriber() { time.Sleep(50 * time.Millisecond) }
+
+func TestHandler_RequiresBearerToken(t *testing.T) {
+	f := newFixture(t)
+
+	for _, auth := range []string{"", "Bearer wrong", "secret"} {
+		req, _ := http.NewRequest(http.MethodGet, f.server.URL, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("auth %q: got status %d, want 401", auth, resp.StatusCode)
+		}
+	}
+}
+
+func TestHandler_StreamsLiveEventsWithTypeFilter(t *testing.T) {
+	f := newFixture(t)
+	stream := f.open(t, "types=note.*,action_item.completed", "")
+	waitForSubscriber()
+
+	f.dispatch(t,
+		domain.NewTranscriptUpdatedEvent("m-1", 3),
+		annotation.NewNoteAddedEvent("n-1", "m-1", "agent"),
+		domain.NewActionItemCompletedEvent("m-1", "ai-1"),
+	)
+
+	expect(t, stream, "2|note.added", "3|action_item.completed")
+}
+
+func TestHandler_ResumesFromLastEventID(t *testing.T) {
+	f := newFixture(t)
+	f.dispatch(t,
+		domain.NewTranscriptUpdatedEvent("m-1", 1),
+		domain.NewTranscriptUpdatedEvent("m-1", 2),
+		domain.NewTranscriptUpdatedEvent("m-1", 3),
+	)
+
+	stream := f.open(t, "", "1")
+	expect(t, stream, "2|transcript.updated", "3|transcript.updated")
+
+	waitForSubscriber()
+	f.dispatch(t, annotation.NewNoteDeletedEvent("n-1", "m-1"))
+	expect(t, stream, "4|note.deleted")
+}
+
+func TestHandler_RejectsInvalidLastEventID(t *testing.T) {
+	f := newFixture(t)
+
+	req, _ := http.NewRequest(http.MethodGet, f.server.URL+"/?last_event_id=abc", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("got status %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestBroker_DropsSlowSubscriber(t *testing.T) {
+	b := eventstream.NewBroker()
+	ch, cancel := b.Subscribe()
+	defer cancel()
+
+	for i := 0; i < 100; i++ {
+		b.EventRecorded(eventstore.Record{Seq: int64(i + 1)})
+	}
+
+	n := 0
+	for range ch {
+		n++
+	}
+	if n == 0 || n >= 100 {
+		t.Errorf("expected a buffered prefix before the channel closed, got %d records", n)
+	}
+}
@@ -1,6 +1,7 @@
 package granola
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -38,9 +39,19 @@ func (c *Client) SetToken(token string) {
 
 // ListNotes calls GET /v1/notes with optional cursor pagination.
 func (c *Client) ListNotes(ctx context.Context, createdAfter *time.Time, cursor string, pageSize int) (*NoteListResponse, error) {
+	return c.listNotes(ctx, "created_after", createdAfter, cursor, pageSize)
+}
+
+// ListNotesUpdatedAfter calls GET /v1/notes filtered to notes modified after
+// updatedAfter, so sync can pick up edits to older notes.
+func (c *Client) ListNotesUpdatedAfter(ctx context.Context, updatedAfter *time.Time, cursor string, pageSize int) (*NoteListResponse, error) {
+	return c.listNotes(ctx, "updated_after", updatedAfter, cursor, pageSize)
+}
+
+func (c *Client) listNotes(ctx context.Context, timeParam string, after *time.Time, cursor string, pageSize int) (*NoteListResponse, error) {
 	params := url.Values{}
-	if createdAfter != nil {
-		params.Set("created_after", createdAfter.Format(time.RFC3339))
+	if after != nil {
+		params.Set(timeParam, after.Format(time.RFC3339))
 	}
 	if cursor != "" {
 		params.Set("cursor", cursor)
@@ -92,23 +103,102 @@ func (c *Client) get(ctx context.Context, path string, params url.Values, target
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return ErrNotFound
+	if err := checkResponse(resp); err != nil {
+		return err
 	}
-	if resp.StatusCode == http.StatusTooManyRequests {
-		return ErrRateLimited
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
 	}
-	if resp.StatusCode == http.StatusUnauthorized {
-		return ErrUnauthorized
+
+	return nil
+}
+
+// PostJSON sends body as JSON to path on the Granola write API.
+// The response body is discarded.
+func (c *Client) PostJSON(ctx context.Context, path string, body any) error {
+	return c.send(ctx, http.MethodPost, path, body, "", nil)
+}
+
+// GetDocument fetches the editable document at path on the Granola write API.
+func (c *Client) GetDocument(ctx context.Context, path string) (*DocumentDTO, error) {
+	var doc DocumentDTO
+	if err := c.get(ctx, path, nil, &doc); err != nil {
+		return nil, err
 	}
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("api error (status %d): %s", resp.StatusCode, string(body))
+	return &doc, nil
+}
+
+// PutDocument replaces the content of the document at path, provided it is
+// still at revision, and returns the document as stored. It fails with
+// ErrStale if the document was edited since revision was read.
+func (c *Client) PutDocument(ctx context.Context, path, content, revision string) (*DocumentDTO, error) {
+	var doc DocumentDTO
+	if err := c.send(ctx, http.MethodPut, path, DocumentDTO{Content: content}, revision, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// send writes body as JSON to path, with an If-Match header when ifMatch is
+// set, and decodes the response into target unless it is nil.
+func (c *Client) send(ctx context.Context, method, path string, body any, ifMatch string, target any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
 	}
 
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if target == nil {
+		return nil
+	}
 	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
 		return fmt.Errorf("decoding response: %w", err)
 	}
+	return nil
+}
 
+// checkResponse maps HTTP error statuses to infrastructure errors.
+func checkResponse(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return ErrStale
+	case resp.StatusCode == http.StatusBadRequest,
+		resp.StatusCode == http.StatusConflict,
+		resp.StatusCode == http.StatusUnprocessableEntity:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%w (status %d): %s", ErrRejected, resp.StatusCode, string(body))
+	case resp.StatusCode >= 400:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("api error (status %d): %s", resp.StatusCode, string(body))
+	}
 	return nil
 }
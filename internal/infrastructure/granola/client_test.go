@@ -3,6 +3,7 @@ package granola_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -180,3 +181,39 @@ func TestClient_RateLimited(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestClient_PostJSON(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/events" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Error("missing or wrong auth header")
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := granola.NewClient(server.URL, server.Client(), "test-token")
+	if err := client.PostJSON(context.Background(), "/v1/events", map[string]string{"id": "evt-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["id"] != "evt-1" {
+		t.Errorf("got body %v", got)
+	}
+}
+
+func TestClient_PostJSON_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	client := granola.NewClient(server.URL, server.Client(), "test-token")
+	err := client.PostJSON(context.Background(), "/v1/events", map[string]string{})
+	if !errors.Is(err, granola.ErrRejected) {
+		t.Errorf("got error %v, want %v", err, granola.ErrRejected)
+	}
+}
@@ -8,4 +8,6 @@ var (
 	ErrNotFound     = errors.New("granola: resource not found")
 	ErrRateLimited  = errors.New("granola: rate limited")
 	ErrUnauthorized = errors.New("granola: unauthorized")
+	ErrRejected     = errors.New("granola: request rejected")
+	ErrStale        = errors.New("granola: document changed since it was read")
 )
@@ -0,0 +1,127 @@
+package granola
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// ContentHashes fingerprints the last synced transcript and summary of a note.
+type ContentHashes struct {
+	Transcript string
+	Summary    string
+	UpdatedAt  time.Time // upstream updated_at when the hashes were taken
+}
+
+// HashStore persists content hashes between syncs so Sync can tell which
+// notes actually changed.
+type HashStore interface {
+	Get(ctx context.Context, noteID string) (*ContentHashes, error) // nil when unseen
+	// Apply stores puts and forgets deletes in one transaction. Sync calls it
+	// once the whole listing succeeded, so a failed sync leaves the hashes
+	// as they were and its changes are reported again by the next one.
+	Apply(ctx context.Context, puts map[string]ContentHashes, deletes []string) error
+	IDs(ctx context.Context) ([]string, error) // every note with stored hashes
+}
+
+// SQLiteHashStore is a HashStore backed by the meeting_content_hashes table
+// of local.db, created by migration 0010_meeting_content_hashes.
+type SQLiteHashStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteHashStore creates a new SQLite-backed content hash store.
+func NewSQLiteHashStore(db *sql.DB) *SQLiteHashStore {
+	return &SQLiteHashStore{db: db}
+}
+
+func (s *SQLiteHashStore) Get(ctx context.Context, noteID string) (*ContentHashes, error) {
+	var h ContentHashes
+	err := s.db.QueryRowContext(ctx,
+		"SELECT transcript_hash, summary_hash, updated_at FROM meeting_content_hashes WHERE meeting_id = ?",
+		noteID,
+	).Scan(&h.Transcript, &h.Summary, &h.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (s *SQLiteHashStore) Apply(ctx context.Context, puts map[string]ContentHashes, deletes []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	checkedAt := time.Now().UTC()
+	for noteID, h := range puts {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO meeting_content_hashes (meeting_id, transcript_hash, summary_hash, updated_at, checked_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(meeting_id) DO UPDATE SET
+				transcript_hash = excluded.transcript_hash,
+				summary_hash = excluded.summary_hash,
+				updated_at = excluded.updated_at,
+				checked_at = excluded.checked_at`,
+			noteID, h.Transcript, h.Summary, h.UpdatedAt.UTC(), checkedAt,
+		); err != nil {
+			return err
+		}
+	}
+	for _, noteID := range deletes {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM meeting_content_hashes WHERE meeting_id = ?", noteID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteHashStore) IDs(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT meeting_id FROM meeting_content_hashes ORDER BY meeting_id")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// hashNoteContent fingerprints the transcript and summary of a note detail.
+// Empty content hashes to "" so a first transcript or summary counts as a change.
+func hashNoteContent(dto NoteDetailResponse) ContentHashes {
+	h := ContentHashes{UpdatedAt: dto.UpdatedAt}
+
+	if len(dto.Transcript) > 0 {
+		sum := sha256.New()
+		for _, u := range dto.Transcript {
+			sum.Write([]byte(u.Speaker))
+			sum.Write([]byte{0})
+			sum.Write([]byte(u.Text))
+			sum.Write([]byte{0})
+			sum.Write([]byte(u.Timestamp.UTC().Format(time.RFC3339Nano)))
+			sum.Write([]byte{'\n'})
+		}
+		h.Transcript = hex.EncodeToString(sum.Sum(nil))
+	}
+
+	if content := summaryContent(dto); content != "" {
+		sum := sha256.Sum256([]byte(content))
+		h.Summary = hex.EncodeToString(sum[:])
+	}
+	return h
+}
@@ -0,0 +1,278 @@
+package granola_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/granola"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newHashStore(t *testing.T) *granola.SQLiteHashStore {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	return granola.NewSQLiteHashStore(db)
+}
+
+func TestSQLiteHashStore_GetPut(t *testing.T) {
+	ctx := context.Background()
+	store := newHashStore(t)
+
+	got, err := store.Get(ctx, "m-1")
+	if err != nil || got != nil {
+		t.Fatalf("got %+v, %v; want nil for unseen note", got, err)
+	}
+
+	updated := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for _, summary := range []string{"s1", "s2"} {
+		puts := map[string]granola.ContentHashes{"m-1": {Transcript: "t1", Summary: summary, UpdatedAt: updated}}
+		if err := store.Apply(ctx, puts, nil); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+
+	got, err = store.Get(ctx, "m-1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Transcript != "t1" || got.Summary != "s2" || !got.UpdatedAt.Equal(updated) {
+		t.Errorf("got %+v", got)
+	}
+
+	if err := store.Apply(ctx, nil, []string{"m-1"}); err != nil {
+		t.Fatalf("apply delete: %v", err)
+	}
+	if got, _ := store.Get(ctx, "m-1"); got != nil {
+		t.Errorf("got %+v after delete, want nil", got)
+	}
+}
+
+// fakeNotesAPI serves a mutable set of notes for list and detail requests.
+type fakeNotesAPI struct {
+	mu           sync.Mutex
+	notes        map[string]*granola.NoteDetailResponse
+	detailCalls  int
+	updatedAfter string
+	failing      map[string]bool // notes whose detail request fails
+}
+
+func (f *fakeNotesAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/v1/notes" {
+		f.updatedAfter = r.URL.Query().Get("updated_after")
+		var resp granola.NoteListResponse
+		for _, n := range f.notes {
+			resp.Notes = append(resp.Notes, granola.NoteListItem{ID: n.ID, Title: n.Title, CreatedAt: n.CreatedAt, UpdatedAt: n.UpdatedAt})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	f.detailCalls++
+	if f.failing[strings.TrimPrefix(r.URL.Path, "/v1/notes/")] {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	n, ok := f.notes[strings.TrimPrefix(r.URL.Path, "/v1/notes/")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(n)
+}
+
+func (f *fakeNotesAPI) edit(id string, fn func(n *granola.NoteDetailResponse)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := f.notes[id]
+	fn(n)
+	n.UpdatedAt = n.UpdatedAt.Add(time.Minute)
+}
+
+func eventNames(events []domain.DomainEvent) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.EventName()
+	}
+	return names
+}
+
+func TestRepository_Sync_DetectsContentChanges(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	api := &fakeNotesAPI{notes: map[string]*granola.NoteDetailResponse{
+		"m-1": {
+			ID: "m-1", Title: "Planning", CreatedAt: created, UpdatedAt: created,
+			SummaryText: "First draft",
+			Transcript:  []granola.TranscriptItemDTO{{Speaker: "Alice", Text: "Hello", Timestamp: created}},
+		},
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	repo := granola.NewRepository(granola.NewClient(server.URL, server.Client(), "token"))
+	repo.SetHashStore(newHashStore(t))
+
+	events, err := repo.Sync(ctx, nil)
+	if err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+	if names := eventNames(events); len(names) != 1 || names[0] != "meeting.created" {
+		t.Fatalf("first sync got %v, want [meeting.created]", names)
+	}
+
+	// Unchanged updated_at: no events and no detail fetch.
+	before := api.detailCalls
+	events, err = repo.Sync(ctx, &created)
+	if err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	if len(events) != 0 || api.detailCalls != before {
+		t.Errorf("unchanged note: got %v and %d detail calls", eventNames(events), api.detailCalls-before)
+	}
+	if api.updatedAfter == "" {
+		t.Error("expected sync to filter by updated_after")
+	}
+
+	// Touched without content change: re-fetched but no events.
+	api.edit("m-1", func(n *granola.NoteDetailResponse) {})
+	events, _ = repo.Sync(ctx, &created)
+	if len(events) != 0 {
+		t.Errorf("metadata-only change: got %v, want no events", eventNames(events))
+	}
+
+	// Transcript grows.
+	api.edit("m-1", func(n *granola.NoteDetailResponse) {
+		n.Transcript = append(n.Transcript, granola.TranscriptItemDTO{Speaker: "Bob", Text: "Hi", Timestamp: created.Add(time.Second)})
+	})
+	events, _ = repo.Sync(ctx, &created)
+	if len(events) != 1 {
+		t.Fatalf("transcript change: got %v", eventNames(events))
+	}
+	tu, ok := events[0].(domain.TranscriptUpdated)
+	if !ok || tu.UtteranceCount() != 2 || tu.MeetingID() != "m-1" {
+		t.Errorf("got %+v, want transcript.updated with 2 utterances", events[0])
+	}
+
+	// Summary rewritten as markdown.
+	api.edit("m-1", func(n *granola.NoteDetailResponse) {
+		md := "## Decisions\n- Ship it"
+		n.SummaryMarkdown = &md
+	})
+	events, _ = repo.Sync(ctx, &created)
+	if len(events) != 1 {
+		t.Fatalf("summary change: got %v", eventNames(events))
+	}
+	su, ok := events[0].(domain.SummaryUpdated)
+	if !ok || su.Kind() != domain.SummaryAuto {
+		t.Errorf("got %+v, want summary.updated (auto)", events[0])
+	}
+}
+
+func TestRepository_Sync_FullSyncDetectsDeletions(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	api := &fakeNotesAPI{notes: map[string]*granola.NoteDetailResponse{
+		"m-1": {ID: "m-1", Title: "Planning", CreatedAt: created, UpdatedAt: created},
+		"m-2": {ID: "m-2", Title: "Retro", CreatedAt: created, UpdatedAt: created},
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	store := newHashStore(t)
+	repo := granola.NewRepository(granola.NewClient(server.URL, server.Client(), "token"))
+	repo.SetHashStore(store)
+
+	if _, err := repo.Sync(ctx, nil); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+
+	api.mu.Lock()
+	delete(api.notes, "m-2")
+	api.mu.Unlock()
+
+	// Incremental syncs cannot see deletions.
+	events, err := repo.Sync(ctx, &created)
+	if err != nil {
+		t.Fatalf("incremental sync: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("incremental sync got %v, want no events", eventNames(events))
+	}
+
+	events, err = repo.Sync(ctx, nil)
+	if err != nil {
+		t.Fatalf("full sync: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("full sync got %v, want [meeting.deleted]", eventNames(events))
+	}
+	if deleted, ok := events[0].(domain.MeetingDeleted); !ok || deleted.MeetingID() != "m-2" {
+		t.Errorf("got %+v, want meeting.deleted for m-2", events[0])
+	}
+	if h, _ := store.Get(ctx, "m-2"); h != nil {
+		t.Error("expected hashes of the deleted note to be forgotten")
+	}
+
+	// Reported once only.
+	events, _ = repo.Sync(ctx, nil)
+	if len(events) != 0 {
+		t.Errorf("repeat full sync got %v, want no events", eventNames(events))
+	}
+}
+
+func TestRepository_Sync_FailedSyncKeepsChangesForTheNextOne(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	api := &fakeNotesAPI{
+		notes: map[string]*granola.NoteDetailResponse{
+			"m-1": {ID: "m-1", Title: "Planning", CreatedAt: created, UpdatedAt: created},
+			"m-2": {ID: "m-2", Title: "Retro", CreatedAt: created, UpdatedAt: created},
+		},
+		failing: map[string]bool{"m-2": true},
+	}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	store := newHashStore(t)
+	repo := granola.NewRepository(granola.NewClient(server.URL, server.Client(), "token"))
+	repo.SetHashStore(store)
+
+	if _, err := repo.Sync(ctx, nil); err == nil {
+		t.Fatal("expected the sync to fail")
+	}
+	if ids, _ := store.IDs(ctx); len(ids) != 0 {
+		t.Errorf("failed sync stored hashes for %v", ids)
+	}
+
+	api.mu.Lock()
+	api.failing = nil
+	api.mu.Unlock()
+
+	events, err := repo.Sync(ctx, nil)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if names := eventNames(events); len(names) != 2 || names[0] != "meeting.created" || names[1] != "meeting.created" {
+		t.Errorf("retry got %v, want both meetings created", names)
+	}
+}
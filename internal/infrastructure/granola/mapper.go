@@ -39,13 +39,8 @@ func mapNoteDetailToDomain(dto NoteDetailResponse) (*domain.Meeting, error) {
 	mtg.ClearDomainEvents()
 
 	// Map summary — prefer markdown if present, fall back to text
-	summaryContent := dto.SummaryText
-	summaryKind := domain.SummaryAuto
-	if dto.SummaryMarkdown != nil && *dto.SummaryMarkdown != "" {
-		summaryContent = *dto.SummaryMarkdown
-	}
-	if summaryContent != "" {
-		mtg.AttachSummary(domain.NewSummary(domain.MeetingID(dto.ID), summaryContent, summaryKind))
+	if content := summaryContent(dto); content != "" {
+		mtg.AttachSummary(domain.NewSummary(domain.MeetingID(dto.ID), content, domain.SummaryAuto))
 		mtg.ClearDomainEvents()
 	}
 
@@ -55,6 +50,14 @@ func mapNoteDetailToDomain(dto NoteDetailResponse) (*domain.Meeting, error) {
 	return mtg, nil
 }
 
+// summaryContent prefers the markdown summary, falling back to plain text.
+func summaryContent(dto NoteDetailResponse) string {
+	if dto.SummaryMarkdown != nil && *dto.SummaryMarkdown != "" {
+		return *dto.SummaryMarkdown
+	}
+	return dto.SummaryText
+}
+
 func mapNoteListItemToDomain(dto NoteListItem) (*domain.Meeting, error) {
 	var participants []domain.Participant
 	if dto.Owner.Name != "" || dto.Owner.Email != "" {
@@ -21,6 +21,7 @@ type NoteListItem struct {
 	Title     string    `json:"title"`
 	Owner     UserDTO   `json:"owner"`
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
 }
 
 type NoteDetailResponse struct {
@@ -29,6 +30,7 @@ type NoteDetailResponse struct {
 	Title            string              `json:"title"`
 	Owner            UserDTO             `json:"owner"`
 	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at,omitempty"`
 	CalendarEvent    *CalendarEventDTO   `json:"calendar_event,omitempty"`
 	Attendees        []UserDTO           `json:"attendees,omitempty"`
 	FolderMembership []FolderDTO         `json:"folder_membership,omitempty"`
@@ -58,3 +60,12 @@ type FolderDTO struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
+
+// --- Write API DTOs ---
+
+// DocumentDTO is the editable body of a Granola note. Revision changes on
+// every edit and is sent back as If-Match to detect concurrent edits.
+type DocumentDTO struct {
+	Content  string `json:"content"`
+	Revision string `json:"revision"`
+}
@@ -3,6 +3,7 @@ package granola
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"time"
 
@@ -14,12 +15,19 @@ import (
 // it translates between infrastructure (HTTP API) and domain concepts.
 type Repository struct {
 	client *Client
+	hashes HashStore
 }
 
 func NewRepository(client *Client) *Repository {
 	return &Repository{client: client}
 }
 
+// SetHashStore enables change detection in Sync. Without a store, Sync
+// reports every listed note as created.
+func (r *Repository) SetHashStore(store HashStore) {
+	r.hashes = store
+}
+
 func (r *Repository) FindByID(ctx context.Context, id domain.MeetingID) (*domain.Meeting, error) {
 	dto, err := r.client.GetNote(ctx, string(id), false)
 	if err != nil {
@@ -73,6 +81,10 @@ func (r *Repository) GetActionItems(_ context.Context, _ domain.MeetingID) ([]*d
 }
 
 func (r *Repository) Sync(ctx context.Context, since *time.Time) ([]domain.DomainEvent, error) {
+	if r.hashes != nil {
+		return r.syncChanges(ctx, since)
+	}
+
 	var allEvents []domain.DomainEvent
 	var cursor string
 
@@ -99,6 +111,111 @@ func (r *Repository) Sync(ctx context.Context, since *time.Time) ([]domain.Domai
 	return allEvents, nil
 }
 
+// syncChanges lists notes modified since the last sync and re-fetches their
+// details. Unseen notes yield MeetingCreated; known notes yield
+// TranscriptUpdated or SummaryUpdated only when the content hash changed.
+// Notes whose updated_at has not moved are skipped without a detail fetch.
+// A full sync (since == nil) also reports known notes missing from the
+// listing as MeetingDeleted. The new hashes are stored only once every page
+// was processed, so a sync that fails midway loses no changes.
+func (r *Repository) syncChanges(ctx context.Context, since *time.Time) ([]domain.DomainEvent, error) {
+	var allEvents []domain.DomainEvent
+	var cursor string
+	listed := make(map[string]bool)
+	changes := hashChanges{puts: make(map[string]ContentHashes)}
+
+	for {
+		resp, err := r.client.ListNotesUpdatedAfter(ctx, since, cursor, 0)
+		if err != nil {
+			return nil, r.mapError(err)
+		}
+
+		for _, item := range resp.Notes {
+			listed[item.ID] = true
+			events, err := r.detectChanges(ctx, item, &changes)
+			if err != nil {
+				return nil, err
+			}
+			allEvents = append(allEvents, events...)
+		}
+
+		if !resp.HasMore || resp.Cursor == "" {
+			break
+		}
+		cursor = resp.Cursor
+	}
+
+	if since == nil {
+		known, err := r.hashes.IDs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list known notes: %w", err)
+		}
+		for _, id := range known {
+			if listed[id] {
+				continue
+			}
+			changes.deletes = append(changes.deletes, id)
+			allEvents = append(allEvents, domain.NewMeetingDeletedEvent(domain.MeetingID(id)))
+		}
+	}
+
+	if err := r.hashes.Apply(ctx, changes.puts, changes.deletes); err != nil {
+		return nil, fmt.Errorf("store content hashes: %w", err)
+	}
+	return allEvents, nil
+}
+
+// hashChanges collects the hash store writes of one sync.
+type hashChanges struct {
+	puts    map[string]ContentHashes
+	deletes []string
+}
+
+func (r *Repository) detectChanges(ctx context.Context, item NoteListItem, changes *hashChanges) ([]domain.DomainEvent, error) {
+	prev, err := r.hashes.Get(ctx, item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("load content hashes for %s: %w", item.ID, err)
+	}
+	if prev != nil && !item.UpdatedAt.IsZero() && !item.UpdatedAt.After(prev.UpdatedAt) {
+		return nil, nil
+	}
+
+	dto, err := r.client.GetNote(ctx, item.ID, true)
+	if errors.Is(err, ErrNotFound) {
+		// Removed between list and fetch.
+		if prev == nil {
+			return nil, nil
+		}
+		changes.deletes = append(changes.deletes, item.ID)
+		return []domain.DomainEvent{domain.NewMeetingDeletedEvent(domain.MeetingID(item.ID))}, nil
+	}
+	if err != nil {
+		return nil, r.mapError(err)
+	}
+
+	cur := hashNoteContent(*dto)
+	if cur.UpdatedAt.IsZero() {
+		cur.UpdatedAt = item.UpdatedAt
+	}
+
+	var events []domain.DomainEvent
+	id := domain.MeetingID(item.ID)
+	switch {
+	case prev == nil:
+		events = append(events, domain.NewMeetingCreatedEvent(id, item.Title, item.CreatedAt))
+	default:
+		if cur.Transcript != prev.Transcript {
+			events = append(events, domain.NewTranscriptUpdatedEvent(id, len(dto.Transcript)))
+		}
+		if cur.Summary != prev.Summary {
+			events = append(events, domain.NewSummaryUpdatedEvent(id, domain.SummaryAuto))
+		}
+	}
+
+	changes.puts[item.ID] = cur
+	return events, nil
+}
+
 // mapError translates infrastructure errors to domain errors.
 // This ensures the domain layer never sees HTTP-specific error types.
 func (r *Repository) mapError(err error) error {
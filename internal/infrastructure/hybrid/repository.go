@@ -0,0 +1,316 @@
+// Package hybrid provides a repository that merges the Granola API with the
+// desktop app's local cache. The desktop cache gives instant, offline and
+// complete transcripts of meetings recorded on this machine; the API adds
+// shared and team meetings that were never opened locally.
+package hybrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// Preference names the side whose value wins when both have one.
+type Preference string
+
+const (
+	PreferLocal Preference = "local"
+	PreferAPI   Preference = "api"
+)
+
+// ParsePreference validates a configured preference.
+func ParsePreference(s string) (Preference, error) {
+	switch p := Preference(s); p {
+	case PreferLocal, PreferAPI:
+		return p, nil
+	default:
+		return "", fmt.Errorf("hybrid: invalid preference %q (want %q or %q)", s, PreferLocal, PreferAPI)
+	}
+}
+
+// Precedence chooses, per field, which side wins for a meeting present on
+// both. The other side is used when the preferred one has no value.
+// Title, time, source, metadata and action items always come from the API
+// when it has the meeting, since the desktop cache does not carry them all.
+type Precedence struct {
+	Transcript   Preference
+	Summary      Preference
+	Participants Preference
+}
+
+// DefaultPrecedence prefers desktop transcripts and API summaries and
+// participants.
+func DefaultPrecedence() Precedence {
+	return Precedence{Transcript: PreferLocal, Summary: PreferAPI, Participants: PreferAPI}
+}
+
+// Repository implements domain.Repository over an API-backed repository and
+// a desktop-cache repository, merging meetings by ID. When one side fails the
+// other's results are served and the failure is logged, so the desktop cache
+// keeps working offline.
+type Repository struct {
+	api        domain.Repository
+	local      domain.Repository
+	precedence Precedence
+}
+
+// NewRepository creates a hybrid repository.
+func NewRepository(api, local domain.Repository, precedence Precedence) *Repository {
+	return &Repository{api: api, local: local, precedence: precedence}
+}
+
+func (r *Repository) FindByID(ctx context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+	apiMtg, apiErr := r.api.FindByID(ctx, id)
+	localMtg, localErr := r.local.FindByID(ctx, id)
+
+	switch {
+	case apiErr == nil && localErr == nil:
+		return r.merge(apiMtg, localMtg)
+	case apiErr == nil:
+		logUnlessNotFound("local", localErr)
+		return apiMtg, nil
+	case localErr == nil:
+		logUnlessNotFound("api", apiErr)
+		return localMtg, nil
+	default:
+		return nil, preferFound(apiErr, localErr)
+	}
+}
+
+func (r *Repository) List(ctx context.Context, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	return r.mergeLists(filter, func(repo domain.Repository, f domain.ListFilter) ([]*domain.Meeting, error) {
+		return repo.List(ctx, f)
+	})
+}
+
+func (r *Repository) SearchTranscripts(ctx context.Context, query string, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	return r.mergeLists(filter, func(repo domain.Repository, f domain.ListFilter) ([]*domain.Meeting, error) {
+		return repo.SearchTranscripts(ctx, query, f)
+	})
+}
+
+func (r *Repository) GetTranscript(ctx context.Context, id domain.MeetingID) (*domain.Transcript, error) {
+	first, second := r.ordered(r.precedence.Transcript)
+	t, err := first.GetTranscript(ctx, id)
+	if err == nil && t != nil {
+		return t, nil
+	}
+	t2, err2 := second.GetTranscript(ctx, id)
+	if err2 == nil && t2 != nil {
+		return t2, nil
+	}
+	if err == nil {
+		err = domain.ErrTranscriptNotReady
+	}
+	if err2 == nil {
+		err2 = domain.ErrTranscriptNotReady
+	}
+	return nil, preferFound(err, err2)
+}
+
+func (r *Repository) GetActionItems(ctx context.Context, id domain.MeetingID) ([]*domain.ActionItem, error) {
+	items, err := r.api.GetActionItems(ctx, id)
+	if err == nil {
+		return items, nil
+	}
+	logUnlessNotFound("api", err)
+	localItems, localErr := r.local.GetActionItems(ctx, id)
+	if localErr != nil {
+		return nil, err
+	}
+	return localItems, nil
+}
+
+// Sync syncs both sides and merges their events. A meeting reported by both
+// is reported once, and a deletion on one side is dropped while the other
+// side still has the meeting.
+func (r *Repository) Sync(ctx context.Context, since *time.Time) ([]domain.DomainEvent, error) {
+	apiEvents, apiErr := r.api.Sync(ctx, since)
+	localEvents, localErr := r.local.Sync(ctx, since)
+	if apiErr != nil && localErr != nil {
+		return nil, apiErr
+	}
+	if apiErr != nil {
+		log.Printf("hybrid: api sync failed, using desktop cache only: %v", apiErr)
+	}
+	if localErr != nil {
+		log.Printf("hybrid: desktop cache sync failed, using api only: %v", localErr)
+	}
+
+	type eventKey struct {
+		name      string
+		meetingID domain.MeetingID
+	}
+	seen := make(map[eventKey]bool)
+	var merged []domain.DomainEvent
+	add := func(events []domain.DomainEvent, other domain.Repository) {
+		for _, event := range events {
+			m, ok := event.(interface{ MeetingID() domain.MeetingID })
+			if !ok {
+				merged = append(merged, event)
+				continue
+			}
+			key := eventKey{event.EventName(), m.MeetingID()}
+			if seen[key] {
+				continue
+			}
+			if _, deleted := event.(domain.MeetingDeleted); deleted {
+				if _, err := other.FindByID(ctx, m.MeetingID()); err == nil {
+					continue
+				}
+			}
+			seen[key] = true
+			merged = append(merged, event)
+		}
+	}
+	add(apiEvents, r.local)
+	add(localEvents, r.api)
+	return merged, nil
+}
+
+// mergeLists queries both sides without paging, merges meetings present on
+// both, and pages the merged result newest first.
+func (r *Repository) mergeLists(filter domain.ListFilter, query func(domain.Repository, domain.ListFilter) ([]*domain.Meeting, error)) ([]*domain.Meeting, error) {
+	unpaged := filter
+	unpaged.Offset = 0
+	if filter.Limit > 0 {
+		unpaged.Limit = filter.Offset + filter.Limit
+	}
+
+	apiList, apiErr := query(r.api, unpaged)
+	localList, localErr := query(r.local, unpaged)
+	if apiErr != nil && localErr != nil {
+		return nil, apiErr
+	}
+	if apiErr != nil {
+		log.Printf("hybrid: api unavailable, listing desktop cache only: %v", apiErr)
+	}
+	if localErr != nil {
+		log.Printf("hybrid: desktop cache unavailable, listing api only: %v", localErr)
+	}
+
+	position := make(map[domain.MeetingID]int, len(apiList)+len(localList))
+	meetings := make([]*domain.Meeting, 0, len(apiList)+len(localList))
+	for _, m := range apiList {
+		if _, dup := position[m.ID()]; !dup {
+			position[m.ID()] = len(meetings)
+			meetings = append(meetings, m)
+		}
+	}
+	fromAPI := len(meetings)
+	for _, m := range localList {
+		i, both := position[m.ID()]
+		if !both {
+			position[m.ID()] = len(meetings)
+			meetings = append(meetings, m)
+			continue
+		}
+		if i >= fromAPI {
+			continue // duplicate within the desktop cache results
+		}
+		merged, err := r.merge(meetings[i], m)
+		if err != nil {
+			return nil, err
+		}
+		meetings[i] = merged
+	}
+
+	sort.SliceStable(meetings, func(i, j int) bool {
+		return meetings[i].Datetime().After(meetings[j].Datetime())
+	})
+
+	if filter.Offset > 0 && filter.Offset < len(meetings) {
+		meetings = meetings[filter.Offset:]
+	} else if filter.Offset >= len(meetings) {
+		return []*domain.Meeting{}, nil
+	}
+	if filter.Limit > 0 && filter.Limit < len(meetings) {
+		meetings = meetings[:filter.Limit]
+	}
+	return meetings, nil
+}
+
+// merge reconstitutes one meeting from both sides according to the
+// configured precedence.
+func (r *Repository) merge(apiMtg, localMtg *domain.Meeting) (*domain.Meeting, error) {
+	pick := func(p Preference) (preferred, other *domain.Meeting) {
+		if p == PreferLocal {
+			return localMtg, apiMtg
+		}
+		return apiMtg, localMtg
+	}
+
+	preferred, other := pick(r.precedence.Participants)
+	participants := preferred.Participants()
+	if len(participants) == 0 {
+		participants = other.Participants()
+	}
+
+	merged, err := domain.New(apiMtg.ID(), apiMtg.Title(), apiMtg.Datetime(), apiMtg.Source(), participants)
+	if err != nil {
+		return nil, err
+	}
+
+	preferred, other = pick(r.precedence.Transcript)
+	if t := firstNonNil(preferred.Transcript(), other.Transcript()); t != nil {
+		merged.AttachTranscript(*t)
+	}
+	preferred, other = pick(r.precedence.Summary)
+	if s := firstNonNil(preferred.Summary(), other.Summary()); s != nil {
+		merged.AttachSummary(*s)
+	}
+
+	items := apiMtg.ActionItems()
+	if len(items) == 0 {
+		items = localMtg.ActionItems()
+	}
+	for _, item := range items {
+		merged.AddActionItem(item)
+	}
+	merged.SetMetadata(apiMtg.Metadata())
+
+	// Reconstitution, not creation: no events.
+	merged.ClearDomainEvents()
+	return merged, nil
+}
+
+// ordered returns the repositories in preference order.
+func (r *Repository) ordered(p Preference) (first, second domain.Repository) {
+	if p == PreferLocal {
+		return r.local, r.api
+	}
+	return r.api, r.local
+}
+
+func firstNonNil[T any](values ...*T) *T {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// preferFound returns the more informative of two errors: anything other
+// than "not found" explains the failure better.
+func preferFound(a, b error) error {
+	if isNotFound(a) && !isNotFound(b) {
+		return b
+	}
+	return a
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, domain.ErrMeetingNotFound) || errors.Is(err, domain.ErrTranscriptNotReady)
+}
+
+func logUnlessNotFound(side string, err error) {
+	if !isNotFound(err) {
+		log.Printf("hybrid: %s lookup failed: %v", side, err)
+	}
+}
@@ -0,0 +1,311 @@
+package hybrid_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/hybrid"
+)
+
+// stubRepo serves a fixed set of meetings, or fails every call with err.
+type stubRepo struct {
+	meetings []*domain.Meeting
+	events   []domain.DomainEvent
+	err      error
+}
+
+func (s *stubRepo) find(id domain.MeetingID) (*domain.Meeting, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	for _, m := range s.meetings {
+		if m.ID() == id {
+			return m, nil
+		}
+	}
+	return nil, domain.ErrMeetingNotFound
+}
+
+func (s *stubRepo) FindByID(_ context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+	return s.find(id)
+}
+
+func (s *stubRepo) List(_ context.Context, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	list := s.meetings
+	if filter.Limit > 0 && filter.Limit < len(list) {
+		list = list[:filter.Limit]
+	}
+	return list, nil
+}
+
+func (s *stubRepo) GetTranscript(_ context.Context, id domain.MeetingID) (*domain.Transcript, error) {
+	m, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if m.Transcript() == nil {
+		return nil, domain.ErrTranscriptNotReady
+	}
+	return m.Transcript(), nil
+}
+
+func (s *stubRepo) SearchTranscripts(ctx context.Context, _ string, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	return s.List(ctx, filter)
+}
+
+func (s *stubRepo) GetActionItems(_ context.Context, id domain.MeetingID) ([]*domain.ActionItem, error) {
+	m, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	return m.ActionItems(), nil
+}
+
+func (s *stubRepo) Sync(_ context.Context, _ *time.Time) ([]domain.DomainEvent, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.events, nil
+}
+
+var base = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func mustMeeting(t *testing.T, id, title string, hoursAgo int, participants ...string) *domain.Meeting {
+	t.Helper()
+	var ps []domain.Participant
+	for _, name := range participants {
+		ps = append(ps, domain.NewParticipant(name, "", domain.RoleAttendee))
+	}
+	m, err := domain.New(domain.MeetingID(id), title, base.Add(-time.Duration(hoursAgo)*time.Hour), domain.SourceOther, ps)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	m.ClearDomainEvents()
+	return m
+}
+
+func withTranscript(m *domain.Meeting, text string) *domain.Meeting {
+	m.AttachTranscript(domain.NewTranscript(m.ID(), []domain.Utterance{
+		domain.NewUtterance("Alice", text, m.Datetime(), 1),
+	}))
+	return m
+}
+
+func withSummary(m *domain.Meeting, content string) *domain.Meeting {
+	m.AttachSummary(domain.NewSummary(m.ID(), content, domain.SummaryAuto))
+	return m
+}
+
+func TestFindByID_MergesWithDefaultPrecedence(t *testing.T) {
+	api := &stubRepo{meetings: []*domain.Meeting{
+		withSummary(withTranscript(mustMeeting(t, "m-1", "API title", 1, "Alice", "Bob"), "api words"), "api summary"),
+	}}
+	local := &stubRepo{meetings: []*domain.Meeting{
+		withSummary(withTranscript(mustMeeting(t, "m-1", "Local title", 1, "Alice"), "local words"), "local summary"),
+	}}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	m, err := repo.FindByID(context.Background(), "m-1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if m.Title() != "API title" {
+		t.Errorf("title = %q, want API title", m.Title())
+	}
+	if got := m.Transcript().Utterances()[0].Text(); got != "local words" {
+		t.Errorf("transcript = %q, want the desktop transcript", got)
+	}
+	if got := m.Summary().Content(); got != "api summary" {
+		t.Errorf("summary = %q, want the API summary", got)
+	}
+	if len(m.Participants()) != 2 {
+		t.Errorf("participants = %d, want the API's 2", len(m.Participants()))
+	}
+	if len(m.DomainEvents()) != 0 {
+		t.Errorf("merged meeting raised %d events", len(m.DomainEvents()))
+	}
+}
+
+func TestFindByID_PrecedenceFallsBackWhenPreferredEmpty(t *testing.T) {
+	api := &stubRepo{meetings: []*domain.Meeting{withTranscript(mustMeeting(t, "m-1", "T", 1), "api words")}}
+	local := &stubRepo{meetings: []*domain.Meeting{withSummary(mustMeeting(t, "m-1", "T", 1, "Carol"), "local summary")}}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	m, err := repo.FindByID(context.Background(), "m-1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if m.Transcript() == nil || m.Transcript().Utterances()[0].Text() != "api words" {
+		t.Error("expected the API transcript when the desktop has none")
+	}
+	if m.Summary() == nil || m.Summary().Content() != "local summary" {
+		t.Error("expected the desktop summary when the API has none")
+	}
+	if len(m.Participants()) != 1 || m.Participants()[0].Name() != "Carol" {
+		t.Errorf("participants = %v, want the desktop's", m.Participants())
+	}
+}
+
+func TestFindByID_OneSideOnly(t *testing.T) {
+	api := &stubRepo{meetings: []*domain.Meeting{mustMeeting(t, "shared", "Team sync", 1)}}
+	local := &stubRepo{meetings: []*domain.Meeting{mustMeeting(t, "mine", "1:1", 2)}}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	for _, id := range []domain.MeetingID{"shared", "mine"} {
+		if _, err := repo.FindByID(context.Background(), id); err != nil {
+			t.Errorf("FindByID(%s): %v", id, err)
+		}
+	}
+	if _, err := repo.FindByID(context.Background(), "nope"); !errors.Is(err, domain.ErrMeetingNotFound) {
+		t.Errorf("got %v, want ErrMeetingNotFound", err)
+	}
+}
+
+func TestFindByID_APIOffline(t *testing.T) {
+	offline := errors.New("connection refused")
+	api := &stubRepo{err: offline}
+	local := &stubRepo{meetings: []*domain.Meeting{mustMeeting(t, "m-1", "T", 1)}}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	if _, err := repo.FindByID(context.Background(), "m-1"); err != nil {
+		t.Errorf("FindByID: %v", err)
+	}
+	if _, err := repo.FindByID(context.Background(), "m-2"); !errors.Is(err, offline) {
+		t.Errorf("got %v, want the API error over not found", err)
+	}
+}
+
+func TestList_DeduplicatesSortsAndPages(t *testing.T) {
+	api := &stubRepo{meetings: []*domain.Meeting{
+		mustMeeting(t, "a", "A", 1),
+		mustMeeting(t, "c", "C", 3),
+	}}
+	local := &stubRepo{meetings: []*domain.Meeting{
+		mustMeeting(t, "a", "A", 1),
+		mustMeeting(t, "b", "B", 2),
+		mustMeeting(t, "d", "D", 4),
+	}}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	all, err := repo.List(context.Background(), domain.ListFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := ids(all); got != "abcd" {
+		t.Errorf("List = %s, want abcd", got)
+	}
+
+	page, err := repo.List(context.Background(), domain.ListFilter{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := ids(page); got != "bc" {
+		t.Errorf("page = %s, want bc", got)
+	}
+
+	beyond, err := repo.List(context.Background(), domain.ListFilter{Offset: 10})
+	if err != nil || len(beyond) != 0 {
+		t.Errorf("List past the end = %v, %v", beyond, err)
+	}
+}
+
+func TestSearchTranscripts_OneSideOffline(t *testing.T) {
+	api := &stubRepo{err: errors.New("timeout")}
+	local := &stubRepo{meetings: []*domain.Meeting{mustMeeting(t, "a", "A", 1)}}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	got, err := repo.SearchTranscripts(context.Background(), "q", domain.ListFilter{})
+	if err != nil {
+		t.Fatalf("SearchTranscripts: %v", err)
+	}
+	if ids(got) != "a" {
+		t.Errorf("got %s, want a", ids(got))
+	}
+
+	local.err = errors.New("corrupt")
+	if _, err := repo.SearchTranscripts(context.Background(), "q", domain.ListFilter{}); err == nil {
+		t.Error("expected an error when both sides fail")
+	}
+}
+
+func TestGetTranscript_FallsBackToOtherSide(t *testing.T) {
+	api := &stubRepo{meetings: []*domain.Meeting{withTranscript(mustMeeting(t, "shared", "T", 1), "api words")}}
+	local := &stubRepo{meetings: []*domain.Meeting{mustMeeting(t, "shared", "T", 1)}}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	tr, err := repo.GetTranscript(context.Background(), "shared")
+	if err != nil {
+		t.Fatalf("GetTranscript: %v", err)
+	}
+	if tr.Utterances()[0].Text() != "api words" {
+		t.Errorf("got %q, want the API transcript", tr.Utterances()[0].Text())
+	}
+
+	if _, err := repo.GetTranscript(context.Background(), "nope"); !errors.Is(err, domain.ErrMeetingNotFound) {
+		t.Errorf("got %v, want ErrMeetingNotFound", err)
+	}
+}
+
+func TestSync_DeduplicatesAndSuppressesDeletions(t *testing.T) {
+	api := &stubRepo{
+		meetings: []*domain.Meeting{mustMeeting(t, "kept", "K", 1)},
+		events: []domain.DomainEvent{
+			domain.NewMeetingCreatedEvent("new", "N", base),
+		},
+	}
+	local := &stubRepo{
+		events: []domain.DomainEvent{
+			domain.NewMeetingCreatedEvent("new", "N", base),
+			domain.NewMeetingDeletedEvent("kept"),
+			domain.NewMeetingDeletedEvent("gone"),
+		},
+	}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	events, err := repo.Sync(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	var names []string
+	for _, e := range events {
+		names = append(names, e.EventName()+":"+string(e.(interface{ MeetingID() domain.MeetingID }).MeetingID()))
+	}
+	want := []string{"meeting.created:new", "meeting.deleted:gone"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] {
+		t.Errorf("events = %v, want %v", names, want)
+	}
+}
+
+func TestSync_OneSideFailing(t *testing.T) {
+	api := &stubRepo{err: errors.New("offline")}
+	local := &stubRepo{events: []domain.DomainEvent{domain.NewMeetingCreatedEvent("m", "M", base)}}
+	repo := hybrid.NewRepository(api, local, hybrid.DefaultPrecedence())
+
+	events, err := repo.Sync(context.Background(), nil)
+	if err != nil || len(events) != 1 {
+		t.Errorf("Sync = %d events, %v; want the desktop events", len(events), err)
+	}
+}
+
+func TestParsePreference(t *testing.T) {
+	if p, err := hybrid.ParsePreference("local"); err != nil || p != hybrid.PreferLocal {
+		t.Errorf("ParsePreference(local) = %q, %v", p, err)
+	}
+	if _, err := hybrid.ParsePreference("both"); err == nil {
+		t.Error("expected an error for an unknown preference")
+	}
+}
+
+func ids(meetings []*domain.Meeting) string {
+	var s string
+	for _, m := range meetings {
+		s += string(m.ID())
+	}
+	return s
+}
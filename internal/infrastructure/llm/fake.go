@@ -0,0 +1,46 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// Fake is an in-memory LLMProvider for tests. It records every request and
+// answers with Reply, or with a numbered placeholder when Reply is nil.
+type Fake struct {
+	Reply func(req domain.CompletionRequest) (string, error)
+
+	mu       sync.Mutex
+	requests []domain.CompletionRequest
+}
+
+// NewFake creates a fake provider with placeholder replies.
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Model() string { return "fake" }
+
+func (f *Fake) Complete(_ context.Context, req domain.CompletionRequest) (string, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	n := len(f.requests)
+	f.mu.Unlock()
+
+	if f.Reply != nil {
+		return f.Reply(req)
+	}
+	return fmt.Sprintf("summary %d", n), nil
+}
+
+// Requests returns the requests received so far, in order.
+func (f *Fake) Requests() []domain.CompletionRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]domain.CompletionRequest(nil), f.requests...)
+}
+
+var _ domain.LLMProvider = (*Fake)(nil)
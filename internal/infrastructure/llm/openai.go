@@ -0,0 +1,110 @@
+// Package llm implements domain.LLMProvider adapters: a client for servers
+// speaking the OpenAI chat completions API, which local runtimes such as
+// Ollama, llama.cpp and LM Studio expose, and a fake for tests.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// httpClientTimeout is generous: local models on modest hardware can take
+// minutes to answer a long prompt.
+const httpClientTimeout = 5 * time.Minute
+
+// ErrNoChoices is returned when the server answers without a completion.
+var ErrNoChoices = errors.New("llm: response has no choices")
+
+// OpenAIProvider calls POST {baseURL}/chat/completions.
+type OpenAIProvider struct {
+	baseURL    string // e.g. http://localhost:11434/v1
+	model      string
+	apiKey     string // optional; local servers usually need none
+	httpClient *http.Client
+}
+
+// NewOpenAIProvider creates a chat completions client. A nil httpClient uses
+// one with a five minute timeout.
+func NewOpenAIProvider(baseURL, model, apiKey string, httpClient *http.Client) *OpenAIProvider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: httpClientTimeout}
+	}
+	return &OpenAIProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		model:      model,
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (p *OpenAIProvider) Model() string { return p.model }
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model     string        `json:"model"`
+	Messages  []chatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens,omitempty"`
+	Stream    bool          `json:"stream"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (p *OpenAIProvider) Complete(ctx context.Context, req domain.CompletionRequest) (string, error) {
+	var messages []chatMessage
+	if req.System != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: req.System})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: req.Prompt})
+
+	data, err := json.Marshal(chatRequest{Model: p.model, Messages: messages, MaxTokens: req.MaxTokens})
+	if err != nil {
+		return "", fmt.Errorf("llm: encoding request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("llm: creating request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("llm: executing request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("llm: api error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var out chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("llm: decoding response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", ErrNoChoices
+	}
+	return out.Choices[0].Message.Content, nil
+}
+
+var _ domain.LLMProvider = (*OpenAIProvider)(nil)
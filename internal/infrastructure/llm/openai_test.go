@@ -0,0 +1,78 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/llm"
+)
+
+func TestOpenAIProvider_Complete(t *testing.T) {
+	var got struct {
+		Model    string `json:"model"`
+		Messages []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+		MaxTokens int  `json:"max_tokens"`
+		Stream    bool `json:"stream"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("got auth %q", auth)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"The team agreed to ship."}}]}`))
+	}))
+	defer srv.Close()
+
+	p := llm.NewOpenAIProvider(srv.URL+"/v1/", "llama3.2", "secret", srv.Client())
+	reply, err := p.Complete(context.Background(), domain.CompletionRequest{System: "Be brief.", Prompt: "Summarize.", MaxTokens: 100})
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if reply != "The team agreed to ship." {
+		t.Errorf("got reply %q", reply)
+	}
+	if got.Model != "llama3.2" || got.MaxTokens != 100 || got.Stream {
+		t.Errorf("unexpected request %+v", got)
+	}
+	if len(got.Messages) != 2 || got.Messages[0].Role != "system" || got.Messages[1].Role != "user" || got.Messages[1].Content != "Summarize." {
+		t.Errorf("unexpected messages %+v", got.Messages)
+	}
+	if p.Model() != "llama3.2" {
+		t.Errorf("got model %q", p.Model())
+	}
+}
+
+func TestOpenAIProvider_Errors(t *testing.T) {
+	status, body := http.StatusOK, `{"choices":[]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("sent auth without an API key")
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	p := llm.NewOpenAIProvider(srv.URL, "llama3.2", "", srv.Client())
+
+	if _, err := p.Complete(context.Background(), domain.CompletionRequest{Prompt: "hi"}); !errors.Is(err, llm.ErrNoChoices) {
+		t.Errorf("empty choices: got %v, want ErrNoChoices", err)
+	}
+
+	status, body = http.StatusNotFound, `{"error":"model \"llama3.2\" not found"}`
+	_, err := p.Complete(context.Background(), domain.CompletionRequest{Prompt: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "404") || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("api error: got %v", err)
+	}
+}
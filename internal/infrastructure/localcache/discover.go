@@ -0,0 +1,68 @@
+package localcache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// cacheFileName matches the versioned files the desktop app writes:
+// cache-v2.json, cache-v3.json and whatever comes next.
+var cacheFileName = regexp.MustCompile(`^cache-v(\d+)\.json$`)
+
+// DefaultRoots returns the directory the Granola desktop app keeps its data
+// in on the given platform (runtime.GOOS):
+//   - darwin:  ~/Library/Application Support/Granola
+//   - linux:   $XDG_CONFIG_HOME/Granola, falling back to ~/.config/Granola
+//   - windows: %APPDATA%\Granola, falling back to ~/AppData/Roaming/Granola
+func DefaultRoots(homeDir, goos string, getenv func(string) string) []string {
+	switch goos {
+	case "darwin":
+		return []string{filepath.Join(homeDir, "Library", "Application Support", "Granola")}
+	case "windows":
+		appData := getenv("APPDATA")
+		if appData == "" {
+			appData = filepath.Join(homeDir, "AppData", "Roaming")
+		}
+		return []string{filepath.Join(appData, "Granola")}
+	default:
+		configHome := getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(homeDir, ".config")
+		}
+		return []string{filepath.Join(configHome, "Granola")}
+	}
+}
+
+// Locate returns the cache file to read from the first root that holds
+// one, preferring the highest cache-v<N>.json version within a root. When
+// none is found the error wraps ErrCacheFileNotFound and lists every
+// location that was checked.
+func Locate(roots []string) (string, error) {
+	checked := make([]string, 0, len(roots))
+	for _, root := range roots {
+		checked = append(checked, filepath.Join(root, "cache-v*.json"))
+
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		best, bestVersion := "", -1
+		for _, entry := range entries {
+			m := cacheFileName.FindStringSubmatch(entry.Name())
+			if m == nil || entry.IsDir() {
+				continue
+			}
+			if v, err := strconv.Atoi(m[1]); err == nil && v > bestVersion {
+				best, bestVersion = entry.Name(), v
+			}
+		}
+		if best != "" {
+			return filepath.Join(root, best), nil
+		}
+	}
+	return "", fmt.Errorf("%w; checked:\n  %s", ErrCacheFileNotFound, strings.Join(checked, "\n  "))
+}
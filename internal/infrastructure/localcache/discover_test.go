@@ -0,0 +1,69 @@
+package localcache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultRoots(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(k string) string { return env[k] }
+
+	tests := []struct {
+		goos string
+		env  map[string]string
+		want string
+	}{
+		{goos: "darwin", want: filepath.Join("/home/ana", "Library", "Application Support", "Granola")},
+		{goos: "linux", want: filepath.Join("/home/ana", ".config", "Granola")},
+		{goos: "linux", env: map[string]string{"XDG_CONFIG_HOME": "/xdg"}, want: filepath.Join("/xdg", "Granola")},
+		{goos: "windows", want: filepath.Join("/home/ana", "AppData", "Roaming", "Granola")},
+		{goos: "windows", env: map[string]string{"APPDATA": "/appdata"}, want: filepath.Join("/appdata", "Granola")},
+	}
+	for _, tt := range tests {
+		env = tt.env
+		got := DefaultRoots("/home/ana", tt.goos, getenv)
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s %v: got %q, want %q", tt.goos, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestLocate_PrefersNewestVersionInFirstRoot(t *testing.T) {
+	empty, first, second := t.TempDir(), t.TempDir(), t.TempDir()
+	for _, p := range []string{
+		filepath.Join(first, "cache-v2.json"),
+		filepath.Join(first, "cache-v10.json"),
+		filepath.Join(first, "cache-v3.json"),
+		filepath.Join(first, "cache-backup.json"),
+		filepath.Join(second, "cache-v11.json"),
+	} {
+		if err := os.WriteFile(p, []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := Locate([]string{filepath.Join(empty, "missing"), empty, first, second})
+	if err != nil {
+		t.Fatalf("locate: %v", err)
+	}
+	if want := filepath.Join(first, "cache-v10.json"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLocate_ListsCheckedPaths(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	_, err := Locate([]string{a, b})
+	if !errors.Is(err, ErrCacheFileNotFound) {
+		t.Fatalf("expected ErrCacheFileNotFound, got %v", err)
+	}
+	for _, root := range []string{a, b} {
+		if !strings.Contains(err.Error(), filepath.Join(root, "cache-v*.json")) {
+			t.Errorf("error does not list %s: %v", root, err)
+		}
+	}
+}
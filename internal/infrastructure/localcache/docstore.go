@@ -0,0 +1,66 @@
+package localcache
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DocStore persists the documents seen by the last Sync, so deletions are
+// detected across process restarts.
+type DocStore interface {
+	Load(ctx context.Context) (map[string]string, error) // doc ID → updatedAt
+	// Replace swaps the stored documents for docs in one transaction.
+	Replace(ctx context.Context, docs map[string]string) error
+}
+
+// SQLiteDocStore is a DocStore backed by the local_cache_documents table of
+// local.db, created by migration 0011_local_cache_documents.
+type SQLiteDocStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteDocStore creates a new SQLite-backed document store.
+func NewSQLiteDocStore(db *sql.DB) *SQLiteDocStore {
+	return &SQLiteDocStore{db: db}
+}
+
+func (s *SQLiteDocStore) Load(ctx context.Context) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT doc_id, updated_at FROM local_cache_documents")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	docs := make(map[string]string)
+	for rows.Next() {
+		var id, updatedAt string
+		if err := rows.Scan(&id, &updatedAt); err != nil {
+			return nil, err
+		}
+		docs[id] = updatedAt
+	}
+	return docs, rows.Err()
+}
+
+func (s *SQLiteDocStore) Replace(ctx context.Context, docs map[string]string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM local_cache_documents"); err != nil {
+		return err
+	}
+	for id, updatedAt := range docs {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO local_cache_documents (doc_id, updated_at) VALUES (?, ?)",
+			id, updatedAt,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+var _ DocStore = (*SQLiteDocStore)(nil)
@@ -7,4 +7,5 @@ import "errors"
 var (
 	ErrCacheFileNotFound = errors.New("localcache: cache file not found")
 	ErrCacheFileCorrupt  = errors.New("localcache: cache file is corrupt or unreadable")
+	ErrCacheFileChanged  = errors.New("localcache: cache file changed since it was indexed")
 )
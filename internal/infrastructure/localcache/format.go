@@ -0,0 +1,45 @@
+package localcache
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// Format identifies how a cache file lays out its data. The desktop app has
+// shipped several layouts across cache versions; all of them hold the same
+// documents, meetingsMetadata and transcripts collections, optionally
+// wrapped in a "state" object.
+type Format string
+
+const (
+	// FormatEncodedEnvelope is {"cache": "<JSON string>"}: the state is
+	// JSON-encoded a second time inside a string (cache-v3.json).
+	FormatEncodedEnvelope Format = "encoded-envelope"
+	// FormatObjectEnvelope is {"cache": {...}}: the state is embedded as a
+	// plain object.
+	FormatObjectEnvelope Format = "object-envelope"
+	// FormatPlain is a bare object holding "state" or the collections
+	// themselves at the top level.
+	FormatPlain Format = "plain"
+)
+
+// DetectFormat reports the layout of the cache file at path by reading only
+// as far as the first member that gives it away.
+func DetectFormat(path string) (Format, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrCacheFileNotFound, path)
+		}
+		return "", fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+	}
+	defer f.Close()
+
+	scanner := &envelopeScanner{src: bufio.NewReader(f)}
+	format, _, err := scanner.detect()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+	}
+	return format, nil
+}
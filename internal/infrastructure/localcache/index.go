@@ -0,0 +1,313 @@
+package localcache
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// readBufferSize is the buffer used when streaming the cache file.
+const readBufferSize = 256 << 10
+
+// span locates a value inside the decoded inner JSON. For layouts that are
+// not double-encoded, offsets are plain file offsets.
+type span struct {
+	offset int64
+	length int64
+}
+
+// Index is a lightweight view of the cache file. Documents and meeting
+// metadata are decoded eagerly because every query needs them; transcripts,
+// which make up most of a long-lived cache, are only located by byte
+// offset and decoded on demand from the file.
+//
+// An Index is immutable once built and safe for concurrent use.
+type Index struct {
+	Documents        map[string]CacheDocument
+	MeetingsMetadata map[string]CacheMeetingMeta
+
+	path        string
+	format      Format
+	stamp       fileStamp
+	transcripts map[string]span
+	checkpoints []checkpoint // ordered by inner offset; encoded envelopes only
+}
+
+// Format returns the layout the cache file was read as.
+func (idx *Index) Format() Format {
+	return idx.format
+}
+
+// TranscriptCount returns the number of transcripts in the cache.
+func (idx *Index) TranscriptCount() int {
+	return len(idx.transcripts)
+}
+
+// HasTranscript reports whether the cache holds a transcript for the document.
+func (idx *Index) HasTranscript(id string) bool {
+	_, ok := idx.transcripts[id]
+	return ok
+}
+
+// Transcript decodes the transcript for the document from the cache file.
+// It returns nil when the document has no transcript, and
+// ErrCacheFileChanged when the file was rewritten after it was indexed.
+func (idx *Index) Transcript(id string) (*CacheTranscript, error) {
+	if !idx.HasTranscript(id) {
+		return nil, nil
+	}
+	loader, err := idx.openTranscripts()
+	if err != nil {
+		return nil, err
+	}
+	defer loader.Close()
+	return loader.Load(id)
+}
+
+// transcriptLoader decodes transcripts from one open handle on the cache
+// file, so scanning many transcripts does not reopen it each time.
+type transcriptLoader struct {
+	idx *Index
+	f   *os.File
+	br  *bufio.Reader
+}
+
+// openTranscripts opens the cache file for transcript reads, failing with
+// ErrCacheFileChanged if it no longer matches the indexed version.
+func (idx *Index) openTranscripts() (*transcriptLoader, error) {
+	f, err := os.Open(idx.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrCacheFileChanged, idx.path)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+	}
+	if (fileStamp{modTime: info.ModTime(), size: info.Size()}) != idx.stamp {
+		f.Close()
+		return nil, fmt.Errorf("%w: %s", ErrCacheFileChanged, idx.path)
+	}
+	return &transcriptLoader{idx: idx, f: f, br: bufio.NewReaderSize(f, readBufferSize)}, nil
+}
+
+// Load decodes one transcript, or returns nil if the document has none.
+func (l *transcriptLoader) Load(id string) (*CacheTranscript, error) {
+	s, ok := l.idx.transcripts[id]
+	if !ok {
+		return nil, nil
+	}
+
+	var src io.Reader
+	if l.idx.format == FormatEncodedEnvelope {
+		sr, err := l.seekEncoded(id, s)
+		if err != nil {
+			return nil, err
+		}
+		src = sr
+	} else {
+		if _, err := l.f.Seek(s.offset, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+		}
+		l.br.Reset(l.f)
+		src = l.br
+	}
+
+	data := make([]byte, s.length)
+	if _, err := io.ReadFull(src, data); err != nil {
+		return nil, fmt.Errorf("%w: transcript %s: %v", ErrCacheFileCorrupt, id, err)
+	}
+
+	var t CacheTranscript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("%w: transcript %s: %v", ErrCacheFileCorrupt, id, err)
+	}
+	return &t, nil
+}
+
+// seekEncoded positions a string reader on the first byte of s, resuming
+// decoding at the last checkpoint before it.
+func (l *transcriptLoader) seekEncoded(id string, s span) (*stringReader, error) {
+	cps := l.idx.checkpoints
+	i := sort.Search(len(cps), func(i int) bool { return cps[i].inner > s.offset }) - 1
+	if i < 0 {
+		return nil, fmt.Errorf("%w: no checkpoint for transcript %s", ErrCacheFileCorrupt, id)
+	}
+	cp := cps[i]
+	if _, err := l.f.Seek(cp.outer, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+	}
+	l.br.Reset(l.f)
+
+	sr := newStringReader(l.br, cp.outer, cp.inner)
+	if _, err := io.CopyN(io.Discard, sr, s.offset-cp.inner); err != nil {
+		return nil, fmt.Errorf("%w: transcript %s: %v", ErrCacheFileCorrupt, id, err)
+	}
+	return sr, nil
+}
+
+// Close releases the file handle.
+func (l *transcriptLoader) Close() error {
+	return l.f.Close()
+}
+
+// buildIndex streams the cache file once. Double-encoded state is decoded
+// straight out of the envelope string; other layouts are decoded in place.
+func buildIndex(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrCacheFileNotFound, path)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+	}
+
+	br := bufio.NewReaderSize(f, readBufferSize)
+	env := &envelopeScanner{src: br}
+	format, start, err := env.detect()
+	if errors.Is(err, errNoCacheData) {
+		return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: outer JSON: %v", ErrCacheFileCorrupt, err)
+	}
+
+	idx := &Index{
+		path:        path,
+		format:      format,
+		stamp:       fileStamp{modTime: info.ModTime(), size: info.Size()},
+		transcripts: make(map[string]span),
+	}
+
+	if format != FormatEncodedEnvelope {
+		// Decode the whole file in place; offsets are file offsets.
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+		}
+		dec := json.NewDecoder(bufio.NewReaderSize(f, readBufferSize))
+		if err := idx.decodeLayout(dec); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
+		}
+		return idx, nil
+	}
+
+	sr := newStringReader(br, start, 0)
+	sr.checkpoints = &idx.checkpoints
+
+	dec := json.NewDecoder(sr)
+	if err := idx.decodeLayout(dec); err != nil {
+		if errors.Is(err, io.EOF) && sr.out == 0 {
+			return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, errNoCacheData)
+		}
+		return nil, fmt.Errorf("%w: inner JSON: %v", ErrCacheFileCorrupt, err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: inner JSON: unexpected data after state", ErrCacheFileCorrupt)
+	}
+	return idx, nil
+}
+
+// decodeLayout walks an object holding the documents, meetingsMetadata and
+// transcripts collections, descending into "state" and "cache" wrappers
+// and skipping members it does not know.
+func (idx *Index) decodeLayout(dec *json.Decoder) error {
+	return decodeObject(dec, func(key string) error {
+		switch key {
+		case "state", "cache":
+			return idx.decodeLayout(dec)
+		case "documents":
+			return dec.Decode(&idx.Documents)
+		case "meetingsMetadata":
+			return dec.Decode(&idx.MeetingsMetadata)
+		case "transcripts":
+			return idx.decodeTranscripts(dec)
+		default:
+			return skipValue(dec)
+		}
+	})
+}
+
+// decodeTranscripts records where each transcript sits instead of
+// keeping it. Only one transcript is buffered at a time.
+func (idx *Index) decodeTranscripts(dec *json.Decoder) error {
+	var raw json.RawMessage
+	return decodeObject(dec, func(id string) error {
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		end := dec.InputOffset()
+		idx.transcripts[id] = span{offset: end - int64(len(raw)), length: int64(len(raw))}
+		return nil
+	})
+}
+
+// decodeObject reads a JSON object (or null) member by member, calling fn
+// with each key while the decoder is positioned at its value.
+func decodeObject(dec *json.Decoder, fn func(key string) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected object, found %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := fn(tok.(string)); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // closing '}'
+	return err
+}
+
+func skipValue(dec *json.Decoder) error {
+	var discard json.RawMessage
+	return dec.Decode(&discard)
+}
+
+// materialise decodes every transcript, producing the full CacheState.
+func (idx *Index) materialise() (*CacheState, error) {
+	state := &CacheState{State: CacheInner{
+		Documents:        idx.Documents,
+		MeetingsMetadata: idx.MeetingsMetadata,
+	}}
+	if len(idx.transcripts) == 0 {
+		return state, nil
+	}
+
+	loader, err := idx.openTranscripts()
+	if err != nil {
+		return nil, err
+	}
+	defer loader.Close()
+
+	state.State.Transcripts = make(map[string]CacheTranscript, len(idx.transcripts))
+	for id := range idx.transcripts {
+		t, err := loader.Load(id)
+		if err != nil {
+			return nil, err
+		}
+		state.State.Transcripts[id] = *t
+	}
+	return state, nil
+}
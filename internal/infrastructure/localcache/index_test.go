@@ -0,0 +1,334 @@
+package localcache
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var benchCacheMB = flag.Int("cache-mb", 256, "approximate size of the synthetic cache used by BenchmarkLargeCache")
+
+// writeCacheFile double-encodes inner the way the desktop app does.
+func writeCacheFile(t testing.TB, path, inner string) {
+	t.Helper()
+	outer, _ := json.Marshal(CacheFileEnvelope{Cache: inner})
+	if err := os.WriteFile(path, outer, 0o644); err != nil {
+		t.Fatalf("write cache: %v", err)
+	}
+}
+
+// decodeWhole is the reference decoder: both JSON layers fully in memory.
+func decodeWhole(t testing.TB, path string) *CacheState {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env CacheFileEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+	var state CacheState
+	if err := json.Unmarshal([]byte(env.Cache), &state); err != nil {
+		t.Fatal(err)
+	}
+	return &state
+}
+
+func syntheticInner(meetings, segmentsPerMeeting int) string {
+	var b strings.Builder
+	b.WriteString(`{"state":{"documents":{`)
+	for i := range meetings {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `"m-%d":{"id":"m-%d","title":"Meeting \"%d\" <café>","created_at":"2025-01-15T10:00:00Z","updated_at":"2025-01-15T10:30:00Z"}`, i, i, i)
+	}
+	b.WriteString(`},"meetingsMetadata":{"m-0":{"attendees":[{"name":"Ana","email":"ana@example.com"}]}},"transcripts":{`)
+	for i := range meetings {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `"m-%d":[`, i)
+		for j := range segmentsPerMeeting {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(&b, `{"speaker":"Speaker %d","text":"Line %d of meeting %d — naïve \"quote\"\\n\\ttab 🎉   done","source":"microphone","timestamp":"2025-01-15T10:00:%02dZ"}`, j%3, j, i, j%60)
+		}
+		b.WriteString(`]`)
+	}
+	b.WriteString(`}},"version":3}`)
+	return b.String()
+}
+
+func TestIndex_MatchesFullDecode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache-v3.json")
+	writeCacheFile(t, path, syntheticInner(40, 60))
+
+	idx, err := NewReader(path).Index()
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	if len(idx.checkpoints) < 3 {
+		t.Fatalf("expected the cache to span several checkpoints, got %d", len(idx.checkpoints))
+	}
+
+	want := decodeWhole(t, path)
+	if !reflect.DeepEqual(idx.Documents, want.State.Documents) {
+		t.Error("documents differ from a full decode")
+	}
+	if !reflect.DeepEqual(idx.MeetingsMetadata, want.State.MeetingsMetadata) {
+		t.Error("metadata differs from a full decode")
+	}
+	if idx.TranscriptCount() != len(want.State.Transcripts) {
+		t.Fatalf("got %d transcripts, want %d", idx.TranscriptCount(), len(want.State.Transcripts))
+	}
+	for id, wantT := range want.State.Transcripts {
+		got, err := idx.Transcript(id)
+		if err != nil {
+			t.Fatalf("transcript %s: %v", id, err)
+		}
+		if !reflect.DeepEqual(*got, wantT) {
+			t.Fatalf("transcript %s differs from a full decode", id)
+		}
+	}
+}
+
+func TestIndex_TranscriptFormats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache-v3.json")
+	writeCacheFile(t, path, `{"state":{"documents":{},"transcripts":{
+		"array":[{"speaker":"A","text":"from an array"}],
+		"object":{"segments":[{"speaker":"B","text":"from an object"}]},
+		"empty":null
+	}}}`)
+
+	idx, err := NewReader(path).Index()
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	for id, text := range map[string]string{"array": "from an array", "object": "from an object"} {
+		got, err := idx.Transcript(id)
+		if err != nil {
+			t.Fatalf("transcript %s: %v", id, err)
+		}
+		if len(got.Segments) != 1 || got.Segments[0].Text != text {
+			t.Errorf("transcript %s: got %+v", id, got.Segments)
+		}
+	}
+	if got, err := idx.Transcript("empty"); err != nil || len(got.Segments) != 0 {
+		t.Errorf("null transcript: got %+v, %v", got, err)
+	}
+	if got, err := idx.Transcript("missing"); err != nil || got != nil {
+		t.Errorf("missing transcript: got %+v, %v", got, err)
+	}
+}
+
+func TestIndex_SkipsOtherEnvelopeMembers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache-v3.json")
+	content := `{ "version": 3, "meta": {"a": [1, "x\"}y", {"b": null}]}, "flag": true,
+		"cache" : "{\"state\":{\"documents\":{\"m-1\":{\"id\":\"m-1\",\"title\":\"Café 🎉 \ud800\"}}}}" }`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := NewReader(path).Index()
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	want := decodeWhole(t, path)
+	if got := idx.Documents["m-1"].Title; got != want.State.Documents["m-1"].Title || got != "Café 🎉 �" {
+		t.Errorf("got title %q, want %q", got, want.State.Documents["m-1"].Title)
+	}
+}
+
+func TestIndex_Layouts(t *testing.T) {
+	state := `{"documents":{"m-1":{"id":"m-1","title":"Planning"}},` +
+		`"meetingsMetadata":{"m-1":{"attendees":[{"name":"Ana"}]}},` +
+		`"transcripts":{"m-1":[{"speaker":"Ana","text":"Let's plan"}]}}`
+	encoded, _ := json.Marshal(`{"state":` + state + `}`)
+	encodedBare, _ := json.Marshal(state)
+
+	tests := []struct {
+		name    string
+		content string
+		want    Format
+	}{
+		{"v3 encoded envelope", `{"cache":` + string(encoded) + `}`, FormatEncodedEnvelope},
+		{"encoded envelope without state", `{"version":4,"cache":` + string(encodedBare) + `}`, FormatEncodedEnvelope},
+		{"object envelope", `{"version":2,"cache":{"state":` + state + `}}`, FormatObjectEnvelope},
+		{"plain state", `{"state":` + state + `,"version":2}`, FormatPlain},
+		{"plain collections", state, FormatPlain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cache.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, err := DetectFormat(path); err != nil || got != tt.want {
+				t.Errorf("DetectFormat = %q, %v; want %q", got, err, tt.want)
+			}
+			idx, err := NewReader(path).Index()
+			if err != nil {
+				t.Fatalf("index: %v", err)
+			}
+			if idx.Format() != tt.want {
+				t.Errorf("index format = %q, want %q", idx.Format(), tt.want)
+			}
+			if idx.Documents["m-1"].Title != "Planning" || len(idx.MeetingsMetadata["m-1"].Attendees) != 1 {
+				t.Errorf("unexpected documents %+v / metadata %+v", idx.Documents, idx.MeetingsMetadata)
+			}
+			transcript, err := idx.Transcript("m-1")
+			if err != nil {
+				t.Fatalf("transcript: %v", err)
+			}
+			if len(transcript.Segments) != 1 || transcript.Segments[0].Text != "Let's plan" {
+				t.Errorf("unexpected transcript %+v", transcript)
+			}
+		})
+	}
+}
+
+func TestDetectFormat_Unrecognised(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	if err := os.WriteFile(path, []byte(`{"version":3,"settings":{}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DetectFormat(path); !errors.Is(err, ErrCacheFileCorrupt) {
+		t.Errorf("expected ErrCacheFileCorrupt, got %v", err)
+	}
+}
+
+func TestIndex_DetectsRewrittenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache-v3.json")
+	writeCacheFile(t, path, syntheticInner(2, 2))
+	idx, err := NewReader(path).Index()
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+
+	writeCacheFile(t, path, syntheticInner(3, 2))
+	later := time.Now().Add(time.Minute)
+	_ = os.Chtimes(path, later, later)
+
+	if _, err := idx.Transcript("m-0"); !errors.Is(err, ErrCacheFileChanged) {
+		t.Errorf("expected ErrCacheFileChanged, got %v", err)
+	}
+}
+
+func TestRepository_ReindexesRewrittenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache-v3.json")
+	writeCacheFile(t, path, syntheticInner(2, 2))
+	repo := NewRepository(NewReader(path))
+	if _, err := repo.GetTranscript(t.Context(), "m-1"); err != nil {
+		t.Fatalf("get transcript: %v", err)
+	}
+
+	writeCacheFile(t, path, syntheticInner(2, 5))
+	later := time.Now().Add(time.Minute)
+	_ = os.Chtimes(path, later, later)
+
+	transcript, err := repo.GetTranscript(t.Context(), "m-1")
+	if err != nil {
+		t.Fatalf("get transcript after rewrite: %v", err)
+	}
+	if got := len(transcript.Utterances()); got != 5 {
+		t.Errorf("got %d utterances, want the rewritten 5", got)
+	}
+}
+
+// writeLargeCache streams a synthetic cache of roughly mb megabytes.
+func writeLargeCache(b *testing.B, path string, mb int) int {
+	b.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+
+	// Each meeting's transcript is written as escaped inner JSON straight
+	// into the outer string, so the whole cache never sits in memory.
+	const segments = 400
+	chunk := syntheticInner(1, segments)
+	meetings := max(mb<<20/len(chunk)/2, 1) // escaping roughly doubles the size
+
+	escape := func(s string) {
+		q, _ := json.Marshal(s)
+		_, _ = w.Write(q[1 : len(q)-1])
+	}
+	_, _ = io.WriteString(w, `{"cache":"`)
+	escape(`{"state":{"documents":{`)
+	for i := range meetings {
+		if i > 0 {
+			escape(",")
+		}
+		escape(fmt.Sprintf(`"m-%d":{"id":"m-%d","title":"Meeting %d","created_at":"2025-01-15T10:00:00Z","updated_at":"2025-01-15T10:30:00Z"}`, i, i, i))
+	}
+	escape(`},"transcripts":{`)
+	transcript := chunk[strings.Index(chunk, `"transcripts":{"m-0":`)+len(`"transcripts":{"m-0":`) : len(chunk)-len(`}},"version":3}`)]
+	for i := range meetings {
+		if i > 0 {
+			escape(",")
+		}
+		escape(fmt.Sprintf(`"m-%d":%s`, i, transcript))
+	}
+	escape(`}}}`)
+	_, _ = io.WriteString(w, `"}`)
+	if err := w.Flush(); err != nil {
+		b.Fatal(err)
+	}
+	return meetings
+}
+
+// BenchmarkLargeCache compares indexing a large cache with decoding it
+// fully. Run with -bench LargeCache -benchmem [-cache-mb N].
+func BenchmarkLargeCache(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "cache-v3.json")
+	meetings := writeLargeCache(b, path, *benchCacheMB)
+	info, _ := os.Stat(path)
+	b.Logf("synthetic cache: %d MB, %d meetings", info.Size()>>20, meetings)
+
+	b.Run("FullDecode", func(b *testing.B) {
+		b.SetBytes(info.Size())
+		b.ReportAllocs()
+		for b.Loop() {
+			decodeWhole(b, path)
+		}
+	})
+
+	b.Run("Index", func(b *testing.B) {
+		b.SetBytes(info.Size())
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := NewReader(path).Index(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Transcript", func(b *testing.B) {
+		idx, err := NewReader(path).Index()
+		if err != nil {
+			b.Fatal(err)
+		}
+		id := fmt.Sprintf("m-%d", meetings-1)
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := idx.Transcript(id); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -53,14 +53,14 @@ func mapDocumentToDomain(doc CacheDocument, meta *CacheMeetingMeta) (*domain.Mee
 	// Clear creation event — this is reconstitution, not creation
 	mtg.ClearDomainEvents()
 
-	// Map ProseMirror notes to summary
+	// Map the user's ProseMirror notes to a Markdown summary
 	if len(doc.NotesProsemirror) > 0 {
-		summaryContent := prosemirrorToPlainText(doc.NotesProsemirror)
+		summaryContent := prosemirrorToMarkdown(doc.NotesProsemirror)
 		if summaryContent != "" {
 			mtg.AttachSummary(domain.NewSummary(
 				domain.MeetingID(doc.ID),
 				summaryContent,
-				domain.SummaryAuto,
+				domain.SummaryEdited,
 			))
 			mtg.ClearDomainEvents()
 		}
@@ -0,0 +1,301 @@
+package localcache
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// prosemirrorToMarkdown renders a ProseMirror JSON document as Markdown,
+// keeping the structure of the user's notes: heading levels, bold, italic,
+// strikethrough, inline code and link marks, nested bullet, ordered and task
+// lists, blockquotes, code blocks, rules and tables (as GFM pipe tables).
+// Unknown node types are rendered through their children.
+func prosemirrorToMarkdown(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var doc prosemirrorNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(markdownBlocks(doc.Content, "\n\n"))
+}
+
+// markdownBlocks renders block nodes joined by sep, dropping empty blocks.
+func markdownBlocks(nodes []prosemirrorNode, sep string) string {
+	var blocks []string
+	for _, node := range nodes {
+		if block := markdownBlock(node); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return strings.Join(blocks, sep)
+}
+
+func markdownBlock(node prosemirrorNode) string {
+	switch node.Type {
+	case "paragraph":
+		return markdownInline(node.Content)
+
+	case "heading":
+		level := min(max(node.intAttr("level", 1), 1), 6)
+		text := markdownInline(node.Content)
+		if text == "" {
+			return ""
+		}
+		return strings.Repeat("#", level) + " " + text
+
+	case "blockquote":
+		return prefixLines(markdownBlocks(node.Content, "\n\n"), "> ", ">")
+
+	case "codeBlock":
+		code := strings.TrimSuffix(plainInline(node.Content), "\n")
+		fence := codeFence(code, "```")
+		return fence + node.stringAttr("language") + "\n" + code + "\n" + fence
+
+	case "horizontalRule":
+		return "---"
+
+	case "bulletList", "taskList":
+		return markdownList(node, func(int) string { return "- " })
+
+	case "orderedList":
+		start := node.intAttr("start", node.intAttr("order", 1))
+		return markdownList(node, func(i int) string { return fmt.Sprintf("%d. ", start+i) })
+
+	case "table":
+		return markdownTable(node)
+
+	case "image":
+		return markdownInline([]prosemirrorNode{node})
+
+	case "text", "hardBreak":
+		return markdownInline([]prosemirrorNode{node})
+
+	default:
+		if len(node.Content) > 0 && isInline(node.Content[0]) {
+			return markdownInline(node.Content)
+		}
+		return markdownBlocks(node.Content, "\n\n")
+	}
+}
+
+// markdownList renders list items with the given marker, indenting each
+// item's continuation lines (including nested lists) under its text.
+func markdownList(list prosemirrorNode, marker func(i int) string) string {
+	lines := make([]string, 0, len(list.Content))
+	for i, item := range list.Content {
+		m := marker(i)
+		if checked, ok := item.boolAttr("checked"); ok {
+			box := "[ ] "
+			if checked {
+				box = "[x] "
+			}
+			m += box
+		}
+		body := markdownBlocks(item.Content, "\n")
+		first, rest, nested := strings.Cut(body, "\n")
+		line := strings.TrimRight(m+first, " ")
+		if nested {
+			line += "\n" + prefixLines(rest, strings.Repeat(" ", len(marker(i))), "")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownTable renders a GFM pipe table. The first row is the header, as
+// GFM requires one.
+func markdownTable(table prosemirrorNode) string {
+	var rows [][]string
+	width := 0
+	for _, row := range table.Content {
+		var cells []string
+		for _, cell := range row.Content {
+			text := markdownBlocks(cell.Content, "<br>")
+			text = strings.ReplaceAll(text, "\\\n", "<br>")
+			text = strings.ReplaceAll(text, "\n", "<br>")
+			cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+		}
+		width = max(width, len(cells))
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 || width == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for i := range width {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			b.WriteString(" " + cell + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(rows[0])
+	b.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// markdownInline renders inline nodes, applying marks to text.
+func markdownInline(nodes []prosemirrorNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(markText(node))
+		case "hardBreak":
+			b.WriteString("\\\n")
+		case "image":
+			fmt.Fprintf(&b, "![%s](%s)", escapeMarkdown(node.stringAttr("alt")), linkDestination(node.stringAttr("src")))
+		default:
+			if label := node.stringAttr("label"); label != "" && len(node.Content) == 0 {
+				b.WriteString(escapeMarkdown(label)) // mentions and similar atoms
+				continue
+			}
+			b.WriteString(markdownInline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+// markText wraps a text node in the Markdown for its marks. Whitespace at
+// the edges is kept outside the delimiters, where Markdown requires it.
+func markText(node prosemirrorNode) string {
+	text := node.Text
+	if text == "" {
+		return ""
+	}
+	inner := strings.TrimSpace(text)
+	if inner == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, inner)]
+	trail := text[len(lead)+len(inner):]
+
+	var code, bold, italic, strike bool
+	href := ""
+	for _, mark := range node.Marks {
+		switch mark.Type {
+		case "code":
+			code = true
+		case "bold", "strong":
+			bold = true
+		case "italic", "em":
+			italic = true
+		case "strike", "strikethrough":
+			strike = true
+		case "link":
+			href = mark.stringAttr("href")
+		}
+	}
+
+	if code {
+		fence := codeFence(inner, "`")
+		pad := ""
+		if strings.HasPrefix(inner, "`") || strings.HasSuffix(inner, "`") {
+			pad = " "
+		}
+		inner = fence + pad + inner + pad + fence
+	} else {
+		inner = escapeMarkdown(inner)
+	}
+	if strike {
+		inner = "~~" + inner + "~~"
+	}
+	if italic {
+		inner = "_" + inner + "_"
+	}
+	if bold {
+		inner = "**" + inner + "**"
+	}
+	if href != "" {
+		inner = "[" + inner + "](" + linkDestination(href) + ")"
+	}
+	return lead + inner + trail
+}
+
+// plainInline concatenates the raw text of inline nodes, for code blocks.
+func plainInline(nodes []prosemirrorNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		if node.Type == "hardBreak" {
+			b.WriteByte('\n')
+			continue
+		}
+		b.WriteString(node.Text)
+		b.WriteString(plainInline(node.Content))
+	}
+	return b.String()
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+)
+
+// Block markers that would turn the start of a line into a heading,
+// blockquote, list item or ordered list item.
+var (
+	lineStartMarker  = regexp.MustCompile(`(?m)^([ \t]*)([#>+-])`)
+	lineStartOrdinal = regexp.MustCompile(`(?m)^([ \t]*\d+)([.)])`)
+)
+
+// escapeMarkdown escapes characters that would otherwise start emphasis,
+// code spans or links, and block markers at the start of a line.
+func escapeMarkdown(s string) string {
+	s = markdownEscaper.Replace(s)
+	s = lineStartMarker.ReplaceAllString(s, `$1\$2`)
+	return lineStartOrdinal.ReplaceAllString(s, `$1\$2`)
+}
+
+var destinationEscaper = strings.NewReplacer(`\`, `\\`, `<`, `\<`, `>`, `\>`)
+
+// linkDestination renders a URL as a link destination, wrapping it in angle
+// brackets when spaces, parentheses or brackets would otherwise end it early.
+func linkDestination(url string) string {
+	if !strings.ContainsAny(url, " ()<>") {
+		return url
+	}
+	return "<" + destinationEscaper.Replace(url) + ">"
+}
+
+// codeFence returns a run of fence characters longer than any run of the
+// same character inside s.
+func codeFence(s, unit string) string {
+	fence := unit
+	for strings.Contains(s, fence) {
+		fence += unit[:1]
+	}
+	return fence
+}
+
+// prefixLines prefixes every line of s, using emptyPrefix for blank lines.
+func prefixLines(s, prefix, emptyPrefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+			continue
+		}
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isInline(node prosemirrorNode) bool {
+	switch node.Type {
+	case "text", "hardBreak", "image", "mention":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package localcache
+
+import (
+	"encoding/json"
+	"testing"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+func TestProsemirrorToMarkdown(t *testing.T) {
+	p := func(inline string) string { return `{"type":"paragraph","content":[` + inline + `]}` }
+	txt := func(s string) string { return `{"type":"text","text":"` + s + `"}` }
+	item := func(blocks ...string) string {
+		c := ""
+		for i, b := range blocks {
+			if i > 0 {
+				c += ","
+			}
+			c += b
+		}
+		return `{"type":"listItem","content":[` + c + `]}`
+	}
+	doc := func(blocks string) string { return `{"type":"doc","content":[` + blocks + `]}` }
+
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "heading levels",
+			raw:  doc(`{"type":"heading","attrs":{"level":2},"content":[` + txt("Decisions") + `]},` + p(txt("Ship it"))),
+			want: "## Decisions\n\nShip it",
+		},
+		{
+			name: "marks",
+			raw: doc(p(`{"type":"text","text":"bold ","marks":[{"type":"bold"}]},` +
+				`{"type":"text","text":"italic","marks":[{"type":"italic"}]},` + txt(" ") + `,` +
+				`{"type":"text","text":"gone","marks":[{"type":"strike"}]},` + txt(" ") + `,` +
+				`{"type":"text","text":"go test","marks":[{"type":"code"}]},` + txt(" ") + `,` +
+				`{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}},{"type":"bold"}]}`)),
+			want: "**bold** _italic_ ~~gone~~ `go test` [**docs**](https://example.com)",
+		},
+		{
+			name: "escapes literal markdown characters",
+			raw:  doc(p(txt(`snake_case *not bold* [x]`))),
+			want: `snake\_case \*not bold\* \[x\]`,
+		},
+		{
+			name: "escapes block markers at the start of a line",
+			raw: doc(p(txt("# not a heading")) + `,` + p(txt("> not a quote")) + `,` +
+				p(txt("- not a list")) + `,` + p(txt("1. not ordered")) + `,` + p(txt("a - b # c"))),
+			want: "\\# not a heading\n\n\\> not a quote\n\n\\- not a list\n\n1\\. not ordered\n\na - b # c",
+		},
+		{
+			name: "wraps link destinations that would break the link",
+			raw: doc(p(`{"type":"text","text":"wiki","marks":[{"type":"link","attrs":{"href":"https://en.wikipedia.org/wiki/Go_(language)"}}]},` + txt(" ") + `,` +
+				`{"type":"text","text":"doc","marks":[{"type":"link","attrs":{"href":"file:///My Notes/plan.md"}}]}`)),
+			want: "[wiki](<https://en.wikipedia.org/wiki/Go_(language)>) [doc](<file:///My Notes/plan.md>)",
+		},
+		{
+			name: "nested bullet and ordered lists",
+			raw: doc(`{"type":"bulletList","content":[` +
+				item(p(txt("Outer")), `{"type":"orderedList","attrs":{"start":3},"content":[`+item(p(txt("Third")))+`,`+item(p(txt("Fourth")))+`]}`) + `,` +
+				item(p(txt("Next"))) + `]}`),
+			want: "- Outer\n  3. Third\n  4. Fourth\n- Next",
+		},
+		{
+			name: "task list",
+			raw: doc(`{"type":"taskList","content":[` +
+				`{"type":"taskItem","attrs":{"checked":true},"content":[` + p(txt("Draft plan")) + `]},` +
+				`{"type":"taskItem","attrs":{"checked":false},"content":[` + p(txt("Send invite")) + `]}]}`),
+			want: "- [x] Draft plan\n- [ ] Send invite",
+		},
+		{
+			name: "blockquote, code block and rule",
+			raw: doc(`{"type":"blockquote","content":[` + p(txt("Quoted")) + `,` + p(txt("Again")) + `]},` +
+				`{"type":"codeBlock","attrs":{"language":"go"},"content":[` + txt(`fmt.Println(\"hi\")`) + `]},` +
+				`{"type":"horizontalRule"}`),
+			want: "> Quoted\n>\n> Again\n\n```go\nfmt.Println(\"hi\")\n```\n\n---",
+		},
+		{
+			name: "table",
+			raw: doc(`{"type":"table","content":[` +
+				`{"type":"tableRow","content":[{"type":"tableHeader","content":[` + p(txt("Owner")) + `]},{"type":"tableHeader","content":[` + p(txt("Task")) + `]}]},` +
+				`{"type":"tableRow","content":[{"type":"tableCell","content":[` + p(txt("Ana")) + `]},{"type":"tableCell","content":[` + p(txt("a | b")) + `]}]}]}`),
+			want: "| Owner | Task |\n| --- | --- |\n| Ana | a \\| b |",
+		},
+		{
+			name: "hard break",
+			raw:  doc(p(txt("Line 1") + `,{"type":"hardBreak"},` + txt("Line 2"))),
+			want: "Line 1\\\nLine 2",
+		},
+		{
+			name: "empty and invalid documents",
+			raw:  `{invalid`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := prosemirrorToMarkdown(json.RawMessage(tt.raw))
+			if got != tt.want {
+				t.Errorf("prosemirrorToMarkdown() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapDocumentToDomain_MarkdownSummary(t *testing.T) {
+	doc := CacheDocument{
+		ID:        "m-1",
+		Title:     "Planning",
+		CreatedAt: "2025-01-15T10:00:00Z",
+		NotesProsemirror: json.RawMessage(`{"type":"doc","content":[
+			{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Notes"}]},
+			{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"Agree scope","marks":[{"type":"bold"}]}]}]}]}
+		]}`),
+	}
+
+	mtg, err := mapDocumentToDomain(doc, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary := mtg.Summary()
+	if summary == nil {
+		t.Fatal("expected summary to be set")
+	}
+	if summary.Kind() != domain.SummaryEdited {
+		t.Errorf("got summary kind %q, want %q", summary.Kind(), domain.SummaryEdited)
+	}
+	if want := "# Notes\n\n- **Agree scope**"; summary.Content() != want {
+		t.Errorf("got summary %q, want %q", summary.Content(), want)
+	}
+}
@@ -10,9 +10,48 @@ type prosemirrorNode struct {
 	Type    string            `json:"type"`
 	Content []prosemirrorNode `json:"content,omitempty"`
 	Text    string            `json:"text,omitempty"`
+	Marks   []prosemirrorMark `json:"marks,omitempty"`
 	Attrs   json.RawMessage   `json:"attrs,omitempty"`
 }
 
+// prosemirrorMark is an inline mark (bold, link, ...) applied to a text node.
+type prosemirrorMark struct {
+	Type  string          `json:"type"`
+	Attrs json.RawMessage `json:"attrs,omitempty"`
+}
+
+func (n prosemirrorNode) stringAttr(key string) string {
+	s, _ := decodeAttrs(n.Attrs)[key].(string)
+	return s
+}
+
+func (n prosemirrorNode) intAttr(key string, def int) int {
+	if f, ok := decodeAttrs(n.Attrs)[key].(float64); ok {
+		return int(f)
+	}
+	return def
+}
+
+// boolAttr returns the attribute and whether it is set to a boolean.
+func (n prosemirrorNode) boolAttr(key string) (bool, bool) {
+	b, ok := decodeAttrs(n.Attrs)[key].(bool)
+	return b, ok
+}
+
+func (m prosemirrorMark) stringAttr(key string) string {
+	s, _ := decodeAttrs(m.Attrs)[key].(string)
+	return s
+}
+
+// decodeAttrs decodes an attrs object, treating anything else as empty.
+func decodeAttrs(raw json.RawMessage) map[string]any {
+	var attrs map[string]any
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &attrs)
+	}
+	return attrs
+}
+
 // prosemirrorToPlainText converts a ProseMirror JSON document to plain text.
 // It recursively walks the node tree, extracting text content.
 // Unknown node types are silently recursed into.
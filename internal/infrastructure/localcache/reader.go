@@ -1,11 +1,5 @@
 package localcache
 
-import (
-	"encoding/json"
-	"fmt"
-	"os"
-)
-
 // Reader handles file I/O and double-JSON decoding for the Granola cache file.
 type Reader struct {
 	path string
@@ -21,32 +15,22 @@ func (r *Reader) Path() string {
 	return r.path
 }
 
-// Read loads and decodes the cache file.
+// Index streams the cache file and returns an index of its documents,
+// metadata and transcript locations.
 // The file uses double-JSON encoding: the outer JSON has a "cache" field
 // containing a JSON-encoded string that must be decoded a second time.
+// The string is unescaped as it is read, so the inner JSON is never held
+// in memory, and transcripts are left on disk until requested.
+func (r *Reader) Index() (*Index, error) {
+	return buildIndex(r.path)
+}
+
+// Read loads and decodes the whole cache file, including every transcript.
+// Prefer Index for large caches.
 func (r *Reader) Read() (*CacheState, error) {
-	data, err := os.ReadFile(r.path)
+	idx, err := r.Index()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("%w: %s", ErrCacheFileNotFound, r.path)
-		}
-		return nil, fmt.Errorf("%w: %v", ErrCacheFileCorrupt, err)
-	}
-
-	// First decode: extract the "cache" JSON string
-	var envelope CacheFileEnvelope
-	if err := json.Unmarshal(data, &envelope); err != nil {
-		return nil, fmt.Errorf("%w: outer JSON: %v", ErrCacheFileCorrupt, err)
+		return nil, err
 	}
-	if envelope.Cache == "" {
-		return nil, fmt.Errorf("%w: empty cache field", ErrCacheFileCorrupt)
-	}
-
-	// Second decode: parse the inner JSON string
-	var state CacheState
-	if err := json.Unmarshal([]byte(envelope.Cache), &state); err != nil {
-		return nil, fmt.Errorf("%w: inner JSON: %v", ErrCacheFileCorrupt, err)
-	}
-
-	return &state, nil
+	return idx.materialise()
 }
@@ -2,6 +2,8 @@ package localcache
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"sort"
 	"strings"
@@ -12,12 +14,14 @@ import (
 )
 
 // Repository implements domain.Repository by reading from the Granola desktop
-// app's local cache file. All filtering is done in-memory.
+// app's local cache file. Documents are filtered in-memory; transcripts are
+// read from the file only when a query needs them.
 type Repository struct {
 	reader     *Reader
 	mu         sync.RWMutex
-	state      *CacheState
+	index      *Index
 	prevDocIDs map[string]string // doc ID → updatedAt for Sync change detection
+	docs       DocStore
 }
 
 // NewRepository creates a Repository backed by the given Reader.
@@ -28,52 +32,109 @@ func NewRepository(reader *Reader) *Repository {
 	}
 }
 
-// ensureLoaded lazily loads (or reloads) the cache state.
+// SetDocStore persists the documents seen by Sync. Without a store they are
+// kept in memory, so the first Sync of a process reports no deletions.
+func (r *Repository) SetDocStore(store DocStore) {
+	r.docs = store
+}
+
+// ensureLoaded lazily loads (or reloads) the cache index.
 func (r *Repository) ensureLoaded() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.state != nil {
+	if r.index != nil {
 		return nil
 	}
 	return r.loadLocked()
 }
 
-// loadLocked reads the cache file into memory. Caller must hold r.mu write lock.
+// loadLocked indexes the cache file. Caller must hold r.mu write lock.
 func (r *Repository) loadLocked() error {
-	state, err := r.reader.Read()
+	index, err := r.reader.Index()
 	if err != nil {
 		return err
 	}
-	r.state = state
+	r.index = index
 	return nil
 }
 
-func (r *Repository) FindByID(ctx context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+// currentIndex returns the loaded index. The index is immutable, so callers
+// may keep using it after the lock is released.
+func (r *Repository) currentIndex() (*Index, error) {
 	if err := r.ensureLoaded(); err != nil {
-		return nil, r.mapError(err)
+		return nil, err
 	}
-
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	return r.index, nil
+}
+
+// refreshIndex re-indexes the file after it changed underneath stale.
+// Callers racing on the same stale index reload it only once.
+func (r *Repository) refreshIndex(stale *Index) (*Index, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.index != stale {
+		return r.index, nil
+	}
+	if err := r.loadLocked(); err != nil {
+		return nil, err
+	}
+	return r.index, nil
+}
+
+// openTranscripts opens a transcript loader on the current index,
+// re-indexing once if the desktop app rewrote the file since it was
+// indexed. It returns the index the loader belongs to.
+func (r *Repository) openTranscripts() (*Index, *transcriptLoader, error) {
+	index, err := r.currentIndex()
+	if err != nil {
+		return nil, nil, err
+	}
+	loader, err := index.openTranscripts()
+	if errors.Is(err, ErrCacheFileChanged) {
+		if index, err = r.refreshIndex(index); err != nil {
+			return nil, nil, err
+		}
+		loader, err = index.openTranscripts()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return index, loader, nil
+}
+
+func (r *Repository) FindByID(ctx context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+	index, err := r.currentIndex()
+	if err != nil {
+		return nil, r.mapError(err)
+	}
 
-	doc, ok := r.state.State.Documents[string(id)]
+	doc, ok := index.Documents[string(id)]
 	if !ok {
 		return nil, domain.ErrMeetingNotFound
 	}
 
-	meta := r.metaFor(string(id))
+	meta := metaFor(index, string(id))
 	mtg, err := mapDocumentToDomain(doc, meta)
 	if err != nil {
 		return nil, err
 	}
 
-	// Attach transcript if available
-	if transcript, ok := r.state.State.Transcripts[string(id)]; ok {
-		t := mapTranscriptToDomain(string(id), transcript)
-		if t != nil {
-			mtg.AttachTranscript(*t)
-			mtg.ClearDomainEvents()
+	// Attach transcript if available. The meeting is still returned when the
+	// file is mid-rewrite and the transcript cannot be read back.
+	if index.HasTranscript(string(id)) {
+		transcript, err := r.loadTranscript(string(id))
+		if err != nil {
+			log.Printf("localcache: transcript for %s unavailable: %v", id, err)
+		}
+		if transcript != nil {
+			if t := mapTranscriptToDomain(string(id), *transcript); t != nil {
+				mtg.AttachTranscript(*t)
+				mtg.ClearDomainEvents()
+			}
 		}
 	}
 
@@ -81,16 +142,14 @@ func (r *Repository) FindByID(ctx context.Context, id domain.MeetingID) (*domain
 }
 
 func (r *Repository) List(ctx context.Context, filter domain.ListFilter) ([]*domain.Meeting, error) {
-	if err := r.ensureLoaded(); err != nil {
+	index, err := r.currentIndex()
+	if err != nil {
 		return nil, r.mapError(err)
 	}
 
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
 	var meetings []*domain.Meeting
-	for id, doc := range r.state.State.Documents {
-		meta := r.metaFor(id)
+	for id, doc := range index.Documents {
+		meta := metaFor(index, id)
 		mtg, err := mapDocumentToDomain(doc, meta)
 		if err != nil {
 			log.Printf("localcache: skipping invalid document %s: %v", id, err)
@@ -127,24 +186,28 @@ func (r *Repository) List(ctx context.Context, filter domain.ListFilter) ([]*dom
 }
 
 func (r *Repository) GetTranscript(ctx context.Context, id domain.MeetingID) (*domain.Transcript, error) {
-	if err := r.ensureLoaded(); err != nil {
+	index, err := r.currentIndex()
+	if err != nil {
 		return nil, r.mapError(err)
 	}
 
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
 	// Verify the meeting exists
-	if _, ok := r.state.State.Documents[string(id)]; !ok {
+	if _, ok := index.Documents[string(id)]; !ok {
 		return nil, domain.ErrMeetingNotFound
 	}
+	if !index.HasTranscript(string(id)) {
+		return nil, domain.ErrTranscriptNotReady
+	}
 
-	transcript, ok := r.state.State.Transcripts[string(id)]
-	if !ok {
+	transcript, err := r.loadTranscript(string(id))
+	if err != nil {
+		return nil, r.mapError(err)
+	}
+	if transcript == nil {
 		return nil, domain.ErrTranscriptNotReady
 	}
 
-	t := mapTranscriptToDomain(string(id), transcript)
+	t := mapTranscriptToDomain(string(id), *transcript)
 	if t == nil {
 		return nil, domain.ErrTranscriptNotReady
 	}
@@ -153,18 +216,25 @@ func (r *Repository) GetTranscript(ctx context.Context, id domain.MeetingID) (*d
 }
 
 func (r *Repository) SearchTranscripts(ctx context.Context, query string, filter domain.ListFilter) ([]*domain.Meeting, error) {
-	if err := r.ensureLoaded(); err != nil {
+	index, err := r.currentIndex()
+	if err != nil {
 		return nil, r.mapError(err)
 	}
 
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	// Transcripts are streamed from one open handle on the file.
+	var loader *transcriptLoader
+	if index.TranscriptCount() > 0 {
+		if index, loader, err = r.openTranscripts(); err != nil {
+			return nil, r.mapError(err)
+		}
+		defer loader.Close()
+	}
 
 	queryLower := strings.ToLower(query)
 	var meetings []*domain.Meeting
 
-	for id, doc := range r.state.State.Documents {
-		meta := r.metaFor(id)
+	for id, doc := range index.Documents {
+		meta := metaFor(index, id)
 		mtg, err := mapDocumentToDomain(doc, meta)
 		if err != nil {
 			continue
@@ -180,18 +250,22 @@ func (r *Repository) SearchTranscripts(ctx context.Context, query string, filter
 			continue
 		}
 
-		// Match against transcript text
-		if transcript, ok := r.state.State.Transcripts[id]; ok {
-			if transcriptContains(transcript, queryLower) {
+		// Match against notes (ProseMirror)
+		if len(doc.NotesProsemirror) > 0 {
+			plainText := prosemirrorToPlainText(doc.NotesProsemirror)
+			if strings.Contains(strings.ToLower(plainText), queryLower) {
 				meetings = append(meetings, mtg)
 				continue
 			}
 		}
 
-		// Match against notes (ProseMirror)
-		if len(doc.NotesProsemirror) > 0 {
-			plainText := prosemirrorToPlainText(doc.NotesProsemirror)
-			if strings.Contains(strings.ToLower(plainText), queryLower) {
+		// Match against transcript text, the only check that reads the file
+		if loader != nil && index.HasTranscript(id) {
+			transcript, err := loader.Load(id)
+			if err != nil {
+				return nil, r.mapError(err)
+			}
+			if transcript != nil && transcriptContains(*transcript, queryLower) {
 				meetings = append(meetings, mtg)
 				continue
 			}
@@ -228,11 +302,18 @@ func (r *Repository) Sync(ctx context.Context, since *time.Time) ([]domain.Domai
 	if err := r.loadLocked(); err != nil {
 		return nil, r.mapError(err)
 	}
+	if r.docs != nil {
+		prev, err := r.docs.Load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("load synced documents: %w", err)
+		}
+		r.prevDocIDs = prev
+	}
 
 	var events []domain.DomainEvent
-	currentDocIDs := make(map[string]string, len(r.state.State.Documents))
+	currentDocIDs := make(map[string]string, len(r.index.Documents))
 
-	for id, doc := range r.state.State.Documents {
+	for id, doc := range r.index.Documents {
 		currentDocIDs[id] = doc.UpdatedAt
 
 		createdAt, err := time.Parse(cacheTimestampLayout, doc.CreatedAt)
@@ -247,7 +328,7 @@ func (r *Repository) Sync(ctx context.Context, since *time.Time) ([]domain.Domai
 			if !existed || prevUpdatedAt != doc.UpdatedAt {
 				events = append(events, domain.NewSummaryUpdatedEvent(
 					domain.MeetingID(id),
-					domain.SummaryAuto,
+					domain.SummaryEdited,
 				))
 			}
 			continue
@@ -263,10 +344,55 @@ func (r *Repository) Sync(ctx context.Context, since *time.Time) ([]domain.Domai
 		}
 	}
 
+	// Documents seen by the previous sync but gone from the cache were deleted.
+	var removed []string
+	for id := range r.prevDocIDs {
+		if _, ok := currentDocIDs[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		events = append(events, domain.NewMeetingDeletedEvent(domain.MeetingID(id)))
+	}
+
+	if r.docs != nil {
+		if err := r.docs.Replace(ctx, currentDocIDs); err != nil {
+			return nil, fmt.Errorf("store synced documents: %w", err)
+		}
+	}
 	r.prevDocIDs = currentDocIDs
 	return events, nil
 }
 
+// docState is what change detection remembers of a cached document.
+type docState struct {
+	title     string
+	createdAt time.Time
+	updatedAt string
+}
+
+// reload re-reads the cache file and returns the state of every document.
+// Unlike Sync it keeps no state of its own, so callers can diff against
+// their own snapshot.
+func (r *Repository) reload() (map[string]docState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.loadLocked(); err != nil {
+		return nil, r.mapError(err)
+	}
+	docs := make(map[string]docState, len(r.index.Documents))
+	for id, doc := range r.index.Documents {
+		createdAt, err := time.Parse(cacheTimestampLayout, doc.CreatedAt)
+		if err != nil {
+			createdAt = time.Now().UTC()
+		}
+		docs[id] = docState{title: doc.Title, createdAt: createdAt, updatedAt: doc.UpdatedAt}
+	}
+	return docs, nil
+}
+
 // matchesFilter checks if a meeting passes the given filter criteria.
 func (r *Repository) matchesFilter(mtg *domain.Meeting, filter domain.ListFilter) bool {
 	if filter.Since != nil && mtg.Datetime().Before(*filter.Since) {
@@ -301,9 +427,20 @@ func (r *Repository) matchesFilter(mtg *domain.Meeting, filter domain.ListFilter
 	return true
 }
 
+// loadTranscript reads one transcript from the cache file, or returns nil
+// if the current index has none for the document.
+func (r *Repository) loadTranscript(id string) (*CacheTranscript, error) {
+	_, loader, err := r.openTranscripts()
+	if err != nil {
+		return nil, err
+	}
+	defer loader.Close()
+	return loader.Load(id)
+}
+
 // metaFor returns the metadata for a document ID, or nil if not found.
-func (r *Repository) metaFor(id string) *CacheMeetingMeta {
-	if meta, ok := r.state.State.MeetingsMetadata[id]; ok {
+func metaFor(index *Index, id string) *CacheMeetingMeta {
+	if meta, ok := index.MeetingsMetadata[id]; ok {
 		return &meta
 	}
 	return nil
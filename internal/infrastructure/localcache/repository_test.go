@@ -2,6 +2,7 @@ package localcache
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	_ "github.com/mattn/go-sqlite3"
 )
 
 // writeSampleCache writes a valid cache file to the given path.
@@ -410,6 +413,92 @@ func TestRepositorySync(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("sync detects deleted documents", func(t *testing.T) {
+		inner := CacheState{
+			State: CacheInner{
+				Documents: map[string]CacheDocument{
+					"mtg-1": {
+						ID: "mtg-1", Title: "Morning Standup",
+						CreatedAt: "2025-01-15T09:00:00Z",
+						UpdatedAt: "2025-01-15T12:00:00Z",
+					},
+					"mtg-4": {
+						ID: "mtg-4", Title: "New Meeting",
+						CreatedAt: "2025-01-17T10:00:00Z",
+						UpdatedAt: "2025-01-17T10:30:00Z",
+					},
+				},
+			},
+		}
+
+		innerBytes, _ := json.Marshal(inner)
+		outerBytes, _ := json.Marshal(CacheFileEnvelope{Cache: string(innerBytes)})
+		if err := os.WriteFile(path, outerBytes, 0o644); err != nil {
+			t.Fatalf("failed to write test cache file: %v", err)
+		}
+
+		events, err := repo.Sync(ctx, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(events) != 2 {
+			t.Fatalf("expected 2 deletion events, got %d", len(events))
+		}
+		for i, want := range []domain.MeetingID{"mtg-2", "mtg-3"} {
+			deleted, ok := events[i].(domain.MeetingDeleted)
+			if !ok || deleted.MeetingID() != want {
+				t.Errorf("event %d: got %#v, want meeting.deleted for %s", i, events[i], want)
+			}
+		}
+	})
+}
+
+func TestRepositorySync_DetectsDeletionsAcrossRestarts(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	dir := t.TempDir()
+	path := writeSampleCache(t, dir)
+	ctx := context.Background()
+
+	first := NewRepository(NewReader(path))
+	first.SetDocStore(NewSQLiteDocStore(db))
+	if _, err := first.Sync(ctx, nil); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+
+	// mtg-2 and mtg-3 are removed before the next process starts.
+	inner := CacheState{State: CacheInner{Documents: map[string]CacheDocument{
+		"mtg-1": {ID: "mtg-1", Title: "Morning Standup", CreatedAt: "2025-01-15T09:00:00Z", UpdatedAt: "2025-01-15T10:00:00Z"},
+	}}}
+	innerBytes, _ := json.Marshal(inner)
+	outerBytes, _ := json.Marshal(CacheFileEnvelope{Cache: string(innerBytes)})
+	if err := os.WriteFile(path, outerBytes, 0o644); err != nil {
+		t.Fatalf("failed to write test cache file: %v", err)
+	}
+
+	second := NewRepository(NewReader(path))
+	second.SetDocStore(NewSQLiteDocStore(db))
+	events, err := second.Sync(ctx, nil)
+	if err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want deletions of mtg-2 and mtg-3: %v", len(events), events)
+	}
+	for i, want := range []domain.MeetingID{"mtg-2", "mtg-3"} {
+		deleted, ok := events[i].(domain.MeetingDeleted)
+		if !ok || deleted.MeetingID() != want {
+			t.Errorf("event %d: got %#v, want meeting.deleted for %s", i, events[i], want)
+		}
+	}
 }
 
 func TestRepositoryMissingCacheFile(t *testing.T) {
@@ -0,0 +1,391 @@
+package localcache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// checkpointInterval is how many decoded bytes separate two checkpoints
+// of the cache string. Materialising a transcript decodes at most this
+// many bytes before reaching its span.
+const checkpointInterval = 64 << 10
+
+// checkpoint maps an offset in the decoded inner JSON to the file offset
+// holding the same byte, taken between escape sequences so decoding can
+// resume there.
+type checkpoint struct {
+	inner int64
+	outer int64
+}
+
+// stringReader streams the contents of a JSON string literal, undoing
+// its escapes. It starts just after the opening quote and reports io.EOF
+// at the closing quote, so the double-encoded cache can be decoded a
+// second time without ever holding the string in memory.
+type stringReader struct {
+	src     *bufio.Reader
+	pos     int64  // file offset of the next byte in src
+	out     int64  // decoded bytes returned so far
+	pending []byte // decoded bytes of an escape not yet returned
+	buf     [utf8.UTFMax]byte
+	done    bool
+	err     error
+
+	// When non-nil, a checkpoint is appended every checkpointInterval
+	// decoded bytes.
+	checkpoints *[]checkpoint
+	next        int64
+}
+
+// newStringReader creates a stringReader positioned at file offset pos
+// (just past the opening quote, or at a checkpoint) whose first decoded
+// byte sits at inner offset out.
+func newStringReader(src *bufio.Reader, pos, out int64) *stringReader {
+	return &stringReader{src: src, pos: pos, out: out}
+}
+
+func (r *stringReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) && r.err == nil {
+		if len(r.pending) > 0 {
+			c := copy(p[n:], r.pending)
+			r.pending = r.pending[c:]
+			r.out += int64(c)
+			n += c
+			continue
+		}
+		if r.done {
+			break
+		}
+		if r.checkpoints != nil && r.out >= r.next {
+			*r.checkpoints = append(*r.checkpoints, checkpoint{inner: r.out, outer: r.pos})
+			r.next = r.out + checkpointInterval
+		}
+
+		// Fast path: copy a run of plain bytes straight out of the buffer.
+		if buffered, _ := r.src.Peek(r.src.Buffered()); len(buffered) > 0 {
+			limit := len(p) - n
+			if r.checkpoints != nil && r.next-r.out < int64(limit) {
+				limit = int(r.next - r.out)
+			}
+			run := 0
+			for run < len(buffered) && run < limit {
+				if c := buffered[run]; c == '"' || c == '\\' || c < 0x20 {
+					break
+				}
+				run++
+			}
+			if run > 0 {
+				copy(p[n:], buffered[:run])
+				_, _ = r.src.Discard(run)
+				r.pos += int64(run)
+				r.out += int64(run)
+				n += run
+				continue
+			}
+		}
+
+		c, err := r.readByte()
+		if err != nil {
+			break
+		}
+		switch {
+		case c == '"':
+			r.done = true
+		case c == '\\':
+			r.unescape()
+		case c < 0x20:
+			r.err = fmt.Errorf("control character %#x in string", c)
+		default:
+			p[n] = c
+			r.out++
+			n++
+		}
+	}
+
+	if n == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+	}
+	return n, nil
+}
+
+// readByte reads one raw byte, treating the end of the file as an
+// unterminated string.
+func (r *stringReader) readByte() (byte, error) {
+	c, err := r.src.ReadByte()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		r.err = err
+		return 0, err
+	}
+	r.pos++
+	return c, nil
+}
+
+// unescape decodes the escape sequence following a backslash into pending.
+func (r *stringReader) unescape() {
+	c, err := r.readByte()
+	if err != nil {
+		return
+	}
+	switch c {
+	case '"', '\\', '/':
+		r.buf[0] = c
+	case 'b':
+		r.buf[0] = '\b'
+	case 'f':
+		r.buf[0] = '\f'
+	case 'n':
+		r.buf[0] = '\n'
+	case 'r':
+		r.buf[0] = '\r'
+	case 't':
+		r.buf[0] = '\t'
+	case 'u':
+		rn, ok := r.readHex4()
+		if !ok {
+			return
+		}
+		if utf16.IsSurrogate(rn) {
+			// A surrogate pair spans two escapes; a lone half decodes to
+			// U+FFFD like encoding/json does.
+			low := utf8.RuneError
+			if next, err := r.src.Peek(6); err == nil && next[0] == '\\' && next[1] == 'u' {
+				if lo, ok := parseHex4(next[2:6]); ok {
+					if dec := utf16.DecodeRune(rn, lo); dec != utf8.RuneError {
+						_, _ = r.src.Discard(6)
+						r.pos += 6
+						low = dec
+					}
+				}
+			}
+			rn = low
+		}
+		r.pending = utf8.AppendRune(r.buf[:0], rn)
+		return
+	default:
+		r.err = fmt.Errorf("invalid escape '\\%c' in string", c)
+		return
+	}
+	r.pending = r.buf[:1]
+}
+
+func (r *stringReader) readHex4() (rune, bool) {
+	var hex [4]byte
+	for i := range hex {
+		c, err := r.readByte()
+		if err != nil {
+			return 0, false
+		}
+		hex[i] = c
+	}
+	rn, ok := parseHex4(hex[:])
+	if !ok {
+		r.err = fmt.Errorf("invalid unicode escape '\\u%s' in string", hex[:])
+	}
+	return rn, ok
+}
+
+func parseHex4(b []byte) (rune, bool) {
+	var rn rune
+	for _, c := range b {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			return 0, false
+		}
+		rn = rn<<4 | rune(c)
+	}
+	return rn, true
+}
+
+// envelopeScanner walks the top-level object of a cache file far enough
+// to tell its Format, skipping members it does not need without decoding
+// them.
+type envelopeScanner struct {
+	src *bufio.Reader
+	pos int64
+}
+
+// errNoCacheData reports a file whose cache is missing, null or empty.
+var errNoCacheData = errors.New("no cache data")
+
+// detect identifies the layout of the file. For FormatEncodedEnvelope it
+// stops on the first byte inside the "cache" string and returns that
+// file offset.
+func (s *envelopeScanner) detect() (Format, int64, error) {
+	if err := s.expect('{'); err != nil {
+		return "", 0, err
+	}
+	for first := true; ; first = false {
+		c, err := s.peekNonSpace()
+		if err != nil {
+			return "", 0, err
+		}
+		if c == '}' {
+			return "", 0, errNoCacheData
+		}
+		if !first {
+			if err := s.expect(','); err != nil {
+				return "", 0, err
+			}
+		}
+		if err := s.expect('"'); err != nil {
+			return "", 0, err
+		}
+		sr := s.stringReader()
+		key, err := io.ReadAll(sr)
+		if err != nil {
+			return "", 0, err
+		}
+		s.pos = sr.pos
+		if err := s.expect(':'); err != nil {
+			return "", 0, err
+		}
+
+		switch string(key) {
+		case "cache":
+			c, err := s.peekNonSpace()
+			if err != nil {
+				return "", 0, err
+			}
+			switch c {
+			case '"':
+				_, _ = s.readByte()
+				return FormatEncodedEnvelope, s.pos, nil
+			case '{':
+				return FormatObjectEnvelope, 0, nil
+			case 'n':
+				return "", 0, errNoCacheData
+			default:
+				return "", 0, fmt.Errorf("unsupported cache value starting with '%c'", c)
+			}
+		case "state", "documents", "meetingsMetadata", "transcripts":
+			return FormatPlain, 0, nil
+		default:
+			if err := s.skipValue(); err != nil {
+				return "", 0, err
+			}
+		}
+	}
+}
+
+// stringReader reads the string whose opening quote was just consumed.
+// s.pos must be synced from the reader once it is drained.
+func (s *envelopeScanner) stringReader() *stringReader {
+	return newStringReader(s.src, s.pos, 0)
+}
+
+// drainString skips the rest of a string whose opening quote was read.
+func (s *envelopeScanner) drainString() error {
+	sr := s.stringReader()
+	_, err := io.Copy(io.Discard, sr)
+	s.pos = sr.pos
+	return err
+}
+
+func (s *envelopeScanner) readByte() (byte, error) {
+	c, err := s.src.ReadByte()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	s.pos++
+	return c, nil
+}
+
+// peekNonSpace skips whitespace and returns the next byte without
+// consuming it.
+func (s *envelopeScanner) peekNonSpace() (byte, error) {
+	for {
+		c, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if !isSpace(c) {
+			_ = s.src.UnreadByte()
+			s.pos--
+			return c, nil
+		}
+	}
+}
+
+func (s *envelopeScanner) expect(want byte) error {
+	if _, err := s.peekNonSpace(); err != nil {
+		return err
+	}
+	c, _ := s.readByte()
+	if c != want {
+		return fmt.Errorf("expected '%c' at offset %d, found '%c'", want, s.pos-1, c)
+	}
+	return nil
+}
+
+// skipValue skips one JSON value of any kind.
+func (s *envelopeScanner) skipValue() error {
+	c, err := s.peekNonSpace()
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '"':
+		_, _ = s.readByte()
+		return s.drainString()
+	case '{', '[':
+		depth := 0
+		for {
+			c, err := s.readByte()
+			if err != nil {
+				return err
+			}
+			switch c {
+			case '"':
+				if err := s.drainString(); err != nil {
+					return err
+				}
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return nil
+				}
+			}
+		}
+	default:
+		// Number, boolean or null: runs until a delimiter.
+		for {
+			c, err := s.readByte()
+			if err != nil {
+				return err
+			}
+			if c == ',' || c == '}' || c == ']' || isSpace(c) {
+				_ = s.src.UnreadByte()
+				s.pos--
+				return nil
+			}
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
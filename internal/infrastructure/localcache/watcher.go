@@ -0,0 +1,205 @@
+package localcache
+
+import (
+	"context"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// WatchConfig controls how the cache file is polled.
+type WatchConfig struct {
+	Interval time.Duration // how often the file is stat'ed
+	Debounce time.Duration // how long a change must settle before reloading
+}
+
+// DefaultWatchConfig returns polling defaults suited to the desktop app,
+// which rewrites the whole cache file on every save.
+func DefaultWatchConfig() WatchConfig {
+	return WatchConfig{Interval: 2 * time.Second, Debounce: time.Second}
+}
+
+// fileStamp identifies a version of the cache file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher polls the cache file's modification time and, once a change has
+// settled, reloads the repository and dispatches an event for every
+// document created, edited or deleted since the previous reload. The
+// watcher diffs against its own snapshot of the documents, independent of
+// Sync callers. The repository swaps in the new state only after a
+// successful read, so a half-written file leaves the previous state in
+// place and is retried on the next poll.
+type Watcher struct {
+	repo       *Repository
+	dispatcher domain.EventDispatcher
+	cfg        WatchConfig
+
+	loaded       fileStamp           // stamp of the last successful reload
+	docs         map[string]docState // documents as of the last successful reload
+	primed       bool                // whether docs holds a baseline yet
+	pending      *fileStamp          // changed stamp waiting for the debounce
+	pendingSince time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatcher creates a watcher. Zero-valued config fields fall back to
+// DefaultWatchConfig.
+func NewWatcher(repo *Repository, dispatcher domain.EventDispatcher, cfg WatchConfig) *Watcher {
+	def := DefaultWatchConfig()
+	if cfg.Interval <= 0 {
+		cfg.Interval = def.Interval
+	}
+	if cfg.Debounce < 0 {
+		cfg.Debounce = 0
+	}
+	return &Watcher{repo: repo, dispatcher: dispatcher, cfg: cfg}
+}
+
+// Start launches the background watch goroutine.
+// It returns immediately. Call Stop to shut down gracefully.
+func (w *Watcher) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.run(ctx)
+}
+
+// Stop gracefully shuts down the watcher.
+func (w *Watcher) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if w.done != nil {
+		<-w.done
+	}
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer close(w.done)
+
+	if err := w.prime(ctx); err != nil {
+		log.Printf("cache watcher: initial load: %v", err)
+	}
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.poll(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				log.Printf("cache watcher: %v", err)
+			}
+		}
+	}
+}
+
+// prime records the current file as the baseline so existing meetings are
+// not reported as created. When it fails, for instance because the file does
+// not exist yet, the first successful reload in poll becomes the baseline.
+func (w *Watcher) prime(ctx context.Context) error {
+	stamp, err := statStamp(w.repo.reader.Path())
+	if err != nil {
+		return err
+	}
+	docs, err := w.repo.reload()
+	if err != nil {
+		return err
+	}
+	w.loaded = stamp
+	w.docs = docs
+	w.primed = true
+	return nil
+}
+
+// poll checks the file once and reloads it when a change has been stable
+// for the debounce period.
+func (w *Watcher) poll(ctx context.Context, now time.Time) error {
+	stamp, err := statStamp(w.repo.reader.Path())
+	if err != nil {
+		return err
+	}
+	if stamp == w.loaded {
+		w.pending = nil
+		return nil
+	}
+	if w.pending == nil || *w.pending != stamp {
+		w.pending = &stamp
+		w.pendingSince = now
+	}
+	if now.Sub(w.pendingSince) < w.cfg.Debounce {
+		return nil
+	}
+
+	docs, err := w.repo.reload()
+	if err != nil {
+		return err // keep pending; retried on the next poll
+	}
+	var events []domain.DomainEvent
+	if w.primed {
+		events = diffDocs(w.docs, docs)
+	}
+	w.loaded = stamp
+	w.docs = docs
+	w.primed = true
+	w.pending = nil
+
+	if len(events) == 0 || w.dispatcher == nil {
+		return nil
+	}
+	return w.dispatcher.Dispatch(ctx, events)
+}
+
+// diffDocs returns the events that turn prev into cur: a meeting.created
+// for each new document, a summary.updated for each document whose
+// updatedAt changed, and a meeting.deleted for each document that is gone.
+func diffDocs(prev, cur map[string]docState) []domain.DomainEvent {
+	ids := make([]string, 0, len(cur))
+	for id := range cur {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var events []domain.DomainEvent
+	for _, id := range ids {
+		doc := cur[id]
+		old, existed := prev[id]
+		switch {
+		case !existed:
+			events = append(events, domain.NewMeetingCreatedEvent(domain.MeetingID(id), doc.title, doc.createdAt))
+		case old.updatedAt != doc.updatedAt:
+			events = append(events, domain.NewSummaryUpdatedEvent(domain.MeetingID(id), domain.SummaryEdited))
+		}
+	}
+
+	var removed []string
+	for id := range prev {
+		if _, ok := cur[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		events = append(events, domain.NewMeetingDeletedEvent(domain.MeetingID(id)))
+	}
+	return events
+}
+
+func statStamp(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
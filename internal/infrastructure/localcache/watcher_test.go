@@ -0,0 +1,204 @@
+package localcache
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+type recordingDispatcher struct {
+	events []domain.DomainEvent
+}
+
+func (d *recordingDispatcher) Dispatch(_ context.Context, events []domain.DomainEvent) error {
+	d.events = append(d.events, events...)
+	return nil
+}
+
+// rewriteCache replaces the cache file with the given documents and bumps
+// its mtime so the change is visible regardless of filesystem resolution.
+func rewriteCache(t *testing.T, path string, docs map[string]CacheDocument, mtime time.Time) {
+	t.Helper()
+	innerBytes, _ := json.Marshal(CacheState{State: CacheInner{Documents: docs}})
+	outerBytes, _ := json.Marshal(CacheFileEnvelope{Cache: string(innerBytes)})
+	if err := os.WriteFile(path, outerBytes, 0o644); err != nil {
+		t.Fatalf("write cache: %v", err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+}
+
+func TestWatcher_ReloadsAfterDebounceAndDispatches(t *testing.T) {
+	ctx := context.Background()
+	path := writeSampleCache(t, t.TempDir())
+	repo := NewRepository(NewReader(path))
+	dispatcher := &recordingDispatcher{}
+	w := NewWatcher(repo, dispatcher, WatchConfig{Interval: time.Second, Debounce: time.Second})
+
+	if err := w.prime(ctx); err != nil {
+		t.Fatalf("prime: %v", err)
+	}
+
+	now := time.Now()
+	docs := map[string]CacheDocument{
+		"mtg-1": {ID: "mtg-1", Title: "Morning Standup", CreatedAt: "2025-01-15T09:00:00Z", UpdatedAt: "2025-01-15T09:30:00Z"},
+		"mtg-2": {ID: "mtg-2", Title: "Sprint Review", CreatedAt: "2025-01-16T14:00:00Z", UpdatedAt: "2025-01-16T15:00:00Z"},
+		"mtg-3": {ID: "mtg-3", Title: "1:1 with Manager", CreatedAt: "2025-01-14T11:00:00Z", UpdatedAt: "2025-01-14T11:30:00Z"},
+		"mtg-4": {ID: "mtg-4", Title: "Design Review", CreatedAt: "2025-01-17T10:00:00Z", UpdatedAt: "2025-01-17T10:30:00Z"},
+	}
+	rewriteCache(t, path, docs, now.Add(time.Minute))
+
+	if err := w.poll(ctx, now); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(dispatcher.events) != 0 {
+		t.Fatalf("dispatched %d events before the debounce elapsed", len(dispatcher.events))
+	}
+
+	if err := w.poll(ctx, now.Add(time.Second)); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(dispatcher.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(dispatcher.events))
+	}
+	created, ok := dispatcher.events[0].(domain.MeetingCreated)
+	if !ok || created.MeetingID() != "mtg-4" {
+		t.Errorf("got %#v, want meeting.created for mtg-4", dispatcher.events[0])
+	}
+	if _, err := repo.FindByID(ctx, "mtg-4"); err != nil {
+		t.Errorf("new meeting not visible after reload: %v", err)
+	}
+
+	// Unchanged file: nothing more to do.
+	if err := w.poll(ctx, now.Add(time.Hour)); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(dispatcher.events) != 1 {
+		t.Errorf("got %d events after an idle poll, want 1", len(dispatcher.events))
+	}
+}
+
+func TestWatcher_DispatchesEditsToExistingMeetings(t *testing.T) {
+	ctx := context.Background()
+	path := writeSampleCache(t, t.TempDir())
+	repo := NewRepository(NewReader(path))
+	dispatcher := &recordingDispatcher{}
+	w := NewWatcher(repo, dispatcher, WatchConfig{Debounce: 0})
+
+	if err := w.prime(ctx); err != nil {
+		t.Fatalf("prime: %v", err)
+	}
+	// Another Sync caller must not consume the watcher's changes.
+	if _, err := repo.Sync(ctx, nil); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	now := time.Now()
+	rewriteCache(t, path, map[string]CacheDocument{
+		"mtg-1": {ID: "mtg-1", Title: "Morning Standup", CreatedAt: "2025-01-15T09:00:00Z", UpdatedAt: "2025-01-20T08:00:00Z"},
+		"mtg-2": {ID: "mtg-2", Title: "Sprint Review", CreatedAt: "2025-01-16T14:00:00Z", UpdatedAt: "2025-01-16T15:00:00Z"},
+		"mtg-3": {ID: "mtg-3", Title: "1:1 with Manager", CreatedAt: "2025-01-14T11:00:00Z", UpdatedAt: "2025-01-14T11:30:00Z"},
+	}, now.Add(time.Minute))
+	if _, err := repo.Sync(ctx, nil); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	if err := w.poll(ctx, now); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(dispatcher.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(dispatcher.events))
+	}
+	updated, ok := dispatcher.events[0].(domain.SummaryUpdated)
+	if !ok || updated.MeetingID() != "mtg-1" {
+		t.Errorf("got %#v, want summary.updated for mtg-1", dispatcher.events[0])
+	}
+}
+
+func TestWatcher_FirstReadAfterMissingFileIsBaseline(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache-v3.json")
+	repo := NewRepository(NewReader(path))
+	dispatcher := &recordingDispatcher{}
+	w := NewWatcher(repo, dispatcher, WatchConfig{Debounce: 0})
+
+	if err := w.prime(ctx); err == nil {
+		t.Fatal("expected prime to fail before the cache file exists")
+	}
+
+	now := time.Now()
+	docs := map[string]CacheDocument{
+		"mtg-1": {ID: "mtg-1", Title: "Morning Standup", CreatedAt: "2025-01-15T09:00:00Z", UpdatedAt: "2025-01-15T09:30:00Z"},
+		"mtg-2": {ID: "mtg-2", Title: "Sprint Review", CreatedAt: "2025-01-16T14:00:00Z", UpdatedAt: "2025-01-16T15:00:00Z"},
+	}
+	rewriteCache(t, path, docs, now)
+	if err := w.poll(ctx, now); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(dispatcher.events) != 0 {
+		t.Fatalf("dispatched %d events for existing meetings, want 0", len(dispatcher.events))
+	}
+
+	docs["mtg-3"] = CacheDocument{ID: "mtg-3", Title: "Design Review", CreatedAt: "2025-01-17T10:00:00Z", UpdatedAt: "2025-01-17T10:30:00Z"}
+	rewriteCache(t, path, docs, now.Add(time.Minute))
+	if err := w.poll(ctx, now.Add(time.Minute)); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(dispatcher.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(dispatcher.events))
+	}
+	created, ok := dispatcher.events[0].(domain.MeetingCreated)
+	if !ok || created.MeetingID() != "mtg-3" {
+		t.Errorf("got %#v, want meeting.created for mtg-3", dispatcher.events[0])
+	}
+}
+
+func TestWatcher_KeepsStateWhileFileIsHalfWritten(t *testing.T) {
+	ctx := context.Background()
+	path := writeSampleCache(t, t.TempDir())
+	repo := NewRepository(NewReader(path))
+	dispatcher := &recordingDispatcher{}
+	w := NewWatcher(repo, dispatcher, WatchConfig{Debounce: 0})
+
+	if err := w.prime(ctx); err != nil {
+		t.Fatalf("prime: %v", err)
+	}
+
+	now := time.Now()
+	if err := os.WriteFile(path, []byte(`{"cache": "{\"state\": {`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Chtimes(path, now.Add(time.Minute), now.Add(time.Minute))
+
+	if err := w.poll(ctx, now); err == nil {
+		t.Fatal("expected an error for a truncated cache file")
+	}
+	if _, err := repo.FindByID(ctx, "mtg-1"); err != nil {
+		t.Errorf("previous state lost after failed reload: %v", err)
+	}
+
+	rewriteCache(t, path, map[string]CacheDocument{
+		"mtg-1": {ID: "mtg-1", Title: "Morning Standup", CreatedAt: "2025-01-15T09:00:00Z", UpdatedAt: "2025-01-15T09:30:00Z"},
+	}, now.Add(2*time.Minute))
+	if err := w.poll(ctx, now.Add(time.Second)); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(dispatcher.events) != 2 {
+		t.Errorf("got %d events, want 2 deletions once the file is complete", len(dispatcher.events))
+	}
+}
+
+func TestWatcher_StartStop(t *testing.T) {
+	path := writeSampleCache(t, t.TempDir())
+	w := NewWatcher(NewRepository(NewReader(path)), nil, WatchConfig{Interval: 10 * time.Millisecond})
+
+	w.Start(context.Background())
+	time.Sleep(30 * time.Millisecond)
+	w.Stop()
+}
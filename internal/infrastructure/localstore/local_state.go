@@ -0,0 +1,32 @@
+package localstore
+
+import (
+	"context"
+	"database/sql"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// LocalStateCounter implements domain.LocalStateCounter over the local
+// notes and action item override tables.
+type LocalStateCounter struct {
+	db *sql.DB
+}
+
+// NewLocalStateCounter creates a new SQLite-backed local state counter.
+func NewLocalStateCounter(db *sql.DB) *LocalStateCounter {
+	return &LocalStateCounter{db: db}
+}
+
+func (c *LocalStateCounter) CountLocalState(ctx context.Context, id domain.MeetingID) (domain.LocalState, error) {
+	var state domain.LocalState
+	err := c.db.QueryRowContext(ctx,
+		`SELECT
+			(SELECT COUNT(*) FROM agent_notes WHERE meeting_id = ?),
+			(SELECT COUNT(*) FROM action_item_overrides WHERE meeting_id = ?)`,
+		string(id), string(id),
+	).Scan(&state.Notes, &state.ActionItemOverrides)
+	return state, err
+}
+
+var _ domain.LocalStateCounter = (*LocalStateCounter)(nil)
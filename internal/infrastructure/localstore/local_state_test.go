@@ -0,0 +1,48 @@
+package localstore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+)
+
+func TestLocalStateCounter_CountsNotesAndOverrides(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+
+	notes := localstore.NewNoteRepository(db)
+	for _, id := range []annotation.NoteID{"n-1", "n-2"} {
+		note, _ := annotation.NewAgentNote(id, "m-1", "claude", "observation")
+		if err := notes.Save(ctx, note); err != nil {
+			t.Fatalf("save note: %v", err)
+		}
+	}
+	item, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Write report", nil)
+	item.Complete()
+	if err := localstore.NewWriteRepository(db).SaveActionItemState(ctx, item); err != nil {
+		t.Fatalf("save override: %v", err)
+	}
+
+	counter := localstore.NewLocalStateCounter(db)
+	state, err := counter.CountLocalState(ctx, "m-1")
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if state.Notes != 2 || state.ActionItemOverrides != 1 {
+		t.Errorf("got %+v, want 2 notes and 1 override", state)
+	}
+
+	empty, err := counter.CountLocalState(ctx, "m-2")
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if !empty.Empty() {
+		t.Errorf("got %+v, want empty state", empty)
+	}
+}
@@ -0,0 +1,75 @@
+-- Baseline: the schema created by releases before versioned migrations.
+-- IF NOT EXISTS adopts existing installs; later changes are new migrations.
+CREATE TABLE IF NOT EXISTS agent_notes (
+	id         TEXT PRIMARY KEY,
+	meeting_id TEXT NOT NULL,
+	author     TEXT NOT NULL,
+	content    TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_agent_notes_meeting ON agent_notes(meeting_id);
+
+CREATE TABLE IF NOT EXISTS action_item_overrides (
+	action_item_id TEXT PRIMARY KEY,
+	meeting_id     TEXT NOT NULL,
+	text           TEXT,
+	completed      INTEGER,
+	updated_at     DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_action_item_overrides_meeting ON action_item_overrides(meeting_id);
+
+CREATE TABLE IF NOT EXISTS outbox_entries (
+	id         TEXT PRIMARY KEY,
+	event_type TEXT NOT NULL,
+	payload    BLOB NOT NULL,
+	status     TEXT NOT NULL DEFAULT 'pending',
+	created_at DATETIME NOT NULL,
+	synced_at  DATETIME,
+	attempts   INTEGER NOT NULL DEFAULT 0,
+	next_attempt_at DATETIME,
+	last_error TEXT,
+	sink       TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_status ON outbox_entries(status);
+
+CREATE TABLE IF NOT EXISTS task_links (
+	action_item_id TEXT NOT NULL,
+	sink           TEXT NOT NULL,
+	external_id    TEXT NOT NULL,
+	fingerprint    TEXT NOT NULL DEFAULT '',
+	closed         INTEGER NOT NULL DEFAULT 0,
+	updated_at     DATETIME NOT NULL,
+	PRIMARY KEY (action_item_id, sink)
+);
+
+CREATE TABLE IF NOT EXISTS events (
+	seq            INTEGER PRIMARY KEY AUTOINCREMENT,
+	id             TEXT NOT NULL UNIQUE,
+	event_type     TEXT NOT NULL,
+	schema_version INTEGER NOT NULL,
+	aggregate_id   TEXT NOT NULL,
+	meeting_id     TEXT NOT NULL DEFAULT '',
+	occurred_at    DATETIME NOT NULL,
+	data           BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_events_meeting ON events(meeting_id, seq);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+	id          TEXT PRIMARY KEY,
+	url         TEXT NOT NULL,
+	event_types TEXT NOT NULL,
+	secret      TEXT NOT NULL,
+	created_at  DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	subscription_id TEXT NOT NULL,
+	delivery_id     TEXT NOT NULL,
+	event_type      TEXT NOT NULL,
+	status_code     INTEGER NOT NULL DEFAULT 0,
+	error           TEXT NOT NULL DEFAULT '',
+	duration_ms     INTEGER NOT NULL DEFAULT 0,
+	attempted_at    DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, id);
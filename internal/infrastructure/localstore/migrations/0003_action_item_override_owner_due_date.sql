@@ -0,0 +1,3 @@
+-- Overrides used to drop the owner and due date of the item they replace.
+ALTER TABLE action_item_overrides ADD COLUMN owner TEXT;
+ALTER TABLE action_item_overrides ADD COLUMN due_date DATETIME;
@@ -0,0 +1,8 @@
+-- Notes carry a kind, an optional transcript anchor (a time range or one
+-- utterance index) and, for replies, the note they answer.
+ALTER TABLE agent_notes ADD COLUMN kind TEXT NOT NULL DEFAULT 'note';
+ALTER TABLE agent_notes ADD COLUMN anchor_start DATETIME;
+ALTER TABLE agent_notes ADD COLUMN anchor_end DATETIME;
+ALTER TABLE agent_notes ADD COLUMN anchor_utterance INTEGER;
+ALTER TABLE agent_notes ADD COLUMN parent_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_agent_notes_parent ON agent_notes(parent_id);
@@ -0,0 +1,15 @@
+-- Notes can be edited. agent_notes holds the current revision; every
+-- revision an edit replaces is kept in note_revisions.
+ALTER TABLE agent_notes ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE agent_notes ADD COLUMN updated_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE agent_notes ADD COLUMN updated_at DATETIME;
+UPDATE agent_notes SET updated_by = author, updated_at = created_at;
+
+CREATE TABLE IF NOT EXISTS note_revisions (
+	note_id    TEXT NOT NULL,
+	revision   INTEGER NOT NULL,
+	content    TEXT NOT NULL,
+	author     TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	PRIMARY KEY (note_id, revision)
+);
@@ -0,0 +1,16 @@
+-- Full-text index over note content, keyed by agent_notes rowid and kept
+-- current by triggers. FTS4 ships with the default go-sqlite3 build.
+CREATE VIRTUAL TABLE IF NOT EXISTS agent_notes_fts USING fts4(content);
+INSERT INTO agent_notes_fts (rowid, content) SELECT rowid, content FROM agent_notes;
+
+CREATE TRIGGER IF NOT EXISTS agent_notes_fts_insert AFTER INSERT ON agent_notes BEGIN
+	INSERT INTO agent_notes_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+CREATE TRIGGER IF NOT EXISTS agent_notes_fts_update AFTER UPDATE OF content ON agent_notes BEGIN
+	UPDATE agent_notes_fts SET content = new.content WHERE rowid = old.rowid;
+END;
+CREATE TRIGGER IF NOT EXISTS agent_notes_fts_delete AFTER DELETE ON agent_notes BEGIN
+	DELETE FROM agent_notes_fts WHERE rowid = old.rowid;
+END;
+
+CREATE INDEX IF NOT EXISTS idx_agent_notes_created ON agent_notes(created_at);
@@ -0,0 +1,4 @@
+-- Clients may send an idempotency key when creating a note; a retry with
+-- the same key returns the note created first.
+ALTER TABLE agent_notes ADD COLUMN idempotency_key TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agent_notes_idempotency_key ON agent_notes(idempotency_key);
@@ -0,0 +1,17 @@
+-- State of pushing agent notes into Granola documents. note_push_blocks
+-- holds, per note, the content hash last merged from each side (empty when
+-- absent), so the next push can tell who changed a note since.
+CREATE TABLE IF NOT EXISTS note_push_blocks (
+	note_id       TEXT PRIMARY KEY,
+	meeting_id    TEXT NOT NULL,
+	local_hash    TEXT NOT NULL,
+	upstream_hash TEXT NOT NULL,
+	conflicted_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_note_push_blocks_meeting ON note_push_blocks(meeting_id);
+
+CREATE TABLE IF NOT EXISTS note_push_documents (
+	meeting_id        TEXT PRIMARY KEY,
+	upstream_revision TEXT NOT NULL,
+	pushed_at         DATETIME NOT NULL
+);
@@ -0,0 +1,8 @@
+-- Summaries generated locally by a language model, for meetings Granola
+-- has no summary for. One per meeting; regenerating replaces it.
+CREATE TABLE IF NOT EXISTS generated_summaries (
+	meeting_id   TEXT PRIMARY KEY,
+	content      TEXT NOT NULL,
+	kind         TEXT NOT NULL,
+	generated_at DATETIME NOT NULL
+);
@@ -0,0 +1,10 @@
+-- Fingerprints of the transcript and summary of each meeting as of the last
+-- API sync, so sync reports only meetings whose content changed. Releases
+-- before this migration created the table at runtime, hence IF NOT EXISTS.
+CREATE TABLE IF NOT EXISTS meeting_content_hashes (
+	meeting_id      TEXT PRIMARY KEY,
+	transcript_hash TEXT NOT NULL,
+	summary_hash    TEXT NOT NULL,
+	updated_at      DATETIME NOT NULL,
+	checked_at      DATETIME NOT NULL
+);
@@ -0,0 +1,6 @@
+-- Documents of the desktop app's cache file as of the last sync, so a sync
+-- in a new process still reports documents removed since as deleted.
+CREATE TABLE local_cache_documents (
+	doc_id     TEXT PRIMARY KEY,
+	updated_at TEXT NOT NULL
+);
@@ -0,0 +1,4 @@
+-- Idempotency keys are scoped to the note's author, so two agents that
+-- happen to pick the same key do not collide.
+DROP INDEX IF EXISTS idx_agent_notes_idempotency_key;
+CREATE UNIQUE INDEX idx_agent_notes_author_idempotency_key ON agent_notes(author, idempotency_key);
@@ -3,6 +3,7 @@ package localstore
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/felixgeelhaar/acai/internal/domain/annotation"
@@ -18,102 +19,262 @@ func NewNoteRepository(db *sql.DB) *NoteRepository {
 	return &NoteRepository{db: db}
 }
 
-func (r *NoteRepository) Save(_ context.Context, note *annotation.AgentNote) error {
-	_, err := r.db.Exec(
-		"INSERT OR REPLACE INTO agent_notes (id, meeting_id, author, content, created_at) VALUES (?, ?, ?, ?, ?)",
-		string(note.ID()), note.MeetingID(), note.Author(), note.Content(), note.CreatedAt().UTC(),
+// noteColumns is the column list scanned by scanNote.
+const noteColumns = "id, meeting_id, author, content, created_at, kind, anchor_start, anchor_end, anchor_utterance, parent_id, revision, updated_by, updated_at, idempotency_key"
+
+// noteUpsertSet overwrites every column but id with the inserted values.
+var noteUpsertSet = func() string {
+	columns := strings.Split(noteColumns, ", ")[1:]
+	set := make([]string, len(columns))
+	for i, c := range columns {
+		set[i] = c + " = excluded." + c
+	}
+	return strings.Join(set, ", ")
+}()
+
+// notePlaceholders is one bind parameter per column in noteColumns.
+var notePlaceholders = strings.TrimSuffix(strings.Repeat("?, ", len(strings.Split(noteColumns, ", "))), ", ")
+
+// Save inserts or overwrites the note. It upserts rather than replacing
+// the row, so the full-text index triggers see an update. The insert is
+// skipped when another note by the same author holds the idempotency key,
+// which the caller sees as ErrDuplicateIdempotencyKey.
+func (r *NoteRepository) Save(ctx context.Context, note *annotation.AgentNote) error {
+	args := append(noteValues(note), note.Author(), nullString(note.IdempotencyKey()), string(note.ID()))
+	result, err := r.db.ExecContext(ctx,
+		"INSERT INTO agent_notes ("+noteColumns+") SELECT "+notePlaceholders+
+			" WHERE NOT EXISTS (SELECT 1 FROM agent_notes WHERE author = ? AND idempotency_key = ? AND id != ?)"+
+			" ON CONFLICT(id) DO UPDATE SET "+noteUpsertSet,
+		args...,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return annotation.ErrDuplicateIdempotencyKey
+	}
+	return nil
 }
 
-func (r *NoteRepository) FindByID(_ context.Context, id annotation.NoteID) (*annotation.AgentNote, error) {
-	var (
-		noteID    string
-		meetingID string
-		author    string
-		content   string
-		createdAt time.Time
-	)
-	err := r.db.QueryRow(
-		"SELECT id, meeting_id, author, content, created_at FROM agent_notes WHERE id = ?",
-		string(id),
-	).Scan(&noteID, &meetingID, &author, &content, &createdAt)
+func (r *NoteRepository) FindByIdempotencyKey(ctx context.Context, author, key string) (*annotation.AgentNote, error) {
+	note, err := scanNote(r.db.QueryRowContext(ctx,
+		"SELECT "+noteColumns+" FROM agent_notes WHERE author = ? AND idempotency_key = ?", author, key))
 	if err == sql.ErrNoRows {
 		return nil, annotation.ErrNoteNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
-	return annotation.ReconstructAgentNote(
-		annotation.NoteID(noteID), meetingID, author, content, createdAt,
-	), nil
+	return note, nil
 }
 
-func (r *NoteRepository) ListByMeeting(_ context.Context, meetingID string) ([]*annotation.AgentNote, error) {
-	rows, err := r.db.Query(
-		"SELECT id, meeting_id, author, content, created_at FROM agent_notes WHERE meeting_id = ? ORDER BY created_at ASC",
-		meetingID,
+// Update writes the edited note and records prior in note_revisions, in one
+// transaction, provided the stored note is still at prior's revision.
+func (r *NoteRepository) Update(ctx context.Context, note *annotation.AgentNote, prior annotation.NoteRevision) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE agent_notes SET content = ?, revision = ?, updated_by = ?, updated_at = ? WHERE id = ? AND revision = ?",
+		note.Content(), note.Revision(), note.UpdatedBy(), note.UpdatedAt().UTC(), string(note.ID()), prior.Number(),
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM agent_notes WHERE id = ?)", string(note.ID())).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return annotation.ErrNoteNotFound
+		}
+		return annotation.ErrRevisionConflict
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO note_revisions (note_id, revision, content, author, created_at) VALUES (?, ?, ?, ?, ?)",
+		string(prior.NoteID()), prior.Number(), prior.Content(), prior.Author(), prior.CreatedAt().UTC(),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *NoteRepository) ListRevisions(ctx context.Context, id annotation.NoteID) ([]annotation.NoteRevision, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT note_id, revision, content, author, created_at FROM note_revisions WHERE note_id = ? ORDER BY revision ASC",
+		string(id),
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = rows.Close() }()
 
-	var notes []*annotation.AgentNote
+	revisions := []annotation.NoteRevision{}
 	for rows.Next() {
 		var (
 			noteID    string
-			mid       string
-			author    string
+			number    int
 			content   string
+			author    string
 			createdAt time.Time
 		)
-		if err := rows.Scan(&noteID, &mid, &author, &content, &createdAt); err != nil {
+		if err := rows.Scan(&noteID, &number, &content, &author, &createdAt); err != nil {
 			return nil, err
 		}
-		notes = append(notes, annotation.ReconstructAgentNote(
-			annotation.NoteID(noteID), mid, author, content, createdAt,
-		))
+		revisions = append(revisions, annotation.ReconstructNoteRevision(annotation.NoteID(noteID), number, content, author, createdAt))
+	}
+	return revisions, rows.Err()
+}
+
+// noteValues returns note's values in noteColumns order.
+func noteValues(note *annotation.AgentNote) []any {
+	var (
+		anchorStart, anchorEnd *time.Time
+		anchorUtterance        *int
+		parentID               *string
+	)
+	if start, end, ok := note.Anchor().TimeRange(); ok {
+		anchorStart, anchorEnd = &start, &end
 	}
-	if notes == nil {
-		notes = []*annotation.AgentNote{}
+	if index, ok := note.Anchor().UtteranceIndex(); ok {
+		anchorUtterance = &index
 	}
-	return notes, rows.Err()
+	if note.IsReply() {
+		id := string(note.ParentID())
+		parentID = &id
+	}
+	return []any{
+		string(note.ID()), note.MeetingID(), note.Author(), note.Content(), note.CreatedAt().UTC(),
+		string(note.Kind()), anchorStart, anchorEnd, anchorUtterance, parentID,
+		note.Revision(), note.UpdatedBy(), note.UpdatedAt().UTC(), nullString(note.IdempotencyKey()),
+	}
+}
+
+// nullString stores the empty string as NULL, which the unique index on
+// idempotency_key does not compare.
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func (r *NoteRepository) FindByID(_ context.Context, id annotation.NoteID) (*annotation.AgentNote, error) {
+	note, err := scanNote(r.db.QueryRow("SELECT "+noteColumns+" FROM agent_notes WHERE id = ?", string(id)))
+	if err == sql.ErrNoRows {
+		return nil, annotation.ErrNoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (r *NoteRepository) ListByMeeting(_ context.Context, meetingID string) ([]*annotation.AgentNote, error) {
+	rows, err := r.db.Query(
+		"SELECT "+noteColumns+" FROM agent_notes WHERE meeting_id = ? ORDER BY created_at ASC",
+		meetingID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanNotes(rows)
 }
 
 func (r *NoteRepository) ListAll(_ context.Context) ([]*annotation.AgentNote, error) {
 	rows, err := r.db.Query(
-		"SELECT id, meeting_id, author, content, created_at FROM agent_notes ORDER BY created_at ASC",
+		"SELECT " + noteColumns + " FROM agent_notes ORDER BY created_at ASC",
 	)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = rows.Close() }()
+	return scanNotes(rows)
+}
 
-	var notes []*annotation.AgentNote
-	for rows.Next() {
-		var (
-			noteID    string
-			mid       string
-			author    string
-			content   string
-			createdAt time.Time
-		)
-		if err := rows.Scan(&noteID, &mid, &author, &content, &createdAt); err != nil {
-			return nil, err
+func (r *NoteRepository) Search(ctx context.Context, filter annotation.NoteFilter) ([]*annotation.AgentNote, error) {
+	clauses := []string{"1 = 1"}
+	var args []any
+	if filter.MeetingID != "" {
+		clauses = append(clauses, "meeting_id = ?")
+		args = append(args, filter.MeetingID)
+	}
+	if filter.Author != "" {
+		clauses = append(clauses, "author = ?")
+		args = append(args, filter.Author)
+	}
+	if filter.Kind != "" {
+		clauses = append(clauses, "kind = ?")
+		args = append(args, string(filter.Kind))
+	}
+	if filter.Since != nil {
+		clauses = append(clauses, "created_at >= ?")
+		args = append(args, filter.Since.UTC())
+	}
+	if filter.Until != nil {
+		clauses = append(clauses, "created_at <= ?")
+		args = append(args, filter.Until.UTC())
+	}
+	if filter.Query != "" {
+		match := ftsQuery(filter.Query)
+		if match == "" {
+			// Only quotes or whitespace: nothing to match on.
+			return nil, annotation.ErrInvalidSearchQuery
 		}
-		notes = append(notes, annotation.ReconstructAgentNote(
-			annotation.NoteID(noteID), mid, author, content, createdAt,
-		))
+		clauses = append(clauses, "rowid IN (SELECT rowid FROM agent_notes_fts WHERE agent_notes_fts MATCH ?)")
+		args = append(args, match)
 	}
-	if notes == nil {
-		notes = []*annotation.AgentNote{}
+
+	query := "SELECT " + noteColumns + " FROM agent_notes WHERE " + strings.Join(clauses, " AND ") +
+		" ORDER BY created_at DESC, id"
+	if filter.Limit > 0 || filter.Offset > 0 {
+		limit := filter.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, filter.Offset)
 	}
-	return notes, rows.Err()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanNotes(rows)
+}
+
+// ftsQuery turns free text into an FTS query matching notes that contain
+// every word. Each word is quoted so that FTS operators in the text are
+// taken literally; quotes themselves are dropped, as the tokenizer would.
+func ftsQuery(text string) string {
+	var terms []string
+	for _, w := range strings.Fields(strings.ReplaceAll(text, `"`, " ")) {
+		terms = append(terms, `"`+w+`"`)
+	}
+	return strings.Join(terms, " ")
 }
 
-func (r *NoteRepository) Delete(_ context.Context, id annotation.NoteID) error {
-	result, err := r.db.Exec("DELETE FROM agent_notes WHERE id = ?", string(id))
+// Delete removes the note and its revision history.
+func (r *NoteRepository) Delete(ctx context.Context, id annotation.NoteID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM agent_notes WHERE id = ?", string(id))
 	if err != nil {
 		return err
 	}
@@ -124,7 +285,67 @@ func (r *NoteRepository) Delete(_ context.Context, id annotation.NoteID) error {
 	if affected == 0 {
 		return annotation.ErrNoteNotFound
 	}
-	return nil
+	if _, err := tx.ExecContext(ctx, "DELETE FROM note_revisions WHERE note_id = ?", string(id)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+type rowScanner interface{ Scan(dest ...any) error }
+
+func scanNote(row rowScanner) (*annotation.AgentNote, error) {
+	var (
+		noteID          string
+		meetingID       string
+		author          string
+		content         string
+		createdAt       time.Time
+		kind            string
+		anchorStart     sql.NullTime
+		anchorEnd       sql.NullTime
+		anchorUtterance sql.NullInt64
+		parentID        sql.NullString
+		revision        int
+		updatedBy       string
+		updatedAt       sql.NullTime
+		idempotencyKey  sql.NullString
+	)
+	if err := row.Scan(&noteID, &meetingID, &author, &content, &createdAt,
+		&kind, &anchorStart, &anchorEnd, &anchorUtterance, &parentID,
+		&revision, &updatedBy, &updatedAt, &idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	// Anchors were validated when the note was written.
+	var anchor annotation.Anchor
+	switch {
+	case anchorStart.Valid:
+		anchor, _ = annotation.NewTimeRangeAnchor(anchorStart.Time, anchorEnd.Time)
+	case anchorUtterance.Valid:
+		anchor, _ = annotation.NewUtteranceAnchor(int(anchorUtterance.Int64))
+	}
+
+	note := annotation.ReconstructAgentNote(
+		annotation.NoteID(noteID), meetingID, author, content, createdAt,
+		annotation.NoteKind(kind), anchor, annotation.NoteID(parentID.String),
+		revision, updatedBy, updatedAt.Time,
+	)
+	_ = note.SetIdempotencyKey(idempotencyKey.String)
+	return note, nil
+}
+
+func scanNotes(rows *sql.Rows) ([]*annotation.AgentNote, error) {
+	defer func() { _ = rows.Close() }()
+
+	notes := []*annotation.AgentNote{}
+	for rows.Next() {
+		note, err := scanNote(rows)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+	return notes, rows.Err()
 }
 
 var _ annotation.NoteRepository = (*NoteRepository)(nil)
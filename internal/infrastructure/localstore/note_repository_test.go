@@ -2,7 +2,10 @@ package localstore_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/acai/internal/domain/annotation"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
@@ -141,3 +144,228 @@ func TestNoteRepository_SaveOverwrite(t *testing.T) {
 		t.Errorf("got content %q, want %q", found.Content(), "updated")
 	}
 }
+
+func TestNoteRepository_KeepsKindAnchorAndParent(t *testing.T) {
+	repo := setupNoteRepo(t)
+	ctx := context.Background()
+
+	start := time.Date(2026, 1, 15, 10, 5, 0, 0, time.UTC)
+	anchor, _ := annotation.NewTimeRangeAnchor(start, start.Add(time.Minute))
+	risk, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Vendor lock-in")
+	_ = risk.Classify(annotation.KindRisk)
+	risk.AnchorTo(anchor)
+	reply, _ := annotation.NewAgentNote("n-2", "m-1", "gpt", "Mitigated by the exit clause")
+	_ = reply.ReplyTo(risk)
+	utterance, _ := annotation.NewUtteranceAnchor(0)
+	reply.AnchorTo(utterance)
+	for _, n := range []*annotation.AgentNote{risk, reply} {
+		if err := repo.Save(ctx, n); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+	}
+
+	found, err := repo.FindByID(ctx, "n-1")
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	gotStart, gotEnd, ok := found.Anchor().TimeRange()
+	if found.Kind() != annotation.KindRisk || !ok || !gotStart.Equal(start) || !gotEnd.Equal(start.Add(time.Minute)) {
+		t.Errorf("got kind %q, anchor %v-%v (%v)", found.Kind(), gotStart, gotEnd, ok)
+	}
+	if found.IsReply() {
+		t.Error("top-level note reported as a reply")
+	}
+
+	found, err = repo.FindByID(ctx, "n-2")
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if i, ok := found.Anchor().UtteranceIndex(); !ok || i != 0 {
+		t.Errorf("got utterance anchor %d, %v", i, ok)
+	}
+	if found.ParentID() != "n-1" || found.Kind() != annotation.KindNote {
+		t.Errorf("got parent %q, kind %q", found.ParentID(), found.Kind())
+	}
+}
+
+func TestNoteRepository_UpdateKeepsRevisions(t *testing.T) {
+	repo := setupNoteRepo(t)
+	ctx := context.Background()
+
+	note, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Budget is blocked")
+	if err := repo.Save(ctx, note); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	prior, _ := note.Edit("Budget approved on Friday", "alice", 1)
+	if err := repo.Update(ctx, note, prior); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, "n-1")
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if found.Content() != "Budget approved on Friday" || found.Revision() != 2 || found.UpdatedBy() != "alice" || found.Author() != "claude" {
+		t.Errorf("found = %q revision %d by %q, author %q", found.Content(), found.Revision(), found.UpdatedBy(), found.Author())
+	}
+
+	revisions, err := repo.ListRevisions(ctx, "n-1")
+	if err != nil {
+		t.Fatalf("list revisions: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].Number() != 1 || revisions[0].Content() != "Budget is blocked" || revisions[0].Author() != "claude" {
+		t.Errorf("revisions = %+v", revisions)
+	}
+
+	// A second writer still holding revision 1 loses.
+	stale, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Budget is blocked")
+	stalePrior, _ := stale.Edit("Budget cut", "bob", 1)
+	if err := repo.Update(ctx, stale, stalePrior); err != annotation.ErrRevisionConflict {
+		t.Errorf("stale update: got %v, want ErrRevisionConflict", err)
+	}
+	if revisions, _ := repo.ListRevisions(ctx, "n-1"); len(revisions) != 1 {
+		t.Errorf("stale update recorded a revision: %d revisions", len(revisions))
+	}
+
+	if err := repo.Delete(ctx, "n-1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if revisions, _ := repo.ListRevisions(ctx, "n-1"); len(revisions) != 0 {
+		t.Errorf("delete kept %d revisions", len(revisions))
+	}
+}
+
+func TestNoteRepository_Search(t *testing.T) {
+	repo := setupNoteRepo(t)
+	ctx := context.Background()
+
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i, n := range []struct {
+		id, meeting, author, content string
+		kind                         annotation.NoteKind
+	}{
+		{"n-1", "m-1", "claude", "Budget approval is blocked by finance", annotation.KindRisk},
+		{"n-2", "m-1", "gpt", "Team agreed to ship the beta", annotation.KindDecision},
+		{"n-3", "m-2", "claude", "Finance approved the budget", annotation.KindDecision},
+		{"n-4", "m-2", "claude", `Ask about "budget" AND headcount`, annotation.KindQuestion},
+	} {
+		note := annotation.ReconstructAgentNote(annotation.NoteID(n.id), n.meeting, n.author, n.content,
+			base.Add(time.Duration(i)*time.Hour), n.kind, annotation.Anchor{}, "", 1, "", time.Time{})
+		if err := repo.Save(ctx, note); err != nil {
+			t.Fatalf("save %s: %v", n.id, err)
+		}
+	}
+	since := base.Add(time.Hour)
+
+	cases := []struct {
+		name   string
+		filter annotation.NoteFilter
+		want   []annotation.NoteID
+	}{
+		{"all, newest first", annotation.NoteFilter{}, []annotation.NoteID{"n-4", "n-3", "n-2", "n-1"}},
+		{"every word across meetings", annotation.NoteFilter{Query: "finance BUDGET"}, []annotation.NoteID{"n-3", "n-1"}},
+		{"operators taken literally", annotation.NoteFilter{Query: `"budget" AND`}, []annotation.NoteID{"n-4"}},
+		{"author and kind", annotation.NoteFilter{Author: "claude", Kind: annotation.KindDecision}, []annotation.NoteID{"n-3"}},
+		{"meeting and date range", annotation.NoteFilter{MeetingID: "m-1", Since: &since}, []annotation.NoteID{"n-2"}},
+		{"page", annotation.NoteFilter{Limit: 2, Offset: 1}, []annotation.NoteID{"n-3", "n-2"}},
+		{"punctuation only", annotation.NoteFilter{Query: "- ?"}, nil},
+	}
+	for _, c := range cases {
+		notes, err := repo.Search(ctx, c.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		var got []annotation.NoteID
+		for _, n := range notes {
+			got = append(got, n.ID())
+		}
+		if fmt.Sprint(got) != fmt.Sprint(c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	// A query with nothing to match on is rejected rather than matching
+	// every note.
+	for _, q := range []string{`""`, "  \t"} {
+		if _, err := repo.Search(ctx, annotation.NoteFilter{Query: q}); !errors.Is(err, annotation.ErrInvalidSearchQuery) {
+			t.Errorf("query %q: got error %v, want %v", q, err, annotation.ErrInvalidSearchQuery)
+		}
+	}
+
+	// Edits, overwrites and deletes keep the index current.
+	n1, _ := repo.FindByID(ctx, "n-1")
+	prior, _ := n1.Edit("Headcount freeze lifted", "alice", 1)
+	if err := repo.Update(ctx, n1, prior); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	n2, _ := repo.FindByID(ctx, "n-2")
+	_ = n2.Classify(annotation.KindInsight)
+	if err := repo.Save(ctx, n2); err != nil {
+		t.Fatalf("resave: %v", err)
+	}
+	if err := repo.Delete(ctx, "n-3"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if notes, _ := repo.Search(ctx, annotation.NoteFilter{Query: "finance"}); len(notes) != 0 {
+		t.Errorf("stale matches for finance: %d", len(notes))
+	}
+	if notes, _ := repo.Search(ctx, annotation.NoteFilter{Query: "headcount"}); len(notes) != 2 {
+		t.Errorf("got %d matches for headcount, want 2", len(notes))
+	}
+	if notes, _ := repo.Search(ctx, annotation.NoteFilter{Query: "beta"}); len(notes) != 1 {
+		t.Errorf("re-saved note lost from the index: %d matches", len(notes))
+	}
+}
+
+func TestNoteRepository_IdempotencyKey(t *testing.T) {
+	repo := setupNoteRepo(t)
+	ctx := context.Background()
+
+	keyed := func(id annotation.NoteID, key string) *annotation.AgentNote {
+		note, _ := annotation.NewAgentNote(id, "m-1", "claude", "observation")
+		_ = note.SetIdempotencyKey(key)
+		return note
+	}
+
+	if err := repo.Save(ctx, keyed("n-1", "req-1")); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	// Notes without a key never conflict.
+	for _, id := range []annotation.NoteID{"n-2", "n-3"} {
+		if err := repo.Save(ctx, keyed(id, "")); err != nil {
+			t.Fatalf("save %s: %v", id, err)
+		}
+	}
+	// Saving the keyed note again overwrites it.
+	if err := repo.Save(ctx, keyed("n-1", "req-1")); err != nil {
+		t.Fatalf("resave: %v", err)
+	}
+
+	if err := repo.Save(ctx, keyed("n-4", "req-1")); !errors.Is(err, annotation.ErrDuplicateIdempotencyKey) {
+		t.Errorf("duplicate key: got %v, want ErrDuplicateIdempotencyKey", err)
+	}
+	if _, err := repo.FindByID(ctx, "n-4"); !errors.Is(err, annotation.ErrNoteNotFound) {
+		t.Errorf("note with duplicate key was stored: %v", err)
+	}
+
+	// Keys are scoped to the author.
+	other, _ := annotation.NewAgentNote("n-5", "m-1", "gpt", "observation")
+	_ = other.SetIdempotencyKey("req-1")
+	if err := repo.Save(ctx, other); err != nil {
+		t.Fatalf("same key, other author: %v", err)
+	}
+
+	found, err := repo.FindByIdempotencyKey(ctx, "claude", "req-1")
+	if err != nil {
+		t.Fatalf("find by key: %v", err)
+	}
+	if found.ID() != "n-1" || found.IdempotencyKey() != "req-1" {
+		t.Errorf("found (%s, %q), want (n-1, req-1)", found.ID(), found.IdempotencyKey())
+	}
+	if found, err := repo.FindByIdempotencyKey(ctx, "gpt", "req-1"); err != nil || found.ID() != "n-5" {
+		t.Errorf("other author's key: got %v, %v; want n-5", found, err)
+	}
+	if _, err := repo.FindByIdempotencyKey(ctx, "claude", "req-2"); !errors.Is(err, annotation.ErrNoteNotFound) {
+		t.Errorf("unknown key: got %v, want ErrNoteNotFound", err)
+	}
+}
@@ -3,39 +3,71 @@
 // decorator chain, writes go directly to local SQLite.
 package localstore
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
 
-// InitSchema creates the local store tables if they don't exist.
+	"github.com/felixgeelhaar/acai/internal/infrastructure/migrate"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations is the versioned schema of local.db.
+var Migrations = migrate.MustLoad("localstore", migrationFiles, "migrations",
+	migrate.Migration{Version: 2, Name: "outbox_retry_columns", Func: addOutboxRetryColumns},
+)
+
+// InitSchema applies pending local store migrations.
 func InitSchema(db *sql.DB) error {
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS agent_notes (
-			id         TEXT PRIMARY KEY,
-			meeting_id TEXT NOT NULL,
-			author     TEXT NOT NULL,
-			content    TEXT NOT NULL,
-			created_at DATETIME NOT NULL
-		);
-		CREATE INDEX IF NOT EXISTS idx_agent_notes_meeting ON agent_notes(meeting_id);
-
-		CREATE TABLE IF NOT EXISTS action_item_overrides (
-			action_item_id TEXT PRIMARY KEY,
-			meeting_id     TEXT NOT NULL,
-			text           TEXT,
-			completed      INTEGER,
-			updated_at     DATETIME NOT NULL
-		);
-		CREATE INDEX IF NOT EXISTS idx_action_item_overrides_meeting ON action_item_overrides(meeting_id);
-
-		CREATE TABLE IF NOT EXISTS outbox_entries (
-			id         TEXT PRIMARY KEY,
-			event_type TEXT NOT NULL,
-			payload    BLOB NOT NULL,
-			status     TEXT NOT NULL DEFAULT 'pending',
-			created_at DATETIME NOT NULL,
-			synced_at  DATETIME,
-			attempts   INTEGER NOT NULL DEFAULT 0
-		);
-		CREATE INDEX IF NOT EXISTS idx_outbox_status ON outbox_entries(status);
-	`)
+	_, err := migrate.Up(context.Background(), db, Migrations)
+	return err
+}
+
+// addOutboxRetryColumns adds the outbox retry columns to tables created by
+// releases that predate them. The baseline creates them for new installs,
+// so each column is added only when missing.
+func addOutboxRetryColumns(ctx context.Context, tx *sql.Tx) error {
+	if err := ensureColumn(ctx, tx, "outbox_entries", "next_attempt_at", "DATETIME"); err != nil {
+		return err
+	}
+	if err := ensureColumn(ctx, tx, "outbox_entries", "last_error", "TEXT"); err != nil {
+		return err
+	}
+	return ensureColumn(ctx, tx, "outbox_entries", "sink", "TEXT NOT NULL DEFAULT ''")
+}
+
+// ensureColumn adds column to table unless it already exists.
+func ensureColumn(ctx context.Context, tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			colType    string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultVal, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_ = rows.Close()
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
 	return err
 }
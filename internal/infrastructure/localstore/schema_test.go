@@ -24,7 +24,7 @@ func TestInitSchema_CreatesAllTables(t *testing.T) {
 		t.Fatalf("init schema: %v", err)
 	}
 
-	tables := []string{"agent_notes", "action_item_overrides", "outbox_entries"}
+	tables := []string{"agent_notes", "note_revisions", "action_item_overrides", "outbox_entries", "task_links", "events", "webhook_subscriptions", "webhook_deliveries", "generated_summaries", "meeting_content_hashes", "local_cache_documents"}
 	for _, table := range tables {
 		var name string
 		err := db.QueryRow(
@@ -45,3 +45,55 @@ func TestInitSchema_Idempotent(t *testing.T) {
 		t.Fatalf("second init should be idempotent: %v", err)
 	}
 }
+
+func TestInitSchema_AddsOutboxRetryColumnsToExistingTable(t *testing.T) {
+	db := openTestDB(t)
+	// Outbox table as created by earlier releases, without retry columns.
+	if _, err := db.Exec(`CREATE TABLE outbox_entries (
+		id TEXT PRIMARY KEY, event_type TEXT NOT NULL, payload BLOB NOT NULL,
+		status TEXT NOT NULL DEFAULT 'pending', created_at DATETIME NOT NULL,
+		synced_at DATETIME, attempts INTEGER NOT NULL DEFAULT 0)`); err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	if _, err := db.Exec("SELECT next_attempt_at, last_error FROM outbox_entries"); err != nil {
+		t.Errorf("retry columns missing after upgrade: %v", err)
+	}
+}
+
+func TestInitSchema_UpgradesLegacyInstall(t *testing.T) {
+	db := openTestDB(t)
+	// action_item_overrides as created before versioned migrations.
+	if _, err := db.Exec(`CREATE TABLE action_item_overrides (
+		action_item_id TEXT PRIMARY KEY, meeting_id TEXT NOT NULL, text TEXT,
+		completed INTEGER, updated_at DATETIME NOT NULL)`); err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO action_item_overrides VALUES ('ai-1', 'm-1', 'Ship it', 1, CURRENT_TIMESTAMP)`); err != nil {
+		t.Fatalf("insert legacy row: %v", err)
+	}
+
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+
+	var text string
+	var owner sql.NullString
+	if err := db.QueryRow("SELECT text, owner FROM action_item_overrides WHERE action_item_id = 'ai-1'").Scan(&text, &owner); err != nil {
+		t.Fatalf("owner column missing after upgrade: %v", err)
+	}
+	if text != "Ship it" || owner.Valid {
+		t.Errorf("legacy row changed: text %q, owner %v", text, owner)
+	}
+
+	var applied int
+	if err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE component = 'localstore'").Scan(&applied); err != nil {
+		t.Fatalf("schema_migrations: %v", err)
+	}
+	if applied != len(localstore.Migrations.Migrations) {
+		t.Errorf("recorded %d migrations, want %d", applied, len(localstore.Migrations.Migrations))
+	}
+}
@@ -0,0 +1,103 @@
+package localstore
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// SummaryOverlay decorates a domain.Repository, attaching locally generated
+// summaries to meetings read without one. A summary from Granola always
+// takes precedence.
+type SummaryOverlay struct {
+	inner     domain.Repository
+	summaries domain.SummaryStore
+}
+
+// NewSummaryOverlay creates a repository decorator that fills in generated
+// summaries.
+func NewSummaryOverlay(inner domain.Repository, summaries domain.SummaryStore) *SummaryOverlay {
+	return &SummaryOverlay{inner: inner, summaries: summaries}
+}
+
+func (r *SummaryOverlay) FindByID(ctx context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+	mtg, err := r.inner.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.overlay(ctx, []*domain.Meeting{mtg}); err != nil {
+		return nil, err
+	}
+	return mtg, nil
+}
+
+func (r *SummaryOverlay) List(ctx context.Context, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	meetings, err := r.inner.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return meetings, r.overlay(ctx, meetings)
+}
+
+func (r *SummaryOverlay) SearchTranscripts(ctx context.Context, query string, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	meetings, err := r.inner.SearchTranscripts(ctx, query, filter)
+	if err != nil {
+		return nil, err
+	}
+	return meetings, r.overlay(ctx, meetings)
+}
+
+func (r *SummaryOverlay) GetTranscript(ctx context.Context, id domain.MeetingID) (*domain.Transcript, error) {
+	return r.inner.GetTranscript(ctx, id)
+}
+
+func (r *SummaryOverlay) GetActionItems(ctx context.Context, id domain.MeetingID) ([]*domain.ActionItem, error) {
+	return r.inner.GetActionItems(ctx, id)
+}
+
+func (r *SummaryOverlay) Sync(ctx context.Context, since *time.Time) ([]domain.DomainEvent, error) {
+	return r.inner.Sync(ctx, since)
+}
+
+// MeetingStats passes through to the inner repository's aggregation, so a
+// projection below the overlay keeps answering stats queries.
+func (r *SummaryOverlay) MeetingStats(ctx context.Context, filter domain.ListFilter) (*domain.Stats, error) {
+	if reader, ok := r.inner.(domain.StatsReader); ok {
+		return reader.MeetingStats(ctx, filter)
+	}
+	return nil, domain.ErrStatsUnavailable
+}
+
+// overlay attaches stored summaries to the meetings that lack one. The
+// meetings are freshly read, so attaching is reconstitution: no events.
+func (r *SummaryOverlay) overlay(ctx context.Context, meetings []*domain.Meeting) error {
+	var missing []domain.MeetingID
+	for _, m := range meetings {
+		if m != nil && m.Summary() == nil {
+			missing = append(missing, m.ID())
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	summaries, err := r.summaries.FindSummaries(ctx, missing)
+	if err != nil {
+		return err
+	}
+	for _, m := range meetings {
+		if m == nil || m.Summary() != nil {
+			continue
+		}
+		if s, ok := summaries[m.ID()]; ok {
+			m.AttachSummary(s)
+			m.ClearDomainEvents()
+		}
+	}
+	return nil
+}
+
+var (
+	_ domain.Repository  = (*SummaryOverlay)(nil)
+	_ domain.StatsReader = (*SummaryOverlay)(nil)
+)
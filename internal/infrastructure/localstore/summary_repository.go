@@ -0,0 +1,68 @@
+package localstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// maxSummaryLookup bounds the IDs bound in one FindSummaries query, below
+// SQLite's default limit on host parameters.
+const maxSummaryLookup = 500
+
+// SummaryRepository implements domain.SummaryStore using SQLite.
+type SummaryRepository struct {
+	db *sql.DB
+}
+
+// NewSummaryRepository creates a new SQLite-backed summary store.
+func NewSummaryRepository(db *sql.DB) *SummaryRepository {
+	return &SummaryRepository{db: db}
+}
+
+func (r *SummaryRepository) SaveSummary(ctx context.Context, summary domain.Summary) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT OR REPLACE INTO generated_summaries (meeting_id, content, kind, generated_at)
+		VALUES (?, ?, ?, ?)`,
+		string(summary.MeetingID()), summary.Content(), string(summary.Kind()), time.Now().UTC(),
+	)
+	return err
+}
+
+func (r *SummaryRepository) FindSummaries(ctx context.Context, ids []domain.MeetingID) (map[domain.MeetingID]domain.Summary, error) {
+	summaries := make(map[domain.MeetingID]domain.Summary)
+	for start := 0; start < len(ids); start += maxSummaryLookup {
+		batch := ids[start:min(start+maxSummaryLookup, len(ids))]
+		args := make([]any, len(batch))
+		for i, id := range batch {
+			args[i] = string(id)
+		}
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT meeting_id, content, kind FROM generated_summaries
+			WHERE meeting_id IN (?`+strings.Repeat(", ?", len(batch)-1)+`)`,
+			args...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id, content, kind string
+			if err := rows.Scan(&id, &content, &kind); err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+			summaries[domain.MeetingID(id)] = domain.NewSummary(domain.MeetingID(id), content, domain.SummaryKind(kind))
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return summaries, nil
+}
+
+var _ domain.SummaryStore = (*SummaryRepository)(nil)
@@ -0,0 +1,137 @@
+package localstore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+)
+
+func setupSummaryRepo(t *testing.T) *localstore.SummaryRepository {
+	t.Helper()
+	db := openTestDB(t)
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	return localstore.NewSummaryRepository(db)
+}
+
+func TestSummaryRepository_SaveAndFind(t *testing.T) {
+	repo := setupSummaryRepo(t)
+	ctx := context.Background()
+
+	if err := repo.SaveSummary(ctx, domain.NewSummary("m-1", "First draft", domain.SummaryGenerated)); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := repo.SaveSummary(ctx, domain.NewSummary("m-1", "Regenerated", domain.SummaryGenerated)); err != nil {
+		t.Fatalf("save again: %v", err)
+	}
+
+	found, err := repo.FindSummaries(ctx, []domain.MeetingID{"m-1", "m-2"})
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("got %d summaries, want 1", len(found))
+	}
+	if s := found["m-1"]; s.Content() != "Regenerated" || s.Kind() != domain.SummaryGenerated || s.MeetingID() != "m-1" {
+		t.Errorf("unexpected summary %+v", s)
+	}
+
+	if found, err := repo.FindSummaries(ctx, nil); err != nil || len(found) != 0 {
+		t.Errorf("no IDs: got %v, %v", found, err)
+	}
+}
+
+func TestSummaryRepository_FindManyIDs(t *testing.T) {
+	repo := setupSummaryRepo(t)
+	ctx := context.Background()
+
+	ids := make([]domain.MeetingID, 1200)
+	for i := range ids {
+		ids[i] = domain.MeetingID(fmt.Sprintf("m-%d", i))
+	}
+	for _, id := range []domain.MeetingID{"m-3", "m-700", "m-1199"} {
+		_ = repo.SaveSummary(ctx, domain.NewSummary(id, "s", domain.SummaryGenerated))
+	}
+
+	found, err := repo.FindSummaries(ctx, ids)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if len(found) != 3 {
+		t.Errorf("got %d summaries, want 3", len(found))
+	}
+}
+
+// stubRepository serves fixed meetings; only the methods the overlay
+// decorates do anything.
+type stubRepository struct {
+	domain.Repository
+	meetings []*domain.Meeting
+}
+
+func (r *stubRepository) FindByID(_ context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+	for _, m := range r.meetings {
+		if m.ID() == id {
+			return m, nil
+		}
+	}
+	return nil, domain.ErrMeetingNotFound
+}
+
+func (r *stubRepository) List(context.Context, domain.ListFilter) ([]*domain.Meeting, error) {
+	return r.meetings, nil
+}
+
+func (r *stubRepository) SearchTranscripts(context.Context, string, domain.ListFilter) ([]*domain.Meeting, error) {
+	return r.meetings, nil
+}
+
+func TestSummaryOverlay_FillsMissingSummaries(t *testing.T) {
+	ctx := context.Background()
+	summaries := setupSummaryRepo(t)
+	_ = summaries.SaveSummary(ctx, domain.NewSummary("m-1", "Generated for m-1", domain.SummaryGenerated))
+	_ = summaries.SaveSummary(ctx, domain.NewSummary("m-2", "Generated for m-2", domain.SummaryGenerated))
+
+	var meetings []*domain.Meeting
+	for _, id := range []domain.MeetingID{"m-1", "m-2", "m-3"} {
+		m, _ := domain.New(id, "Meeting "+string(id), time.Now().UTC(), domain.SourceZoom, nil)
+		m.ClearDomainEvents()
+		meetings = append(meetings, m)
+	}
+	meetings[1].AttachSummary(domain.NewSummary("m-2", "From Granola", domain.SummaryAuto))
+	meetings[1].ClearDomainEvents()
+
+	overlay := localstore.NewSummaryOverlay(&stubRepository{meetings: meetings}, summaries)
+	listed, err := overlay.List(ctx, domain.ListFilter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+
+	if s := listed[0].Summary(); s == nil || s.Content() != "Generated for m-1" || s.Kind() != domain.SummaryGenerated {
+		t.Errorf("m-1: got %+v, want the generated summary", s)
+	}
+	if s := listed[1].Summary(); s == nil || s.Content() != "From Granola" {
+		t.Errorf("m-2: got %+v, want Granola's summary to win", s)
+	}
+	if listed[2].Summary() != nil {
+		t.Errorf("m-3: got %+v, want none", listed[2].Summary())
+	}
+	for _, m := range listed {
+		if len(m.DomainEvents()) != 0 {
+			t.Errorf("%s: overlay raised events %v", m.ID(), m.DomainEvents())
+		}
+	}
+
+	found, err := overlay.FindByID(ctx, "m-1")
+	if err != nil || found.Summary() == nil {
+		t.Errorf("find by ID: got %v, %v", found, err)
+	}
+	if _, err := overlay.FindByID(ctx, "m-9"); err != domain.ErrMeetingNotFound {
+		t.Errorf("unknown meeting: got %v", err)
+	}
+}
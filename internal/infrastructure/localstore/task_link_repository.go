@@ -0,0 +1,62 @@
+package localstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// TaskLinkRepository implements domain.TaskLinkRepository using SQLite.
+// It maps action items to the tickets they were filed as in external trackers.
+type TaskLinkRepository struct {
+	db *sql.DB
+}
+
+// NewTaskLinkRepository creates a new SQLite-backed task link repository.
+func NewTaskLinkRepository(db *sql.DB) *TaskLinkRepository {
+	return &TaskLinkRepository{db: db}
+}
+
+func (r *TaskLinkRepository) GetTaskLink(_ context.Context, sink string, id domain.ActionItemID) (*domain.TaskLink, error) {
+	var (
+		link   domain.TaskLink
+		itemID string
+		closed int
+	)
+	err := r.db.QueryRow(
+		`SELECT action_item_id, sink, external_id, fingerprint, closed, updated_at
+		FROM task_links WHERE sink = ? AND action_item_id = ?`,
+		sink, string(id),
+	).Scan(&itemID, &link.Sink, &link.ExternalID, &link.Fingerprint, &closed, &link.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTaskLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	link.ActionItemID = domain.ActionItemID(itemID)
+	link.Closed = closed == 1
+	return &link, nil
+}
+
+func (r *TaskLinkRepository) SaveTaskLink(_ context.Context, link domain.TaskLink) error {
+	var closed int
+	if link.Closed {
+		closed = 1
+	}
+	updatedAt := link.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+	_, err := r.db.Exec(
+		`INSERT OR REPLACE INTO task_links
+			(action_item_id, sink, external_id, fingerprint, closed, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		string(link.ActionItemID), link.Sink, link.ExternalID, link.Fingerprint, closed, updatedAt.UTC(),
+	)
+	return err
+}
+
+var _ domain.TaskLinkRepository = (*TaskLinkRepository)(nil)
@@ -0,0 +1,77 @@
+package localstore_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+)
+
+func setupTaskLinkRepo(t *testing.T) *localstore.TaskLinkRepository {
+	t.Helper()
+	db := openTestDB(t)
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	return localstore.NewTaskLinkRepository(db)
+}
+
+func TestTaskLinkRepository_SaveAndGet(t *testing.T) {
+	repo := setupTaskLinkRepo(t)
+	ctx := context.Background()
+
+	err := repo.SaveTaskLink(ctx, domain.TaskLink{
+		ActionItemID: "ai-1",
+		Sink:         "github",
+		ExternalID:   "42",
+		Fingerprint:  "abc",
+	})
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	link, err := repo.GetTaskLink(ctx, "github", "ai-1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if link.ExternalID != "42" || link.Fingerprint != "abc" || link.Closed {
+		t.Errorf("unexpected link %+v", link)
+	}
+	if link.UpdatedAt.IsZero() {
+		t.Error("updated_at should be set")
+	}
+}
+
+func TestTaskLinkRepository_UpsertKeepsOneRowPerSink(t *testing.T) {
+	repo := setupTaskLinkRepo(t)
+	ctx := context.Background()
+
+	_ = repo.SaveTaskLink(ctx, domain.TaskLink{ActionItemID: "ai-1", Sink: "github", ExternalID: "42"})
+	_ = repo.SaveTaskLink(ctx, domain.TaskLink{ActionItemID: "ai-1", Sink: "jira", ExternalID: "OPS-7"})
+	_ = repo.SaveTaskLink(ctx, domain.TaskLink{ActionItemID: "ai-1", Sink: "github", ExternalID: "42", Closed: true})
+
+	gh, err := repo.GetTaskLink(ctx, "github", "ai-1")
+	if err != nil {
+		t.Fatalf("get github: %v", err)
+	}
+	if !gh.Closed {
+		t.Error("github link should be closed after upsert")
+	}
+	jira, err := repo.GetTaskLink(ctx, "jira", "ai-1")
+	if err != nil {
+		t.Fatalf("get jira: %v", err)
+	}
+	if jira.ExternalID != "OPS-7" {
+		t.Errorf("got jira id %q", jira.ExternalID)
+	}
+}
+
+func TestTaskLinkRepository_NotFound(t *testing.T) {
+	repo := setupTaskLinkRepo(t)
+
+	_, err := repo.GetTaskLink(context.Background(), "github", "missing")
+	if err != domain.ErrTaskLinkNotFound {
+		t.Errorf("got error %v, want %v", err, domain.ErrTaskLinkNotFound)
+	}
+}
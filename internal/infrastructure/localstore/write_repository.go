@@ -9,7 +9,8 @@ import (
 )
 
 // WriteRepository implements domain.WriteRepository using SQLite.
-// It stores local overrides for action items (text, completion state).
+// It stores local overrides for action items (text, completion state), along
+// with the owner and due date of the item they replace.
 type WriteRepository struct {
 	db *sql.DB
 }
@@ -26,9 +27,10 @@ func (r *WriteRepository) SaveActionItemState(_ context.Context, item *domain.Ac
 	}
 	_, err := r.db.Exec(
 		`INSERT OR REPLACE INTO action_item_overrides
-			(action_item_id, meeting_id, text, completed, updated_at)
-		VALUES (?, ?, ?, ?, ?)`,
-		string(item.ID()), string(item.MeetingID()), item.Text(), completed, time.Now().UTC(),
+			(action_item_id, meeting_id, text, completed, owner, due_date, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		string(item.ID()), string(item.MeetingID()), item.Text(), completed,
+		item.Owner(), item.DueDate(), time.Now().UTC(),
 	)
 	return err
 }
@@ -39,11 +41,13 @@ func (r *WriteRepository) GetLocalActionItemState(_ context.Context, id domain.A
 		meetingID    string
 		text         sql.NullString
 		completed    sql.NullInt64
+		owner        sql.NullString
+		dueDate      sql.NullTime
 	)
 	err := r.db.QueryRow(
-		"SELECT action_item_id, meeting_id, text, completed FROM action_item_overrides WHERE action_item_id = ?",
+		"SELECT action_item_id, meeting_id, text, completed, owner, due_date FROM action_item_overrides WHERE action_item_id = ?",
 		string(id),
-	).Scan(&actionItemID, &meetingID, &text, &completed)
+	).Scan(&actionItemID, &meetingID, &text, &completed, &owner, &dueDate)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrMeetingNotFound
 	}
@@ -56,12 +60,17 @@ func (r *WriteRepository) GetLocalActionItemState(_ context.Context, id domain.A
 		itemText = text.String
 	}
 
+	var due *time.Time
+	if dueDate.Valid {
+		due = &dueDate.Time
+	}
+
 	item, err := domain.NewActionItem(
 		domain.ActionItemID(actionItemID),
 		domain.MeetingID(meetingID),
-		"", // owner not stored in overrides
+		owner.String, // empty for overrides saved before owners were kept
 		itemText,
-		nil,
+		due,
 	)
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package localstore_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
@@ -96,3 +97,25 @@ func TestWriteRepository_SaveOverwrite(t *testing.T) {
 		t.Error("should be completed after update")
 	}
 }
+
+func TestWriteRepository_KeepsOwnerAndDueDate(t *testing.T) {
+	repo := setupWriteRepo(t)
+	ctx := context.Background()
+
+	due := time.Date(2025, 7, 1, 17, 0, 0, 0, time.UTC)
+	item, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Write report", &due)
+	if err := repo.SaveActionItemState(ctx, item); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	found, err := repo.GetLocalActionItemState(ctx, "ai-1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if found.Owner() != "Alice" {
+		t.Errorf("got owner %q, want Alice", found.Owner())
+	}
+	if found.DueDate() == nil || !found.DueDate().Equal(due) {
+		t.Errorf("got due date %v, want %v", found.DueDate(), due)
+	}
+}
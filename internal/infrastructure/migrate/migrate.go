@@ -0,0 +1,280 @@
+// Package migrate applies versioned schema migrations to the SQLite
+// databases. Each component that owns tables (the local store, the API
+// cache, the projection) embeds its own migrations directory; the versions
+// applied to a database are recorded per component in schema_migrations.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidMigration   = errors.New("invalid migration")
+	ErrDuplicateMigration = errors.New("duplicate migration version")
+)
+
+// Migration is one versioned schema change, applied in a transaction. It
+// runs either SQL or, for changes SQL cannot express idempotently, Func.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+	Func    func(ctx context.Context, tx *sql.Tx) error
+}
+
+// Set is the ordered migrations of one component.
+type Set struct {
+	Component  string
+	Migrations []Migration
+}
+
+// fileName matches migration files: 0001_create_notes.sql.
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// Load reads the *.sql migrations in dir of fsys, named
+// <version>_<name>.sql, plus any extra (Go) migrations, ordered by version.
+func Load(component string, fsys fs.FS, dir string, extra ...Migration) (Set, error) {
+	set := Set{Component: component, Migrations: append([]Migration(nil), extra...)}
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return Set{}, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		m := fileName.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return Set{}, fmt.Errorf("%w: %s/%s", ErrInvalidMigration, component, entry.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return Set{}, err
+		}
+		set.Migrations = append(set.Migrations, Migration{Version: version, Name: m[2], SQL: string(data)})
+	}
+
+	sort.Slice(set.Migrations, func(i, j int) bool { return set.Migrations[i].Version < set.Migrations[j].Version })
+	for i, m := range set.Migrations {
+		if m.Version <= 0 || (m.SQL == "") == (m.Func == nil) {
+			return Set{}, fmt.Errorf("%w: %s version %d", ErrInvalidMigration, component, m.Version)
+		}
+		if i > 0 && set.Migrations[i-1].Version == m.Version {
+			return Set{}, fmt.Errorf("%w: %s version %d", ErrDuplicateMigration, component, m.Version)
+		}
+	}
+	return set, nil
+}
+
+// MustLoad is Load for embedded migrations, which are checked by tests.
+func MustLoad(component string, fsys fs.FS, dir string, extra ...Migration) Set {
+	set, err := Load(component, fsys, dir, extra...)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// Entry is the state of one migration in a database.
+type Entry struct {
+	Component string
+	Version   int
+	Name      string
+	AppliedAt *time.Time // nil while pending
+}
+
+// Up applies the pending migrations of every set in order, each in its own
+// transaction, and returns the ones it applied.
+func Up(ctx context.Context, db *sql.DB, sets ...Set) ([]Entry, error) {
+	if err := ensureTable(ctx, db); err != nil {
+		return nil, err
+	}
+	var applied []Entry
+	for _, set := range sets {
+		done, err := appliedVersions(ctx, db, set.Component)
+		if err != nil {
+			return applied, err
+		}
+		for _, m := range set.Migrations {
+			if _, ok := done[m.Version]; ok {
+				continue
+			}
+			at, err := apply(ctx, db, set.Component, m)
+			if err != nil {
+				return applied, fmt.Errorf("migrate %s %04d_%s: %w", set.Component, m.Version, m.Name, err)
+			}
+			applied = append(applied, Entry{Component: set.Component, Version: m.Version, Name: m.Name, AppliedAt: &at})
+		}
+	}
+	return applied, nil
+}
+
+// Status reports every migration of the sets, applied or pending.
+func Status(ctx context.Context, db *sql.DB, sets ...Set) ([]Entry, error) {
+	if err := ensureTable(ctx, db); err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, set := range sets {
+		done, err := appliedVersions(ctx, db, set.Component)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range set.Migrations {
+			entry := Entry{Component: set.Component, Version: m.Version, Name: m.Name}
+			if at, ok := done[m.Version]; ok {
+				entry.AppliedAt = &at
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func apply(ctx context.Context, db *sql.DB, component string, m Migration) (time.Time, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if m.Func != nil {
+		err = m.Func(ctx, tx)
+	} else {
+		_, err = tx.ExecContext(ctx, m.SQL)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	now := time.Now().UTC()
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO schema_migrations (component, version, name, applied_at) VALUES (?, ?, ?, ?)",
+		component, m.Version, m.Name, now,
+	); err != nil {
+		return time.Time{}, err
+	}
+	return now, tx.Commit()
+}
+
+func ensureTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			component  TEXT NOT NULL,
+			version    INTEGER NOT NULL,
+			name       TEXT NOT NULL,
+			applied_at DATETIME NOT NULL,
+			PRIMARY KEY (component, version)
+		);
+	`)
+	return err
+}
+
+func appliedVersions(ctx context.Context, db *sql.DB, component string) (map[int]time.Time, error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT version, applied_at FROM schema_migrations WHERE component = ?", component)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	done := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		done[version] = at
+	}
+	return done, rows.Err()
+}
+
+// Migrator migrates one database file, backing it up first.
+type Migrator struct {
+	name string
+	path string
+	db   *sql.DB
+	sets []Set
+}
+
+// NewMigrator creates a migrator for the database at path, opened as db.
+// name labels it in command output, e.g. "local.db".
+func NewMigrator(name, path string, db *sql.DB, sets ...Set) *Migrator {
+	return &Migrator{name: name, path: path, db: db, sets: sets}
+}
+
+func (m *Migrator) Name() string { return m.name }
+func (m *Migrator) Path() string { return m.path }
+
+// Status reports every migration of the database, applied or pending.
+func (m *Migrator) Status(ctx context.Context) ([]Entry, error) {
+	return Status(ctx, m.db, m.sets...)
+}
+
+// Result describes one Migrate run.
+type Result struct {
+	Applied []Entry
+	Backup  string // path of the pre-migration copy; empty if none was taken
+}
+
+// Migrate applies pending migrations. An existing database is first copied
+// next to the original (<file>.bak-<UTC timestamp>), so a failed or
+// unwanted upgrade can be rolled back by restoring the copy.
+func (m *Migrator) Migrate(ctx context.Context) (Result, error) {
+	entries, err := m.Status(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	pending := false
+	for _, e := range entries {
+		pending = pending || e.AppliedAt == nil
+	}
+	if !pending {
+		return Result{}, nil
+	}
+
+	var result Result
+	if hasTables, err := m.hasUserTables(ctx); err != nil {
+		return Result{}, err
+	} else if hasTables {
+		result.Backup = fmt.Sprintf("%s.bak-%s", m.path, time.Now().UTC().Format("20060102T150405Z"))
+		if err := m.backup(ctx, result.Backup); err != nil {
+			return Result{}, fmt.Errorf("back up %s: %w", m.name, err)
+		}
+	}
+
+	result.Applied, err = Up(ctx, m.db, m.sets...)
+	return result, err
+}
+
+// hasUserTables reports whether the database holds tables other than
+// schema_migrations, i.e. whether there is anything worth backing up.
+func (m *Migrator) hasUserTables(ctx context.Context) (bool, error) {
+	var n int
+	err := m.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations', 'sqlite_sequence')",
+	).Scan(&n)
+	return n > 0, err
+}
+
+// backup writes a consistent copy of the database with VACUUM INTO.
+func (m *Migrator) backup(ctx context.Context, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", dest)
+	}
+	_, err := m.db.ExecContext(ctx, "VACUUM INTO ?", dest)
+	return err
+}
@@ -0,0 +1,170 @@
+package migrate_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/migrate"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openFileDB(t *testing.T) (*sql.DB, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "local.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db, path
+}
+
+func testSet(t *testing.T, files fstest.MapFS, extra ...migrate.Migration) migrate.Set {
+	t.Helper()
+	set, err := migrate.Load("notes", files, "migrations", extra...)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return set
+}
+
+var notesV1 = fstest.MapFS{
+	"migrations/0001_create_notes.sql": {Data: []byte("CREATE TABLE notes (id TEXT PRIMARY KEY, body TEXT NOT NULL);")},
+}
+
+func TestLoad_OrdersAndValidates(t *testing.T) {
+	set := testSet(t, fstest.MapFS{
+		"migrations/0002_add_author.sql":   {Data: []byte("ALTER TABLE notes ADD COLUMN author TEXT;")},
+		"migrations/0001_create_notes.sql": {Data: []byte("CREATE TABLE notes (id TEXT);")},
+		"migrations/README.md":             {Data: []byte("ignored")},
+	}, migrate.Migration{Version: 3, Name: "backfill", Func: func(context.Context, *sql.Tx) error { return nil }})
+
+	var names []string
+	for _, m := range set.Migrations {
+		names = append(names, m.Name)
+	}
+	if got := strings.Join(names, ","); got != "create_notes,add_author,backfill" {
+		t.Errorf("migrations = %s", got)
+	}
+
+	_, err := migrate.Load("notes", fstest.MapFS{"migrations/create.sql": {Data: []byte("x")}}, "migrations")
+	if !errors.Is(err, migrate.ErrInvalidMigration) {
+		t.Errorf("unversioned file: got %v, want ErrInvalidMigration", err)
+	}
+	_, err = migrate.Load("notes", notesV1, "migrations", migrate.Migration{Version: 1, Name: "dup", SQL: "SELECT 1"})
+	if !errors.Is(err, migrate.ErrDuplicateMigration) {
+		t.Errorf("duplicate version: got %v, want ErrDuplicateMigration", err)
+	}
+}
+
+func TestUp_AppliesOnceAndRecordsStatus(t *testing.T) {
+	db, _ := openFileDB(t)
+	ctx := context.Background()
+	set := testSet(t, notesV1)
+
+	applied, err := migrate.Up(ctx, db, set)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != 1 || applied[0].Version != 1 || applied[0].AppliedAt == nil {
+		t.Fatalf("applied = %+v", applied)
+	}
+	again, err := migrate.Up(ctx, db, set)
+	if err != nil || len(again) != 0 {
+		t.Errorf("second Up = %+v, %v; want nothing applied", again, err)
+	}
+
+	next := testSet(t, fstest.MapFS{
+		"migrations/0001_create_notes.sql": notesV1["migrations/0001_create_notes.sql"],
+		"migrations/0002_add_author.sql":   {Data: []byte("ALTER TABLE notes ADD COLUMN author TEXT;")},
+	})
+	status, err := migrate.Status(ctx, db, next)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(status) != 2 || status[0].AppliedAt == nil || status[1].AppliedAt != nil {
+		t.Errorf("status = %+v; want 0001 applied, 0002 pending", status)
+	}
+}
+
+func TestUp_RollsBackFailedMigration(t *testing.T) {
+	db, _ := openFileDB(t)
+	ctx := context.Background()
+	set := testSet(t, fstest.MapFS{
+		"migrations/0001_create_notes.sql": notesV1["migrations/0001_create_notes.sql"],
+		"migrations/0002_broken.sql":       {Data: []byte("CREATE TABLE tags (id TEXT); ALTER TABLE missing ADD COLUMN x TEXT;")},
+	})
+
+	applied, err := migrate.Up(ctx, db, set)
+	if err == nil || !strings.Contains(err.Error(), "0002_broken") {
+		t.Fatalf("Up error = %v, want the failing migration named", err)
+	}
+	if len(applied) != 1 {
+		t.Errorf("applied %d migrations before the failure, want 1", len(applied))
+	}
+
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'tags'").Scan(&n); err != nil || n != 0 {
+		t.Errorf("partial migration left table tags behind (n=%d, err=%v)", n, err)
+	}
+	status, _ := migrate.Status(ctx, db, set)
+	if status[1].AppliedAt != nil {
+		t.Error("failed migration recorded as applied")
+	}
+}
+
+func TestMigrator_BacksUpExistingDatabase(t *testing.T) {
+	db, path := openFileDB(t)
+	ctx := context.Background()
+
+	fresh := migrate.NewMigrator("local.db", path, db, testSet(t, notesV1))
+	result, err := fresh.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if result.Backup != "" {
+		t.Errorf("backed up an empty database to %s", result.Backup)
+	}
+	if _, err := db.Exec("INSERT INTO notes VALUES ('n-1', 'kept')"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	upgraded := migrate.NewMigrator("local.db", path, db, testSet(t, fstest.MapFS{
+		"migrations/0001_create_notes.sql": notesV1["migrations/0001_create_notes.sql"],
+		"migrations/0002_add_author.sql":   {Data: []byte("ALTER TABLE notes ADD COLUMN author TEXT;")},
+	}))
+	result, err = upgraded.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(result.Applied) != 1 || result.Backup == "" {
+		t.Fatalf("result = %+v; want one migration and a backup", result)
+	}
+
+	backup, err := sql.Open("sqlite3", result.Backup)
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	defer func() { _ = backup.Close() }()
+	var body string
+	if err := backup.QueryRow("SELECT body FROM notes WHERE id = 'n-1'").Scan(&body); err != nil || body != "kept" {
+		t.Errorf("backup row = %q, %v", body, err)
+	}
+	if _, err := backup.Exec("SELECT author FROM notes"); err == nil {
+		t.Error("backup should hold the pre-migration schema")
+	}
+
+	result, err = upgraded.Migrate(ctx)
+	if err != nil || result.Backup != "" || len(result.Applied) != 0 {
+		t.Errorf("up-to-date Migrate = %+v, %v; want a no-op", result, err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("database file: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package notepush
+
+import (
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+// noteEventTypes are the events that change a meeting's agent notes.
+var noteEventTypes = map[string]bool{
+	"note.added":   true,
+	"note.updated": true,
+	"note.deleted": true,
+}
+
+// queuedPush is the outbox payload of a push: the meeting whose document
+// needs its agent notes section rewritten, and the note that changed.
+type queuedPush struct {
+	MeetingID string `json:"meeting_id"`
+	NoteID    string `json:"note_id"`
+}
+
+// NewDispatcher creates a dispatcher decorator queueing an outbox entry
+// addressed to the push sink for every note event. The outbox relay
+// delivers the entries through Sink.
+func NewDispatcher(inner domain.EventDispatcher, store outbox.Store) *outbox.QueueDispatcher {
+	return outbox.NewQueueDispatcher(inner, store, SinkName, "push_", routeNoteEvent)
+}
+
+func routeNoteEvent(event domain.DomainEvent) (any, bool) {
+	if !noteEventTypes[event.EventName()] {
+		return nil, false
+	}
+	note, ok := event.(interface {
+		NoteID() string
+		MeetingID() string
+	})
+	if !ok {
+		return nil, false
+	}
+	return queuedPush{MeetingID: note.MeetingID(), NoteID: note.NoteID()}, true
+}
@@ -0,0 +1,196 @@
+package notepush
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+)
+
+// The agent notes section is delimited by HTML comments, which Granola's
+// markdown rendering hides. Each note is a block marked with its ID.
+const (
+	sectionStart = "<!-- acai:agent-notes -->"
+	sectionEnd   = "<!-- /acai:agent-notes -->"
+	sectionHead  = "## Agent notes\n\n_Added by acai. Edit or delete a note here and acai keeps your change._"
+	blockEnd     = "<!-- /acai:note -->"
+)
+
+var blockPattern = regexp.MustCompile(`(?s)<!-- acai:note (\S+) -->(.*?)` + regexp.QuoteMeta(blockEnd))
+
+// block is one note as written in the document.
+type block struct {
+	noteID string
+	body   string
+}
+
+func (b block) String() string {
+	return "<!-- acai:note " + b.noteID + " -->\n" + b.body + "\n" + blockEnd
+}
+
+// hash fingerprints a block body; the empty string stands for an absent block.
+func hash(body string, present bool) string {
+	if !present {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// renderNote formats a note as a block body.
+func renderNote(n *annotation.AgentNote) string {
+	label := strings.ToUpper(string(n.Kind())[:1]) + string(n.Kind())[1:]
+	if n.IsReply() {
+		label = "Reply"
+	}
+	return fmt.Sprintf("**%s** from %s, %s\n%s", label, n.Author(), n.CreatedAt().UTC().Format("2006-01-02 15:04 UTC"), n.Content())
+}
+
+// parseSection returns the note blocks in the document's agent notes
+// section, in document order.
+func parseSection(doc string) []block {
+	section, _, _, ok := findSection(doc)
+	if !ok {
+		return nil
+	}
+	var blocks []block
+	for _, m := range blockPattern.FindAllStringSubmatch(section, -1) {
+		blocks = append(blocks, block{noteID: m[1], body: strings.TrimSpace(m[2])})
+	}
+	return blocks
+}
+
+// findSection locates the agent notes section, markers included.
+func findSection(doc string) (section string, start, end int, ok bool) {
+	start = strings.Index(doc, sectionStart)
+	if start < 0 {
+		return "", 0, 0, false
+	}
+	n := strings.Index(doc[start:], sectionEnd)
+	if n < 0 {
+		return "", 0, 0, false
+	}
+	end = start + n + len(sectionEnd)
+	return doc[start:end], start, end, true
+}
+
+// writeSection replaces the document's agent notes section with blocks,
+// appending the section if the document has none and removing it if
+// blocks is empty. Text outside the section is left as it is.
+func writeSection(doc string, blocks []block) string {
+	var section string
+	if len(blocks) > 0 {
+		parts := []string{sectionStart, sectionHead}
+		for _, b := range blocks {
+			parts = append(parts, b.String())
+		}
+		section = strings.Join(parts, "\n\n") + "\n" + sectionEnd
+	}
+
+	if _, start, end, ok := findSection(doc); ok {
+		if section == "" {
+			return strings.TrimRight(doc[:start], "\n") + trimLeadingBlankLines(doc[end:])
+		}
+		return doc[:start] + section + doc[end:]
+	}
+	if section == "" {
+		return doc
+	}
+	if strings.TrimSpace(doc) == "" {
+		return section + "\n"
+	}
+	return strings.TrimRight(doc, "\n") + "\n\n" + section + "\n"
+}
+
+func trimLeadingBlankLines(s string) string {
+	trimmed := strings.TrimLeft(s, "\n")
+	if trimmed == "" {
+		return "\n"
+	}
+	return "\n\n" + trimmed
+}
+
+// blockState is what a note looked like on each side when the two were
+// last merged, as hashes of its block body ("" when absent).
+type blockState struct {
+	localHash    string
+	upstreamHash string
+	conflictedAt *time.Time
+}
+
+// mergeResult is the outcome of merging local notes into a document.
+type mergeResult struct {
+	blocks    []block
+	states    map[string]blockState // by note ID; notes absent on both sides are dropped
+	conflicts []string              // note IDs changed on both sides
+}
+
+// merge reconciles each note's local block with its block in the document,
+// using states to tell which side changed since the last merge:
+//
+//   - changed only locally: the local version is written (added, edited
+//     or removed);
+//   - changed only in the document: the human's edit or deletion is kept;
+//   - changed on both sides to different content: a conflict, and the
+//     human's version is kept.
+//
+// Local notes come first in their order, followed by blocks only the
+// document has.
+func merge(local, upstream []block, states map[string]blockState, now time.Time) mergeResult {
+	localByID := make(map[string]block, len(local))
+	upstreamByID := make(map[string]block, len(upstream))
+	var order []string
+	seen := make(map[string]bool)
+	for _, b := range local {
+		localByID[b.noteID] = b
+		if !seen[b.noteID] {
+			order, seen[b.noteID] = append(order, b.noteID), true
+		}
+	}
+	for _, b := range upstream {
+		upstreamByID[b.noteID] = b
+		if !seen[b.noteID] {
+			order, seen[b.noteID] = append(order, b.noteID), true
+		}
+	}
+	for id := range states {
+		if !seen[id] {
+			order, seen[id] = append(order, id), true
+		}
+	}
+
+	result := mergeResult{states: make(map[string]blockState)}
+	for _, id := range order {
+		ours, inLocal := localByID[id]
+		theirs, inUpstream := upstreamByID[id]
+		oursHash, theirsHash := hash(ours.body, inLocal), hash(theirs.body, inUpstream)
+		prior := states[id]
+
+		localChanged := oursHash != prior.localHash
+		upstreamChanged := theirsHash != prior.upstreamHash
+
+		keep, present := theirs, inUpstream
+		state := blockState{localHash: oursHash, conflictedAt: prior.conflictedAt}
+		switch {
+		case localChanged && !upstreamChanged:
+			keep, present = ours, inLocal
+		case localChanged && upstreamChanged && oursHash != theirsHash:
+			result.conflicts = append(result.conflicts, id)
+			at := now
+			state.conflictedAt = &at
+		}
+		if present {
+			result.blocks = append(result.blocks, keep)
+		}
+		state.upstreamHash = hash(keep.body, present)
+
+		if state.localHash != "" || state.upstreamHash != "" {
+			result.states[id] = state
+		}
+	}
+	return result
+}
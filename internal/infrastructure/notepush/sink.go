@@ -0,0 +1,119 @@
+// Package notepush pushes agent notes upstream into Granola. Each meeting's
+// notes are written as a marked section at the end of its Granola document
+// and kept in step with local edits and deletions, driven by the outbox
+// relay. A human's edits to that section win over the agent's.
+package notepush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/granola"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+// SinkName addresses outbox entries to the note push sink.
+const SinkName = "granola_notes"
+
+// maxStaleRetries bounds how often one delivery re-reads a document that
+// was edited between reading and writing it.
+const maxStaleRetries = 3
+
+// DocumentClient is the subset of granola.Client used to read and write
+// Granola documents.
+type DocumentClient interface {
+	GetDocument(ctx context.Context, path string) (*granola.DocumentDTO, error)
+	PutDocument(ctx context.Context, path, content, revision string) (*granola.DocumentDTO, error)
+}
+
+// Sink writes a meeting's agent notes into its Granola document.
+// It implements outbox.Sink and is registered with the relay via Route.
+type Sink struct {
+	client DocumentClient
+	path   string // document path; "{id}" is replaced by the meeting ID
+	notes  annotation.NoteRepository
+	store  *SQLiteStore
+	now    func() time.Time
+}
+
+// NewSink creates the note push sink. path is the document endpoint on the
+// Granola API, e.g. "/v1/notes/{id}/document".
+func NewSink(client DocumentClient, path string, notes annotation.NoteRepository, store *SQLiteStore) *Sink {
+	return &Sink{client: client, path: path, notes: notes, store: store, now: time.Now}
+}
+
+func (s *Sink) Name() string { return SinkName }
+
+// Deliver pushes the notes of the entry's meeting. Every push writes the
+// meeting's current notes, so replayed or out-of-order entries are harmless.
+func (s *Sink) Deliver(ctx context.Context, entry outbox.Entry) error {
+	var queued queuedPush
+	if err := json.Unmarshal(entry.Payload, &queued); err != nil || queued.MeetingID == "" {
+		return outbox.Permanent(fmt.Errorf("invalid note push payload: %s", entry.Payload))
+	}
+	return s.Push(ctx, queued.MeetingID)
+}
+
+// Push merges the meeting's agent notes into its Granola document. The
+// document is written only if it is still at the revision that was read;
+// if a human edited it in between, the merge is redone.
+func (s *Sink) Push(ctx context.Context, meetingID string) error {
+	notes, err := s.notes.ListByMeeting(ctx, meetingID)
+	if err != nil {
+		return fmt.Errorf("list notes: %w", err)
+	}
+	local := make([]block, len(notes))
+	for i, n := range notes {
+		local[i] = block{noteID: string(n.ID()), body: renderNote(n)}
+	}
+	path := strings.ReplaceAll(s.path, "{id}", url.PathEscape(meetingID))
+
+	for attempt := 0; attempt < maxStaleRetries; attempt++ {
+		doc, err := s.client.GetDocument(ctx, path)
+		if err != nil {
+			return classify(fmt.Errorf("get document: %w", err))
+		}
+		states, err := s.store.states(ctx, meetingID)
+		if err != nil {
+			return err
+		}
+
+		now := s.now().UTC()
+		result := merge(local, parseSection(doc.Content), states, now)
+		revision := doc.Revision
+		if content := writeSection(doc.Content, result.blocks); content != doc.Content {
+			updated, err := s.client.PutDocument(ctx, path, content, doc.Revision)
+			if errors.Is(err, granola.ErrStale) {
+				continue
+			}
+			if err != nil {
+				return classify(fmt.Errorf("put document: %w", err))
+			}
+			revision = updated.Revision
+		}
+
+		for _, id := range result.conflicts {
+			log.Printf("note push: note %s in meeting %s was also edited in Granola; keeping the Granola version", id, meetingID)
+		}
+		return s.store.save(ctx, meetingID, revision, result.states, now)
+	}
+	return fmt.Errorf("document for meeting %s kept changing during the push", meetingID)
+}
+
+// classify marks errors that retrying cannot fix as permanent: the meeting
+// has no Granola document, or Granola rejected the request.
+func classify(err error) error {
+	if errors.Is(err, granola.ErrNotFound) || errors.Is(err, granola.ErrRejected) {
+		return outbox.Permanent(err)
+	}
+	return err
+}
+
+var _ outbox.Sink = (*Sink)(nil)
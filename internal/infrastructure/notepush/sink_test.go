@@ -0,0 +1,339 @@
+package notepush_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/granola"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/notepush"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// fakeGranola serves one document per meeting at /v1/notes/{id}/document,
+// enforcing If-Match on writes.
+type fakeGranola struct {
+	mu       sync.Mutex
+	docs     map[string]string
+	revision map[string]int
+	puts     int
+
+	// beforePut runs once, after the next read and before its write,
+	// to simulate a human editing the document concurrently.
+	beforePut func()
+}
+
+func newFakeGranola(t *testing.T) (*fakeGranola, *granola.Client) {
+	t.Helper()
+	f := &fakeGranola{docs: map[string]string{}, revision: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(server.Close)
+	return f, granola.NewClient(server.URL, server.Client(), "test-token")
+}
+
+func (f *fakeGranola) serve(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/notes/"), "/document")
+	if r.Method == http.MethodPut && f.beforePut != nil {
+		hook := f.beforePut
+		f.beforePut = nil
+		hook()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	doc, ok := f.docs[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.Method == http.MethodPut {
+		if r.Header.Get("If-Match") != strconv.Itoa(f.revision[id]) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		var body granola.DocumentDTO
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		doc = body.Content
+		f.docs[id] = doc
+		f.revision[id]++
+		f.puts++
+	}
+	_ = json.NewEncoder(w).Encode(granola.DocumentDTO{Content: doc, Revision: strconv.Itoa(f.revision[id])})
+}
+
+// edit changes the document as a human would in Granola.
+func (f *fakeGranola) edit(id string, change func(string) string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.docs[id] = change(f.docs[id])
+	f.revision[id]++
+}
+
+func (f *fakeGranola) doc(id string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.docs[id]
+}
+
+type fixture struct {
+	db      *sql.DB
+	granola *fakeGranola
+	notes   *localstore.NoteRepository
+	store   *notepush.SQLiteStore
+	sink    *notepush.Sink
+}
+
+func setup(t *testing.T) *fixture {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+
+	fake, client := newFakeGranola(t)
+	fake.docs["m-1"] = "# Sprint planning\n\nWe agreed to ship on Friday.\n"
+	notes := localstore.NewNoteRepository(db)
+	store := notepush.NewSQLiteStore(db)
+	return &fixture{
+		db:      db,
+		granola: fake,
+		notes:   notes,
+		store:   store,
+		sink:    notepush.NewSink(client, "/v1/notes/{id}/document", notes, store),
+	}
+}
+
+func (f *fixture) addNote(t *testing.T, id, content string) *annotation.AgentNote {
+	t.Helper()
+	note, _ := annotation.NewAgentNote(annotation.NoteID(id), "m-1", "claude", content)
+	if err := f.notes.Save(context.Background(), note); err != nil {
+		t.Fatalf("save note: %v", err)
+	}
+	return note
+}
+
+func (f *fixture) editNote(t *testing.T, note *annotation.AgentNote, content string) {
+	t.Helper()
+	prior, err := note.Edit(content, "claude", note.Revision())
+	if err != nil {
+		t.Fatalf("edit note: %v", err)
+	}
+	if err := f.notes.Update(context.Background(), note, prior); err != nil {
+		t.Fatalf("update note: %v", err)
+	}
+}
+
+func (f *fixture) push(t *testing.T) string {
+	t.Helper()
+	if err := f.sink.Push(context.Background(), "m-1"); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	return f.granola.doc("m-1")
+}
+
+func TestSink_WritesNotesSectionAndTracksRevision(t *testing.T) {
+	f := setup(t)
+	f.addNote(t, "n-1", "Budget might be the blocker")
+	risk := f.addNote(t, "n-2", "Timeline is at risk")
+	_ = risk.Classify(annotation.KindRisk)
+	_ = f.notes.Save(context.Background(), risk)
+
+	doc := f.push(t)
+	if !strings.HasPrefix(doc, "# Sprint planning\n\nWe agreed to ship on Friday.\n\n<!-- acai:agent-notes -->") {
+		t.Errorf("section not appended after the existing content:\n%s", doc)
+	}
+	for _, want := range []string{"## Agent notes", "<!-- acai:note n-1 -->", "Budget might be the blocker", "**Risk** from claude", "Timeline is at risk"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document lacks %q:\n%s", want, doc)
+		}
+	}
+	if strings.Index(doc, "n-1") > strings.Index(doc, "n-2") {
+		t.Error("notes out of creation order")
+	}
+
+	var revision string
+	err := f.db.QueryRow("SELECT upstream_revision FROM note_push_documents WHERE meeting_id = 'm-1'").Scan(&revision)
+	if err != nil || revision != "1" {
+		t.Errorf("tracked revision = %q, %v; want 1", revision, err)
+	}
+
+	// Pushing again without changes leaves the document alone.
+	if f.push(t) != doc || f.granola.puts != 1 {
+		t.Errorf("unchanged push rewrote the document (%d writes)", f.granola.puts)
+	}
+}
+
+func TestSink_FollowsLocalEditsAndDeletes(t *testing.T) {
+	f := setup(t)
+	n1 := f.addNote(t, "n-1", "Budget might be the blocker")
+	f.addNote(t, "n-2", "Timeline is at risk")
+	f.push(t)
+
+	f.editNote(t, n1, "Budget approved on Friday")
+	if err := f.notes.Delete(context.Background(), "n-2"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	doc := f.push(t)
+	if !strings.Contains(doc, "Budget approved on Friday") || strings.Contains(doc, "Budget might be") {
+		t.Errorf("edit not pushed:\n%s", doc)
+	}
+	if strings.Contains(doc, "n-2") {
+		t.Errorf("deleted note still in the document:\n%s", doc)
+	}
+
+	if err := f.notes.Delete(context.Background(), "n-1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if doc := f.push(t); doc != "# Sprint planning\n\nWe agreed to ship on Friday.\n" {
+		t.Errorf("empty section not removed:\n%q", doc)
+	}
+}
+
+func TestSink_KeepsHumanEditsAndDeletions(t *testing.T) {
+	f := setup(t)
+	n1 := f.addNote(t, "n-1", "Budget might be the blocker")
+	n2 := f.addNote(t, "n-2", "Timeline is at risk")
+	f.push(t)
+
+	f.granola.edit("m-1", func(doc string) string {
+		doc = strings.Replace(doc, "Budget might be the blocker", "Budget is the blocker (Dana)", 1)
+		start := strings.Index(doc, "<!-- acai:note n-2 -->")
+		end := start + strings.Index(doc[start:], "<!-- /acai:note -->") + len("<!-- /acai:note -->")
+		return doc[:start] + strings.TrimLeft(doc[end:], "\n")
+	})
+	f.addNote(t, "n-3", "Follow up with finance")
+
+	doc := f.push(t)
+	if !strings.Contains(doc, "Budget is the blocker (Dana)") {
+		t.Errorf("human edit overwritten:\n%s", doc)
+	}
+	if strings.Contains(doc, "n-2") {
+		t.Errorf("note the human deleted was re-added:\n%s", doc)
+	}
+	if !strings.Contains(doc, "Follow up with finance") {
+		t.Errorf("new note not pushed:\n%s", doc)
+	}
+
+	// A later local edit is the newest change and is written.
+	f.editNote(t, n2, "Timeline slipped two weeks")
+	f.editNote(t, n1, "Budget approved")
+	doc = f.push(t)
+	if !strings.Contains(doc, "Timeline slipped two weeks") || !strings.Contains(doc, "Budget approved") {
+		t.Errorf("later local edits not pushed:\n%s", doc)
+	}
+	if conflicts, _ := f.store.PushConflicts(context.Background(), []annotation.NoteID{"n-1", "n-2", "n-3"}); len(conflicts) != 0 {
+		t.Errorf("got conflicts %v, want none", conflicts)
+	}
+}
+
+func TestSink_ConflictKeepsHumanVersion(t *testing.T) {
+	f := setup(t)
+	n1 := f.addNote(t, "n-1", "Budget might be the blocker")
+	f.push(t)
+
+	f.granola.edit("m-1", func(doc string) string {
+		return strings.Replace(doc, "Budget might be the blocker", "Budget is the blocker (Dana)", 1)
+	})
+	f.editNote(t, n1, "Budget approved")
+
+	doc := f.push(t)
+	if !strings.Contains(doc, "Budget is the blocker (Dana)") || strings.Contains(doc, "Budget approved") {
+		t.Errorf("conflict did not keep the human version:\n%s", doc)
+	}
+	conflicts, err := f.store.PushConflicts(context.Background(), []annotation.NoteID{"n-1", "n-2"})
+	if err != nil {
+		t.Fatalf("conflicts: %v", err)
+	}
+	if _, ok := conflicts["n-1"]; !ok || len(conflicts) != 1 {
+		t.Errorf("got conflicts %v, want n-1", conflicts)
+	}
+}
+
+func TestSink_RedoesMergeWhenDocumentChangesDuringPush(t *testing.T) {
+	f := setup(t)
+	f.addNote(t, "n-1", "Budget might be the blocker")
+	f.granola.beforePut = func() {
+		f.granola.edit("m-1", func(doc string) string { return doc + "Action: send the deck.\n" })
+	}
+
+	doc := f.push(t)
+	if !strings.Contains(doc, "Action: send the deck.") || !strings.Contains(doc, "Budget might be the blocker") {
+		t.Errorf("concurrent edit or note lost:\n%s", doc)
+	}
+}
+
+func TestSink_DeliverQueuedPush(t *testing.T) {
+	f := setup(t)
+	note := f.addNote(t, "n-1", "Budget might be the blocker")
+
+	store := &captureStore{}
+	d := notepush.NewDispatcher(&nopDispatcher{}, store)
+	events := []domain.DomainEvent{
+		annotation.NewNoteAddedEventFor(note),
+		domain.NewActionItemCompletedEvent("m-1", "ai-1"),
+	}
+	if err := d.Dispatch(context.Background(), events); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(store.entries) != 1 || store.entries[0].Sink != notepush.SinkName {
+		t.Fatalf("queued %+v, want one entry for %s", store.entries, notepush.SinkName)
+	}
+
+	if err := f.sink.Deliver(context.Background(), store.entries[0]); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if !strings.Contains(f.granola.doc("m-1"), "Budget might be the blocker") {
+		t.Error("queued push not delivered")
+	}
+
+	// A meeting without a Granola document cannot be pushed.
+	err := f.sink.Deliver(context.Background(), outbox.Entry{ID: "push_x", Payload: []byte(`{"meeting_id":"m-local"}`)})
+	if !outbox.IsPermanent(err) || !errors.Is(err, granola.ErrNotFound) {
+		t.Errorf("missing document: got %v, want a permanent ErrNotFound", err)
+	}
+}
+
+func TestDispatcher_QueueFailureDoesNotFailDispatch(t *testing.T) {
+	note, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Budget might be the blocker")
+	d := notepush.NewDispatcher(&nopDispatcher{}, &captureStore{err: errors.New("disk full")})
+
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{annotation.NewNoteAddedEventFor(note)}); err != nil {
+		t.Errorf("dispatch failed after the inner chain committed: %v", err)
+	}
+}
+
+type nopDispatcher struct{}
+
+func (nopDispatcher) Dispatch(context.Context, []domain.DomainEvent) error { return nil }
+
+// captureStore records appended outbox entries, or fails with err.
+type captureStore struct {
+	outbox.Store
+	entries []outbox.Entry
+	err     error
+}
+
+func (s *captureStore) Append(e outbox.Entry) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.entries = append(s.entries, e)
+	return nil
+}
@@ -0,0 +1,126 @@
+package notepush
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+)
+
+// SQLiteStore persists push state in the local database. Its tables are
+// created by local.db migration 0008_note_push.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore creates a new SQLite-backed push state store.
+func NewSQLiteStore(db *sql.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// maxConflictLookup bounds the note IDs bound to one query, below SQLite's
+// host parameter limit.
+const maxConflictLookup = 500
+
+// PushConflicts returns, for each of the given notes whose local edits lost
+// to a human edit in Granola, the time of the latest conflict.
+func (s *SQLiteStore) PushConflicts(ctx context.Context, ids []annotation.NoteID) (map[annotation.NoteID]time.Time, error) {
+	conflicts := make(map[annotation.NoteID]time.Time)
+	for start := 0; start < len(ids); start += maxConflictLookup {
+		batch := ids[start:min(start+maxConflictLookup, len(ids))]
+		args := make([]any, len(batch))
+		for i, id := range batch {
+			args[i] = string(id)
+		}
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT note_id, conflicted_at FROM note_push_blocks
+			WHERE conflicted_at IS NOT NULL AND note_id IN (?`+strings.Repeat(", ?", len(batch)-1)+`)`,
+			args...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var (
+				noteID       string
+				conflictedAt time.Time
+			)
+			if err := rows.Scan(&noteID, &conflictedAt); err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+			conflicts[annotation.NoteID(noteID)] = conflictedAt.UTC()
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return conflicts, nil
+}
+
+func (s *SQLiteStore) states(ctx context.Context, meetingID string) (map[string]blockState, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT note_id, local_hash, upstream_hash, conflicted_at FROM note_push_blocks WHERE meeting_id = ?", meetingID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	states := make(map[string]blockState)
+	for rows.Next() {
+		var (
+			noteID       string
+			st           blockState
+			conflictedAt sql.NullTime
+		)
+		if err := rows.Scan(&noteID, &st.localHash, &st.upstreamHash, &conflictedAt); err != nil {
+			return nil, err
+		}
+		if conflictedAt.Valid {
+			t := conflictedAt.Time.UTC()
+			st.conflictedAt = &t
+		}
+		states[noteID] = st
+	}
+	return states, rows.Err()
+}
+
+// save replaces the meeting's push state, in one transaction.
+func (s *SQLiteStore) save(ctx context.Context, meetingID, revision string, states map[string]blockState, pushedAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM note_push_blocks WHERE meeting_id = ?", meetingID); err != nil {
+		return err
+	}
+	for id, st := range states {
+		var conflictedAt *time.Time
+		if st.conflictedAt != nil {
+			t := st.conflictedAt.UTC()
+			conflictedAt = &t
+		}
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO note_push_blocks (note_id, meeting_id, local_hash, upstream_hash, conflicted_at) VALUES (?, ?, ?, ?, ?)",
+			id, meetingID, st.localHash, st.upstreamHash, conflictedAt,
+		); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx,
+		"INSERT OR REPLACE INTO note_push_documents (meeting_id, upstream_revision, pushed_at) VALUES (?, ?, ?)",
+		meetingID, revision, pushedAt.UTC(),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+var _ annotation.PushConflictReader = (*SQLiteStore)(nil)
@@ -2,17 +2,16 @@ package outbox
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/hex"
 	"fmt"
-	"time"
 
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
 )
 
 // writeEventTypes are the event types that should be persisted to the outbox.
 var writeEventTypes = map[string]bool{
 	"note.added":            true,
+	"note.updated":          true,
 	"note.deleted":          true,
 	"action_item.completed": true,
 	"action_item.updated":   true,
@@ -32,21 +31,25 @@ func NewDispatcher(inner domain.EventDispatcher, store Store) *Dispatcher {
 
 // Dispatch forwards all events to the inner dispatcher, and additionally
 // persists write-related events to the outbox for future upstream sync.
+// An entry takes the ID of its event, as recorded in the event store.
 func (d *Dispatcher) Dispatch(ctx context.Context, events []domain.DomainEvent) error {
+	ctx, ids := eventcodec.WithEventIDs(ctx, events)
+
 	// Always dispatch to inner (MCP sessions) first
 	if err := d.inner.Dispatch(ctx, events); err != nil {
 		return err
 	}
 
 	// Persist write events to outbox
-	for _, event := range events {
+	for i, event := range events {
 		if writeEventTypes[event.EventName()] {
-			payload, err := MarshalEventPayload(event)
+			id := ids[i]
+			payload, err := MarshalEventPayload(id, event)
 			if err != nil {
 				return fmt.Errorf("outbox marshal %s: %w", event.EventName(), err)
 			}
 			entry := Entry{
-				ID:        generateEntryID(event.EventName()),
+				ID:        id,
 				EventType: event.EventName(),
 				Payload:   payload,
 				CreatedAt: event.OccurredAt(),
@@ -59,12 +62,4 @@ func (d *Dispatcher) Dispatch(ctx context.Context, events []domain.DomainEvent)
 	return nil
 }
 
-// generateEntryID produces a unique outbox entry ID using
-// the event name, timestamp, and random bytes to avoid collisions.
-func generateEntryID(eventName string) string {
-	var b [8]byte
-	_, _ = rand.Read(b[:])
-	return fmt.Sprintf("%s-%d-%s", eventName, time.Now().UnixNano(), hex.EncodeToString(b[:]))
-}
-
 var _ domain.EventDispatcher = (*Dispatcher)(nil)
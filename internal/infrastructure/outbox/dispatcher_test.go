@@ -34,8 +34,12 @@ func (m *mockOutboxStore) Append(entry outbox.Entry) error {
 }
 
 func (m *mockOutboxStore) ListPending() ([]outbox.Entry, error) { return m.entries, nil }
-func (m *mockOutboxStore) MarkSynced(_ string) error            { return nil }
-func (m *mockOutboxStore) MarkFailed(_ string) error            { return nil }
+func (m *mockOutboxStore) ListDue(_ time.Time, _ int, _ []string) ([]outbox.Entry, error) {
+	return m.entries, nil
+}
+func (m *mockOutboxStore) MarkSynced(_ string) error                       { return nil }
+func (m *mockOutboxStore) MarkRetry(_ string, _ time.Time, _ string) error { return nil }
+func (m *mockOutboxStore) MarkFailed(_ string, _ string) error             { return nil }
 
 func TestOutboxDispatcher_PersistsWriteEvents(t *testing.T) {
 	inner := &mockInnerDispatcher{}
@@ -62,6 +66,15 @@ func TestOutboxDispatcher_PersistsWriteEvents(t *testing.T) {
 	if store.entries[0].EventType != "action_item.completed" {
 		t.Errorf("got event type %q", store.entries[0].EventType)
 	}
+
+	// Payload should be a self-describing envelope keyed by the entry ID
+	env, _, err := outbox.UnmarshalEventPayload(store.entries[0].Payload)
+	if err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if env.ID != store.entries[0].ID || env.AggregateID != "ai-1" {
+		t.Errorf("unexpected envelope %+v", env)
+	}
 }
 
 func TestOutboxDispatcher_SkipsNonWriteEvents(t *testing.T) {
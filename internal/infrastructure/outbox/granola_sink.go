@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/granola"
+)
+
+// GranolaWriter is the subset of granola.Client used to push events upstream.
+type GranolaWriter interface {
+	PostJSON(ctx context.Context, path string, body any) error
+}
+
+// GranolaSink pushes outbox entries to a Granola write API endpoint.
+// Requests Granola rejects as invalid are dead-lettered immediately.
+type GranolaSink struct {
+	client GranolaWriter
+	path   string
+}
+
+// NewGranolaSink creates a sink that POSTs deliveries to path on the Granola API.
+func NewGranolaSink(client GranolaWriter, path string) *GranolaSink {
+	return &GranolaSink{client: client, path: path}
+}
+
+func (s *GranolaSink) Name() string { return "granola" }
+
+func (s *GranolaSink) Deliver(ctx context.Context, entry Entry) error {
+	err := s.client.PostJSON(ctx, s.path, NewDelivery(entry))
+	if errors.Is(err, granola.ErrRejected) {
+		return Permanent(err)
+	}
+	return err
+}
+
+var _ Sink = (*GranolaSink)(nil)
@@ -0,0 +1,162 @@
+package outbox
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrEntryNotFound is returned when an outbox entry does not exist.
+var ErrEntryNotFound = errors.New("outbox entry not found")
+
+// TypeStats summarises outbox entries of one event type by status.
+type TypeStats struct {
+	EventType string `json:"event_type"`
+	Pending   int    `json:"pending"`
+	Failed    int    `json:"failed"`
+	Synced    int    `json:"synced"`
+}
+
+// Inspector is the operator-facing view of the outbox: listing, replaying,
+// and purging entries. It is kept separate from Store so the dispatcher and
+// relay only depend on what they use.
+type Inspector interface {
+	// List returns entries with the given status (all statuses when empty),
+	// newest first. A limit <= 0 means no limit.
+	List(status string, limit int) ([]Entry, error)
+	Get(id string) (*Entry, error)
+	// Retry resets an entry to pending with a fresh attempt budget, so the
+	// relay delivers it on its next pass. The last error is cleared and
+	// synced entries are replayed.
+	Retry(id string) error
+	// RetryAllFailed resets every dead-lettered entry and returns how many were reset.
+	RetryAllFailed() (int, error)
+	// Purge deletes entries with the given status last touched before the
+	// cutoff and returns how many were deleted.
+	Purge(status string, before time.Time) (int, error)
+	Stats() ([]TypeStats, error)
+}
+
+// ValidStatus reports whether status is a known entry status.
+func ValidStatus(status string) bool {
+	switch status {
+	case StatusPending, StatusSynced, StatusFailed:
+		return true
+	}
+	return false
+}
+
+func (s *SQLiteStore) List(status string, limit int) ([]Entry, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	if status == "" {
+		return s.query(
+			"SELECT "+entryColumns+" FROM outbox_entries ORDER BY created_at DESC LIMIT ?",
+			limit,
+		)
+	}
+	return s.query(
+		"SELECT "+entryColumns+" FROM outbox_entries WHERE status = ? ORDER BY created_at DESC LIMIT ?",
+		status, limit,
+	)
+}
+
+func (s *SQLiteStore) Get(id string) (*Entry, error) {
+	entries, err := s.query("SELECT "+entryColumns+" FROM outbox_entries WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrEntryNotFound
+	}
+	return &entries[0], nil
+}
+
+func (s *SQLiteStore) Retry(id string) error {
+	res, err := s.db.Exec(
+		"UPDATE outbox_entries SET status = 'pending', attempts = 0, next_attempt_at = NULL, synced_at = NULL, last_error = NULL WHERE id = ?",
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteStore) RetryAllFailed() (int, error) {
+	res, err := s.db.Exec(
+		"UPDATE outbox_entries SET status = 'pending', attempts = 0, next_attempt_at = NULL, last_error = NULL WHERE status = 'failed'",
+	)
+	return rowsAffected(res, err)
+}
+
+func (s *SQLiteStore) Purge(status string, before time.Time) (int, error) {
+	if !ValidStatus(status) {
+		return 0, fmt.Errorf("invalid outbox status %q", status)
+	}
+	res, err := s.db.Exec(
+		"DELETE FROM outbox_entries WHERE status = ? AND COALESCE(synced_at, created_at) < ?",
+		status, before.UTC(),
+	)
+	return rowsAffected(res, err)
+}
+
+func (s *SQLiteStore) Stats() ([]TypeStats, error) {
+	rows, err := s.db.Query("SELECT event_type, status, COUNT(*) FROM outbox_entries GROUP BY event_type, status")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	byType := make(map[string]*TypeStats)
+	for rows.Next() {
+		var eventType, status string
+		var count int
+		if err := rows.Scan(&eventType, &status, &count); err != nil {
+			return nil, err
+		}
+		st, ok := byType[eventType]
+		if !ok {
+			st = &TypeStats{EventType: eventType}
+			byType[eventType] = st
+		}
+		switch status {
+		case StatusPending:
+			st.Pending = count
+		case StatusFailed:
+			st.Failed = count
+		case StatusSynced:
+			st.Synced = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := make([]TypeStats, 0, len(byType))
+	for _, st := range byType {
+		stats = append(stats, *st)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].EventType < stats[j].EventType })
+	return stats, nil
+}
+
+func rowsAffected(res sql.Result, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+var _ Inspector = (*SQLiteStore)(nil)
@@ -0,0 +1,149 @@
+package outbox_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+func seedInspectStore(t *testing.T) *outbox.SQLiteStore {
+	t.Helper()
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	base := time.Now().UTC().Add(-time.Hour)
+	for i, e := range []struct{ id, typ string }{
+		{"evt-1", "note.added"},
+		{"evt-2", "note.added"},
+		{"evt-3", "action_item.completed"},
+	} {
+		if err := store.Append(outbox.Entry{ID: e.id, EventType: e.typ, CreatedAt: base.Add(time.Duration(i) * time.Minute)}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if err := store.MarkSynced("evt-1"); err != nil {
+		t.Fatalf("mark synced: %v", err)
+	}
+	if err := store.MarkFailed("evt-3", "boom"); err != nil {
+		t.Fatalf("mark failed: %v", err)
+	}
+	return store
+}
+
+func TestSQLiteStore_ListByStatus(t *testing.T) {
+	store := seedInspectStore(t)
+
+	all, err := store.List("", 0)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(all) != 3 || all[0].ID != "evt-3" {
+		t.Fatalf("expected 3 entries newest first, got %+v", all)
+	}
+
+	failed, err := store.List(outbox.StatusFailed, 0)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(failed) != 1 || failed[0].LastError != "boom" {
+		t.Errorf("unexpected failed entries: %+v", failed)
+	}
+}
+
+func TestSQLiteStore_GetNotFound(t *testing.T) {
+	store := seedInspectStore(t)
+
+	if _, err := store.Get("missing"); !errors.Is(err, outbox.ErrEntryNotFound) {
+		t.Errorf("expected ErrEntryNotFound, got %v", err)
+	}
+	e, err := store.Get("evt-2")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if e.EventType != "note.added" {
+		t.Errorf("got type %q", e.EventType)
+	}
+}
+
+func TestSQLiteStore_Retry(t *testing.T) {
+	store := seedInspectStore(t)
+
+	if err := store.Retry("evt-1"); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	e, _ := store.Get("evt-1")
+	if e.Status != outbox.StatusPending || e.SyncedAt != nil || e.Attempts != 0 {
+		t.Errorf("expected replayable pending entry, got %+v", e)
+	}
+	if err := store.Retry("evt-3"); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if e, _ := store.Get("evt-3"); e.LastError != "" {
+		t.Errorf("last error kept after retry: %q", e.LastError)
+	}
+	if err := store.Retry("missing"); !errors.Is(err, outbox.ErrEntryNotFound) {
+		t.Errorf("expected ErrEntryNotFound, got %v", err)
+	}
+}
+
+func TestSQLiteStore_RetryAllFailed(t *testing.T) {
+	store := seedInspectStore(t)
+
+	n, err := store.RetryAllFailed()
+	if err != nil {
+		t.Fatalf("retry all: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("got %d reset, want 1", n)
+	}
+	due, _ := store.ListDue(time.Now(), 0, []string{""})
+	if len(due) != 2 {
+		t.Errorf("expected 2 due entries, got %d", len(due))
+	}
+	if e, _ := store.Get("evt-3"); e.LastError != "" {
+		t.Errorf("last error kept after retry: %q", e.LastError)
+	}
+}
+
+func TestSQLiteStore_Purge(t *testing.T) {
+	store := seedInspectStore(t)
+
+	n, err := store.Purge(outbox.StatusSynced, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("recent entry purged")
+	}
+	n, err = store.Purge(outbox.StatusSynced, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("got %d purged, want 1", n)
+	}
+	if _, err := store.Purge("bogus", time.Now()); err == nil {
+		t.Error("expected error for invalid status")
+	}
+}
+
+func TestSQLiteStore_Stats(t *testing.T) {
+	store := seedInspectStore(t)
+
+	stats, err := store.Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	want := []outbox.TypeStats{
+		{EventType: "action_item.completed", Failed: 1},
+		{EventType: "note.added", Pending: 1, Synced: 1},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("got %+v", stats)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("stats[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+)
+
+// QueueRoute selects the events a QueueDispatcher queues and returns the
+// payload of each one's entry; ok is false for events it does not queue.
+type QueueRoute func(event domain.DomainEvent) (payload any, ok bool)
+
+// QueueDispatcher decorates a domain.EventDispatcher, queueing an outbox
+// entry addressed to one sink for every event its route accepts. The relay
+// delivers the entries through the sink of that name.
+type QueueDispatcher struct {
+	inner    domain.EventDispatcher
+	store    Store
+	sink     string
+	idPrefix string
+	route    QueueRoute
+}
+
+// NewQueueDispatcher creates a dispatcher decorator queueing entries for
+// sink, with IDs starting with idPrefix.
+func NewQueueDispatcher(inner domain.EventDispatcher, store Store, sink, idPrefix string, route QueueRoute) *QueueDispatcher {
+	return &QueueDispatcher{inner: inner, store: store, sink: sink, idPrefix: idPrefix, route: route}
+}
+
+// Dispatch forwards events to the inner dispatcher, then queues an entry for
+// each routed event. The change is committed by then, so an entry that
+// cannot be queued is logged rather than failing the use case.
+func (d *QueueDispatcher) Dispatch(ctx context.Context, events []domain.DomainEvent) error {
+	if err := d.inner.Dispatch(ctx, events); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		v, ok := d.route(event)
+		if !ok {
+			continue
+		}
+		payload, err := json.Marshal(v)
+		if err != nil {
+			log.Printf("outbox %s: marshal %s: %v", d.sink, event.EventName(), err)
+			continue
+		}
+		entry := Entry{
+			ID:        eventcodec.RandomID(d.idPrefix, 16),
+			EventType: event.EventName(),
+			Payload:   payload,
+			CreatedAt: event.OccurredAt(),
+			Sink:      d.sink,
+		}
+		if err := d.store.Append(entry); err != nil {
+			log.Printf("outbox %s: queue %s: %v", d.sink, event.EventName(), err)
+		}
+	}
+	return nil
+}
+
+var _ domain.EventDispatcher = (*QueueDispatcher)(nil)
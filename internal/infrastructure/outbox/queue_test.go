@@ -0,0 +1,69 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+// completedOnly routes action_item.completed events with their item ID.
+func completedOnly(event domain.DomainEvent) (any, bool) {
+	e, ok := event.(domain.ActionItemCompleted)
+	if !ok {
+		return nil, false
+	}
+	return map[string]string{"action_item_id": string(e.ActionItemID())}, true
+}
+
+func TestQueueDispatcher_QueuesRoutedEvents(t *testing.T) {
+	inner := &mockInnerDispatcher{}
+	store := &mockOutboxStore{}
+	d := outbox.NewQueueDispatcher(inner, store, "trackers", "task_", completedOnly)
+
+	events := []domain.DomainEvent{
+		domain.NewActionItemCompletedEvent("m-1", "ai-1"),
+		domain.NewActionItemUpdatedEvent("m-1", "ai-2", "Send the deck"),
+	}
+	if err := d.Dispatch(context.Background(), events); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(inner.dispatched) != 2 {
+		t.Errorf("inner got %d events, want 2", len(inner.dispatched))
+	}
+	if len(store.entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(store.entries))
+	}
+	e := store.entries[0]
+	if e.Sink != "trackers" || !strings.HasPrefix(e.ID, "task_") || string(e.Payload) != `{"action_item_id":"ai-1"}` {
+		t.Errorf("unexpected entry %+v", e)
+	}
+}
+
+func TestQueueDispatcher_InnerErrorStopsQueueing(t *testing.T) {
+	store := &mockOutboxStore{}
+	d := outbox.NewQueueDispatcher(&mockInnerDispatcher{err: errors.New("boom")}, store, "trackers", "task_", completedOnly)
+
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewActionItemCompletedEvent("m-1", "ai-1")}); err == nil {
+		t.Fatal("expected inner error")
+	}
+	if len(store.entries) != 0 {
+		t.Errorf("got %d entries, want 0 after inner failure", len(store.entries))
+	}
+}
+
+// failingOutboxStore fails every append.
+type failingOutboxStore struct{ mockOutboxStore }
+
+func (*failingOutboxStore) Append(outbox.Entry) error { return errors.New("disk full") }
+
+func TestQueueDispatcher_QueueFailureDoesNotFailDispatch(t *testing.T) {
+	d := outbox.NewQueueDispatcher(&mockInnerDispatcher{}, &failingOutboxStore{}, "trackers", "task_", completedOnly)
+
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewActionItemCompletedEvent("m-1", "ai-1")}); err != nil {
+		t.Errorf("dispatch failed after the inner chain committed: %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Sink delivers outbox entries to an external system. Delivery is
+// at-least-once: receivers should deduplicate on Entry.ID.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, entry Entry) error
+}
+
+// errPermanent marks delivery errors that retrying cannot fix.
+var errPermanent = errors.New("permanent delivery failure")
+
+// Permanent wraps err so the relay dead-letters the entry without retrying,
+// e.g. for a 4xx response to a malformed payload.
+func Permanent(err error) error {
+	return fmt.Errorf("%w: %w", errPermanent, err)
+}
+
+// IsPermanent reports whether err was wrapped with Permanent.
+func IsPermanent(err error) bool {
+	return errors.Is(err, errPermanent)
+}
+
+// RelayConfig controls polling and retry behaviour.
+type RelayConfig struct {
+	Interval       time.Duration // poll interval
+	BatchSize      int           // max entries per poll (0 = unlimited)
+	MaxAttempts    int           // deliveries before an entry is dead-lettered
+	InitialBackoff time.Duration // delay after the first failure; doubles per attempt
+	MaxBackoff     time.Duration
+}
+
+// DefaultRelayConfig returns conservative relay defaults.
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		Interval:       30 * time.Second,
+		BatchSize:      100,
+		MaxAttempts:    8,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     time.Hour,
+	}
+}
+
+// RelayResult summarises one relay pass.
+type RelayResult struct {
+	Delivered    int
+	Retried      int
+	DeadLettered int
+}
+
+// Relay polls the outbox and delivers due entries to every sink.
+// An entry is marked synced once all sinks accept it; otherwise it is
+// rescheduled with exponential backoff until MaxAttempts is reached,
+// after which it moves to the dead-letter status (StatusFailed).
+//
+// Entries addressed to a sink by name (Entry.Sink) are delivered only to the
+// matching routed sink; see Route.
+type Relay struct {
+	store  Store
+	sinks  []Sink
+	routed map[string]Sink
+	cfg    RelayConfig
+	now    func() time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex // serialises passes between the loop and RunOnce callers
+}
+
+// NewRelay creates a relay. Zero-valued config fields fall back to DefaultRelayConfig.
+func NewRelay(store Store, sinks []Sink, cfg RelayConfig) *Relay {
+	def := DefaultRelayConfig()
+	if cfg.Interval <= 0 {
+		cfg.Interval = def.Interval
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = def.MaxAttempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = def.InitialBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = def.MaxBackoff
+	}
+	return &Relay{store: store, sinks: sinks, routed: make(map[string]Sink), cfg: cfg, now: time.Now}
+}
+
+// Route registers a sink that receives only the entries addressed to it
+// (Entry.Sink == sink.Name()) instead of every unaddressed entry.
+// Call before Start.
+func (r *Relay) Route(sink Sink) {
+	r.routed[sink.Name()] = sink
+}
+
+// Sinks reports how many sinks are configured, including routed sinks.
+func (r *Relay) Sinks() int { return len(r.sinks) + len(r.routed) }
+
+// Start launches the background relay goroutine.
+// It returns immediately. Call Stop to shut down gracefully.
+func (r *Relay) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.run(ctx)
+}
+
+// Stop gracefully shuts down the relay.
+func (r *Relay) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.done != nil {
+		<-r.done
+	}
+}
+
+func (r *Relay) run(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("outbox relay: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce delivers all currently due entries.
+func (r *Relay) RunOnce(ctx context.Context) (RelayResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result RelayResult
+	if r.Sinks() == 0 {
+		return result, nil
+	}
+
+	// Only fetch entries some sink can take, so undeliverable entries stay
+	// pending without starving the batch.
+	var targets []string
+	if len(r.sinks) > 0 {
+		targets = append(targets, "")
+	}
+	for name := range r.routed {
+		targets = append(targets, name)
+	}
+
+	entries, err := r.store.ListDue(r.now().UTC(), r.cfg.BatchSize, targets)
+	if err != nil {
+		return result, fmt.Errorf("list due entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		deliveryErr := r.deliver(ctx, entry)
+		switch {
+		case deliveryErr == nil:
+			if err := r.store.MarkSynced(entry.ID); err != nil {
+				return result, fmt.Errorf("mark synced %s: %w", entry.ID, err)
+			}
+			result.Delivered++
+		case IsPermanent(deliveryErr) || entry.Attempts+1 >= r.cfg.MaxAttempts:
+			if err := r.store.MarkFailed(entry.ID, deliveryErr.Error()); err != nil {
+				return result, fmt.Errorf("mark failed %s: %w", entry.ID, err)
+			}
+			log.Printf("outbox relay: dead-lettered %s after %d attempts: %v", entry.ID, entry.Attempts+1, deliveryErr)
+			result.DeadLettered++
+		default:
+			next := r.now().UTC().Add(r.backoff(entry.Attempts))
+			if err := r.store.MarkRetry(entry.ID, next, deliveryErr.Error()); err != nil {
+				return result, fmt.Errorf("mark retry %s: %w", entry.ID, err)
+			}
+			result.Retried++
+		}
+	}
+	return result, nil
+}
+
+// deliver sends the entry to its routed sink, or to every sink when the
+// entry is unaddressed, returning the joined failures.
+func (r *Relay) deliver(ctx context.Context, entry Entry) error {
+	if entry.Sink != "" {
+		sink, ok := r.routed[entry.Sink]
+		if !ok {
+			return Permanent(fmt.Errorf("no sink named %q", entry.Sink))
+		}
+		if err := sink.Deliver(ctx, entry); err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+		return nil
+	}
+
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Deliver(ctx, entry); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backoff returns InitialBackoff * 2^attempts, capped at MaxBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := r.cfg.InitialBackoff
+	for i := 0; i < attempts; i++ {
+		d *= 2
+		if d >= r.cfg.MaxBackoff {
+			return r.cfg.MaxBackoff
+		}
+	}
+	return d
+}
@@ -0,0 +1,256 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+// scriptedSink returns the queued errors in order, then succeeds.
+type scriptedSink struct {
+	errs      []error
+	delivered []string
+}
+
+func (s *scriptedSink) Name() string { return "scripted" }
+
+func (s *scriptedSink) Deliver(_ context.Context, entry outbox.Entry) error {
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	s.delivered = append(s.delivered, entry.ID)
+	return nil
+}
+
+func appendEntry(t *testing.T, store outbox.Store, id string) {
+	t.Helper()
+	if err := store.Append(outbox.Entry{ID: id, EventType: "note.added", CreatedAt: time.Now().UTC()}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+}
+
+func entryStatus(t *testing.T, store *outbox.SQLiteStore, id string, now time.Time) (outbox.Entry, bool) {
+	t.Helper()
+	due, err := store.ListDue(now, 0, []string{""})
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	for _, e := range due {
+		if e.ID == id {
+			return e, true
+		}
+	}
+	return outbox.Entry{}, false
+}
+
+func TestRelay_DeliversAndMarksSynced(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	appendEntry(t, store, "evt-1")
+	sink := &scriptedSink{}
+
+	relay := outbox.NewRelay(store, []outbox.Sink{sink}, outbox.RelayConfig{})
+	result, err := relay.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if result.Delivered != 1 || len(sink.delivered) != 1 {
+		t.Errorf("got result %+v, delivered %v", result, sink.delivered)
+	}
+
+	pending, _ := store.ListPending()
+	if len(pending) != 0 {
+		t.Errorf("got %d pending after delivery, want 0", len(pending))
+	}
+}
+
+func TestRelay_RetriesWithBackoff(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	appendEntry(t, store, "evt-1")
+	sink := &scriptedSink{errs: []error{errors.New("connection refused")}}
+
+	relay := outbox.NewRelay(store, []outbox.Sink{sink}, outbox.RelayConfig{
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+		MaxAttempts:    5,
+	})
+	result, err := relay.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if result.Retried != 1 {
+		t.Fatalf("got result %+v, want 1 retried", result)
+	}
+
+	now := time.Now().UTC()
+	if _, due := entryStatus(t, store, "evt-1", now); due {
+		t.Error("entry should not be due before its backoff elapses")
+	}
+	entry, due := entryStatus(t, store, "evt-1", now.Add(2*time.Minute))
+	if !due {
+		t.Fatal("entry should be due after backoff")
+	}
+	if entry.Attempts != 1 || entry.LastError == "" {
+		t.Errorf("retry not recorded: %+v", entry)
+	}
+
+	// Nothing is due yet, so a second pass delivers nothing.
+	result, _ = relay.RunOnce(context.Background())
+	if result.Delivered != 0 {
+		t.Errorf("entry delivered before backoff elapsed: %+v", result)
+	}
+}
+
+func TestRelay_DeadLettersAfterMaxAttempts(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	appendEntry(t, store, "evt-1")
+	if err := store.MarkRetry("evt-1", time.Now().UTC().Add(-time.Second), "earlier failure"); err != nil {
+		t.Fatalf("mark retry: %v", err)
+	}
+	sink := &scriptedSink{errs: []error{errors.New("still down")}}
+
+	relay := outbox.NewRelay(store, []outbox.Sink{sink}, outbox.RelayConfig{MaxAttempts: 2})
+	result, err := relay.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if result.DeadLettered != 1 {
+		t.Fatalf("got result %+v, want 1 dead-lettered", result)
+	}
+	pending, _ := store.ListPending()
+	if len(pending) != 0 {
+		t.Errorf("dead-lettered entry should leave the pending queue")
+	}
+}
+
+func TestRelay_PermanentErrorDeadLettersImmediately(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	appendEntry(t, store, "evt-1")
+	sink := &scriptedSink{errs: []error{outbox.Permanent(errors.New("400 bad payload"))}}
+
+	relay := outbox.NewRelay(store, []outbox.Sink{sink}, outbox.RelayConfig{MaxAttempts: 10})
+	result, err := relay.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if result.DeadLettered != 1 {
+		t.Errorf("got result %+v, want 1 dead-lettered", result)
+	}
+}
+
+func TestRelay_NoSinksIsNoop(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	appendEntry(t, store, "evt-1")
+
+	result, err := outbox.NewRelay(store, nil, outbox.RelayConfig{}).RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if result != (outbox.RelayResult{}) {
+		t.Errorf("got %+v, want no work", result)
+	}
+	pending, _ := store.ListPending()
+	if len(pending) != 1 {
+		t.Error("entries must stay pending when no sink is configured")
+	}
+}
+
+func TestRelay_StartStop(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	appendEntry(t, store, "evt-1")
+	sink := &scriptedSink{}
+
+	relay := outbox.NewRelay(store, []outbox.Sink{sink}, outbox.RelayConfig{Interval: time.Hour})
+	relay.Start(context.Background())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if pending, _ := store.ListPending(); len(pending) == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	relay.Stop()
+
+	if pending, _ := store.ListPending(); len(pending) != 0 {
+		t.Error("relay should deliver on start")
+	}
+}
+
+// namedSink records deliveries under a configurable name.
+type namedSink struct {
+	name      string
+	delivered []string
+}
+
+func (s *namedSink) Name() string { return s.name }
+
+func (s *namedSink) Deliver(_ context.Context, entry outbox.Entry) error {
+	s.delivered = append(s.delivered, entry.ID)
+	return nil
+}
+
+func TestRelay_RoutesAddressedEntries(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	appendEntry(t, store, "evt-broadcast")
+	if err := store.Append(outbox.Entry{ID: "evt-routed", EventType: "note.added", Sink: "hooks", CreatedAt: time.Now().UTC()}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := store.Append(outbox.Entry{ID: "evt-orphan", EventType: "note.added", Sink: "gone", CreatedAt: time.Now().UTC()}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	broadcast := &namedSink{name: "file"}
+	routed := &namedSink{name: "hooks"}
+	relay := outbox.NewRelay(store, []outbox.Sink{broadcast}, outbox.RelayConfig{})
+	relay.Route(routed)
+
+	if relay.Sinks() != 2 {
+		t.Errorf("got %d sinks, want 2", relay.Sinks())
+	}
+	result, err := relay.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if result.Delivered != 2 {
+		t.Errorf("got result %+v", result)
+	}
+	if len(broadcast.delivered) != 1 || broadcast.delivered[0] != "evt-broadcast" {
+		t.Errorf("broadcast sink got %v", broadcast.delivered)
+	}
+	if len(routed.delivered) != 1 || routed.delivered[0] != "evt-routed" {
+		t.Errorf("routed sink got %v", routed.delivered)
+	}
+
+	// Entries addressed to an unknown sink stay pending
+	pending, _ := store.ListPending()
+	if len(pending) != 1 || pending[0].ID != "evt-orphan" {
+		t.Errorf("expected orphan entry to stay pending, got %+v", pending)
+	}
+}
+
+func TestRelay_RoutedOnlyLeavesBroadcastEntriesPending(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	appendEntry(t, store, "evt-1")
+
+	relay := outbox.NewRelay(store, nil, outbox.RelayConfig{})
+	relay.Route(&namedSink{name: "hooks"})
+
+	result, err := relay.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if result.Delivered != 0 {
+		t.Errorf("got result %+v", result)
+	}
+	pending, _ := store.ListPending()
+	if len(pending) != 1 {
+		t.Errorf("got %d pending, want 1", len(pending))
+	}
+}
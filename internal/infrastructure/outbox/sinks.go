@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Delivery is the JSON document sinks emit for each outbox entry.
+type Delivery struct {
+	ID        string          `json:"id"`
+	EventType string          `json:"event_type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Attempt   int             `json:"attempt"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// NewDelivery builds the wire document for entry. Attempt is 1-based.
+func NewDelivery(entry Entry) Delivery {
+	payload := json.RawMessage(entry.Payload)
+	if len(payload) == 0 || !json.Valid(payload) {
+		payload = json.RawMessage("{}")
+	}
+	return Delivery{
+		ID:        entry.ID,
+		EventType: entry.EventType,
+		CreatedAt: entry.CreatedAt.UTC(),
+		Attempt:   entry.Attempts + 1,
+		Payload:   payload,
+	}
+}
+
+// WebhookSink POSTs each entry as JSON to a fixed URL.
+// 2xx responses are success; 408, 429 and 5xx are retried; other 4xx
+// responses are permanent failures.
+type WebhookSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookSink creates a webhook sink. A nil httpClient uses a 10s timeout.
+func NewWebhookSink(url string, httpClient *http.Client) *WebhookSink {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: webhookTimeout}
+	}
+	return &WebhookSink{url: url, httpClient: httpClient}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Deliver(ctx context.Context, entry Entry) error {
+	body, err := json.Marshal(NewDelivery(entry))
+	if err != nil {
+		return Permanent(fmt.Errorf("encoding delivery: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(fmt.Errorf("creating request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Acai-Event", entry.EventType)
+	req.Header.Set("X-Acai-Delivery", entry.ID)
+	req.Header.Set("Idempotency-Key", entry.ID)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusRequestTimeout,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= 500:
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	default:
+		return Permanent(fmt.Errorf("webhook returned status %d", resp.StatusCode))
+	}
+}
+
+// FileSink appends each entry as one JSON line (NDJSON) to a local file,
+// e.g. for tailing into another tool or shipping with a log forwarder.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink creates a file sink writing to path. Parent directories are
+// created on first delivery.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Name() string { return "file" }
+
+func (s *FileSink) Deliver(_ context.Context, entry Entry) error {
+	line, err := json.Marshal(NewDelivery(entry))
+	if err != nil {
+		return Permanent(fmt.Errorf("encoding delivery: %w", err))
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var (
+	_ Sink = (*WebhookSink)(nil)
+	_ Sink = (*FileSink)(nil)
+)
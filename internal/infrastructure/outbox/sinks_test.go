@@ -0,0 +1,152 @@
+package outbox_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/granola"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+func testEntry() outbox.Entry {
+	return outbox.Entry{
+		ID:        "evt-1",
+		EventType: "note.added",
+		Payload:   []byte(`{"note_id":"n-1"}`),
+		CreatedAt: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookSink_Deliver(t *testing.T) {
+	var got outbox.Delivery
+	var headers http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sink := outbox.NewWebhookSink(srv.URL, srv.Client())
+	if err := sink.Deliver(context.Background(), testEntry()); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if got.ID != "evt-1" || got.EventType != "note.added" || got.Attempt != 1 {
+		t.Errorf("unexpected delivery %+v", got)
+	}
+	if string(got.Payload) != `{"note_id":"n-1"}` {
+		t.Errorf("got payload %s", got.Payload)
+	}
+	if headers.Get("X-Acai-Delivery") != "evt-1" || headers.Get("X-Acai-Event") != "note.added" {
+		t.Errorf("missing delivery headers: %v", headers)
+	}
+}
+
+func TestWebhookSink_StatusClassification(t *testing.T) {
+	tests := []struct {
+		status    int
+		permanent bool
+	}{
+		{http.StatusInternalServerError, false},
+		{http.StatusTooManyRequests, false},
+		{http.StatusBadRequest, true},
+		{http.StatusGone, true},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+		err := outbox.NewWebhookSink(srv.URL, srv.Client()).Deliver(context.Background(), testEntry())
+		srv.Close()
+
+		if err == nil {
+			t.Errorf("status %d: expected error", tt.status)
+			continue
+		}
+		if outbox.IsPermanent(err) != tt.permanent {
+			t.Errorf("status %d: permanent = %v, want %v", tt.status, outbox.IsPermanent(err), tt.permanent)
+		}
+	}
+}
+
+func TestFileSink_AppendsNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "events.ndjson")
+	sink := outbox.NewFileSink(path)
+
+	first := testEntry()
+	second := testEntry()
+	second.ID = "evt-2"
+	for _, e := range []outbox.Entry{first, second} {
+		if err := sink.Deliver(context.Background(), e); err != nil {
+			t.Fatalf("deliver: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var d outbox.Delivery
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			t.Fatalf("invalid json line %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, d.ID)
+	}
+	if len(ids) != 2 || ids[0] != "evt-1" || ids[1] != "evt-2" {
+		t.Errorf("got ids %v", ids)
+	}
+}
+
+type mockGranolaWriter struct {
+	path string
+	body any
+	err  error
+}
+
+func (m *mockGranolaWriter) PostJSON(_ context.Context, path string, body any) error {
+	m.path = path
+	m.body = body
+	return m.err
+}
+
+func TestGranolaSink_Deliver(t *testing.T) {
+	writer := &mockGranolaWriter{}
+	sink := outbox.NewGranolaSink(writer, "/v1/events")
+
+	if err := sink.Deliver(context.Background(), testEntry()); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if writer.path != "/v1/events" {
+		t.Errorf("got path %q", writer.path)
+	}
+	if d, ok := writer.body.(outbox.Delivery); !ok || d.ID != "evt-1" {
+		t.Errorf("unexpected body %#v", writer.body)
+	}
+}
+
+func TestGranolaSink_RejectedIsPermanent(t *testing.T) {
+	sink := outbox.NewGranolaSink(&mockGranolaWriter{err: granola.ErrRejected}, "/v1/events")
+	err := sink.Deliver(context.Background(), testEntry())
+	if !outbox.IsPermanent(err) {
+		t.Errorf("rejected request should be permanent, got %v", err)
+	}
+
+	sink = outbox.NewGranolaSink(&mockGranolaWriter{err: granola.ErrRateLimited}, "/v1/events")
+	err = sink.Deliver(context.Background(), testEntry())
+	if err == nil || outbox.IsPermanent(err) || !errors.Is(err, granola.ErrRateLimited) {
+		t.Errorf("rate limit should be retryable, got %v", err)
+	}
+}
@@ -5,29 +5,54 @@ package outbox
 
 import (
 	"database/sql"
-	"encoding/json"
+	"strings"
 	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+)
+
+// Entry statuses. StatusFailed is the dead-letter status: the relay gave up
+// on the entry and it is only delivered again after an explicit retry.
+const (
+	StatusPending = "pending"
+	StatusSynced  = "synced"
+	StatusFailed  = "failed"
 )
 
 // Entry represents a persisted outbox event.
 type Entry struct {
-	ID        string
-	EventType string
-	Payload   []byte
-	Status    string
-	CreatedAt time.Time
-	SyncedAt  *time.Time
-	Attempts  int
+	ID            string
+	EventType     string
+	Payload       []byte
+	Status        string
+	CreatedAt     time.Time
+	SyncedAt      *time.Time
+	Attempts      int
+	NextAttemptAt *time.Time
+	LastError     string
+	// Sink addresses the entry to a single named sink; empty means every
+	// unaddressed sink receives it.
+	Sink string
 }
 
 // Store is the interface for outbox persistence.
 type Store interface {
 	Append(entry Entry) error
 	ListPending() ([]Entry, error)
+	// ListDue returns pending entries whose next attempt is due at now,
+	// oldest first, restricted to the given Entry.Sink values ("" for
+	// unaddressed entries).
+	ListDue(now time.Time, limit int, sinks []string) ([]Entry, error)
 	MarkSynced(id string) error
-	MarkFailed(id string) error
+	// MarkRetry records a failed delivery and schedules the next attempt.
+	MarkRetry(id string, nextAttemptAt time.Time, lastErr string) error
+	// MarkFailed records a failed delivery and moves the entry to the dead-letter status.
+	MarkFailed(id string, lastErr string) error
 }
 
+const entryColumns = "id, event_type, payload, status, created_at, synced_at, attempts, next_attempt_at, last_error, sink"
+
 // SQLiteStore implements Store using SQLite.
 type SQLiteStore struct {
 	db *sql.DB
@@ -44,16 +69,41 @@ func (s *SQLiteStore) Append(entry Entry) error {
 		payload = []byte("{}")
 	}
 	_, err := s.db.Exec(
-		"INSERT INTO outbox_entries (id, event_type, payload, status, created_at, attempts) VALUES (?, ?, ?, ?, ?, ?)",
-		entry.ID, entry.EventType, payload, "pending", entry.CreatedAt.UTC(), 0,
+		"INSERT INTO outbox_entries (id, event_type, payload, status, created_at, attempts, sink) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		entry.ID, entry.EventType, payload, "pending", entry.CreatedAt.UTC(), 0, entry.Sink,
 	)
 	return err
 }
 
 func (s *SQLiteStore) ListPending() ([]Entry, error) {
-	rows, err := s.db.Query(
-		"SELECT id, event_type, payload, status, created_at, synced_at, attempts FROM outbox_entries WHERE status = 'pending' ORDER BY created_at ASC",
+	return s.query(
+		"SELECT " + entryColumns + " FROM outbox_entries WHERE status = 'pending' ORDER BY created_at ASC",
 	)
+}
+
+func (s *SQLiteStore) ListDue(now time.Time, limit int, sinks []string) ([]Entry, error) {
+	if len(sinks) == 0 {
+		return []Entry{}, nil
+	}
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	args := []any{now.UTC()}
+	for _, sink := range sinks {
+		args = append(args, sink)
+	}
+	args = append(args, limit)
+	return s.query(
+		"SELECT "+entryColumns+` FROM outbox_entries
+		WHERE status = 'pending' AND (next_attempt_at IS NULL OR next_attempt_at <= ?)
+		AND sink IN (?`+strings.Repeat(", ?", len(sinks)-1)+`)
+		ORDER BY created_at ASC LIMIT ?`,
+		args...,
+	)
+}
+
+func (s *SQLiteStore) query(query string, args ...any) ([]Entry, error) {
+	rows, err := s.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -62,13 +112,18 @@ func (s *SQLiteStore) ListPending() ([]Entry, error) {
 	var entries []Entry
 	for rows.Next() {
 		var e Entry
-		var syncedAt sql.NullTime
-		if err := rows.Scan(&e.ID, &e.EventType, &e.Payload, &e.Status, &e.CreatedAt, &syncedAt, &e.Attempts); err != nil {
+		var syncedAt, nextAttemptAt sql.NullTime
+		var lastError sql.NullString
+		if err := rows.Scan(&e.ID, &e.EventType, &e.Payload, &e.Status, &e.CreatedAt, &syncedAt, &e.Attempts, &nextAttemptAt, &lastError, &e.Sink); err != nil {
 			return nil, err
 		}
 		if syncedAt.Valid {
 			e.SyncedAt = &syncedAt.Time
 		}
+		if nextAttemptAt.Valid {
+			e.NextAttemptAt = &nextAttemptAt.Time
+		}
+		e.LastError = lastError.String
 		entries = append(entries, e)
 	}
 	if entries == nil {
@@ -86,17 +141,35 @@ func (s *SQLiteStore) MarkSynced(id string) error {
 	return err
 }
 
-func (s *SQLiteStore) MarkFailed(id string) error {
+func (s *SQLiteStore) MarkRetry(id string, nextAttemptAt time.Time, lastErr string) error {
+	_, err := s.db.Exec(
+		"UPDATE outbox_entries SET attempts = attempts + 1, next_attempt_at = ?, last_error = ? WHERE id = ?",
+		nextAttemptAt.UTC(), lastErr, id,
+	)
+	return err
+}
+
+func (s *SQLiteStore) MarkFailed(id string, lastErr string) error {
 	_, err := s.db.Exec(
-		"UPDATE outbox_entries SET status = 'failed', attempts = attempts + 1 WHERE id = ?",
-		id,
+		"UPDATE outbox_entries SET status = 'failed', attempts = attempts + 1, next_attempt_at = NULL, last_error = ? WHERE id = ?",
+		lastErr, id,
 	)
 	return err
 }
 
-// MarshalEventPayload serializes event data to JSON.
-func MarshalEventPayload(v any) ([]byte, error) {
-	return json.Marshal(v)
+// codec is the envelope registry used for outbox payloads.
+var codec = eventcodec.Default()
+
+// MarshalEventPayload serializes event as a versioned envelope carrying id,
+// so consumers can dedupe, replay, and upcast it.
+func MarshalEventPayload(id string, event domain.DomainEvent) ([]byte, error) {
+	return codec.Marshal(id, event)
+}
+
+// UnmarshalEventPayload parses an outbox payload back into its envelope and
+// domain event, upcasting older schema versions.
+func UnmarshalEventPayload(payload []byte) (eventcodec.Envelope, domain.DomainEvent, error) {
+	return codec.Unmarshal(payload)
 }
 
 var _ Store = (*SQLiteStore)(nil)
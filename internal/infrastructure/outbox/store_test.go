@@ -3,9 +3,11 @@ package outbox_test
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
 	_ "github.com/mattn/go-sqlite3"
@@ -94,7 +96,7 @@ func TestSQLiteStore_MarkFailed(t *testing.T) {
 		t.Fatalf("append: %v", err)
 	}
 
-	if err := store.MarkFailed("evt-1"); err != nil {
+	if err := store.MarkFailed("evt-1", "boom"); err != nil {
 		t.Fatalf("mark failed: %v", err)
 	}
 
@@ -144,12 +146,63 @@ func TestSQLiteStore_MultiplePending(t *testing.T) {
 	}
 }
 
-func TestMarshalEventPayload(t *testing.T) {
-	data, err := outbox.MarshalEventPayload(map[string]string{"key": "val"})
+func TestMarshalEventPayload_RoundTrip(t *testing.T) {
+	event := domain.NewActionItemUpdatedEvent("m-1", "ai-1", "Ship it")
+	data, err := outbox.MarshalEventPayload("evt-1", event)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if string(data) != `{"key":"val"}` {
-		t.Errorf("got %q", string(data))
+	if !strings.Contains(string(data), `"new_text":"Ship it"`) {
+		t.Errorf("expected event data in payload, got %s", data)
+	}
+
+	env, decoded, err := outbox.UnmarshalEventPayload(data)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if env.ID != "evt-1" || env.Type != "action_item.updated" || env.SchemaVersion != 1 || env.AggregateID != "ai-1" {
+		t.Errorf("unexpected envelope %+v", env)
+	}
+	updated, ok := decoded.(domain.ActionItemUpdated)
+	if !ok {
+		t.Fatalf("got %T", decoded)
+	}
+	if updated.MeetingID() != "m-1" || updated.NewText() != "Ship it" {
+		t.Errorf("unexpected event %+v", updated)
+	}
+}
+
+func TestSQLiteStore_ListDue_RespectsNextAttempt(t *testing.T) {
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	now := time.Now().UTC()
+
+	for _, id := range []string{"evt-1", "evt-2"} {
+		if err := store.Append(outbox.Entry{ID: id, EventType: "note.added", CreatedAt: now}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if err := store.MarkRetry("evt-1", now.Add(time.Minute), "timeout"); err != nil {
+		t.Fatalf("mark retry: %v", err)
+	}
+
+	due, err := store.ListDue(now, 10, []string{""})
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != "evt-2" {
+		t.Fatalf("got %v, want only evt-2 due", due)
+	}
+
+	later, err := store.ListDue(now.Add(2*time.Minute), 10, []string{""})
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(later) != 2 {
+		t.Fatalf("got %d due entries, want 2", len(later))
+	}
+	for _, e := range later {
+		if e.ID == "evt-1" && (e.Attempts != 1 || e.LastError != "timeout" || e.NextAttemptAt == nil) {
+			t.Errorf("retry state not recorded: %+v", e)
+		}
 	}
 }
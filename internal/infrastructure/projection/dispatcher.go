@@ -0,0 +1,33 @@
+package projection
+
+import (
+	"context"
+	"log"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// Dispatcher decorates a domain.EventDispatcher, applying events to the
+// projection before forwarding them. Wrap the dispatcher of event sources
+// that bypass Repository.Sync, such as the desktop cache watcher; events
+// from Sync are already applied.
+type Dispatcher struct {
+	inner      domain.EventDispatcher
+	projection *Repository
+}
+
+// NewDispatcher creates a projecting dispatcher decorator.
+func NewDispatcher(inner domain.EventDispatcher, projection *Repository) *Dispatcher {
+	return &Dispatcher{inner: inner, projection: projection}
+}
+
+// Dispatch applies the events to the projection, then forwards them. A
+// projection failure is logged and does not stop delivery.
+func (d *Dispatcher) Dispatch(ctx context.Context, events []domain.DomainEvent) error {
+	if err := d.projection.Apply(ctx, events); err != nil {
+		log.Printf("projection: apply failed, reading from source until the next sync: %v", err)
+	}
+	return d.inner.Dispatch(ctx, events)
+}
+
+var _ domain.EventDispatcher = (*Dispatcher)(nil)
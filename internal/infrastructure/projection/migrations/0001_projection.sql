@@ -0,0 +1,64 @@
+CREATE TABLE IF NOT EXISTS meetings (
+	id             TEXT PRIMARY KEY,
+	title          TEXT NOT NULL,
+	datetime       TEXT NOT NULL,
+	starts_at      INTEGER NOT NULL,
+	day            TEXT NOT NULL,
+	weekday        INTEGER NOT NULL,
+	hour           INTEGER NOT NULL,
+	source         TEXT NOT NULL,
+	links          TEXT NOT NULL DEFAULT '[]',
+	external_refs  TEXT NOT NULL DEFAULT '{}',
+	has_transcript INTEGER NOT NULL DEFAULT 0,
+	projected_at   DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_meetings_starts_at ON meetings(starts_at);
+
+CREATE TABLE IF NOT EXISTS meeting_participants (
+	meeting_id TEXT NOT NULL,
+	position   INTEGER NOT NULL,
+	name       TEXT NOT NULL,
+	email      TEXT NOT NULL,
+	role       TEXT NOT NULL,
+	PRIMARY KEY (meeting_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS utterances (
+	meeting_id TEXT NOT NULL,
+	position   INTEGER NOT NULL,
+	speaker    TEXT NOT NULL,
+	text       TEXT NOT NULL,
+	timestamp  TEXT NOT NULL,
+	confidence REAL NOT NULL,
+	word_count INTEGER NOT NULL,
+	PRIMARY KEY (meeting_id, position)
+);
+CREATE INDEX IF NOT EXISTS idx_utterances_speaker ON utterances(speaker);
+
+CREATE TABLE IF NOT EXISTS summaries (
+	meeting_id TEXT PRIMARY KEY,
+	content    TEXT NOT NULL,
+	kind       TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS action_items (
+	meeting_id TEXT NOT NULL,
+	id         TEXT NOT NULL,
+	position   INTEGER NOT NULL,
+	owner      TEXT NOT NULL,
+	text       TEXT NOT NULL,
+	due_date   TEXT,
+	completed  INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (meeting_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS meeting_tags (
+	meeting_id TEXT NOT NULL,
+	tag        TEXT NOT NULL,
+	PRIMARY KEY (meeting_id, tag)
+);
+
+CREATE TABLE IF NOT EXISTS projection_state (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
@@ -0,0 +1,282 @@
+package projection
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// where builds a WHERE clause over meetings m matching filter and, when
+// search is non-empty, mentioning it in the title, summary or transcript.
+// Text matching is case-insensitive for ASCII, like SQLite's lower().
+func where(filter domain.ListFilter, search string) (string, []any) {
+	clauses := []string{"1 = 1"}
+	var args []any
+	if filter.Since != nil {
+		clauses = append(clauses, "m.starts_at >= ?")
+		args = append(args, filter.Since.UnixNano())
+	}
+	if filter.Until != nil {
+		clauses = append(clauses, "m.starts_at <= ?")
+		args = append(args, filter.Until.UnixNano())
+	}
+	if filter.Source != nil {
+		clauses = append(clauses, "m.source = ?")
+		args = append(args, string(*filter.Source))
+	}
+	if filter.Participant != nil {
+		p := strings.ToLower(*filter.Participant)
+		clauses = append(clauses, `EXISTS (SELECT 1 FROM meeting_participants p WHERE p.meeting_id = m.id
+			AND (instr(lower(p.name), ?) > 0 OR instr(lower(p.email), ?) > 0))`)
+		args = append(args, p, p)
+	}
+	if filter.Query != nil {
+		clauses = append(clauses, "instr(lower(m.title), ?) > 0")
+		args = append(args, strings.ToLower(*filter.Query))
+	}
+	if search != "" {
+		q := strings.ToLower(search)
+		clauses = append(clauses, `(instr(lower(m.title), ?) > 0
+			OR EXISTS (SELECT 1 FROM summaries s WHERE s.meeting_id = m.id AND instr(lower(s.content), ?) > 0)
+			OR EXISTS (SELECT 1 FROM utterances u WHERE u.meeting_id = m.id AND instr(lower(u.text), ?) > 0))`)
+		args = append(args, q, q, q)
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+// queryMeetings returns the page of meetings matching filter and search,
+// newest first, without transcripts.
+func (r *Repository) queryMeetings(ctx context.Context, filter domain.ListFilter, search string) ([]*domain.Meeting, error) {
+	cond, args := where(filter, search)
+	selected := "SELECT m.id FROM meetings m WHERE " + cond + " ORDER BY m.starts_at DESC, m.id"
+	if filter.Limit > 0 || filter.Offset > 0 {
+		limit := filter.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+		selected += " LIMIT ? OFFSET ?"
+		args = append(args, limit, filter.Offset)
+	}
+	return r.loadMeetings(ctx, selected, args)
+}
+
+// loadMeeting returns one projected meeting with its transcript.
+func (r *Repository) loadMeeting(ctx context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+	meetings, err := r.loadMeetings(ctx, "SELECT ?", []any{string(id)})
+	if err != nil {
+		return nil, err
+	}
+	if len(meetings) == 0 {
+		return nil, domain.ErrMeetingNotFound
+	}
+	m := meetings[0]
+	t, err := r.loadTranscript(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		m.AttachTranscript(*t)
+		m.ClearDomainEvents()
+	}
+	return m, nil
+}
+
+// inSelected restricts a query to the meeting IDs bound as a JSON array.
+const inSelected = "IN (SELECT value FROM json_each(?))"
+
+// loadMeetings reconstitutes the meetings whose IDs the selected query
+// yields, newest first. The selection, which may scan every transcript, is
+// run once; its IDs are then bound to one query per child table.
+func (r *Repository) loadMeetings(ctx context.Context, selected string, args []any) ([]*domain.Meeting, error) {
+	var ids []string
+	err := r.each(ctx, selected, args, func(rows *sql.Rows) error {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	idArgs := selectedIDs(ids)
+
+	participants := make(map[domain.MeetingID][]domain.Participant)
+	err = r.each(ctx, `SELECT meeting_id, name, email, role FROM meeting_participants
+		WHERE meeting_id `+inSelected+` ORDER BY meeting_id, position`, idArgs,
+		func(rows *sql.Rows) error {
+			var id, name, email, role string
+			if err := rows.Scan(&id, &name, &email, &role); err != nil {
+				return err
+			}
+			participants[domain.MeetingID(id)] = append(participants[domain.MeetingID(id)],
+				domain.NewParticipant(name, email, domain.ParticipantRole(role)))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[domain.MeetingID]domain.Summary)
+	err = r.each(ctx, `SELECT meeting_id, content, kind FROM summaries WHERE meeting_id `+inSelected, idArgs,
+		func(rows *sql.Rows) error {
+			var id, content, kind string
+			if err := rows.Scan(&id, &content, &kind); err != nil {
+				return err
+			}
+			summaries[domain.MeetingID(id)] = domain.NewSummary(domain.MeetingID(id), content, domain.SummaryKind(kind))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[domain.MeetingID][]string)
+	err = r.each(ctx, `SELECT meeting_id, tag FROM meeting_tags WHERE meeting_id `+inSelected+` ORDER BY meeting_id, tag`, idArgs,
+		func(rows *sql.Rows) error {
+			var id, tag string
+			if err := rows.Scan(&id, &tag); err != nil {
+				return err
+			}
+			tags[domain.MeetingID(id)] = append(tags[domain.MeetingID(id)], tag)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := r.loadActionItems(ctx, idArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var meetings []*domain.Meeting
+	err = r.each(ctx, `SELECT id, title, datetime, source, links, external_refs FROM meetings
+		WHERE id `+inSelected+` ORDER BY starts_at DESC, id`, idArgs,
+		func(rows *sql.Rows) error {
+			var id, title, datetime, source, links, refs string
+			if err := rows.Scan(&id, &title, &datetime, &source, &links, &refs); err != nil {
+				return err
+			}
+			dt, err := time.Parse(time.RFC3339Nano, datetime)
+			if err != nil {
+				return err
+			}
+			mid := domain.MeetingID(id)
+			m, err := domain.New(mid, title, dt, domain.Source(source), participants[mid])
+			if err != nil {
+				return err
+			}
+			if s, ok := summaries[mid]; ok {
+				m.AttachSummary(s)
+			}
+			for _, item := range items[mid] {
+				m.AddActionItem(item)
+			}
+			var linkList []string
+			var refMap map[string]string
+			_ = json.Unmarshal([]byte(links), &linkList)
+			_ = json.Unmarshal([]byte(refs), &refMap)
+			m.SetMetadata(domain.NewMetadata(tags[mid], linkList, refMap))
+
+			// Reconstitution, not creation: no events.
+			m.ClearDomainEvents()
+			meetings = append(meetings, m)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// selectedIDs returns the arguments binding ids to inSelected.
+func selectedIDs(ids []string) []any {
+	data, _ := json.Marshal(ids) // a string slice always marshals
+	return []any{string(data)}
+}
+
+// loadActionItems returns the action items of the meetings bound by
+// idArgs (see selectedIDs).
+func (r *Repository) loadActionItems(ctx context.Context, idArgs []any) (map[domain.MeetingID][]*domain.ActionItem, error) {
+	items := make(map[domain.MeetingID][]*domain.ActionItem)
+	err := r.each(ctx, `SELECT meeting_id, id, owner, text, due_date, completed FROM action_items
+		WHERE meeting_id `+inSelected+` ORDER BY meeting_id, position`, idArgs,
+		func(rows *sql.Rows) error {
+			var meetingID, id, owner, text string
+			var due sql.NullString
+			var completed bool
+			if err := rows.Scan(&meetingID, &id, &owner, &text, &due, &completed); err != nil {
+				return err
+			}
+			var dueDate *time.Time
+			if due.Valid {
+				if t, err := time.Parse(time.RFC3339Nano, due.String); err == nil {
+					dueDate = &t
+				}
+			}
+			item, err := domain.NewActionItem(domain.ActionItemID(id), domain.MeetingID(meetingID), owner, text, dueDate)
+			if err != nil {
+				return err
+			}
+			if completed {
+				item.Complete()
+			}
+			items[domain.MeetingID(meetingID)] = append(items[domain.MeetingID(meetingID)], item)
+			return nil
+		})
+	return items, err
+}
+
+// loadTranscript returns the projected transcript of a meeting, or nil if
+// the meeting is not projected or had no transcript when it was.
+func (r *Repository) loadTranscript(ctx context.Context, id domain.MeetingID) (*domain.Transcript, error) {
+	var hasTranscript bool
+	err := r.db.QueryRowContext(ctx, "SELECT has_transcript FROM meetings WHERE id = ?", string(id)).Scan(&hasTranscript)
+	if err == sql.ErrNoRows || (err == nil && !hasTranscript) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var utterances []domain.Utterance
+	err = r.each(ctx, `SELECT speaker, text, timestamp, confidence FROM utterances
+		WHERE meeting_id = ? ORDER BY position`, []any{string(id)},
+		func(rows *sql.Rows) error {
+			var speaker, text, timestamp string
+			var confidence float64
+			if err := rows.Scan(&speaker, &text, &timestamp, &confidence); err != nil {
+				return err
+			}
+			ts, _ := time.Parse(time.RFC3339Nano, timestamp)
+			utterances = append(utterances, domain.NewUtterance(speaker, text, ts, confidence))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	t := domain.NewTranscript(id, utterances)
+	return &t, nil
+}
+
+// each runs query and calls scan for every row.
+func (r *Repository) each(ctx context.Context, query string, args []any, scan func(*sql.Rows) error) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
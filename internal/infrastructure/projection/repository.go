@@ -0,0 +1,513 @@
+package projection
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// backfillPageSize is the page size used to list every meeting when the
+// projection is first built.
+const backfillPageSize = 1000
+
+// backfillWorkers bounds the meetings fetched from the inner repository at
+// once while backfilling.
+const backfillWorkers = 4
+
+const stateSyncedAt = "synced_at"
+
+// Repository decorates a domain.Repository with the SQLite projection.
+// Sync forwards to the inner repository and applies the resulting events to
+// the projection; a full sync, or the first sync after the projection was
+// invalidated, also projects every listed meeting not yet stored. While the
+// projection is fresh (built and synced within maxAge), reads are answered
+// from SQLite; otherwise they fall through to the inner repository.
+type Repository struct {
+	inner  domain.Repository
+	db     *sql.DB
+	maxAge time.Duration
+}
+
+// NewRepository creates a projection-backed repository decorator.
+// It initializes the projection schema on the provided database connection.
+// A zero maxAge serves reads from the projection however old its last sync.
+func NewRepository(inner domain.Repository, db *sql.DB, maxAge time.Duration) (*Repository, error) {
+	if err := InitSchema(db); err != nil {
+		return nil, err
+	}
+	return &Repository{inner: inner, db: db, maxAge: maxAge}, nil
+}
+
+func (r *Repository) FindByID(ctx context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+	if r.fresh(ctx) {
+		m, err := r.loadMeeting(ctx, id)
+		if err == nil {
+			return m, nil
+		}
+		if !errors.Is(err, domain.ErrMeetingNotFound) {
+			log.Printf("projection: read of meeting %s failed: %v", id, err)
+		}
+	}
+	return r.inner.FindByID(ctx, id)
+}
+
+func (r *Repository) List(ctx context.Context, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	if r.fresh(ctx) {
+		meetings, err := r.queryMeetings(ctx, filter, "")
+		if err == nil {
+			return meetings, nil
+		}
+		log.Printf("projection: list failed: %v", err)
+	}
+	return r.inner.List(ctx, filter)
+}
+
+func (r *Repository) SearchTranscripts(ctx context.Context, query string, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	if r.fresh(ctx) {
+		meetings, err := r.queryMeetings(ctx, filter, query)
+		if err == nil {
+			return meetings, nil
+		}
+		log.Printf("projection: search failed: %v", err)
+	}
+	return r.inner.SearchTranscripts(ctx, query, filter)
+}
+
+func (r *Repository) GetTranscript(ctx context.Context, id domain.MeetingID) (*domain.Transcript, error) {
+	if r.fresh(ctx) {
+		t, err := r.loadTranscript(ctx, id)
+		if err != nil {
+			log.Printf("projection: read of transcript %s failed: %v", id, err)
+		} else if t != nil {
+			return t, nil
+		}
+	}
+	// Transcripts may arrive after the meeting was projected.
+	return r.inner.GetTranscript(ctx, id)
+}
+
+func (r *Repository) GetActionItems(ctx context.Context, id domain.MeetingID) ([]*domain.ActionItem, error) {
+	if r.fresh(ctx) {
+		if ok, err := r.has(ctx, id); err == nil && ok {
+			items, err := r.loadActionItems(ctx, selectedIDs([]string{string(id)}))
+			if err == nil {
+				return items[id], nil
+			}
+			log.Printf("projection: read of action items %s failed: %v", id, err)
+		}
+	}
+	return r.inner.GetActionItems(ctx, id)
+}
+
+// Sync forwards to the inner repository, then brings the projection up to
+// date. A projection update failure is logged, not returned: the events
+// are still valid, and reads fall back to the inner repository until a
+// later sync rebuilds the projection.
+func (r *Repository) Sync(ctx context.Context, since *time.Time) ([]domain.DomainEvent, error) {
+	events, err := r.inner.Sync(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.update(ctx, since == nil, events); err != nil {
+		if ctx.Err() == nil {
+			log.Printf("projection: update failed, reading from source until the next sync: %v", err)
+		}
+		r.invalidate()
+	}
+	return events, nil
+}
+
+// Apply projects the meetings referenced by events: deleted meetings are
+// removed, all others are re-read from the inner repository. It is for
+// event sources that bypass Sync, such as the desktop cache watcher. On
+// failure the projection is invalidated until the next sync.
+func (r *Repository) Apply(ctx context.Context, events []domain.DomainEvent) error {
+	if err := r.apply(ctx, events); err != nil {
+		r.invalidate()
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) update(ctx context.Context, full bool, events []domain.DomainEvent) error {
+	if err := r.apply(ctx, events); err != nil {
+		return err
+	}
+	built, err := r.syncedAt(ctx)
+	if err != nil {
+		return err
+	}
+	if full || built.IsZero() {
+		if err := r.backfill(ctx); err != nil {
+			return err
+		}
+	}
+	_, err = r.db.ExecContext(ctx,
+		"INSERT OR REPLACE INTO projection_state (key, value) VALUES (?, ?)",
+		stateSyncedAt, time.Now().UTC().Format(time.RFC3339Nano),
+	)
+	return err
+}
+
+func (r *Repository) apply(ctx context.Context, events []domain.DomainEvent) error {
+	seen := make(map[domain.MeetingID]bool)
+	var errs []error
+	for _, event := range events {
+		e, ok := event.(interface{ MeetingID() domain.MeetingID })
+		if !ok || seen[e.MeetingID()] {
+			continue
+		}
+		seen[e.MeetingID()] = true
+
+		var err error
+		if _, deleted := event.(domain.MeetingDeleted); deleted {
+			err = r.remove(ctx, e.MeetingID())
+		} else {
+			err = r.refresh(ctx, e.MeetingID())
+		}
+		if err != nil {
+			// Drop the stale copy so the next backfill fetches it again.
+			_ = r.remove(ctx, e.MeetingID())
+			errs = append(errs, fmt.Errorf("project meeting %s: %w", e.MeetingID(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backfill projects every meeting the inner repository lists that the
+// projection does not hold yet. Stored meetings are kept current by events,
+// and removed only by MeetingDeleted, since upstream listings may be capped.
+func (r *Repository) backfill(ctx context.Context) error {
+	seen := make(map[domain.MeetingID]bool)
+	for offset := 0; ; offset += backfillPageSize {
+		page, err := r.inner.List(ctx, domain.ListFilter{Limit: backfillPageSize, Offset: offset})
+		if err != nil {
+			return err
+		}
+		added := 0
+		var missing []domain.MeetingID
+		for _, m := range page {
+			if seen[m.ID()] {
+				continue
+			}
+			seen[m.ID()] = true
+			added++
+
+			ok, err := r.has(ctx, m.ID())
+			if err != nil {
+				return err
+			}
+			if !ok {
+				missing = append(missing, m.ID())
+			}
+		}
+		if err := r.refreshAll(ctx, missing); err != nil {
+			return err
+		}
+		// Sources that ignore Offset return the same page again.
+		if len(page) < backfillPageSize || added == 0 {
+			return nil
+		}
+	}
+}
+
+// fetched is a meeting read from the inner repository for projection.
+type fetched struct {
+	id         domain.MeetingID
+	meeting    *domain.Meeting // nil when the meeting is gone upstream
+	transcript *domain.Transcript
+	items      []*domain.ActionItem
+	err        error
+}
+
+// refresh re-reads one meeting, its transcript and its action items from
+// the inner repository and replaces the projected copy.
+func (r *Repository) refresh(ctx context.Context, id domain.MeetingID) error {
+	f := r.fetch(ctx, id)
+	if f.err != nil {
+		return f.err
+	}
+	return r.save(ctx, f)
+}
+
+// refreshAll refreshes the meetings, fetching up to backfillWorkers of them
+// from the inner repository at a time, since each may take three upstream
+// calls. Writes stay serial, on the calling goroutine. It stops at the
+// first failure.
+func (r *Repository) refreshAll(ctx context.Context, ids []domain.MeetingID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan domain.MeetingID)
+	results := make(chan fetched)
+	var wg sync.WaitGroup
+	for range min(backfillWorkers, len(ids)) {
+		wg.Go(func() {
+			for id := range jobs {
+				results <- r.fetch(ctx, id)
+			}
+		})
+	}
+	go func() {
+		defer close(jobs)
+		for _, id := range ids {
+			select {
+			case jobs <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var firstErr error
+	for f := range results {
+		if firstErr != nil {
+			continue // drain the workers
+		}
+		err := f.err
+		if err == nil {
+			err = r.save(ctx, f)
+		}
+		if err != nil {
+			firstErr = fmt.Errorf("project meeting %s: %w", f.id, err)
+			cancel()
+		}
+	}
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
+
+// fetch reads one meeting with its transcript and action items from the
+// inner repository.
+func (r *Repository) fetch(ctx context.Context, id domain.MeetingID) fetched {
+	f := fetched{id: id}
+	m, err := r.inner.FindByID(ctx, id)
+	if errors.Is(err, domain.ErrMeetingNotFound) {
+		return f
+	}
+	if err != nil {
+		f.err = err
+		return f
+	}
+	f.meeting = m
+
+	f.transcript = m.Transcript()
+	if f.transcript == nil {
+		t, err := r.inner.GetTranscript(ctx, id)
+		switch {
+		case err == nil:
+			f.transcript = t
+		case !isNotFound(err):
+			f.err = err
+			return f
+		}
+	}
+
+	f.items = m.ActionItems()
+	if len(f.items) == 0 {
+		items, err := r.inner.GetActionItems(ctx, id)
+		switch {
+		case err == nil:
+			f.items = items
+		case !isNotFound(err):
+			f.err = err
+			return f
+		}
+	}
+	return f
+}
+
+// save stores a fetched meeting, or removes it if it is gone upstream.
+func (r *Repository) save(ctx context.Context, f fetched) error {
+	if f.meeting == nil {
+		return r.remove(ctx, f.id)
+	}
+	return r.store(ctx, f.meeting, f.transcript, f.items)
+}
+
+// store replaces everything projected for a meeting in one transaction.
+func (r *Repository) store(ctx context.Context, m *domain.Meeting, transcript *domain.Transcript, items []*domain.ActionItem) error {
+	links, err := json.Marshal(nonNil(m.Metadata().Links()))
+	if err != nil {
+		return err
+	}
+	refs, err := json.Marshal(m.Metadata().ExternalRefs())
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := deleteMeeting(ctx, tx, m.ID()); err != nil {
+		return err
+	}
+
+	dt := m.Datetime()
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO meetings (id, title, datetime, starts_at, day, weekday, hour, source, links, external_refs, has_transcript, projected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		string(m.ID()), m.Title(), dt.Format(time.RFC3339Nano), dt.UnixNano(),
+		dt.Format("2006-01-02"), int(dt.Weekday()), dt.Hour(), string(m.Source()),
+		string(links), string(refs), transcript != nil, time.Now().UTC(),
+	); err != nil {
+		return err
+	}
+
+	for i, p := range m.Participants() {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO meeting_participants (meeting_id, position, name, email, role) VALUES (?, ?, ?, ?, ?)",
+			string(m.ID()), i, p.Name(), p.Email(), string(p.Role()),
+		); err != nil {
+			return err
+		}
+	}
+
+	if transcript != nil {
+		for i, u := range transcript.Utterances() {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO utterances (meeting_id, position, speaker, text, timestamp, confidence, word_count)
+				VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				string(m.ID()), i, u.Speaker(), u.Text(), u.Timestamp().Format(time.RFC3339Nano),
+				u.Confidence(), len(strings.Fields(u.Text())),
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s := m.Summary(); s != nil {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO summaries (meeting_id, content, kind) VALUES (?, ?, ?)",
+			string(m.ID()), s.Content(), string(s.Kind()),
+		); err != nil {
+			return err
+		}
+	}
+
+	for i, item := range items {
+		var due *string
+		if d := item.DueDate(); d != nil {
+			formatted := d.Format(time.RFC3339Nano)
+			due = &formatted
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR REPLACE INTO action_items (meeting_id, id, position, owner, text, due_date, completed)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			string(m.ID()), string(item.ID()), i, item.Owner(), item.Text(), due, item.IsCompleted(),
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, tag := range m.Metadata().Tags() {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO meeting_tags (meeting_id, tag) VALUES (?, ?)",
+			string(m.ID()), tag,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *Repository) remove(ctx context.Context, id domain.MeetingID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := deleteMeeting(ctx, tx, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func deleteMeeting(ctx context.Context, tx *sql.Tx, id domain.MeetingID) error {
+	for _, table := range []string{"meeting_participants", "utterances", "summaries", "action_items", "meeting_tags"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE meeting_id = ?", string(id)); err != nil {
+			return err
+		}
+	}
+	_, err := tx.ExecContext(ctx, "DELETE FROM meetings WHERE id = ?", string(id))
+	return err
+}
+
+// syncedAt returns when the projection was last brought up to date, or the
+// zero time if it has not been built or was invalidated.
+func (r *Repository) syncedAt(ctx context.Context) (time.Time, error) {
+	var value string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT value FROM projection_state WHERE key = ?", stateSyncedAt,
+	).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, nil
+	}
+	return t, nil
+}
+
+// fresh reports whether reads may be answered from the projection.
+func (r *Repository) fresh(ctx context.Context) bool {
+	t, err := r.syncedAt(ctx)
+	if err != nil || t.IsZero() {
+		return false
+	}
+	return r.maxAge <= 0 || time.Since(t) <= r.maxAge
+}
+
+// invalidate makes reads fall through to the inner repository until the
+// next sync rebuilds the projection.
+func (r *Repository) invalidate() {
+	if _, err := r.db.Exec("DELETE FROM projection_state WHERE key = ?", stateSyncedAt); err != nil {
+		log.Printf("projection: invalidation failed: %v", err)
+	}
+}
+
+func (r *Repository) has(ctx context.Context, id domain.MeetingID) (bool, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM meetings WHERE id = ?", string(id)).Scan(&n)
+	return n > 0, err
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, domain.ErrMeetingNotFound) || errors.Is(err, domain.ErrTranscriptNotReady)
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+var _ domain.Repository = (*Repository)(nil)
+var _ domain.StatsReader = (*Repository)(nil)
@@ -0,0 +1,471 @@
+package projection_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/projection"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type mockRepo struct {
+	mu          sync.Mutex // backfill fetches concurrently
+	meetings    map[domain.MeetingID]*domain.Meeting
+	transcripts map[domain.MeetingID]*domain.Transcript
+	syncEvents  []domain.DomainEvent
+	findErr     error
+	listCalls   int
+	findCalls   int
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{
+		meetings:    make(map[domain.MeetingID]*domain.Meeting),
+		transcripts: make(map[domain.MeetingID]*domain.Transcript),
+	}
+}
+
+func (m *mockRepo) FindByID(_ context.Context, id domain.MeetingID) (*domain.Meeting, error) {
+	m.mu.Lock()
+	m.findCalls++
+	m.mu.Unlock()
+	if m.findErr != nil {
+		return nil, m.findErr
+	}
+	if mtg, ok := m.meetings[id]; ok {
+		return mtg, nil
+	}
+	return nil, domain.ErrMeetingNotFound
+}
+
+func (m *mockRepo) List(_ context.Context, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	m.listCalls++
+	result := make([]*domain.Meeting, 0, len(m.meetings))
+	for _, mtg := range m.meetings {
+		if filter.Since != nil && mtg.Datetime().Before(*filter.Since) {
+			continue
+		}
+		if filter.Until != nil && mtg.Datetime().After(*filter.Until) {
+			continue
+		}
+		result = append(result, mtg)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Datetime().After(result[j].Datetime()) })
+	if filter.Offset >= len(result) {
+		return []*domain.Meeting{}, nil
+	}
+	result = result[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func (m *mockRepo) GetTranscript(_ context.Context, id domain.MeetingID) (*domain.Transcript, error) {
+	if t, ok := m.transcripts[id]; ok {
+		return t, nil
+	}
+	return nil, domain.ErrTranscriptNotReady
+}
+
+func (m *mockRepo) SearchTranscripts(_ context.Context, _ string, _ domain.ListFilter) ([]*domain.Meeting, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) GetActionItems(_ context.Context, id domain.MeetingID) ([]*domain.ActionItem, error) {
+	if mtg, ok := m.meetings[id]; ok {
+		return mtg.ActionItems(), nil
+	}
+	return nil, domain.ErrMeetingNotFound
+}
+
+func (m *mockRepo) Sync(_ context.Context, _ *time.Time) ([]domain.DomainEvent, error) {
+	return m.syncEvents, nil
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+var base = time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC) // a Monday
+
+// seed adds three meetings with participants, summaries, transcripts,
+// action items and tags.
+func seed(t *testing.T, inner *mockRepo) {
+	t.Helper()
+	add := func(id, title string, at time.Time, source domain.Source, participants []domain.Participant) *domain.Meeting {
+		m, err := domain.New(domain.MeetingID(id), title, at, source, participants)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		m.ClearDomainEvents()
+		inner.meetings[m.ID()] = m
+		return m
+	}
+	alice := domain.NewParticipant("Alice", "alice@acme.io", domain.RoleHost)
+	bob := domain.NewParticipant("Bob", "bob@acme.io", domain.RoleAttendee)
+	carol := domain.NewParticipant("Carol", "carol@acme.io", domain.RoleAttendee)
+
+	planning := add("m-1", "Sprint Planning", base, domain.SourceZoom, []domain.Participant{alice, bob})
+	planning.AttachSummary(domain.NewSummary("m-1", "Scope agreed for the billing sprint", domain.SummaryAuto))
+	due := base.Add(48 * time.Hour)
+	item, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Write the migration", &due)
+	planning.AddActionItem(item)
+	done, _ := domain.NewActionItem("ai-2", "m-1", "Bob", "Book the room", nil)
+	done.Complete()
+	planning.AddActionItem(done)
+	planning.SetMetadata(domain.NewMetadata([]string{"sprint", "billing"}, []string{"https://example.com/doc"}, map[string]string{"jira": "OPS-1"}))
+	planning.ClearDomainEvents()
+	inner.transcripts["m-1"] = transcript("m-1", base, "Alice: let us plan the invoices work", "Bob: sounds good")
+
+	retro := add("m-2", "Retro", base.Add(26*time.Hour), domain.SourceMeet, []domain.Participant{alice})
+	retro.ClearDomainEvents()
+	inner.transcripts["m-2"] = transcript("m-2", base, "Alice: what went well")
+
+	add("m-3", "Customer call", base.Add(-72*time.Hour), domain.SourceZoom, []domain.Participant{alice, bob, carol})
+}
+
+func transcript(id domain.MeetingID, at time.Time, lines ...string) *domain.Transcript {
+	var utterances []domain.Utterance
+	for i, line := range lines {
+		speaker, text, _ := strings.Cut(line, ": ")
+		utterances = append(utterances, domain.NewUtterance(speaker, text, at.Add(time.Duration(i)*time.Second), 0.9))
+	}
+	t := domain.NewTranscript(id, utterances)
+	return &t
+}
+
+func newSynced(t *testing.T, inner *mockRepo) *projection.Repository {
+	t.Helper()
+	repo, err := projection.NewRepository(inner, openTestDB(t), 0)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	if _, err := repo.Sync(context.Background(), nil); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	return repo
+}
+
+func ids(meetings []*domain.Meeting) []domain.MeetingID {
+	out := make([]domain.MeetingID, 0, len(meetings))
+	for _, m := range meetings {
+		out = append(out, m.ID())
+	}
+	return out
+}
+
+func TestRepository_FallsThroughUntilBuilt(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo, err := projection.NewRepository(inner, openTestDB(t), 0)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	if _, err := repo.List(context.Background(), domain.ListFilter{}); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if inner.listCalls != 1 {
+		t.Errorf("inner list calls = %d, want 1", inner.listCalls)
+	}
+	if _, err := repo.MeetingStats(context.Background(), domain.ListFilter{}); !errors.Is(err, domain.ErrStatsUnavailable) {
+		t.Errorf("MeetingStats = %v, want ErrStatsUnavailable", err)
+	}
+}
+
+func TestRepository_ListFromProjection(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo := newSynced(t, inner)
+	calls := inner.listCalls
+
+	ctx := context.Background()
+	all, err := repo.List(ctx, domain.ListFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if inner.listCalls != calls {
+		t.Error("List reached the inner repository")
+	}
+	if got, want := ids(all), []domain.MeetingID{"m-2", "m-1", "m-3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+
+	m := all[1]
+	if len(m.Participants()) != 2 || m.Participants()[0].Name() != "Alice" || m.Participants()[0].Role() != domain.RoleHost {
+		t.Errorf("participants = %v", m.Participants())
+	}
+	if m.Summary() == nil || m.Summary().Content() != "Scope agreed for the billing sprint" {
+		t.Errorf("summary = %v", m.Summary())
+	}
+	items := m.ActionItems()
+	if len(items) != 2 || items[0].DueDate() == nil || !items[0].DueDate().Equal(base.Add(48*time.Hour)) || !items[1].IsCompleted() {
+		t.Errorf("action items not reconstituted: %+v", items)
+	}
+	if tags := m.Metadata().Tags(); !reflect.DeepEqual(tags, []string{"billing", "sprint"}) {
+		t.Errorf("tags = %v", tags)
+	}
+	if m.Metadata().ExternalRefs()["jira"] != "OPS-1" || len(m.Metadata().Links()) != 1 {
+		t.Errorf("metadata = %+v", m.Metadata())
+	}
+	if !m.Datetime().Equal(base) {
+		t.Errorf("datetime = %v, want %v", m.Datetime(), base)
+	}
+	if len(m.DomainEvents()) != 0 {
+		t.Error("reconstituted meeting raised events")
+	}
+}
+
+func TestRepository_ListFilters(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo := newSynced(t, inner)
+	ctx := context.Background()
+
+	zoom := domain.SourceZoom
+	carol := "CAROL"
+	query := "retro"
+	since := base.Add(-time.Hour)
+	tests := []struct {
+		name   string
+		filter domain.ListFilter
+		want   []domain.MeetingID
+	}{
+		{"source", domain.ListFilter{Source: &zoom}, []domain.MeetingID{"m-1", "m-3"}},
+		{"participant", domain.ListFilter{Participant: &carol}, []domain.MeetingID{"m-3"}},
+		{"title query", domain.ListFilter{Query: &query}, []domain.MeetingID{"m-2"}},
+		{"since", domain.ListFilter{Since: &since}, []domain.MeetingID{"m-2", "m-1"}},
+		{"until", domain.ListFilter{Until: &since}, []domain.MeetingID{"m-3"}},
+		{"page", domain.ListFilter{Limit: 1, Offset: 1}, []domain.MeetingID{"m-1"}},
+		{"offset only", domain.ListFilter{Offset: 2}, []domain.MeetingID{"m-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if !reflect.DeepEqual(ids(got), tt.want) {
+				t.Errorf("got %v, want %v", ids(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestRepository_SearchTranscripts(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo := newSynced(t, inner)
+	ctx := context.Background()
+
+	for query, want := range map[string][]domain.MeetingID{
+		"INVOICES":    {"m-1"}, // transcript
+		"billing":     {"m-1"}, // summary
+		"call":        {"m-3"}, // title
+		"went well":   {"m-2"},
+		"no such 50%": {},
+	} {
+		got, err := repo.SearchTranscripts(ctx, query, domain.ListFilter{})
+		if err != nil {
+			t.Fatalf("SearchTranscripts(%q): %v", query, err)
+		}
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(ids(got), want)) {
+			t.Errorf("SearchTranscripts(%q) = %v, want %v", query, ids(got), want)
+		}
+	}
+}
+
+func TestRepository_FindByIDAndTranscript(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo := newSynced(t, inner)
+	ctx := context.Background()
+	finds := inner.findCalls
+
+	m, err := repo.FindByID(ctx, "m-1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if m.Transcript() == nil || len(m.Transcript().Utterances()) != 2 {
+		t.Fatalf("transcript not attached: %v", m.Transcript())
+	}
+	tr, err := repo.GetTranscript(ctx, "m-1")
+	if err != nil {
+		t.Fatalf("GetTranscript: %v", err)
+	}
+	u := tr.Utterances()[0]
+	if u.Speaker() != "Alice" || u.Text() != "let us plan the invoices work" || u.Confidence() != 0.9 || !u.Timestamp().Equal(base) {
+		t.Errorf("utterance = %+v", u)
+	}
+	items, err := repo.GetActionItems(ctx, "m-1")
+	if err != nil || len(items) != 2 {
+		t.Errorf("GetActionItems = %d, %v", len(items), err)
+	}
+	if inner.findCalls != finds {
+		t.Error("reads reached the inner repository")
+	}
+
+	// Meetings without a projected transcript fall through.
+	if _, err := repo.GetTranscript(ctx, "m-3"); !errors.Is(err, domain.ErrTranscriptNotReady) {
+		t.Errorf("GetTranscript(m-3) = %v, want ErrTranscriptNotReady", err)
+	}
+	// Unknown meetings fall through.
+	if _, err := repo.FindByID(ctx, "m-9"); !errors.Is(err, domain.ErrMeetingNotFound) {
+		t.Errorf("FindByID(m-9) = %v, want ErrMeetingNotFound", err)
+	}
+}
+
+func TestRepository_IncrementalSyncAppliesEvents(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo := newSynced(t, inner)
+	ctx := context.Background()
+
+	retro := inner.meetings["m-2"]
+	retro.AttachSummary(domain.NewSummary("m-2", "Ship smaller changes", domain.SummaryEdited))
+	delete(inner.meetings, "m-3")
+	inner.syncEvents = []domain.DomainEvent{
+		domain.NewSummaryUpdatedEvent("m-2", domain.SummaryEdited),
+		domain.NewMeetingDeletedEvent("m-3"),
+	}
+	since := time.Now()
+	if _, err := repo.Sync(ctx, &since); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	all, err := repo.List(ctx, domain.ListFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := ids(all); !reflect.DeepEqual(got, []domain.MeetingID{"m-2", "m-1"}) {
+		t.Errorf("List = %v after deletion", got)
+	}
+	if s := all[0].Summary(); s == nil || s.Content() != "Ship smaller changes" || s.Kind() != domain.SummaryEdited {
+		t.Errorf("summary not refreshed: %v", s)
+	}
+}
+
+func TestRepository_FailedRefreshInvalidates(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo := newSynced(t, inner)
+	ctx := context.Background()
+
+	inner.findErr = errors.New("upstream down")
+	inner.syncEvents = []domain.DomainEvent{domain.NewTranscriptUpdatedEvent("m-1", 3)}
+	since := time.Now()
+	if _, err := repo.Sync(ctx, &since); err != nil {
+		t.Fatalf("Sync should not fail on projection errors: %v", err)
+	}
+
+	calls := inner.listCalls
+	if _, err := repo.List(ctx, domain.ListFilter{}); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if inner.listCalls != calls+1 {
+		t.Error("expected reads to fall through after a failed update")
+	}
+
+	// The next sync backfills the dropped meeting and serves reads again.
+	inner.findErr = nil
+	inner.syncEvents = nil
+	if _, err := repo.Sync(ctx, &since); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	calls = inner.listCalls
+	all, err := repo.List(ctx, domain.ListFilter{})
+	if err != nil || len(all) != 3 || inner.listCalls != calls {
+		t.Errorf("List = %d meetings, %v; inner calls %d → %d", len(all), err, calls, inner.listCalls)
+	}
+}
+
+func TestRepository_StaleProjectionFallsThrough(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo, err := projection.NewRepository(inner, openTestDB(t), time.Nanosecond)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	if _, err := repo.Sync(context.Background(), nil); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	calls := inner.listCalls
+	if _, err := repo.List(context.Background(), domain.ListFilter{}); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if inner.listCalls != calls+1 {
+		t.Error("expected a stale projection to fall through")
+	}
+}
+
+func TestRepository_StatsMatchFullComputation(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo := newSynced(t, inner)
+	ctx := context.Background()
+
+	since := base.Add(-100 * time.Hour)
+	for _, input := range []meetingapp.GetMeetingStatsInput{{}, {Since: &since}, {Until: &base}} {
+		want, err := meetingapp.NewGetMeetingStats(inner).Execute(ctx, input)
+		if err != nil {
+			t.Fatalf("slow path: %v", err)
+		}
+		got, err := meetingapp.NewGetMeetingStats(repo).Execute(ctx, input)
+		if err != nil {
+			t.Fatalf("projection: %v", err)
+		}
+		got.GeneratedAt, want.GeneratedAt = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("stats differ for %+v:\n got %+v\nwant %+v", input, got, want)
+		}
+	}
+}
+
+type recordingDispatcher struct{ events []domain.DomainEvent }
+
+func (d *recordingDispatcher) Dispatch(_ context.Context, events []domain.DomainEvent) error {
+	d.events = append(d.events, events...)
+	return nil
+}
+
+func TestDispatcher_AppliesThenForwards(t *testing.T) {
+	inner := newMockRepo()
+	seed(t, inner)
+	repo := newSynced(t, inner)
+	ctx := context.Background()
+
+	added, _ := domain.New("m-4", "Offsite", base.Add(50*time.Hour), domain.SourceOther, nil)
+	inner.meetings["m-4"] = added
+	next := &recordingDispatcher{}
+	events := []domain.DomainEvent{domain.NewMeetingCreatedEvent("m-4", "Offsite", added.Datetime())}
+	if err := projection.NewDispatcher(next, repo).Dispatch(ctx, events); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if len(next.events) != 1 {
+		t.Errorf("forwarded %d events, want 1", len(next.events))
+	}
+
+	all, err := repo.List(ctx, domain.ListFilter{Limit: 1})
+	if err != nil || len(all) != 1 || all[0].ID() != "m-4" {
+		t.Errorf("List = %v, %v; want the dispatched meeting first", ids(all), err)
+	}
+}
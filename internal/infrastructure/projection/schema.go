@@ -0,0 +1,25 @@
+// Package projection maintains a normalised SQLite copy of the meeting read
+// model — meetings, participants, utterances, summaries, action items and
+// tags — kept current by sync, so list, search and statistics queries are
+// answered with SQL instead of round trips to the upstream source.
+package projection
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/migrate"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations is the versioned schema of the projection tables in cache.db.
+var Migrations = migrate.MustLoad("projection", migrationFiles, "migrations")
+
+// InitSchema applies pending projection migrations.
+func InitSchema(db *sql.DB) error {
+	_, err := migrate.Up(context.Background(), db, Migrations)
+	return err
+}
@@ -0,0 +1,104 @@
+package projection
+
+import (
+	"context"
+	"database/sql"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+// MeetingStats aggregates statistics for meetings within the filter's
+// Since/Until bounds with SQL. It returns domain.ErrStatsUnavailable while
+// the projection is not fresh.
+func (r *Repository) MeetingStats(ctx context.Context, filter domain.ListFilter) (*domain.Stats, error) {
+	if !r.fresh(ctx) {
+		return nil, domain.ErrStatsUnavailable
+	}
+
+	cond, args := where(domain.ListFilter{Since: filter.Since, Until: filter.Until}, "")
+	stats := &domain.Stats{}
+
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*), COUNT(s.meeting_id)
+		FROM meetings m LEFT JOIN summaries s ON s.meeting_id = m.id WHERE `+cond, args...,
+	).Scan(&stats.Meetings, &stats.WithSummary)
+	if err != nil || stats.Meetings == 0 {
+		return stats, err
+	}
+
+	for _, bound := range []struct {
+		order string
+		dest  *string
+	}{{"ASC", &stats.EarliestDay}, {"DESC", &stats.LatestDay}} {
+		err := r.db.QueryRowContext(ctx, `SELECT m.day FROM meetings m WHERE `+cond+
+			` ORDER BY m.starts_at `+bound.order+` LIMIT 1`, args...).Scan(bound.dest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*), COALESCE(SUM(a.completed), 0)
+		FROM action_items a JOIN meetings m ON m.id = a.meeting_id WHERE `+cond, args...,
+	).Scan(&stats.ActionItems, &stats.CompletedActionItems)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []struct {
+		query string
+		scan  func(*sql.Rows) error
+	}{
+		{`SELECT m.day, COUNT(*) FROM meetings m WHERE ` + cond + ` GROUP BY m.day ORDER BY m.day`,
+			func(rows *sql.Rows) error {
+				var c domain.DayCount
+				if err := rows.Scan(&c.Day, &c.Count); err != nil {
+					return err
+				}
+				stats.PerDay = append(stats.PerDay, c)
+				return nil
+			}},
+		{`SELECT m.source, COUNT(*) FROM meetings m WHERE ` + cond + ` GROUP BY m.source ORDER BY 2 DESC, 1`,
+			func(rows *sql.Rows) error {
+				var c domain.SourceCount
+				if err := rows.Scan(&c.Source, &c.Count); err != nil {
+					return err
+				}
+				stats.PerSource = append(stats.PerSource, c)
+				return nil
+			}},
+		{`SELECT p.name, p.email, COUNT(*) FROM meeting_participants p JOIN meetings m ON m.id = p.meeting_id
+			WHERE ` + cond + ` GROUP BY p.name, p.email ORDER BY 3 DESC, 1, 2`,
+			func(rows *sql.Rows) error {
+				var c domain.ParticipantCount
+				if err := rows.Scan(&c.Name, &c.Email, &c.Meetings); err != nil {
+					return err
+				}
+				stats.Participants = append(stats.Participants, c)
+				return nil
+			}},
+		{`SELECT m.weekday, m.hour, COUNT(*) FROM meetings m WHERE ` + cond + ` GROUP BY 1, 2 ORDER BY 1, 2`,
+			func(rows *sql.Rows) error {
+				var c domain.WeekdayHourCount
+				if err := rows.Scan(&c.Weekday, &c.Hour, &c.Count); err != nil {
+					return err
+				}
+				stats.PerWeekdayHour = append(stats.PerWeekdayHour, c)
+				return nil
+			}},
+		{`SELECT u.speaker, SUM(u.word_count), COUNT(*) FROM utterances u JOIN meetings m ON m.id = u.meeting_id
+			WHERE ` + cond + ` GROUP BY u.speaker ORDER BY 2 DESC, 1`,
+			func(rows *sql.Rows) error {
+				var c domain.SpeakerCount
+				if err := rows.Scan(&c.Speaker, &c.Words, &c.Utterances); err != nil {
+					return err
+				}
+				stats.Speakers = append(stats.Speakers, c)
+				return nil
+			}},
+	}
+	for _, q := range queries {
+		if err := r.each(ctx, q.query, args, q.scan); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
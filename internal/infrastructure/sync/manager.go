@@ -11,7 +11,13 @@ import (
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 )
 
-// Manager runs periodic meeting sync in the background.
+// fullSyncInterval is how often a tick syncs without a since bound. Only a
+// full sync lists every meeting, so only a full sync can detect deletions.
+const fullSyncInterval = time.Hour
+
+// Manager runs periodic meeting sync in the background. Ticks sync the
+// changes since the previous tick, with a full sync at most every
+// fullSyncInterval.
 type Manager struct {
 	syncUC     *meetingapp.SyncMeetings
 	dispatcher domain.EventDispatcher
@@ -19,6 +25,7 @@ type Manager struct {
 
 	mu            sync.Mutex
 	lastSyncTime  *time.Time
+	lastFullSync  time.Time
 	cancel        context.CancelFunc
 	done          chan struct{}
 }
@@ -71,6 +78,9 @@ func (m *Manager) run(ctx context.Context) {
 func (m *Manager) tick(ctx context.Context) {
 	m.mu.Lock()
 	since := m.lastSyncTime
+	if time.Since(m.lastFullSync) >= fullSyncInterval {
+		since = nil
+	}
 	m.mu.Unlock()
 
 	out, err := m.syncUC.Execute(ctx, meetingapp.SyncMeetingsInput{Since: since})
@@ -85,8 +95,16 @@ func (m *Manager) tick(ctx context.Context) {
 	now := time.Now().UTC()
 	m.mu.Lock()
 	m.lastSyncTime = &now
+	if since == nil {
+		m.lastFullSync = now
+	}
 	m.mu.Unlock()
 
+	for _, o := range out.Orphans {
+		log.Printf("sync manager: meeting %s deleted upstream; kept %d local note(s) and %d action item override(s)",
+			o.MeetingID, o.State.Notes, o.State.ActionItemOverrides)
+	}
+
 	if len(out.Events) == 0 {
 		return
 	}
@@ -16,6 +16,7 @@ type mockRepo struct {
 	events []domain.DomainEvent
 	err    error
 	calls  int
+	sinces []*time.Time
 }
 
 func (m *mockRepo) FindByID(_ context.Context, _ domain.MeetingID) (*domain.Meeting, error) {
@@ -33,8 +34,9 @@ func (m *mockRepo) SearchTranscripts(_ context.Context, _ string, _ domain.ListF
 func (m *mockRepo) GetActionItems(_ context.Context, _ domain.MeetingID) ([]*domain.ActionItem, error) {
 	return nil, nil
 }
-func (m *mockRepo) Sync(_ context.Context, _ *time.Time) ([]domain.DomainEvent, error) {
+func (m *mockRepo) Sync(_ context.Context, since *time.Time) ([]domain.DomainEvent, error) {
 	m.calls++
+	m.sinces = append(m.sinces, since)
 	return m.events, m.err
 }
 
@@ -53,7 +55,7 @@ func TestSyncManager_Start_TicksSyncAndDispatches(t *testing.T) {
 	event := domain.NewMeetingCreatedEvent("m-1", "Test", time.Now().UTC())
 	repo := &mockRepo{events: []domain.DomainEvent{event}}
 	dispatcher := &mockDispatcher{}
-	uc := meetingapp.NewSyncMeetings(repo)
+	uc := meetingapp.NewSyncMeetings(repo, nil)
 
 	mgr := syncmgr.NewManager(uc, dispatcher, 50*time.Millisecond)
 	mgr.Start(context.Background())
@@ -70,10 +72,29 @@ func TestSyncManager_Start_TicksSyncAndDispatches(t *testing.T) {
 	}
 }
 
+func TestSyncManager_FirstTickIsFullSync(t *testing.T) {
+	repo := &mockRepo{}
+	uc := meetingapp.NewSyncMeetings(repo, nil)
+
+	mgr := syncmgr.NewManager(uc, &mockDispatcher{}, 50*time.Millisecond)
+	mgr.Start(context.Background())
+	time.Sleep(150 * time.Millisecond)
+	mgr.Stop()
+
+	if len(repo.sinces) < 2 {
+		t.Fatalf("expected at least 2 sync calls, got %d", len(repo.sinces))
+	}
+	// The first sync lists every meeting so deletions are detected; the
+	// next ones only ask for changes.
+	if repo.sinces[0] != nil || repo.sinces[1] == nil {
+		t.Errorf("got since %v then %v, want a full sync then an incremental one", repo.sinces[0], repo.sinces[1])
+	}
+}
+
 func TestSyncManager_Stop_GracefulShutdown(t *testing.T) {
 	repo := &mockRepo{}
 	dispatcher := &mockDispatcher{}
-	uc := meetingapp.NewSyncMeetings(repo)
+	uc := meetingapp.NewSyncMeetings(repo, nil)
 
 	mgr := syncmgr.NewManager(uc, dispatcher, 1*time.Hour) // long interval
 	mgr.Start(context.Background())
@@ -96,7 +117,7 @@ func TestSyncManager_Stop_GracefulShutdown(t *testing.T) {
 func TestSyncManager_SyncError_ContinuesNextTick(t *testing.T) {
 	repo := &mockRepo{err: errors.New("api error")}
 	dispatcher := &mockDispatcher{}
-	uc := meetingapp.NewSyncMeetings(repo)
+	uc := meetingapp.NewSyncMeetings(repo, nil)
 
 	mgr := syncmgr.NewManager(uc, dispatcher, 50*time.Millisecond)
 	mgr.Start(context.Background())
@@ -117,7 +138,7 @@ func TestSyncManager_SyncError_ContinuesNextTick(t *testing.T) {
 func TestSyncManager_ContextCanceled_Stops(t *testing.T) {
 	repo := &mockRepo{}
 	dispatcher := &mockDispatcher{}
-	uc := meetingapp.NewSyncMeetings(repo)
+	uc := meetingapp.NewSyncMeetings(repo, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	mgr := syncmgr.NewManager(uc, dispatcher, 50*time.Millisecond)
@@ -150,7 +171,7 @@ func TestSyncManager_TracksSinceTimestamp(t *testing.T) {
 	// We need a custom repo that captures since
 	capRepo := &captureSinceRepo{}
 	dispatcher := &mockDispatcher{}
-	uc := meetingapp.NewSyncMeetings(capRepo)
+	uc := meetingapp.NewSyncMeetings(capRepo, nil)
 
 	mgr := syncmgr.NewManager(uc, dispatcher, 50*time.Millisecond)
 	mgr.Start(context.Background())
@@ -200,7 +221,7 @@ func (m *captureSinceRepo) Sync(_ context.Context, since *time.Time) ([]domain.D
 func TestSyncManager_ZeroEvents_NoDispatch(t *testing.T) {
 	repo := &mockRepo{events: []domain.DomainEvent{}} // empty events
 	dispatcher := &mockDispatcher{}
-	uc := meetingapp.NewSyncMeetings(repo)
+	uc := meetingapp.NewSyncMeetings(repo, nil)
 
 	mgr := syncmgr.NewManager(uc, dispatcher, 50*time.Millisecond)
 	mgr.Start(context.Background())
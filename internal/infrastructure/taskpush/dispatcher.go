@@ -0,0 +1,40 @@
+package taskpush
+
+import (
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+// actionItemEventTypes are the events that change an action item locally.
+var actionItemEventTypes = map[string]bool{
+	"action_item.completed": true,
+	"action_item.updated":   true,
+}
+
+// queuedPush is the outbox payload of a push: the action item to file or
+// update in the external trackers.
+type queuedPush struct {
+	MeetingID    string `json:"meeting_id"`
+	ActionItemID string `json:"action_item_id"`
+}
+
+// NewDispatcher creates a dispatcher decorator queueing an outbox entry
+// addressed to the task push sink for every action item event. The outbox
+// relay delivers the entries through Sink.
+func NewDispatcher(inner domain.EventDispatcher, store outbox.Store) *outbox.QueueDispatcher {
+	return outbox.NewQueueDispatcher(inner, store, SinkName, "task_", routeActionItemEvent)
+}
+
+func routeActionItemEvent(event domain.DomainEvent) (any, bool) {
+	if !actionItemEventTypes[event.EventName()] {
+		return nil, false
+	}
+	item, ok := event.(interface {
+		MeetingID() domain.MeetingID
+		ActionItemID() domain.ActionItemID
+	})
+	if !ok {
+		return nil, false
+	}
+	return queuedPush{MeetingID: string(item.MeetingID()), ActionItemID: string(item.ActionItemID())}, true
+}
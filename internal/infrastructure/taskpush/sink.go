@@ -0,0 +1,57 @@
+// Package taskpush keeps the tickets filed for action items in step with
+// local changes. Completing or editing an action item queues an outbox entry,
+// and the relay pushes the item to every configured tracker (GitHub Issues,
+// Linear, Jira) through the PushActionItem use case.
+package taskpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+// SinkName addresses outbox entries to the task push sink.
+const SinkName = "task_trackers"
+
+// Pusher pushes one action item to the external trackers.
+// It is implemented by meetingapp.PushActionItem.
+type Pusher interface {
+	Execute(ctx context.Context, input meetingapp.PushActionItemInput) (*meetingapp.PushActionItemOutput, error)
+}
+
+// Sink pushes queued action items to the external trackers.
+// It implements outbox.Sink and is registered with the relay via Route.
+type Sink struct {
+	pusher Pusher
+}
+
+// NewSink creates the task push sink.
+func NewSink(pusher Pusher) *Sink {
+	return &Sink{pusher: pusher}
+}
+
+func (s *Sink) Name() string { return SinkName }
+
+// Deliver pushes the entry's action item. Task links make pushes
+// idempotent, so a retry only repeats the trackers that failed.
+func (s *Sink) Deliver(ctx context.Context, entry outbox.Entry) error {
+	var queued queuedPush
+	if err := json.Unmarshal(entry.Payload, &queued); err != nil || queued.MeetingID == "" || queued.ActionItemID == "" {
+		return outbox.Permanent(fmt.Errorf("invalid task push payload: %s", entry.Payload))
+	}
+	_, err := s.pusher.Execute(ctx, meetingapp.PushActionItemInput{
+		MeetingID:    domain.MeetingID(queued.MeetingID),
+		ActionItemID: domain.ActionItemID(queued.ActionItemID),
+	})
+	if errors.Is(err, domain.ErrMeetingNotFound) {
+		return outbox.Permanent(err)
+	}
+	return err
+}
+
+var _ outbox.Sink = (*Sink)(nil)
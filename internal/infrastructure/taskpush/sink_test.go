@@ -0,0 +1,100 @@
+package taskpush_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/taskpush"
+)
+
+// capturePusher records pushed action items and fails with err.
+type capturePusher struct {
+	pushed []meetingapp.PushActionItemInput
+	err    error
+}
+
+func (p *capturePusher) Execute(_ context.Context, input meetingapp.PushActionItemInput) (*meetingapp.PushActionItemOutput, error) {
+	p.pushed = append(p.pushed, input)
+	return &meetingapp.PushActionItemOutput{}, p.err
+}
+
+func TestSink_DeliverQueuedPush(t *testing.T) {
+	store := &captureStore{}
+	d := taskpush.NewDispatcher(nopDispatcher{}, store)
+	note, _ := annotation.NewAgentNote("n-1", "m-1", "claude", "Budget might be the blocker")
+	events := []domain.DomainEvent{
+		domain.NewActionItemCompletedEvent("m-1", "ai-1"),
+		domain.NewActionItemUpdatedEvent("m-1", "ai-2", "Send the deck"),
+		annotation.NewNoteAddedEventFor(note),
+	}
+	if err := d.Dispatch(context.Background(), events); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(store.entries) != 2 || store.entries[0].Sink != taskpush.SinkName {
+		t.Fatalf("queued %+v, want two entries for %s", store.entries, taskpush.SinkName)
+	}
+
+	pusher := &capturePusher{}
+	sink := taskpush.NewSink(pusher)
+	for _, entry := range store.entries {
+		if err := sink.Deliver(context.Background(), entry); err != nil {
+			t.Fatalf("deliver: %v", err)
+		}
+	}
+	if len(pusher.pushed) != 2 || pusher.pushed[0].ActionItemID != "ai-1" || pusher.pushed[1].MeetingID != "m-1" {
+		t.Errorf("pushed %+v, want ai-1 and ai-2 of m-1", pusher.pushed)
+	}
+}
+
+func TestSink_DeliverErrors(t *testing.T) {
+	entry := outbox.Entry{ID: "task_x", Payload: []byte(`{"meeting_id":"m-1","action_item_id":"ai-1"}`)}
+
+	// Tracker failures are retried.
+	sink := taskpush.NewSink(&capturePusher{err: errors.New("github: rate limited")})
+	if err := sink.Deliver(context.Background(), entry); err == nil || outbox.IsPermanent(err) {
+		t.Errorf("tracker failure: got %v, want a retryable error", err)
+	}
+
+	// An item that no longer exists cannot be pushed.
+	sink = taskpush.NewSink(&capturePusher{err: domain.ErrMeetingNotFound})
+	if err := sink.Deliver(context.Background(), entry); !outbox.IsPermanent(err) {
+		t.Errorf("missing item: got %v, want a permanent error", err)
+	}
+
+	entry.Payload = []byte(`{"meeting_id":"m-1"}`)
+	if err := sink.Deliver(context.Background(), entry); !outbox.IsPermanent(err) {
+		t.Errorf("invalid payload: got %v, want a permanent error", err)
+	}
+}
+
+func TestDispatcher_QueueFailureDoesNotFailDispatch(t *testing.T) {
+	d := taskpush.NewDispatcher(nopDispatcher{}, &captureStore{err: errors.New("disk full")})
+
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewActionItemCompletedEvent("m-1", "ai-1")}); err != nil {
+		t.Errorf("dispatch failed after the inner chain committed: %v", err)
+	}
+}
+
+type nopDispatcher struct{}
+
+func (nopDispatcher) Dispatch(context.Context, []domain.DomainEvent) error { return nil }
+
+// captureStore records appended outbox entries, or fails with err.
+type captureStore struct {
+	outbox.Store
+	entries []outbox.Entry
+	err     error
+}
+
+func (s *captureStore) Append(e outbox.Entry) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.entries = append(s.entries, e)
+	return nil
+}
@@ -0,0 +1,93 @@
+package tasksink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubSink files action items as GitHub issues in a single repository.
+type GitHubSink struct {
+	baseURL    string
+	repo       string // "owner/name"
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitHubSink creates a GitHub Issues sink. An empty baseURL uses api.github.com.
+func NewGitHubSink(baseURL, repo, token string, httpClient *http.Client) *GitHubSink {
+	if baseURL == "" {
+		baseURL = defaultGitHubAPIURL
+	}
+	return &GitHubSink{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		repo:       repo,
+		token:      token,
+		httpClient: defaultClient(httpClient),
+	}
+}
+
+func (s *GitHubSink) Name() string { return "github" }
+
+type githubIssueRequest struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+type githubIssueResponse struct {
+	Number int `json:"number"`
+}
+
+func (s *GitHubSink) CreateTask(ctx context.Context, item *domain.ActionItem) (string, error) {
+	var resp githubIssueResponse
+	err := doJSON(ctx, s.httpClient, http.MethodPost, s.issuesURL(), s.setAuth, githubIssueRequest{
+		Title: item.Text(),
+		Body:  describe(item),
+	}, &resp)
+	if err != nil {
+		return "", fmt.Errorf("github create issue: %w", err)
+	}
+	return strconv.Itoa(resp.Number), nil
+}
+
+func (s *GitHubSink) UpdateTask(ctx context.Context, externalID string, item *domain.ActionItem) error {
+	req := githubIssueRequest{Title: item.Text(), Body: describe(item)}
+	if err := doJSON(ctx, s.httpClient, http.MethodPatch, s.issuesURL()+"/"+externalID, s.setAuth, req, nil); err != nil {
+		return fmt.Errorf("github update issue %s: %w", externalID, err)
+	}
+	return nil
+}
+
+func (s *GitHubSink) CloseTask(ctx context.Context, externalID string) error {
+	req := githubIssueRequest{State: "closed"}
+	if err := doJSON(ctx, s.httpClient, http.MethodPatch, s.issuesURL()+"/"+externalID, s.setAuth, req, nil); err != nil {
+		return fmt.Errorf("github close issue %s: %w", externalID, err)
+	}
+	return nil
+}
+
+func (s *GitHubSink) ReopenTask(ctx context.Context, externalID string) error {
+	req := githubIssueRequest{State: "open"}
+	if err := doJSON(ctx, s.httpClient, http.MethodPatch, s.issuesURL()+"/"+externalID, s.setAuth, req, nil); err != nil {
+		return fmt.Errorf("github reopen issue %s: %w", externalID, err)
+	}
+	return nil
+}
+
+func (s *GitHubSink) issuesURL() string {
+	return fmt.Sprintf("%s/repos/%s/issues", s.baseURL, s.repo)
+}
+
+func (s *GitHubSink) setAuth(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+}
+
+var _ domain.TaskSink = (*GitHubSink)(nil)
@@ -0,0 +1,94 @@
+package tasksink_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/tasksink"
+)
+
+func mustItem(t *testing.T) *domain.ActionItem {
+	t.Helper()
+	due := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	item, err := domain.NewActionItem("ai-1", "m-1", "Alice", "Ship release", &due)
+	if err != nil {
+		t.Fatalf("new action item: %v", err)
+	}
+	return item
+}
+
+func TestGitHubSink_CreateUpdateClose(t *testing.T) {
+	var requests []string
+	var lastBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if got := r.Header.Get("Authorization"); got != "Bearer gh-token" {
+			t.Errorf("got auth %q", got)
+		}
+		lastBody = nil
+		_ = json.NewDecoder(r.Body).Decode(&lastBody)
+		_, _ = w.Write([]byte(`{"number":42}`))
+	}))
+	defer srv.Close()
+
+	sink := tasksink.NewGitHubSink(srv.URL, "acme/tasks", "gh-token", srv.Client())
+	ctx := context.Background()
+	item := mustItem(t)
+
+	id, err := sink.CreateTask(ctx, item)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if id != "42" {
+		t.Errorf("got id %q, want 42", id)
+	}
+	if lastBody["title"] != "Ship release" || !strings.Contains(lastBody["body"].(string), "Owner: Alice") {
+		t.Errorf("unexpected create body %v", lastBody)
+	}
+
+	if err := sink.UpdateTask(ctx, id, item); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, ok := lastBody["state"]; ok {
+		t.Errorf("update should leave the state alone, got %v", lastBody)
+	}
+
+	if err := sink.CloseTask(ctx, id); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if lastBody["state"] != "closed" {
+		t.Errorf("close should set state closed, got %v", lastBody)
+	}
+
+	if err := sink.ReopenTask(ctx, id); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if lastBody["state"] != "open" {
+		t.Errorf("reopen should set state open, got %v", lastBody)
+	}
+
+	want := []string{"POST /repos/acme/tasks/issues", "PATCH /repos/acme/tasks/issues/42", "PATCH /repos/acme/tasks/issues/42", "PATCH /repos/acme/tasks/issues/42"}
+	if strings.Join(requests, ",") != strings.Join(want, ",") {
+		t.Errorf("got requests %v, want %v", requests, want)
+	}
+}
+
+func TestGitHubSink_Unauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	sink := tasksink.NewGitHubSink(srv.URL, "acme/tasks", "bad", srv.Client())
+	_, err := sink.CreateTask(context.Background(), mustItem(t))
+	if !errors.Is(err, tasksink.ErrUnauthorized) {
+		t.Errorf("got error %v, want %v", err, tasksink.ErrUnauthorized)
+	}
+}
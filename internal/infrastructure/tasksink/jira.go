@@ -0,0 +1,159 @@
+package tasksink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+const defaultJiraIssueType = "Task"
+
+// JiraSink files action items as Jira issues via the REST API v2.
+type JiraSink struct {
+	baseURL    string
+	email      string
+	apiToken   string
+	projectKey string
+	issueType  string
+	httpClient *http.Client
+}
+
+// NewJiraSink creates a Jira sink. An empty issueType defaults to "Task".
+func NewJiraSink(baseURL, email, apiToken, projectKey, issueType string, httpClient *http.Client) *JiraSink {
+	if issueType == "" {
+		issueType = defaultJiraIssueType
+	}
+	return &JiraSink{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		email:      email,
+		apiToken:   apiToken,
+		projectKey: projectKey,
+		issueType:  issueType,
+		httpClient: defaultClient(httpClient),
+	}
+}
+
+func (s *JiraSink) Name() string { return "jira" }
+
+type jiraCreateResponse struct {
+	Key string `json:"key"`
+}
+
+type jiraTransitionsResponse struct {
+	Transitions []struct {
+		ID string `json:"id"`
+		To struct {
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"to"`
+	} `json:"transitions"`
+}
+
+type jiraStatusResponse struct {
+	Fields struct {
+		Status struct {
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+	} `json:"fields"`
+}
+
+// jiraDoneCategory is the status category key Jira assigns to resolved states.
+const jiraDoneCategory = "done"
+
+func (s *JiraSink) CreateTask(ctx context.Context, item *domain.ActionItem) (string, error) {
+	fields := s.fields(item)
+	fields["project"] = map[string]string{"key": s.projectKey}
+	fields["issuetype"] = map[string]string{"name": s.issueType}
+
+	var resp jiraCreateResponse
+	err := doJSON(ctx, s.httpClient, http.MethodPost, s.baseURL+"/rest/api/2/issue", s.setAuth,
+		map[string]any{"fields": fields}, &resp)
+	if err != nil {
+		return "", fmt.Errorf("jira create issue: %w", err)
+	}
+	return resp.Key, nil
+}
+
+func (s *JiraSink) UpdateTask(ctx context.Context, externalID string, item *domain.ActionItem) error {
+	err := doJSON(ctx, s.httpClient, http.MethodPut, s.issueURL(externalID), s.setAuth,
+		map[string]any{"fields": s.fields(item)}, nil)
+	if err != nil {
+		return fmt.Errorf("jira update issue %s: %w", externalID, err)
+	}
+	return nil
+}
+
+// CloseTask moves the issue through the first transition into the "done"
+// status category. Workflows differ per project, so the transition is
+// discovered rather than configured.
+func (s *JiraSink) CloseTask(ctx context.Context, externalID string) error {
+	if err := s.transition(ctx, externalID, true); err != nil {
+		return fmt.Errorf("jira close issue %s: %w", externalID, err)
+	}
+	return nil
+}
+
+// ReopenTask moves a resolved issue through the first transition out of the
+// "done" status category. A workflow without one cannot reopen the issue.
+func (s *JiraSink) ReopenTask(ctx context.Context, externalID string) error {
+	if err := s.transition(ctx, externalID, false); err != nil {
+		return fmt.Errorf("jira reopen issue %s: %w", externalID, err)
+	}
+	return nil
+}
+
+// transition moves the issue into (done) or out of (!done) the "done" status
+// category, unless it is there already.
+func (s *JiraSink) transition(ctx context.Context, externalID string, done bool) error {
+	var status jiraStatusResponse
+	if err := doJSON(ctx, s.httpClient, http.MethodGet, s.issueURL(externalID)+"?fields=status", s.setAuth, nil, &status); err != nil {
+		return err
+	}
+	if (status.Fields.Status.StatusCategory.Key == jiraDoneCategory) == done {
+		return nil
+	}
+
+	var transitions jiraTransitionsResponse
+	if err := doJSON(ctx, s.httpClient, http.MethodGet, s.issueURL(externalID)+"/transitions", s.setAuth, nil, &transitions); err != nil {
+		return err
+	}
+	for _, t := range transitions.Transitions {
+		if (t.To.StatusCategory.Key == jiraDoneCategory) != done {
+			continue
+		}
+		return doJSON(ctx, s.httpClient, http.MethodPost, s.issueURL(externalID)+"/transitions", s.setAuth,
+			map[string]any{"transition": map[string]string{"id": t.ID}}, nil)
+	}
+	if !done {
+		return fmt.Errorf("no transition out of a done status: %w", domain.ErrReopenUnsupported)
+	}
+	return fmt.Errorf("no transition to a done status")
+}
+
+func (s *JiraSink) fields(item *domain.ActionItem) map[string]any {
+	fields := map[string]any{
+		"summary":     item.Text(),
+		"description": describe(item),
+	}
+	if item.DueDate() != nil {
+		fields["duedate"] = item.DueDate().Format("2006-01-02")
+	}
+	return fields
+}
+
+func (s *JiraSink) issueURL(key string) string {
+	return s.baseURL + "/rest/api/2/issue/" + url.PathEscape(key)
+}
+
+func (s *JiraSink) setAuth(req *http.Request) {
+	req.SetBasicAuth(s.email, s.apiToken)
+}
+
+var _ domain.TaskSink = (*JiraSink)(nil)
@@ -0,0 +1,137 @@
+package tasksink_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/tasksink"
+)
+
+func newJiraServer(t *testing.T, statusCategory string, transitioned *string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "me@example.com" || pass != "jira-token" {
+			t.Errorf("unexpected basic auth %q/%q", user, pass)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /rest/api/2/issue":
+			var body map[string]map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["fields"]["summary"] != "Ship release" || body["fields"]["duedate"] != "2025-02-01" {
+				t.Errorf("unexpected create fields %v", body["fields"])
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"10001","key":"OPS-7"}`))
+		case "PUT /rest/api/2/issue/OPS-7":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /rest/api/2/issue/OPS-7":
+			_, _ = w.Write([]byte(`{"fields":{"status":{"statusCategory":{"key":"` + statusCategory + `"}}}}`))
+		case "GET /rest/api/2/issue/OPS-7/transitions":
+			_, _ = w.Write([]byte(`{"transitions":[
+				{"id":"11","to":{"statusCategory":{"key":"indeterminate"}}},
+				{"id":"31","to":{"statusCategory":{"key":"done"}}}]}`))
+		case "POST /rest/api/2/issue/OPS-7/transitions":
+			var body map[string]map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*transitioned = body["transition"]["id"]
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestJiraSink_CreateUpdateClose(t *testing.T) {
+	var transitioned string
+	srv := newJiraServer(t, "new", &transitioned)
+	defer srv.Close()
+
+	sink := tasksink.NewJiraSink(srv.URL, "me@example.com", "jira-token", "OPS", "", srv.Client())
+	ctx := context.Background()
+	item := mustItem(t)
+
+	key, err := sink.CreateTask(ctx, item)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if key != "OPS-7" {
+		t.Errorf("got key %q, want OPS-7", key)
+	}
+	if err := sink.UpdateTask(ctx, key, item); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := sink.CloseTask(ctx, key); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if transitioned != "31" {
+		t.Errorf("got transition %q, want 31 (first done transition)", transitioned)
+	}
+}
+
+func TestJiraSink_CloseAlreadyDone(t *testing.T) {
+	var transitioned string
+	srv := newJiraServer(t, "done", &transitioned)
+	defer srv.Close()
+
+	sink := tasksink.NewJiraSink(srv.URL, "me@example.com", "jira-token", "OPS", "", srv.Client())
+	if err := sink.CloseTask(context.Background(), "OPS-7"); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if transitioned != "" {
+		t.Error("already resolved issue should not be transitioned again")
+	}
+}
+
+func TestJiraSink_ReopenResolvedIssue(t *testing.T) {
+	var transitioned string
+	srv := newJiraServer(t, "done", &transitioned)
+	defer srv.Close()
+
+	sink := tasksink.NewJiraSink(srv.URL, "me@example.com", "jira-token", "OPS", "", srv.Client())
+	if err := sink.ReopenTask(context.Background(), "OPS-7"); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if transitioned != "11" {
+		t.Errorf("got transition %q, want 11 (first transition out of done)", transitioned)
+	}
+}
+
+func TestJiraSink_ReopenOpenIssueIsNoop(t *testing.T) {
+	var transitioned string
+	srv := newJiraServer(t, "indeterminate", &transitioned)
+	defer srv.Close()
+
+	sink := tasksink.NewJiraSink(srv.URL, "me@example.com", "jira-token", "OPS", "", srv.Client())
+	if err := sink.ReopenTask(context.Background(), "OPS-7"); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if transitioned != "" {
+		t.Error("open issue should not be transitioned")
+	}
+}
+
+func TestJiraSink_ReopenWithoutTransitionIsUnsupported(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/issue/OPS-7":
+			_, _ = w.Write([]byte(`{"fields":{"status":{"statusCategory":{"key":"done"}}}}`))
+		case "/rest/api/2/issue/OPS-7/transitions":
+			_, _ = w.Write([]byte(`{"transitions":[{"id":"31","to":{"statusCategory":{"key":"done"}}}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	sink := tasksink.NewJiraSink(srv.URL, "me@example.com", "jira-token", "OPS", "", srv.Client())
+	if err := sink.ReopenTask(context.Background(), "OPS-7"); !errors.Is(err, domain.ErrReopenUnsupported) {
+		t.Errorf("got %v, want ErrReopenUnsupported", err)
+	}
+}
@@ -0,0 +1,162 @@
+package tasksink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+const defaultLinearAPIURL = "https://api.linear.app/graphql"
+
+// LinearSink files action items as Linear issues via the GraphQL API.
+type LinearSink struct {
+	endpoint    string
+	apiKey      string
+	teamID      string
+	doneStateID string // workflow state used when closing; Linear has no generic "close"
+	openStateID string // workflow state used when reopening; empty: issues are not reopened
+	httpClient  *http.Client
+}
+
+// NewLinearSink creates a Linear sink. An empty endpoint uses api.linear.app.
+func NewLinearSink(endpoint, apiKey, teamID, doneStateID, openStateID string, httpClient *http.Client) *LinearSink {
+	if endpoint == "" {
+		endpoint = defaultLinearAPIURL
+	}
+	return &LinearSink{
+		endpoint:    endpoint,
+		apiKey:      apiKey,
+		teamID:      teamID,
+		doneStateID: doneStateID,
+		openStateID: openStateID,
+		httpClient:  defaultClient(httpClient),
+	}
+}
+
+func (s *LinearSink) Name() string { return "linear" }
+
+const (
+	linearCreateMutation = `mutation IssueCreate($input: IssueCreateInput!) {
+  issueCreate(input: $input) { success issue { id identifier } }
+}`
+	linearUpdateMutation = `mutation IssueUpdate($id: String!, $input: IssueUpdateInput!) {
+  issueUpdate(id: $id, input: $input) { success }
+}`
+)
+
+type linearRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type linearError struct {
+	Message string `json:"message"`
+}
+
+type linearCreateResponse struct {
+	Data struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				ID string `json:"id"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+	} `json:"data"`
+	Errors []linearError `json:"errors"`
+}
+
+type linearUpdateResponse struct {
+	Data struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	} `json:"data"`
+	Errors []linearError `json:"errors"`
+}
+
+func (s *LinearSink) CreateTask(ctx context.Context, item *domain.ActionItem) (string, error) {
+	input := s.issueInput(item)
+	input["teamId"] = s.teamID
+
+	var resp linearCreateResponse
+	if err := s.call(ctx, linearCreateMutation, map[string]any{"input": input}, &resp); err != nil {
+		return "", fmt.Errorf("linear create issue: %w", err)
+	}
+	if err := linearErr(resp.Errors, resp.Data.IssueCreate.Success); err != nil {
+		return "", fmt.Errorf("linear create issue: %w", err)
+	}
+	return resp.Data.IssueCreate.Issue.ID, nil
+}
+
+func (s *LinearSink) UpdateTask(ctx context.Context, externalID string, item *domain.ActionItem) error {
+	return s.update(ctx, externalID, s.issueInput(item))
+}
+
+func (s *LinearSink) CloseTask(ctx context.Context, externalID string) error {
+	if s.doneStateID == "" {
+		return fmt.Errorf("linear close issue %s: done state id not configured", externalID)
+	}
+	return s.update(ctx, externalID, map[string]any{"stateId": s.doneStateID})
+}
+
+func (s *LinearSink) ReopenTask(ctx context.Context, externalID string) error {
+	if s.openStateID == "" {
+		return fmt.Errorf("linear reopen issue %s: open state id not configured: %w", externalID, domain.ErrReopenUnsupported)
+	}
+	return s.update(ctx, externalID, map[string]any{"stateId": s.openStateID})
+}
+
+func (s *LinearSink) update(ctx context.Context, externalID string, input map[string]any) error {
+	var resp linearUpdateResponse
+	vars := map[string]any{"id": externalID, "input": input}
+	if err := s.call(ctx, linearUpdateMutation, vars, &resp); err != nil {
+		return fmt.Errorf("linear update issue %s: %w", externalID, err)
+	}
+	if err := linearErr(resp.Errors, resp.Data.IssueUpdate.Success); err != nil {
+		return fmt.Errorf("linear update issue %s: %w", externalID, err)
+	}
+	return nil
+}
+
+func (s *LinearSink) issueInput(item *domain.ActionItem) map[string]any {
+	input := map[string]any{
+		"title":       item.Text(),
+		"description": describe(item),
+	}
+	if item.DueDate() != nil {
+		input["dueDate"] = item.DueDate().Format("2006-01-02")
+	}
+	return input
+}
+
+func (s *LinearSink) call(ctx context.Context, query string, vars map[string]any, target any) error {
+	return doJSON(ctx, s.httpClient, http.MethodPost, s.endpoint, s.setAuth, linearRequest{
+		Query:     query,
+		Variables: vars,
+	}, target)
+}
+
+func (s *LinearSink) setAuth(req *http.Request) {
+	// Linear personal API keys are sent without a scheme prefix.
+	req.Header.Set("Authorization", s.apiKey)
+}
+
+// linearErr converts GraphQL errors (returned with HTTP 200) into a Go error.
+func linearErr(errs []linearError, success bool) error {
+	if len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, e := range errs {
+			msgs[i] = e.Message
+		}
+		return fmt.Errorf("graphql: %s", strings.Join(msgs, "; "))
+	}
+	if !success {
+		return fmt.Errorf("graphql: mutation reported failure")
+	}
+	return nil
+}
+
+var _ domain.TaskSink = (*LinearSink)(nil)
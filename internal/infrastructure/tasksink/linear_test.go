@@ -0,0 +1,99 @@
+package tasksink_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/tasksink"
+)
+
+type linearCall struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func TestLinearSink_CreateUpdateClose(t *testing.T) {
+	var calls []linearCall
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "lin-key" {
+			t.Errorf("got auth %q", got)
+		}
+		var call linearCall
+		_ = json.NewDecoder(r.Body).Decode(&call)
+		calls = append(calls, call)
+		if strings.Contains(call.Query, "issueCreate") {
+			_, _ = w.Write([]byte(`{"data":{"issueCreate":{"success":true,"issue":{"id":"lin-1","identifier":"ENG-1"}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"issueUpdate":{"success":true}}}`))
+	}))
+	defer srv.Close()
+
+	sink := tasksink.NewLinearSink(srv.URL, "lin-key", "team-1", "state-done", "state-todo", srv.Client())
+	ctx := context.Background()
+	item := mustItem(t)
+
+	id, err := sink.CreateTask(ctx, item)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if id != "lin-1" {
+		t.Errorf("got id %q, want lin-1", id)
+	}
+	input := calls[0].Variables["input"].(map[string]any)
+	if input["teamId"] != "team-1" || input["dueDate"] != "2025-02-01" {
+		t.Errorf("unexpected create input %v", input)
+	}
+
+	if err := sink.UpdateTask(ctx, id, item); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := sink.CloseTask(ctx, id); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	closeInput := calls[2].Variables["input"].(map[string]any)
+	if calls[2].Variables["id"] != "lin-1" || closeInput["stateId"] != "state-done" {
+		t.Errorf("unexpected close variables %v", calls[2].Variables)
+	}
+
+	if err := sink.ReopenTask(ctx, id); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	reopenInput := calls[3].Variables["input"].(map[string]any)
+	if calls[3].Variables["id"] != "lin-1" || reopenInput["stateId"] != "state-todo" {
+		t.Errorf("unexpected reopen variables %v", calls[3].Variables)
+	}
+}
+
+func TestLinearSink_GraphQLError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"errors":[{"message":"team not found"}]}`))
+	}))
+	defer srv.Close()
+
+	sink := tasksink.NewLinearSink(srv.URL, "lin-key", "bogus", "", "", srv.Client())
+	_, err := sink.CreateTask(context.Background(), mustItem(t))
+	if err == nil || !strings.Contains(err.Error(), "team not found") {
+		t.Errorf("expected graphql error, got %v", err)
+	}
+}
+
+func TestLinearSink_CloseRequiresDoneState(t *testing.T) {
+	sink := tasksink.NewLinearSink("http://unused.invalid", "lin-key", "team-1", "", "", nil)
+	if err := sink.CloseTask(context.Background(), "lin-1"); err == nil {
+		t.Error("expected error when done state is not configured")
+	}
+}
+
+func TestLinearSink_ReopenWithoutOpenStateIsUnsupported(t *testing.T) {
+	sink := tasksink.NewLinearSink("http://unused.invalid", "lin-key", "team-1", "state-done", "", nil)
+	if err := sink.ReopenTask(context.Background(), "lin-1"); !errors.Is(err, domain.ErrReopenUnsupported) {
+		t.Errorf("got %v, want ErrReopenUnsupported", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Package tasksink implements domain.TaskSink adapters that file action items
+// as tickets in external trackers (GitHub Issues, Linear, Jira).
+package tasksink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+)
+
+const httpClientTimeout = 30 * time.Second
+
+// Infrastructure-level errors returned by tracker APIs.
+var (
+	ErrNotFound     = errors.New("tasksink: ticket not found")
+	ErrRateLimited  = errors.New("tasksink: rate limited")
+	ErrUnauthorized = errors.New("tasksink: unauthorized")
+)
+
+// describe renders the ticket body shared by all trackers.
+func describe(item *domain.ActionItem) string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%s\n\n", item.Text())
+	if item.Owner() != "" {
+		_, _ = fmt.Fprintf(&b, "Owner: %s\n", item.Owner())
+	}
+	if item.DueDate() != nil {
+		_, _ = fmt.Fprintf(&b, "Due: %s\n", item.DueDate().Format("2006-01-02"))
+	}
+	_, _ = fmt.Fprintf(&b, "Meeting: meeting://%s\n", item.MeetingID())
+	_, _ = fmt.Fprintf(&b, "Action item: %s", item.ID())
+	return b.String()
+}
+
+// doJSON sends body as JSON and decodes the response into target (if non-nil).
+// setAuth applies the tracker-specific authentication headers.
+func doJSON(ctx context.Context, client *http.Client, method, url string, setAuth func(*http.Request), body, target any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	setAuth(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case resp.StatusCode >= 400:
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("api error (status %d): %s", resp.StatusCode, string(data))
+	}
+
+	if target == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+func defaultClient(c *http.Client) *http.Client {
+	if c == nil {
+		return &http.Client{Timeout: httpClientTimeout}
+	}
+	return c
+}
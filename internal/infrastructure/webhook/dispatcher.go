@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+// queuedDelivery is the outbox payload of a webhook delivery.
+type queuedDelivery struct {
+	SubscriptionID string          `json:"subscription_id"`
+	Envelope       json.RawMessage `json:"envelope"`
+}
+
+// Dispatcher decorates a domain.EventDispatcher, queueing one outbox entry
+// per matching subscription for each dispatched event. The outbox relay
+// delivers the entries through Sink.
+type Dispatcher struct {
+	inner domain.EventDispatcher
+	subs  *SQLiteRepository
+	store outbox.Store
+	codec *eventcodec.Registry
+}
+
+// NewDispatcher creates a webhook-queueing dispatcher decorator.
+func NewDispatcher(inner domain.EventDispatcher, subs *SQLiteRepository, store outbox.Store, codec *eventcodec.Registry) *Dispatcher {
+	return &Dispatcher{inner: inner, subs: subs, store: store, codec: codec}
+}
+
+// Dispatch forwards events to the inner dispatcher, then queues deliveries
+// for every subscription whose event types match. The envelope carries the
+// event's ID, as recorded in the event store. The change is committed by
+// then, so a delivery that cannot be queued is logged rather than failing
+// the use case.
+func (d *Dispatcher) Dispatch(ctx context.Context, events []domain.DomainEvent) error {
+	ctx, ids := eventcodec.WithEventIDs(ctx, events)
+	if err := d.inner.Dispatch(ctx, events); err != nil {
+		return err
+	}
+
+	subs, err := d.subs.List(ctx)
+	if err != nil {
+		log.Printf("webhook: list subscriptions: %v", err)
+		return nil
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	for i, event := range events {
+		var matched []Subscription
+		for _, sub := range subs {
+			if sub.Matches(event.EventName()) {
+				matched = append(matched, sub)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		envelope, err := d.codec.Marshal(ids[i], event)
+		if errors.Is(err, eventcodec.ErrUnknownEventType) {
+			continue
+		}
+		if err != nil {
+			log.Printf("webhook: marshal %s: %v", event.EventName(), err)
+			continue
+		}
+
+		for _, sub := range matched {
+			payload, err := json.Marshal(queuedDelivery{SubscriptionID: sub.ID, Envelope: envelope})
+			if err != nil {
+				log.Printf("webhook: marshal delivery of %s for %s: %v", event.EventName(), sub.ID, err)
+				continue
+			}
+			entry := outbox.Entry{
+				ID:        eventcodec.RandomID("dlv_", 16),
+				EventType: event.EventName(),
+				Payload:   payload,
+				CreatedAt: event.OccurredAt(),
+				Sink:      SinkName,
+			}
+			if err := d.store.Append(entry); err != nil {
+				log.Printf("webhook: queue %s for %s: %v", event.EventName(), sub.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+var _ domain.EventDispatcher = (*Dispatcher)(nil)
@@ -0,0 +1,190 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
+)
+
+type mockInnerDispatcher struct {
+	dispatched []domain.DomainEvent
+	err        error
+}
+
+func (m *mockInnerDispatcher) Dispatch(_ context.Context, events []domain.DomainEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.dispatched = append(m.dispatched, events...)
+	return nil
+}
+
+func TestDispatcher_QueuesOneEntryPerMatchingSubscription(t *testing.T) {
+	db := openTestDB(t)
+	repo := webhook.NewSQLiteRepository(db)
+	store := outbox.NewSQLiteStore(db)
+	inner := &mockInnerDispatcher{}
+
+	items := addSubscription(t, repo, "https://a.example.com", "action_item.*")
+	addSubscription(t, repo, "https://b.example.com", "note.added")
+	all := addSubscription(t, repo, "https://c.example.com", "*")
+
+	d := webhook.NewDispatcher(inner, repo, store, eventcodec.Default())
+	event := domain.NewActionItemCompletedEvent("m-1", "ai-1")
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{event}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	if len(inner.dispatched) != 1 {
+		t.Errorf("inner got %d events, want 1", len(inner.dispatched))
+	}
+
+	entries, err := store.ListDue(event.OccurredAt(), 0, []string{webhook.SinkName})
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d queued deliveries, want 2", len(entries))
+	}
+
+	got := map[string]bool{}
+	for _, e := range entries {
+		if e.Sink != webhook.SinkName || e.EventType != "action_item.completed" {
+			t.Errorf("unexpected entry %+v", e)
+		}
+		var payload struct {
+			SubscriptionID string              `json:"subscription_id"`
+			Envelope       eventcodec.Envelope `json:"envelope"`
+		}
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			t.Fatalf("payload: %v", err)
+		}
+		if payload.Envelope.Type != "action_item.completed" || payload.Envelope.AggregateID != "ai-1" {
+			t.Errorf("unexpected envelope %+v", payload.Envelope)
+		}
+		got[payload.SubscriptionID] = true
+	}
+	if !got[items.ID] || !got[all.ID] {
+		t.Errorf("deliveries queued for %v, want %s and %s", got, items.ID, all.ID)
+	}
+}
+
+func TestDispatcher_SharesEventIDWithEventStoreAndOutbox(t *testing.T) {
+	db := openTestDB(t)
+	repo := webhook.NewSQLiteRepository(db)
+	store := outbox.NewSQLiteStore(db)
+	events := eventstore.NewSQLiteStore(db, eventcodec.Default())
+	sub := addSubscription(t, repo, "https://a.example.com", "*")
+
+	d := webhook.NewDispatcher(
+		outbox.NewDispatcher(eventstore.NewDispatcher(&mockInnerDispatcher{}, events), store),
+		repo, store, eventcodec.Default(),
+	)
+	event := domain.NewActionItemCompletedEvent("m-1", "ai-1")
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{event}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	recorded, err := events.ListAfter(context.Background(), 0, 0)
+	if err != nil || len(recorded) != 1 {
+		t.Fatalf("recorded %v, %v; want one event", recorded, err)
+	}
+	id := recorded[0].Envelope.ID
+
+	entries, err := store.ListDue(event.OccurredAt(), 0, []string{""}) // broadcast entries
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != id {
+		t.Errorf("outbox entries %+v, want one with ID %s", entries, id)
+	}
+	deliveries, err := store.ListDue(event.OccurredAt(), 0, []string{webhook.SinkName})
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("deliveries %v, %v; want one for %s", deliveries, err, sub.ID)
+	}
+	var payload struct {
+		Envelope eventcodec.Envelope `json:"envelope"`
+	}
+	if err := json.Unmarshal(deliveries[0].Payload, &payload); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if payload.Envelope.ID != id {
+		t.Errorf("webhook envelope ID %s, want %s", payload.Envelope.ID, id)
+	}
+}
+
+func TestDispatcher_NoSubscriptionsQueuesNothing(t *testing.T) {
+	db := openTestDB(t)
+	store := outbox.NewSQLiteStore(db)
+	d := webhook.NewDispatcher(&mockInnerDispatcher{}, webhook.NewSQLiteRepository(db), store, eventcodec.Default())
+
+	event := domain.NewActionItemCompletedEvent("m-1", "ai-1")
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{event}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	pending, err := store.ListPending()
+	if err != nil {
+		t.Fatalf("list pending: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("got %d entries, want 0", len(pending))
+	}
+}
+
+func TestDispatcher_InnerErrorStopsQueueing(t *testing.T) {
+	db := openTestDB(t)
+	repo := webhook.NewSQLiteRepository(db)
+	store := outbox.NewSQLiteStore(db)
+	addSubscription(t, repo, "https://a.example.com", "*")
+
+	d := webhook.NewDispatcher(&mockInnerDispatcher{err: errors.New("boom")}, repo, store, eventcodec.Default())
+	err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewActionItemCompletedEvent("m-1", "ai-1")})
+	if err == nil {
+		t.Fatal("expected inner error")
+	}
+
+	pending, _ := store.ListPending()
+	if len(pending) != 0 {
+		t.Errorf("got %d entries, want 0 after inner failure", len(pending))
+	}
+}
+
+// failingStore is an outbox store whose appends fail.
+type failingStore struct{ outbox.Store }
+
+func (failingStore) Append(outbox.Entry) error { return errors.New("disk full") }
+
+func TestDispatcher_QueueFailureDoesNotFailDispatch(t *testing.T) {
+	db := openTestDB(t)
+	repo := webhook.NewSQLiteRepository(db)
+	addSubscription(t, repo, "https://a.example.com", "*")
+	inner := &mockInnerDispatcher{}
+
+	d := webhook.NewDispatcher(inner, repo, failingStore{}, eventcodec.Default())
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewActionItemCompletedEvent("m-1", "ai-1")}); err != nil {
+		t.Errorf("dispatch: %v; want the committed change reported as a success", err)
+	}
+	if len(inner.dispatched) != 1 {
+		t.Errorf("inner got %d events, want 1", len(inner.dispatched))
+	}
+}
+
+func TestDispatcher_SubscriptionListFailureDoesNotFailDispatch(t *testing.T) {
+	db := openTestDB(t)
+	repo := webhook.NewSQLiteRepository(db)
+	store := outbox.NewSQLiteStore(openTestDB(t))
+	_ = db.Close()
+
+	d := webhook.NewDispatcher(&mockInnerDispatcher{}, repo, store, eventcodec.Default())
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewActionItemCompletedEvent("m-1", "ai-1")}); err != nil {
+		t.Errorf("dispatch: %v; want the committed change reported as a success", err)
+	}
+}
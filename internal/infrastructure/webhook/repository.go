@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// SQLiteRepository persists subscriptions and delivery attempts in the local
+// database. Tables are created by localstore.InitSchema.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRepository creates a new SQLite-backed subscription repository.
+func NewSQLiteRepository(db *sql.DB) *SQLiteRepository {
+	return &SQLiteRepository{db: db}
+}
+
+func (r *SQLiteRepository) Add(ctx context.Context, sub Subscription) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO webhook_subscriptions (id, url, event_types, secret, created_at) VALUES (?, ?, ?, ?, ?)",
+		sub.ID, sub.URL, strings.Join(sub.EventTypes, ","), sub.Secret, sub.CreatedAt.UTC(),
+	)
+	return err
+}
+
+func (r *SQLiteRepository) Get(ctx context.Context, id string) (*Subscription, error) {
+	subs, err := r.query(ctx, "SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, ErrSubscriptionNotFound
+	}
+	return &subs[0], nil
+}
+
+func (r *SQLiteRepository) List(ctx context.Context) ([]Subscription, error) {
+	return r.query(ctx, "SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions ORDER BY created_at ASC")
+}
+
+func (r *SQLiteRepository) Remove(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteRepository) query(ctx context.Context, query string, args ...any) ([]Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	subs := []Subscription{}
+	for rows.Next() {
+		var s Subscription
+		var types string
+		if err := rows.Scan(&s.ID, &s.URL, &types, &s.Secret, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		s.EventTypes = strings.Split(types, ",")
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// RecordAttempt stores a delivery attempt.
+func (r *SQLiteRepository) RecordAttempt(ctx context.Context, a Attempt) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (subscription_id, delivery_id, event_type, status_code, error, duration_ms, attempted_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		a.SubscriptionID, a.DeliveryID, a.EventType, a.StatusCode, a.Error, a.Duration.Milliseconds(), a.AttemptedAt.UTC(),
+	)
+	return err
+}
+
+// ListAttempts returns up to limit of the most recent attempts for a
+// subscription, newest first. A limit <= 0 means no limit.
+func (r *SQLiteRepository) ListAttempts(ctx context.Context, subscriptionID string, limit int) ([]Attempt, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT subscription_id, delivery_id, event_type, status_code, error, duration_ms, attempted_at
+		FROM webhook_deliveries WHERE subscription_id = ? ORDER BY id DESC LIMIT ?`,
+		subscriptionID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	attempts := []Attempt{}
+	for rows.Next() {
+		var a Attempt
+		var durationMS int64
+		if err := rows.Scan(&a.SubscriptionID, &a.DeliveryID, &a.EventType, &a.StatusCode, &a.Error, &durationMS, &a.AttemptedAt); err != nil {
+			return nil, err
+		}
+		a.Duration = time.Duration(durationMS) * time.Millisecond
+		attempts = append(attempts, a)
+	}
+	return attempts, rows.Err()
+}
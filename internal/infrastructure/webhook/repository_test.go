@@ -0,0 +1,132 @@
+package webhook_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	return db
+}
+
+func addSubscription(t *testing.T, repo *webhook.SQLiteRepository, url string, types ...string) webhook.Subscription {
+	t.Helper()
+	sub := webhook.Subscription{
+		ID:         webhook.NewSubscriptionID(),
+		URL:        url,
+		EventTypes: types,
+		Secret:     webhook.NewSecret(),
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := repo.Add(context.Background(), sub); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	return sub
+}
+
+func TestSQLiteRepository_AddGetListRemove(t *testing.T) {
+	ctx := context.Background()
+	repo := webhook.NewSQLiteRepository(openTestDB(t))
+
+	sub := addSubscription(t, repo, "https://example.com/hook", "note.added", "action_item.*")
+
+	got, err := repo.Get(ctx, sub.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.URL != sub.URL || got.Secret != sub.Secret || len(got.EventTypes) != 2 || got.EventTypes[1] != "action_item.*" {
+		t.Errorf("unexpected subscription %+v", got)
+	}
+
+	subs, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(subs) != 1 {
+		t.Fatalf("got %d subscriptions, want 1", len(subs))
+	}
+
+	if err := repo.Remove(ctx, sub.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := repo.Get(ctx, sub.ID); !errors.Is(err, webhook.ErrSubscriptionNotFound) {
+		t.Errorf("got %v, want ErrSubscriptionNotFound", err)
+	}
+	if err := repo.Remove(ctx, sub.ID); !errors.Is(err, webhook.ErrSubscriptionNotFound) {
+		t.Errorf("second remove: got %v, want ErrSubscriptionNotFound", err)
+	}
+}
+
+func TestSQLiteRepository_ListAttemptsNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	repo := webhook.NewSQLiteRepository(openTestDB(t))
+	now := time.Now().UTC()
+
+	for i, code := range []int{500, 200} {
+		err := repo.RecordAttempt(ctx, webhook.Attempt{
+			SubscriptionID: "sub_1",
+			DeliveryID:     "dlv_1",
+			EventType:      "note.added",
+			StatusCode:     code,
+			Duration:       15 * time.Millisecond,
+			AttemptedAt:    now.Add(time.Duration(i) * time.Second),
+		})
+		if err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+
+	latest, err := repo.ListAttempts(ctx, "sub_1", 1)
+	if err != nil {
+		t.Fatalf("list attempts: %v", err)
+	}
+	if len(latest) != 1 || latest[0].StatusCode != 200 || !latest[0].Succeeded() {
+		t.Errorf("got %+v, want the 200 attempt", latest)
+	}
+	if latest[0].Duration != 15*time.Millisecond {
+		t.Errorf("got duration %v", latest[0].Duration)
+	}
+
+	all, err := repo.ListAttempts(ctx, "sub_1", 0)
+	if err != nil {
+		t.Fatalf("list attempts: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("got %d attempts, want 2", len(all))
+	}
+}
+
+func TestSubscription_Matches(t *testing.T) {
+	sub := webhook.Subscription{EventTypes: []string{"note.added", "action_item.*"}}
+	cases := map[string]bool{
+		"note.added":            true,
+		"note.deleted":          false,
+		"action_item.completed": true,
+		"meeting.created":       false,
+	}
+	for eventType, want := range cases {
+		if got := sub.Matches(eventType); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", eventType, got, want)
+		}
+	}
+	if !(webhook.Subscription{EventTypes: []string{"*"}}).Matches("meeting.created") {
+		t.Error("* should match every event type")
+	}
+}
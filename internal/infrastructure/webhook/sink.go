@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+)
+
+// SinkName addresses outbox entries to the webhook subscription sink.
+const SinkName = "webhook_subscriptions"
+
+// SignatureHeader carries "sha256=<hex HMAC-SHA256 of the body>".
+const SignatureHeader = "X-Acai-Signature"
+
+const deliveryTimeout = 10 * time.Second
+
+// Sign returns the signature header value for body under secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sink delivers queued webhook entries to their subscription's URL.
+// It implements outbox.Sink and is registered with the relay via Route.
+type Sink struct {
+	subs       *SQLiteRepository
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewSink creates the subscription sink. A nil httpClient uses a 10s timeout.
+func NewSink(subs *SQLiteRepository, httpClient *http.Client) *Sink {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: deliveryTimeout}
+	}
+	return &Sink{subs: subs, httpClient: httpClient, now: time.Now}
+}
+
+func (s *Sink) Name() string { return SinkName }
+
+// Deliver sends a queued entry. Entries for removed subscriptions and
+// 4xx responses (other than 408 and 429) fail permanently.
+func (s *Sink) Deliver(ctx context.Context, entry outbox.Entry) error {
+	var queued queuedDelivery
+	if err := json.Unmarshal(entry.Payload, &queued); err != nil {
+		return outbox.Permanent(fmt.Errorf("invalid webhook payload: %w", err))
+	}
+	sub, err := s.subs.Get(ctx, queued.SubscriptionID)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		return outbox.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	attempt := s.Send(ctx, *sub, entry.ID, entry.EventType, queued.Envelope)
+	switch {
+	case attempt.Succeeded():
+		return nil
+	case attempt.StatusCode == 0:
+		return errors.New(attempt.Error)
+	case attempt.StatusCode == http.StatusRequestTimeout,
+		attempt.StatusCode == http.StatusTooManyRequests,
+		attempt.StatusCode >= 500:
+		return fmt.Errorf("status %d", attempt.StatusCode)
+	default:
+		return outbox.Permanent(fmt.Errorf("status %d", attempt.StatusCode))
+	}
+}
+
+// Send POSTs body to the subscription once, signed with its secret, and
+// records the attempt.
+func (s *Sink) Send(ctx context.Context, sub Subscription, deliveryID, eventType string, body []byte) Attempt {
+	attempt := Attempt{
+		SubscriptionID: sub.ID,
+		DeliveryID:     deliveryID,
+		EventType:      eventType,
+		AttemptedAt:    s.now().UTC(),
+	}
+
+	start := time.Now()
+	statusCode, err := s.post(ctx, sub, deliveryID, eventType, body)
+	attempt.Duration = time.Since(start)
+	attempt.StatusCode = statusCode
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	if err := s.subs.RecordAttempt(ctx, attempt); err != nil {
+		log.Printf("webhook: record attempt for %s: %v", sub.ID, err)
+	}
+	return attempt
+}
+
+func (s *Sink) post(ctx context.Context, sub Subscription, deliveryID, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "acai-webhook")
+	req.Header.Set("X-Acai-Event", eventType)
+	req.Header.Set("X-Acai-Delivery", deliveryID)
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	return resp.StatusCode, nil
+}
+
+var _ outbox.Sink = (*Sink)(nil)
+
+// TestEventType is the event type of the synthetic delivery sent by SendTest.
+const TestEventType = "webhook.test"
+
+// SendTest sends a synthetic, signed test event to the subscription
+// immediately, bypassing the outbox.
+func (s *Sink) SendTest(ctx context.Context, sub Subscription) (Attempt, error) {
+	data, err := json.Marshal(map[string]string{"subscription_id": sub.ID})
+	if err != nil {
+		return Attempt{}, err
+	}
+	body, err := json.Marshal(eventcodec.Envelope{
+		ID:            eventcodec.NewEventID(),
+		Type:          TestEventType,
+		SchemaVersion: 1,
+		AggregateID:   sub.ID,
+		OccurredAt:    s.now().UTC(),
+		Data:          data,
+	})
+	if err != nil {
+		return Attempt{}, err
+	}
+	return s.Send(ctx, sub, eventcodec.RandomID("dlv_", 16), TestEventType, body), nil
+}
@@ -0,0 +1,195 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
+)
+
+func queueDelivery(t *testing.T, repo *webhook.SQLiteRepository, sub webhook.Subscription) outbox.Entry {
+	t.Helper()
+	store := &captureStore{}
+	d := webhook.NewDispatcher(&mockInnerDispatcher{}, repo, store, eventcodec.Default())
+	if err := d.Dispatch(context.Background(), []domain.DomainEvent{domain.NewActionItemCompletedEvent("m-1", "ai-1")}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	for _, e := range store.entries {
+		var payload struct {
+			SubscriptionID string `json:"subscription_id"`
+		}
+		_ = json.Unmarshal(e.Payload, &payload)
+		if payload.SubscriptionID == sub.ID {
+			return e
+		}
+	}
+	t.Fatalf("no delivery queued for %s", sub.ID)
+	return outbox.Entry{}
+}
+
+type captureStore struct {
+	entries []outbox.Entry
+}
+
+func (c *captureStore) Append(entry outbox.Entry) error {
+	c.entries = append(c.entries, entry)
+	return nil
+}
+func (c *captureStore) ListPending() ([]outbox.Entry, error) { return c.entries, nil }
+func (c *captureStore) ListDue(_ time.Time, _ int, _ []string) ([]outbox.Entry, error) {
+	return c.entries, nil
+}
+func (c *captureStore) MarkSynced(_ string) error                       { return nil }
+func (c *captureStore) MarkRetry(_ string, _ time.Time, _ string) error { return nil }
+func (c *captureStore) MarkFailed(_ string, _ string) error             { return nil }
+
+func TestSink_DeliversSignedEnvelope(t *testing.T) {
+	ctx := context.Background()
+	repo := webhook.NewSQLiteRepository(openTestDB(t))
+
+	var (
+		gotBody      []byte
+		gotSignature string
+		gotEvent     string
+		gotDelivery  string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(webhook.SignatureHeader)
+		gotEvent = r.Header.Get("X-Acai-Event")
+		gotDelivery = r.Header.Get("X-Acai-Delivery")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sub := addSubscription(t, repo, srv.URL, "*")
+	entry := queueDelivery(t, repo, sub)
+
+	sink := webhook.NewSink(repo, srv.Client())
+	if err := sink.Deliver(ctx, entry); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+
+	if !hmac.Equal([]byte(gotSignature), []byte(webhook.Sign(sub.Secret, gotBody))) {
+		t.Errorf("signature %q does not verify", gotSignature)
+	}
+	if gotEvent != "action_item.completed" || gotDelivery != entry.ID {
+		t.Errorf("got event %q delivery %q", gotEvent, gotDelivery)
+	}
+	var env eventcodec.Envelope
+	if err := json.Unmarshal(gotBody, &env); err != nil || env.Type != "action_item.completed" {
+		t.Errorf("body is not the event envelope: %s (%v)", gotBody, err)
+	}
+
+	attempts, err := repo.ListAttempts(ctx, sub.ID, 0)
+	if err != nil {
+		t.Fatalf("list attempts: %v", err)
+	}
+	if len(attempts) != 1 || attempts[0].StatusCode != http.StatusNoContent || attempts[0].DeliveryID != entry.ID {
+		t.Errorf("unexpected attempts %+v", attempts)
+	}
+}
+
+func TestSink_ClassifiesFailures(t *testing.T) {
+	cases := []struct {
+		status    int
+		permanent bool
+	}{
+		{http.StatusInternalServerError, false},
+		{http.StatusTooManyRequests, false},
+		{http.StatusRequestTimeout, false},
+		{http.StatusBadRequest, true},
+		{http.StatusGone, true},
+	}
+	for _, tc := range cases {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			repo := webhook.NewSQLiteRepository(openTestDB(t))
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			sub := addSubscription(t, repo, srv.URL, "*")
+			err := webhook.NewSink(repo, srv.Client()).Deliver(context.Background(), queueDelivery(t, repo, sub))
+			if err == nil {
+				t.Fatal("expected delivery error")
+			}
+			if outbox.IsPermanent(err) != tc.permanent {
+				t.Errorf("IsPermanent = %v, want %v (%v)", outbox.IsPermanent(err), tc.permanent, err)
+			}
+
+			attempts, _ := repo.ListAttempts(context.Background(), sub.ID, 0)
+			if len(attempts) != 1 || attempts[0].StatusCode != tc.status {
+				t.Errorf("unexpected attempts %+v", attempts)
+			}
+		})
+	}
+}
+
+func TestSink_RemovedSubscriptionIsPermanent(t *testing.T) {
+	ctx := context.Background()
+	repo := webhook.NewSQLiteRepository(openTestDB(t))
+	sub := addSubscription(t, repo, "http://127.0.0.1:1", "*")
+	entry := queueDelivery(t, repo, sub)
+
+	if err := repo.Remove(ctx, sub.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	err := webhook.NewSink(repo, nil).Deliver(ctx, entry)
+	if !outbox.IsPermanent(err) {
+		t.Errorf("got %v, want permanent failure", err)
+	}
+}
+
+func TestSink_RelayRetriesThroughOutbox(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := webhook.NewSQLiteRepository(db)
+	store := outbox.NewSQLiteStore(db)
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	addSubscription(t, repo, srv.URL, "action_item.*")
+	d := webhook.NewDispatcher(&mockInnerDispatcher{}, repo, store, eventcodec.Default())
+	if err := d.Dispatch(ctx, []domain.DomainEvent{domain.NewActionItemCompletedEvent("m-1", "ai-1")}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	relay := outbox.NewRelay(store, nil, outbox.RelayConfig{InitialBackoff: time.Nanosecond, MaxBackoff: time.Nanosecond})
+	relay.Route(webhook.NewSink(repo, srv.Client()))
+
+	first, err := relay.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("first pass: %v", err)
+	}
+	if first.Retried != 1 {
+		t.Fatalf("first pass %+v, want 1 retried", first)
+	}
+
+	time.Sleep(time.Millisecond)
+	second, err := relay.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("second pass: %v", err)
+	}
+	if second.Delivered != 1 {
+		t.Errorf("second pass %+v, want 1 delivered", second)
+	}
+}
@@ -0,0 +1,62 @@
+// Package webhook delivers domain events to subscribed HTTP endpoints.
+// Deliveries are queued in the outbox, signed with HMAC-SHA256, retried by
+// the outbox relay, and every attempt is recorded.
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/eventcodec"
+)
+
+var ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+
+// Subscription is an outbound webhook endpoint and the event types it wants.
+type Subscription struct {
+	ID         string
+	URL        string
+	EventTypes []string // exact types, "prefix.*", or "*" for all
+	Secret     string
+	CreatedAt  time.Time
+}
+
+// Matches reports whether the subscription wants events of eventType.
+func (s Subscription) Matches(eventType string) bool {
+	for _, t := range s.EventTypes {
+		switch {
+		case t == "*" || t == eventType:
+			return true
+		case strings.HasSuffix(t, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(t, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// Attempt records one delivery attempt to a subscription.
+type Attempt struct {
+	SubscriptionID string
+	DeliveryID     string
+	EventType      string
+	StatusCode     int // 0 when no response was received
+	Error          string
+	Duration       time.Duration
+	AttemptedAt    time.Time
+}
+
+// Succeeded reports whether the endpoint accepted the delivery.
+func (a Attempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// NewSecret returns a random signing secret.
+func NewSecret() string {
+	return eventcodec.RandomID("whsec_", 24)
+}
+
+// NewSubscriptionID returns a random subscription ID.
+func NewSubscriptionID() string {
+	return eventcodec.RandomID("sub_", 8)
+}
@@ -240,11 +240,11 @@ func newTestEnv(t *testing.T, fixtures *APIFixtures) *TestEnv {
 	searchTranscripts := meetingapp.NewSearchTranscripts(repo)
 	getActionItems := meetingapp.NewGetActionItems(repo)
 	getMeetingStats := meetingapp.NewGetMeetingStats(repo)
-	syncMeetings := meetingapp.NewSyncMeetings(repo)
-	exportMeeting := exportapp.NewExportMeeting(repo)
+	syncMeetings := meetingapp.NewSyncMeetings(repo, nil)
+	exportMeeting := exportapp.NewExportMeeting(repo, nil)
 
 	addNote := annotationapp.NewAddNote(noteRepo, repo, dispatcher)
-	listNotes := annotationapp.NewListNotes(noteRepo)
+	listNotes := annotationapp.NewListNotes(noteRepo, nil)
 	deleteNote := annotationapp.NewDeleteNote(noteRepo, dispatcher)
 	completeActionItem := meetingapp.NewCompleteActionItem(repo, writeRepo, dispatcher)
 	updateActionItem := meetingapp.NewUpdateActionItem(repo, writeRepo, dispatcher)
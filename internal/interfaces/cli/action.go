@@ -2,8 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"os"
 	"text/tabwriter"
+	"time"
 
+	exportapp "github.com/felixgeelhaar/acai/internal/application/export"
 	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 	"github.com/spf13/cobra"
@@ -13,55 +16,339 @@ func newActionCmd(deps *Dependencies) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "action",
 		Short: "View and manage action items",
-		Long:  "List, complete, and update action items from meetings.",
+		Long:  "List, complete, update, and export action items from meetings.",
 	}
 
 	cmd.AddCommand(
 		newActionListCmd(deps),
 		newActionCompleteCmd(deps),
 		newActionUpdateCmd(deps),
+		newActionExportCmd(deps),
+		newActionPushCmd(deps),
 	)
 	return cmd
 }
 
+// inboxFilterFlags holds the cross-meeting action item filters shared by
+// "action list" and "action export".
+type inboxFilterFlags struct {
+	owner     string
+	open      bool
+	done      bool
+	overdue   bool
+	dueBefore string
+	dueAfter  string
+	since     string
+	until     string
+	tag       string
+	limit     int
+}
+
+func (f *inboxFilterFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.owner, "owner", "", "Filter by owner (substring match, or \"me\")")
+	cmd.Flags().BoolVar(&f.open, "open", false, "Only open action items")
+	cmd.Flags().BoolVar(&f.done, "done", false, "Only completed action items")
+	cmd.Flags().BoolVar(&f.overdue, "overdue", false, "Only open action items past their due date")
+	cmd.Flags().StringVar(&f.dueBefore, "due-before", "", "Due before date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&f.dueAfter, "due-after", "", "Due on or after date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&f.since, "since", "", "Meetings on or after date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&f.until, "until", "", "Meetings on or before date (YYYY-MM-DD includes the whole day, or RFC3339)")
+	cmd.Flags().StringVar(&f.tag, "tag", "", "Filter by meeting tag")
+	cmd.Flags().IntVar(&f.limit, "limit", 0, "Max results (0 = no limit)")
+}
+
+// toInput validates the flags and builds the inbox query. The use case
+// resolves "--owner me" to the configured user name.
+func (f *inboxFilterFlags) toInput() (meetingapp.ListActionItemsInput, error) {
+	if f.open && f.done {
+		return meetingapp.ListActionItemsInput{}, fmt.Errorf("--open and --done are mutually exclusive")
+	}
+
+	input := meetingapp.ListActionItemsInput{Overdue: f.overdue, Limit: f.limit}
+	if f.open {
+		input.Status = meetingapp.ActionItemStatusOpen
+	}
+	if f.done {
+		input.Status = meetingapp.ActionItemStatusCompleted
+	}
+	if f.owner != "" {
+		owner := f.owner
+		input.Owner = &owner
+	}
+	if f.tag != "" {
+		tag := f.tag
+		input.Tag = &tag
+	}
+
+	var err error
+	if input.DueBefore, err = parseDateFlag("--due-before", f.dueBefore); err != nil {
+		return input, err
+	}
+	if input.DueAfter, err = parseDateFlag("--due-after", f.dueAfter); err != nil {
+		return input, err
+	}
+	if input.Since, err = parseDateFlag("--since", f.since); err != nil {
+		return input, err
+	}
+	if input.Until, err = parseUntilFlag("--until", f.until); err != nil {
+		return input, err
+	}
+	return input, nil
+}
+
 func newActionListCmd(deps *Dependencies) *cobra.Command {
-	return &cobra.Command{
-		Use:   "list <meeting_id>",
-		Short: "List action items for a meeting",
-		Long:  "Display all action items for a meeting with their completion status.",
-		Example: "  acai action list meeting-001\n  acai action list meeting-001 --format json",
-		Args:    cobra.ExactArgs(1),
+	var filters inboxFilterFlags
+
+	cmd := &cobra.Command{
+		Use:   "list [meeting_id]",
+		Short: "List action items for a meeting or across all meetings",
+		Long: `Display action items with their completion status.
+
+With a meeting ID, lists that meeting's action items. Without one, lists action
+items across all meetings, filtered by owner, status, due date, meeting date
+range, and tag. Use "--owner me" to match the configured user name
+(ACAI_USER_NAME or user.name in ~/.acai/config.yaml).`,
+		Example: "  acai action list meeting-001\n  acai action list --owner me --open --overdue\n  acai action list --owner alice --due-before 2025-02-01 --format json",
+		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out, err := deps.GetActionItems.Execute(cmd.Context(), meetingapp.GetActionItemsInput{
-				MeetingID: domain.MeetingID(args[0]),
-			})
+			if len(args) == 1 {
+				return runActionListForMeeting(cmd, deps, args[0])
+			}
+			if deps.ListActionItems == nil {
+				return fmt.Errorf("action item inbox not configured")
+			}
+
+			input, err := filters.toInput()
+			if err != nil {
+				return err
+			}
+
+			out, err := deps.ListActionItems.Execute(cmd.Context(), input)
 			if err != nil {
 				return fmt.Errorf("failed to list action items: %w", err)
 			}
 
 			if len(out.Items) == 0 {
-				_, _ = fmt.Fprintln(deps.Out, "No action items found for this meeting.")
+				_, _ = fmt.Fprintln(deps.Out, "No matching action items found.")
 				return nil
 			}
 
 			switch flagFormat {
 			case "json":
-				return printJSON(deps, out.Items)
+				return printJSON(deps, toInboxJSON(out.Items))
 			default:
 				w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
-				_, _ = fmt.Fprintln(w, "ID\tSTATUS\tOWNER\tTEXT")
-				for _, item := range out.Items {
-					status := "open"
-					if item.IsCompleted() {
-						status = "done"
+				_, _ = fmt.Fprintln(w, "ID\tSTATUS\tOWNER\tDUE\tMEETING\tTEXT")
+				for _, entry := range out.Items {
+					item := entry.Item
+					due := "-"
+					if item.DueDate() != nil {
+						due = item.DueDate().Format("2006-01-02")
 					}
-					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-						item.ID(), status, item.Owner(), item.Text())
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+						item.ID(), actionItemStatus(item), item.Owner(), due, entry.MeetingTitle, item.Text())
 				}
 				return w.Flush()
 			}
 		},
 	}
+
+	filters.register(cmd)
+	return cmd
+}
+
+func newActionExportCmd(deps *Dependencies) *cobra.Command {
+	var (
+		filters inboxFilterFlags
+		output  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export action items as iCalendar (VTODO) or CSV",
+		Long: `Export action items across meetings for use in external task trackers.
+
+--format ics (the default) writes an RFC 5545 calendar with one VTODO per action
+item, including due date, status, owner as attendee, and the meeting link.
+--format csv writes one row per action item. Accepts the same filters as
+"acai action list".`,
+		Example: "  acai action export --owner me --open > todo.ics\n  acai action export --format csv --output actions.csv",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.ExportActionItems == nil {
+				return fmt.Errorf("action item export not configured")
+			}
+
+			format := exportapp.Format(flagFormat)
+			if flagFormat == "table" {
+				format = exportapp.FormatICS
+			}
+
+			input, err := filters.toInput()
+			if err != nil {
+				return err
+			}
+
+			out, err := deps.ExportActionItems.Execute(cmd.Context(), exportapp.ExportActionItemsInput{
+				Query:  input,
+				Format: format,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to export action items: %w", err)
+			}
+
+			if output == "" {
+				_, _ = fmt.Fprint(deps.Out, out.Content)
+				return nil
+			}
+			if err := os.WriteFile(output, []byte(out.Content), 0o644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+			_, _ = fmt.Fprintf(deps.Out, "Exported %d action items to %s\n", out.Count, output)
+			return nil
+		},
+	}
+
+	filters.register(cmd)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to file instead of stdout")
+	return cmd
+}
+
+func runActionListForMeeting(cmd *cobra.Command, deps *Dependencies, meetingID string) error {
+	out, err := deps.GetActionItems.Execute(cmd.Context(), meetingapp.GetActionItemsInput{
+		MeetingID: domain.MeetingID(meetingID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list action items: %w", err)
+	}
+
+	if len(out.Items) == 0 {
+		_, _ = fmt.Fprintln(deps.Out, "No action items found for this meeting.")
+		return nil
+	}
+
+	switch flagFormat {
+	case "json":
+		return printJSON(deps, out.Items)
+	default:
+		w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tSTATUS\tOWNER\tTEXT")
+		for _, item := range out.Items {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				item.ID(), actionItemStatus(item), item.Owner(), item.Text())
+		}
+		return w.Flush()
+	}
+}
+
+func actionItemStatus(item *domain.ActionItem) string {
+	if item.IsCompleted() {
+		return "done"
+	}
+	return "open"
+}
+
+// inboxItemJSON is the JSON shape for cross-meeting action item listings.
+type inboxItemJSON struct {
+	ID              string  `json:"id"`
+	MeetingID       string  `json:"meeting_id"`
+	MeetingTitle    string  `json:"meeting_title"`
+	MeetingDatetime string  `json:"meeting_datetime"`
+	Owner           string  `json:"owner"`
+	Text            string  `json:"text"`
+	DueDate         *string `json:"due_date,omitempty"`
+	Completed       bool    `json:"completed"`
+}
+
+func toInboxJSON(items []meetingapp.InboxItem) []inboxItemJSON {
+	result := make([]inboxItemJSON, len(items))
+	for i, entry := range items {
+		item := entry.Item
+		result[i] = inboxItemJSON{
+			ID:              string(item.ID()),
+			MeetingID:       string(item.MeetingID()),
+			MeetingTitle:    entry.MeetingTitle,
+			MeetingDatetime: entry.MeetingDatetime.Format(time.RFC3339),
+			Owner:           item.Owner(),
+			Text:            item.Text(),
+			Completed:       item.IsCompleted(),
+		}
+		if item.DueDate() != nil {
+			d := item.DueDate().Format(time.RFC3339)
+			result[i].DueDate = &d
+		}
+	}
+	return result
+}
+
+// parseDateFlag parses an optional RFC3339 or YYYY-MM-DD flag value.
+// Returns nil for an empty value.
+func parseDateFlag(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s date: %w", name, err)
+		}
+	}
+	return &t, nil
+}
+
+// parseUntilFlag parses an optional upper bound like parseDateFlag. A
+// date-only value means the end of that day, so the day is included.
+func parseUntilFlag(name, value string) (*time.Time, error) {
+	t, err := parseDateFlag(name, value)
+	if err != nil || t == nil {
+		return t, err
+	}
+	if _, dateErr := time.Parse("2006-01-02", value); dateErr == nil {
+		end := t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		t = &end
+	}
+	return t, nil
+}
+
+func newActionPushCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "push <meeting_id> <action_item_id>",
+		Short: "Push an action item to external task trackers",
+		Long: `File an action item in every configured tracker (GitHub Issues, Linear, Jira).
+
+Pushing is idempotent: the first push creates a ticket, later pushes update it
+only when the item changed, and completed items are closed once. Trackers are
+enabled via ACAI_GITHUB_TOKEN, ACAI_LINEAR_API_KEY, or ACAI_JIRA_API_TOKEN
+together with the tasks section of ~/.acai/config.yaml.`,
+		Example: "  acai action push meeting-001 action-001",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.PushActionItem == nil {
+				return errLocalDBRequired
+			}
+			if deps.PushActionItem.Sinks() == 0 {
+				return fmt.Errorf("no task trackers configured")
+			}
+
+			out, err := deps.PushActionItem.Execute(cmd.Context(), meetingapp.PushActionItemInput{
+				MeetingID:    domain.MeetingID(args[0]),
+				ActionItemID: domain.ActionItemID(args[1]),
+			})
+			if out != nil {
+				w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "SINK\tRESULT\tEXTERNAL_ID")
+				for _, r := range out.Results {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.Sink, r.Outcome, r.ExternalID)
+				}
+				_ = w.Flush()
+			}
+			if err != nil {
+				return fmt.Errorf("failed to push action item: %w", err)
+			}
+			return nil
+		},
+	}
 }
 
 func newActionCompleteCmd(deps *Dependencies) *cobra.Command {
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/backup"
+	"github.com/spf13/cobra"
+)
+
+func newBackupCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up and restore local notes, overrides and outbox",
+		Long: `Agent notes, action item overrides, task links, generated summaries, note
+push state and the outbox exist only in local.db. A backup archive carries
+them, together with config.yaml, to another install. API tokens and webhook
+subscriptions, which hold signing secrets, are never archived.`,
+	}
+
+	cmd.AddCommand(
+		newBackupCreateCmd(deps),
+		newBackupRestoreCmd(deps),
+	)
+	return cmd
+}
+
+func newBackupCreateCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:     "create <file>",
+		Short:   "Write all local state to a backup archive",
+		Example: "  acai backup create ~/acai-backup.json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Backup == nil {
+				return errLocalDBRequired
+			}
+
+			archive, err := deps.Backup.Create(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to create backup: %w", err)
+			}
+
+			f, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to create backup: %w", err)
+			}
+			if err := backup.Write(f, archive); err != nil {
+				_ = f.Close()
+				return fmt.Errorf("failed to write backup: %w", err)
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to write backup: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(deps.Out, "Backed up %d notes, %d note revisions, %d action item overrides, %d task links, %d generated summaries, %d outbox entries to %s\n",
+				len(archive.Notes), len(archive.NoteRevisions), len(archive.Overrides), len(archive.TaskLinks), len(archive.Summaries), len(archive.Outbox), args[0])
+			return nil
+		},
+	}
+}
+
+type restoreReportJSON struct {
+	Mode      string             `json:"mode"`
+	DryRun    bool               `json:"dry_run"`
+	Tables    []restoreTableJSON `json:"tables"`
+	Conflicts []backup.Conflict  `json:"conflicts"`
+	Config    string             `json:"config"`
+}
+
+type restoreTableJSON struct {
+	Table     string `json:"table"`
+	Restored  int    `json:"restored"`
+	Unchanged int    `json:"unchanged"`
+	Conflicts int    `json:"conflicts"`
+	Removed   int    `json:"removed"`
+	Dropped   int    `json:"dropped"`
+}
+
+func newBackupRestoreCmd(deps *Dependencies) *cobra.Command {
+	var (
+		mode   string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore local state from a backup archive",
+		Long: `Restore a backup archive into local.db and config.yaml.
+
+--mode merge (default) adds archived rows missing locally. A row present on
+both sides with different contents is reported as a conflict and the local
+row is kept. config.yaml settings unset locally are filled in from the
+archive; a setting set differently on both sides is a conflict as well.
+--mode replace discards local notes, overrides, task links, generated
+summaries, note push state and outbox entries and restores the archive as
+is, including config.yaml.
+
+Webhook subscriptions are not archived, as they hold signing secrets.
+Queued webhook deliveries to a subscription that does not exist locally are
+dropped and counted in the DROPPED column.
+
+Use --dry-run to preview the report without changing anything.`,
+		Example: "  acai backup restore ~/acai-backup.json\n  acai backup restore ~/acai-backup.json --mode replace --dry-run",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Backup == nil {
+				return errLocalDBRequired
+			}
+			m, err := backup.ParseMode(mode)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open backup: %w", err)
+			}
+			archive, err := backup.Read(f)
+			_ = f.Close()
+			if err != nil {
+				return fmt.Errorf("failed to read backup: %w", err)
+			}
+
+			report, err := deps.Backup.Restore(cmd.Context(), archive, m, dryRun)
+			if err != nil {
+				return fmt.Errorf("failed to restore backup: %w", err)
+			}
+
+			if flagFormat == "json" {
+				out := restoreReportJSON{Mode: string(report.Mode), DryRun: report.DryRun, Conflicts: report.Conflicts, Config: report.Config}
+				for _, t := range report.Tables {
+					out.Tables = append(out.Tables, restoreTableJSON(t))
+				}
+				if out.Conflicts == nil {
+					out.Conflicts = []backup.Conflict{}
+				}
+				return printJSON(deps, out)
+			}
+
+			if report.DryRun {
+				_, _ = fmt.Fprintln(deps.Out, "Dry run: nothing was changed.")
+			}
+			w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "TABLE\tRESTORED\tUNCHANGED\tCONFLICTS\tREMOVED\tDROPPED")
+			for _, t := range report.Tables {
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n", t.Table, t.Restored, t.Unchanged, t.Conflicts, t.Removed, t.Dropped)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(deps.Out, "Config: %s\n", report.Config)
+			if len(report.Conflicts) > 0 {
+				_, _ = fmt.Fprintf(deps.Out, "\n%d conflicts (local rows kept):\n", len(report.Conflicts))
+				for _, c := range report.Conflicts {
+					_, _ = fmt.Fprintf(deps.Out, "  %s %s\n", c.Table, c.Key)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&mode, "mode", string(backup.ModeMerge), "Restore mode: merge or replace")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be restored without changing anything")
+	return cmd
+}
@@ -0,0 +1,74 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/backup"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	"github.com/felixgeelhaar/acai/internal/interfaces/cli"
+)
+
+func testBackupService(t *testing.T, notes ...string) *backup.Service {
+	t.Helper()
+	dir := t.TempDir()
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "local.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	for i, content := range notes {
+		note, _ := annotation.NewAgentNote(annotation.NoteID(fmt.Sprintf("n-%d", i+1)), "m-1", "claude", content)
+		if err := localstore.NewNoteRepository(db).Save(context.Background(), note); err != nil {
+			t.Fatalf("save note: %v", err)
+		}
+	}
+	return backup.NewService(db, filepath.Join(dir, "config.yaml"))
+}
+
+func TestBackupCmd_CreateThenRestoreReportsConflicts(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "backup.json")
+
+	deps := testDeps(t)
+	deps.Backup = testBackupService(t, "from the old laptop", "second")
+	root := cli.NewRootCmd(deps)
+	root.SetArgs([]string{"backup", "create", file})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !strings.Contains(deps.Out.(*bytes.Buffer).String(), "Backed up 2 notes") {
+		t.Errorf("unexpected create output: %q", deps.Out.(*bytes.Buffer).String())
+	}
+
+	deps = testDeps(t)
+	deps.Backup = testBackupService(t, "edited on the new laptop")
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"backup", "restore", file})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "1 conflicts (local rows kept)") || !strings.Contains(output, "agent_notes n-1") {
+		t.Errorf("expected conflict report, got: %q", output)
+	}
+}
+
+func TestBackupCmd_RestoreRejectsInvalidMode(t *testing.T) {
+	deps := testDeps(t)
+	deps.Backup = testBackupService(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"backup", "restore", "backup.json", "--mode", "overwrite"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
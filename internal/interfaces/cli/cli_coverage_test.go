@@ -2,9 +2,17 @@ package cli_test
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/llm"
 	"github.com/felixgeelhaar/acai/internal/interfaces/cli"
 )
 
@@ -73,6 +81,35 @@ func TestSyncCmd_WithSinceRFC3339(t *testing.T) {
 	}
 }
 
+// deletingRepo reports one meeting as deleted upstream on Sync.
+type deletingRepo struct{ mockMeetingRepo }
+
+func (r *deletingRepo) Sync(_ context.Context, _ *time.Time) ([]domain.DomainEvent, error) {
+	return []domain.DomainEvent{domain.NewMeetingDeletedEvent("m-gone")}, nil
+}
+
+type fixedLocalState struct{ state domain.LocalState }
+
+func (f fixedLocalState) CountLocalState(_ context.Context, _ domain.MeetingID) (domain.LocalState, error) {
+	return f.state, nil
+}
+
+func TestSyncCmd_WarnsAboutOrphanedLocalState(t *testing.T) {
+	deps := testDeps(t)
+	deps.SyncMeetings = meetingapp.NewSyncMeetings(&deletingRepo{}, fixedLocalState{domain.LocalState{Notes: 2}})
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"sync"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "meeting m-gone was deleted upstream; kept 2 local note(s)") {
+		t.Errorf("expected orphan warning, got: %q", output)
+	}
+}
+
 func TestSyncCmd_InvalidSince(t *testing.T) {
 	deps := testDeps(t)
 	root := cli.NewRootCmd(deps)
@@ -167,6 +204,102 @@ func TestNoteListCmd_JSONFormat(t *testing.T) {
 	}
 }
 
+func TestNoteEditAndHistoryCmd(t *testing.T) {
+	deps := testDeps(t)
+	out := deps.Out.(*bytes.Buffer)
+
+	root := cli.NewRootCmd(deps)
+	root.SetArgs([]string{"note", "add", "m-1", "Budget is blocked"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	var noteID string
+	if _, err := fmt.Sscanf(out.String(), "Note %s added", &noteID); err != nil {
+		t.Fatalf("parse note ID from %q: %v", out.String(), err)
+	}
+
+	out.Reset()
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"note", "edit", noteID, "Budget approved on Friday", "--revision", "1", "--author", "alice"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if !strings.Contains(out.String(), "updated to revision 2") {
+		t.Errorf("expected revision message, got: %q", out.String())
+	}
+
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"note", "edit", noteID, "Stale edit", "--revision", "1"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "note history") {
+		t.Errorf("stale edit: got %v, want revision conflict with a history hint", err)
+	}
+
+	out.Reset()
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"note", "history", noteID})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	history := out.String()
+	if !strings.Contains(history, "Budget is blocked") || !strings.Contains(history, "Budget approved on Friday") {
+		t.Errorf("expected both revisions, got: %q", history)
+	}
+}
+
+func TestNoteEditCmd_RequiresRevision(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"note", "edit", "n-1", "text"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for missing --revision")
+	}
+}
+
+func TestNoteListCmd_SearchAcrossMeetings(t *testing.T) {
+	deps := testDeps(t)
+	out := deps.Out.(*bytes.Buffer)
+	for _, args := range [][]string{
+		{"note", "add", "m-1", "Budget is blocked", "--author", "claude"},
+		{"note", "add", "m-2", "Budget approved", "--author", "gpt"},
+		{"note", "add", "m-2", "Ship the beta", "--author", "claude"},
+	} {
+		root := cli.NewRootCmd(deps)
+		root.SetArgs(args)
+		if err := root.Execute(); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	out.Reset()
+	root := cli.NewRootCmd(deps)
+	root.SetArgs([]string{"note", "list", "--query", "Budget", "--author", "claude"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := out.String()
+	if !strings.Contains(output, "Budget is blocked") || strings.Contains(output, "Budget approved") || strings.Contains(output, "Ship the beta") {
+		t.Errorf("expected only claude's budget note, got: %q", output)
+	}
+
+	// A date-only --until includes that whole day.
+	out.Reset()
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"note", "list", "--author", "gpt", "--until", time.Now().UTC().Format("2006-01-02")})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Budget approved") {
+		t.Errorf("expected today's note with --until today, got: %q", out.String())
+	}
+
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"note", "list", "--since", "last week"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for invalid --since")
+	}
+}
+
 func TestNoteDeleteCmd_NotFound(t *testing.T) {
 	deps := testDeps(t)
 	root := cli.NewRootCmd(deps)
@@ -232,6 +365,152 @@ func TestActionUpdateCmd_MissingArgs(t *testing.T) {
 	}
 }
 
+func TestActionListCmd_Inbox(t *testing.T) {
+	deps := testDeps(t)
+	deps.ListActionItems = meetingapp.NewListActionItems(&mockMeetingRepo{}, &mockWriteRepo{}, "Alice")
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "list", "--owner", "me", "--open", "--overdue"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "No matching action items") {
+		t.Errorf("expected empty inbox message, got: %q", output)
+	}
+}
+
+// afternoonRepo holds one meeting at 15:00 on 2025-01-15 and honours the
+// list filter's meeting date range.
+type afternoonRepo struct{ mockMeetingRepo }
+
+func (r *afternoonRepo) List(_ context.Context, filter domain.ListFilter) ([]*domain.Meeting, error) {
+	m, _ := domain.New("m-1", "Planning", time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC), domain.SourceZoom, nil)
+	if filter.Until != nil && m.Datetime().After(*filter.Until) {
+		return nil, nil
+	}
+	return []*domain.Meeting{m}, nil
+}
+
+func TestActionListCmd_DateOnlyUntilIncludesWholeDay(t *testing.T) {
+	deps := testDeps(t)
+	deps.ListActionItems = meetingapp.NewListActionItems(&afternoonRepo{}, &mockWriteRepo{}, "")
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "list", "--until", "2025-01-15"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := deps.Out.(*bytes.Buffer).String(); !strings.Contains(output, "Review PR") {
+		t.Errorf("expected the afternoon meeting's action item with --until that day, got: %q", output)
+	}
+}
+
+func TestActionListCmd_OwnerMeRequiresUser(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "list", "--owner", "me"})
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "ACAI_USER_NAME") {
+		t.Errorf("expected missing user error, got: %v", err)
+	}
+}
+
+func TestActionListCmd_OpenAndDoneConflict(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "list", "--open", "--done"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for --open with --done")
+	}
+}
+
+func TestActionListCmd_InvalidDueDate(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "list", "--due-before", "next-week"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for invalid --due-before")
+	}
+}
+
+func TestActionListCmd_ForMeeting(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "list", "m-1"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "Review PR") {
+		t.Errorf("expected action item in output, got: %q", output)
+	}
+}
+
+func TestActionExportCmd_DefaultsToICS(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "export"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.HasPrefix(output, "BEGIN:VCALENDAR") {
+		t.Errorf("expected iCalendar output, got: %q", output)
+	}
+}
+
+func TestActionExportCmd_CSVToFile(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+	path := filepath.Join(t.TempDir(), "actions.csv")
+
+	root.SetArgs([]string{"action", "export", "--format", "csv", "--output", path})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "id,meeting_id") {
+		t.Errorf("expected CSV header, got: %q", string(data))
+	}
+	if !strings.Contains(deps.Out.(*bytes.Buffer).String(), "Exported 0 action items") {
+		t.Errorf("expected export summary, got: %q", deps.Out.(*bytes.Buffer).String())
+	}
+}
+
+func TestActionExportCmd_UnsupportedFormat(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "export", "--format", "md"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestActionPushCmd_NoTrackersConfigured(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"action", "push", "m-1", "ai-1"})
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "no task trackers configured") {
+		t.Errorf("expected missing tracker error, got: %v", err)
+	}
+}
+
 func TestAuthLoginCmd_DefaultMethod(t *testing.T) {
 	deps := testDeps(t)
 	root := cli.NewRootCmd(deps)
@@ -246,3 +525,101 @@ func TestAuthLoginCmd_DefaultMethod(t *testing.T) {
 		t.Errorf("expected success message, got: %q", output)
 	}
 }
+
+type stubEventHistory struct {
+	entries []domain.HistoryEntry
+}
+
+func (s *stubEventHistory) MeetingHistory(_ context.Context, _ domain.MeetingID, _ int) ([]domain.HistoryEntry, error) {
+	return s.entries, nil
+}
+
+func TestMeetingHistoryCmd_Table(t *testing.T) {
+	deps := testDeps(t)
+	now := time.Now()
+	deps.GetMeetingHistory = meetingapp.NewGetMeetingHistory(&stubEventHistory{entries: []domain.HistoryEntry{
+		{EventID: "evt-1", OccurredAt: now, Event: domain.NewMeetingCreatedEvent("m-1", "Planning", now)},
+		{EventID: "evt-2", OccurredAt: now, Event: domain.NewActionItemCompletedEvent("m-1", "ai-1")},
+	}})
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"meeting", "history", "m-1"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	for _, want := range []string{"meeting.created", "Meeting created: Planning", "Action item ai-1 completed"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %q", want, output)
+		}
+	}
+}
+
+// transcriptRepo serves a one-line transcript for every meeting.
+type transcriptRepo struct{ mockMeetingRepo }
+
+func (r *transcriptRepo) GetTranscript(_ context.Context, id domain.MeetingID) (*domain.Transcript, error) {
+	t := domain.NewTranscript(id, []domain.Utterance{
+		domain.NewUtterance("Alice", "We ship on Friday", time.Now().UTC(), 0.9),
+	})
+	return &t, nil
+}
+
+type stubSummaryStore struct {
+	saved []domain.Summary
+}
+
+func (s *stubSummaryStore) SaveSummary(_ context.Context, summary domain.Summary) error {
+	s.saved = append(s.saved, summary)
+	return nil
+}
+
+func (s *stubSummaryStore) FindSummaries(context.Context, []domain.MeetingID) (map[domain.MeetingID]domain.Summary, error) {
+	return nil, nil
+}
+
+func TestMeetingSummarizeCmd(t *testing.T) {
+	deps := testDeps(t)
+	store := &stubSummaryStore{}
+	fake := llm.NewFake()
+	fake.Reply = func(domain.CompletionRequest) (string, error) { return "The team will ship on Friday.", nil }
+	deps.GenerateSummary = meetingapp.NewGenerateSummary(&transcriptRepo{}, store, fake, nil)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"meeting", "summarize", "m-1", "--format", "table"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	for _, want := range []string{"The team will ship on Friday.", "Generated by fake from 1 transcript chunks"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %q", want, output)
+		}
+	}
+	if len(store.saved) != 1 || store.saved[0].Kind() != domain.SummaryGenerated {
+		t.Errorf("got saved summaries %+v", store.saved)
+	}
+}
+
+func TestMeetingSummarizeCmd_RequiresLLM(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"meeting", "summarize", "m-1"})
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "ACAI_LLM_URL") {
+		t.Errorf("got %v, want an error naming the LLM settings", err)
+	}
+}
+
+func TestMeetingHistoryCmd_RequiresLocalDB(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"meeting", "history", "m-1"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error without local storage")
+	}
+}
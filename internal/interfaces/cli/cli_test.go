@@ -189,7 +189,8 @@ func (m *mockMeetingRepo) Sync(_ context.Context, _ *time.Time) ([]domain.Domain
 }
 
 type mockNoteRepo struct {
-	notes []*annotation.AgentNote
+	notes     []*annotation.AgentNote
+	revisions []annotation.NoteRevision
 }
 
 func (m *mockNoteRepo) Save(_ context.Context, note *annotation.AgentNote) error {
@@ -204,6 +205,14 @@ func (m *mockNoteRepo) FindByID(_ context.Context, id annotation.NoteID) (*annot
 	}
 	return nil, annotation.ErrNoteNotFound
 }
+func (m *mockNoteRepo) FindByIdempotencyKey(_ context.Context, author, key string) (*annotation.AgentNote, error) {
+	for _, n := range m.notes {
+		if n.Author() == author && n.IdempotencyKey() == key {
+			return n, nil
+		}
+	}
+	return nil, annotation.ErrNoteNotFound
+}
 func (m *mockNoteRepo) ListByMeeting(_ context.Context, meetingID string) ([]*annotation.AgentNote, error) {
 	var result []*annotation.AgentNote
 	for _, n := range m.notes {
@@ -218,6 +227,18 @@ func (m *mockNoteRepo) ListAll(_ context.Context) ([]*annotation.AgentNote, erro
 	copy(result, m.notes)
 	return result, nil
 }
+func (m *mockNoteRepo) Search(_ context.Context, filter annotation.NoteFilter) ([]*annotation.AgentNote, error) {
+	var result []*annotation.AgentNote
+	for _, n := range m.notes {
+		if (filter.MeetingID == "" || n.MeetingID() == filter.MeetingID) &&
+			(filter.Author == "" || n.Author() == filter.Author) &&
+			(filter.Until == nil || !n.CreatedAt().After(*filter.Until)) &&
+			strings.Contains(n.Content(), filter.Query) {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
 func (m *mockNoteRepo) Delete(_ context.Context, id annotation.NoteID) error {
 	for i, n := range m.notes {
 		if n.ID() == id {
@@ -227,6 +248,25 @@ func (m *mockNoteRepo) Delete(_ context.Context, id annotation.NoteID) error {
 	}
 	return annotation.ErrNoteNotFound
 }
+func (m *mockNoteRepo) Update(_ context.Context, note *annotation.AgentNote, prior annotation.NoteRevision) error {
+	for i, n := range m.notes {
+		if n.ID() == note.ID() {
+			m.notes[i] = note
+			m.revisions = append(m.revisions, prior)
+			return nil
+		}
+	}
+	return annotation.ErrNoteNotFound
+}
+func (m *mockNoteRepo) ListRevisions(_ context.Context, id annotation.NoteID) ([]annotation.NoteRevision, error) {
+	var result []annotation.NoteRevision
+	for _, r := range m.revisions {
+		if r.NoteID() == id {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
 
 type mockWriteRepo struct{}
 
@@ -256,17 +296,22 @@ func testDeps(t *testing.T) *cli.Dependencies {
 		GetTranscript:     meetingapp.NewGetTranscript(repo),
 		SearchTranscripts: meetingapp.NewSearchTranscripts(repo),
 		GetActionItems:    meetingapp.NewGetActionItems(repo),
+		ListActionItems:   meetingapp.NewListActionItems(repo, writeRepo, ""),
 		GetMeetingStats:   meetingapp.NewGetMeetingStats(repo),
-		SyncMeetings:      meetingapp.NewSyncMeetings(repo),
-		ExportMeeting:     exportapp.NewExportMeeting(repo),
+		SyncMeetings:      meetingapp.NewSyncMeetings(repo, nil),
+		ExportMeeting:     exportapp.NewExportMeeting(repo, noteRepo),
+		ExportActionItems: exportapp.NewExportActionItems(meetingapp.NewListActionItems(repo, writeRepo, "")),
 		Login:             authapp.NewLogin(authSvc),
 		CheckStatus:       authapp.NewCheckStatus(authSvc),
 		Logout:            authapp.NewLogout(authSvc),
 		AddNote:           annotationapp.NewAddNote(noteRepo, repo, dispatcher),
-		ListNotes:         annotationapp.NewListNotes(noteRepo),
+		UpdateNote:        annotationapp.NewUpdateNote(noteRepo, dispatcher),
+		GetNoteHistory:    annotationapp.NewGetNoteHistory(noteRepo),
+		ListNotes:         annotationapp.NewListNotes(noteRepo, nil),
 		DeleteNote:        annotationapp.NewDeleteNote(noteRepo, dispatcher),
 		CompleteActionItem: meetingapp.NewCompleteActionItem(repo, writeRepo, dispatcher),
 		UpdateActionItem:   meetingapp.NewUpdateActionItem(repo, writeRepo, dispatcher),
+		PushActionItem:     meetingapp.NewPushActionItem(repo, writeRepo, nil, nil),
 		ExportEmbeddings:   embeddingapp.NewExportEmbeddings(repo, noteRepo),
 		MCPServer: mcpiface.NewServer("acai", "test", mcpiface.ServerOptions{
 			ListMeetings:       meetingapp.NewListMeetings(repo),
@@ -276,7 +321,7 @@ func testDeps(t *testing.T) *cli.Dependencies {
 			GetActionItems:     meetingapp.NewGetActionItems(repo),
 			GetMeetingStats:    meetingapp.NewGetMeetingStats(repo),
 			AddNote:            annotationapp.NewAddNote(noteRepo, repo, dispatcher),
-			ListNotes:          annotationapp.NewListNotes(noteRepo),
+			ListNotes:          annotationapp.NewListNotes(noteRepo, nil),
 			DeleteNote:         annotationapp.NewDeleteNote(noteRepo, dispatcher),
 			CompleteActionItem: meetingapp.NewCompleteActionItem(repo, writeRepo, dispatcher),
 			UpdateActionItem:   meetingapp.NewUpdateActionItem(repo, writeRepo, dispatcher),
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/migrate"
+	"github.com/spf13/cobra"
+)
+
+func newDBCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the local SQLite databases",
+		Long:  "Inspect and apply schema migrations of local.db (notes, overrides, outbox) and cache.db (API cache, projection).",
+	}
+
+	cmd.AddCommand(
+		newDBMigrateCmd(deps),
+		newDBStatusCmd(deps),
+	)
+	return cmd
+}
+
+type migrationJSON struct {
+	Database  string  `json:"database"`
+	Component string  `json:"component"`
+	Version   int     `json:"version"`
+	Name      string  `json:"name"`
+	AppliedAt *string `json:"applied_at,omitempty"`
+}
+
+func toMigrationJSON(database string, e migrate.Entry) migrationJSON {
+	out := migrationJSON{Database: database, Component: e.Component, Version: e.Version, Name: e.Name}
+	if e.AppliedAt != nil {
+		t := e.AppliedAt.Format(time.RFC3339)
+		out.AppliedAt = &t
+	}
+	return out
+}
+
+func newDBStatusCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:     "status",
+		Short:   "List applied and pending schema migrations",
+		Example: "  acai db status\n  acai db status --format json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(deps.Databases) == 0 {
+				return errLocalDBRequired
+			}
+
+			var result []migrationJSON
+			for _, db := range deps.Databases {
+				entries, err := db.Status(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("failed to read %s migrations: %w", db.Name(), err)
+				}
+				for _, e := range entries {
+					result = append(result, toMigrationJSON(db.Name(), e))
+				}
+			}
+
+			if flagFormat == "json" {
+				return printJSON(deps, result)
+			}
+
+			w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "DATABASE\tCOMPONENT\tVERSION\tNAME\tAPPLIED")
+			for _, m := range result {
+				applied := "pending"
+				if m.AppliedAt != nil {
+					applied = *m.AppliedAt
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%04d\t%s\t%s\n", m.Database, m.Component, m.Version, m.Name, applied)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newDBMigrateCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending schema migrations",
+		Long: `Apply pending schema migrations to local.db and cache.db. Each migration runs
+in its own transaction. Before migrating, an existing database is copied to
+<file>.bak-<timestamp> in the same directory; restore that copy to roll back.
+
+acai also migrates on startup; run this command to retry a failed upgrade.`,
+		Example: "  acai db migrate",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(deps.Databases) == 0 {
+				return errLocalDBRequired
+			}
+
+			for _, db := range deps.Databases {
+				result, err := db.Migrate(cmd.Context())
+				if result.Backup != "" {
+					_, _ = fmt.Fprintf(deps.Out, "%s: backed up to %s\n", db.Name(), result.Backup)
+				}
+				for _, e := range result.Applied {
+					_, _ = fmt.Fprintf(deps.Out, "%s: applied %s %04d_%s\n", db.Name(), e.Component, e.Version, e.Name)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to migrate %s: %w", db.Name(), err)
+				}
+				if len(result.Applied) == 0 {
+					_, _ = fmt.Fprintf(deps.Out, "%s: up to date\n", db.Name())
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package cli_test
+
+import (
+	"bytes"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/migrate"
+	"github.com/felixgeelhaar/acai/internal/interfaces/cli"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func testMigrator(t *testing.T) *migrate.Migrator {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "local.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	set, err := migrate.Load("notes", fstest.MapFS{
+		"migrations/0001_create_notes.sql": {Data: []byte("CREATE TABLE notes (id TEXT PRIMARY KEY);")},
+	}, "migrations")
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	return migrate.NewMigrator("local.db", path, db, set)
+}
+
+func TestDBCmd_StatusThenMigrate(t *testing.T) {
+	deps := testDeps(t)
+	deps.Databases = []*migrate.Migrator{testMigrator(t)}
+	out := deps.Out.(*bytes.Buffer)
+
+	root := cli.NewRootCmd(deps)
+	root.SetArgs([]string{"db", "status"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if !strings.Contains(out.String(), "0001") || !strings.Contains(out.String(), "pending") {
+		t.Errorf("expected pending migration, got: %q", out.String())
+	}
+
+	out.Reset()
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"db", "migrate"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if !strings.Contains(out.String(), "local.db: applied notes 0001_create_notes") {
+		t.Errorf("unexpected migrate output: %q", out.String())
+	}
+
+	out.Reset()
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"db", "migrate"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("migrate again: %v", err)
+	}
+	if !strings.Contains(out.String(), "local.db: up to date") {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func TestDBCmd_RequiresDatabases(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"db", "status"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error without databases")
+	}
+}
@@ -5,6 +5,7 @@ package cli
 import (
 	"errors"
 	"io"
+	"net/http"
 
 	annotationapp "github.com/felixgeelhaar/acai/internal/application/annotation"
 	authapp "github.com/felixgeelhaar/acai/internal/application/auth"
@@ -12,6 +13,12 @@ import (
 	exportapp "github.com/felixgeelhaar/acai/internal/application/export"
 	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/backup"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localcache"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/migrate"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+	syncmgr "github.com/felixgeelhaar/acai/internal/infrastructure/sync"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
 	mcpiface "github.com/felixgeelhaar/acai/internal/interfaces/mcp"
 )
 
@@ -28,9 +35,12 @@ type Dependencies struct {
 	GetTranscript     *meetingapp.GetTranscript
 	SearchTranscripts *meetingapp.SearchTranscripts
 	GetActionItems    *meetingapp.GetActionItems
+	ListActionItems   *meetingapp.ListActionItems
 	GetMeetingStats   *meetingapp.GetMeetingStats
+	GetMeetingHistory *meetingapp.GetMeetingHistory
 	SyncMeetings      *meetingapp.SyncMeetings
 	ExportMeeting     *exportapp.ExportMeeting
+	ExportActionItems *exportapp.ExportActionItems
 	Login             *authapp.Login
 	CheckStatus       *authapp.CheckStatus
 	Logout            *authapp.Logout
@@ -40,14 +50,45 @@ type Dependencies struct {
 
 	// Write use cases
 	AddNote            *annotationapp.AddNote
+	UpdateNote         *annotationapp.UpdateNote
+	GetNoteHistory     *annotationapp.GetNoteHistory
 	ListNotes          *annotationapp.ListNotes
 	DeleteNote         *annotationapp.DeleteNote
 	CompleteActionItem *meetingapp.CompleteActionItem
 	UpdateActionItem   *meetingapp.UpdateActionItem
+	PushActionItem     *meetingapp.PushActionItem
 
 	// Embedding export
 	ExportEmbeddings *embeddingapp.ExportEmbeddings
 
+	// Summary generation by a language model (nil unless ACAI_LLM_URL and
+	// ACAI_LLM_MODEL are set)
+	GenerateSummary *meetingapp.GenerateSummary
+
+	// Outbox relay (delivers write events to configured sinks)
+	OutboxRelay *outbox.Relay
+	Outbox      outbox.Inspector
+
+	// Outbound webhook subscriptions and their delivery sink
+	WebhookSubscriptions *webhook.SQLiteRepository
+	WebhookSink          *webhook.Sink
+
+	// Reloads the desktop cache file while serving (local_cache data source only)
+	CacheWatcher *localcache.Watcher
+
+	// Syncs in the background while serving, keeping the projection fresh
+	// (nil unless the projection has a max age)
+	SyncManager *syncmgr.Manager
+
+	// Backup and restore of local state
+	Backup *backup.Service
+
+	// Schema migrators of local.db and cache.db, for "acai db"
+	Databases []*migrate.Migrator
+
+	// SSE stream of recorded events, mounted at /events on the HTTP transport
+	EventStream http.Handler
+
 	// Config-provided API token for auth login
 	GranolaAPIToken string
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
@@ -12,6 +13,7 @@ import (
 	domain "github.com/felixgeelhaar/acai/internal/domain/auth"
 	infraauth "github.com/felixgeelhaar/acai/internal/infrastructure/auth"
 	"github.com/felixgeelhaar/acai/internal/infrastructure/config"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localcache"
 )
 
 // newInitCmd creates the "acai init" command.
@@ -57,11 +59,12 @@ func runInit(cmd *cobra.Command) error {
 	}
 
 	// Auto-detect local Granola cache
-	defaultCachePath := filepath.Join(homeDir, "Library", "Application Support", "Granola", "cache-v3.json")
-	cacheDetected := false
-	if _, err := os.Stat(defaultCachePath); err == nil {
-		cacheDetected = true
+	cacheRoots := config.Load().Granola.CacheRoots
+	if len(cacheRoots) == 0 {
+		cacheRoots = localcache.DefaultRoots(homeDir, runtime.GOOS, os.Getenv)
 	}
+	detectedPath, locateErr := localcache.Locate(cacheRoots)
+	cacheDetected := locateErr == nil
 
 	// Build connection options
 	var options []huh.Option[string]
@@ -69,6 +72,7 @@ func runInit(cmd *cobra.Command) error {
 		options = []huh.Option[string]{
 			huh.NewOption("Local cache (Granola desktop app detected)", "local_cache"),
 			huh.NewOption("API token (Enterprise)", "api"),
+			huh.NewOption("Both: desktop cache + API token (hybrid)", "hybrid"),
 		}
 	} else {
 		options = []huh.Option[string]{
@@ -92,7 +96,7 @@ func runInit(cmd *cobra.Command) error {
 	}
 
 	// Handle API token flow
-	if dataSource == "api" {
+	if dataSource == "api" || dataSource == "hybrid" {
 		var token string
 		err = huh.NewInput().
 			Title("Enter your Granola API token").
@@ -123,7 +127,8 @@ func runInit(cmd *cobra.Command) error {
 		var cachePath string
 		err = huh.NewInput().
 			Title("Enter the path to your Granola cache file").
-			Placeholder(defaultCachePath).
+			Description(locateErr.Error()).
+			Placeholder(filepath.Join(cacheRoots[0], "cache-v3.json")).
 			Value(&cachePath).
 			Run()
 		if err != nil {
@@ -131,12 +136,15 @@ func runInit(cmd *cobra.Command) error {
 		}
 
 		if cachePath != "" {
-			if _, err := os.Stat(cachePath); err != nil {
-				return fmt.Errorf("cache file not found: %s", cachePath)
+			if _, err := localcache.DetectFormat(cachePath); err != nil {
+				return fmt.Errorf("unusable cache file: %w", err)
 			}
 			fileCfg.Granola.CachePath = cachePath
 		}
 	}
+	if (dataSource == "local_cache" || dataSource == "hybrid") && cacheDetected {
+		_, _ = fmt.Fprintln(out, "Using Granola cache at", detectedPath)
+	}
 
 	// Write config file
 	if err := config.WriteConfigFile(configPath, fileCfg); err != nil {
@@ -2,8 +2,10 @@ package cli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"text/tabwriter"
+	"time"
 
 	exportapp "github.com/felixgeelhaar/acai/internal/application/export"
 	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
@@ -28,6 +30,8 @@ func newMeetingCmd(deps *Dependencies) *cobra.Command {
 		newMeetingListCmd(deps),
 		newMeetingShowCmd(deps),
 		newMeetingExportCmd(deps),
+		newMeetingHistoryCmd(deps),
+		newMeetingSummarizeCmd(deps),
 	)
 	return cmd
 }
@@ -115,7 +119,11 @@ Use 'acai transcript show <meeting_id>' to view the full transcript.`,
 
 				if m.Summary() != nil {
 					_, _ = fmt.Fprintln(deps.Out)
-					_, _ = fmt.Fprintln(deps.Out, "Summary:")
+					if m.Summary().Kind() == domain.SummaryGenerated {
+						_, _ = fmt.Fprintln(deps.Out, "Summary (generated locally):")
+					} else {
+						_, _ = fmt.Fprintln(deps.Out, "Summary:")
+					}
 					_, _ = fmt.Fprintln(deps.Out, m.Summary().Content())
 				}
 
@@ -142,13 +150,18 @@ Use 'acai transcript show <meeting_id>' to view the full transcript.`,
 }
 
 func newMeetingExportCmd(deps *Dependencies) *cobra.Command {
-	return &cobra.Command{
+	var transcript bool
+
+	cmd := &cobra.Command{
 		Use:   "export <meeting_id>",
 		Short: "Export a meeting as markdown or JSON",
-		Long: `Export a meeting's full content including title, participants, summary, transcript, and action items.
+		Long: `Export a meeting's content including title, participants, summary, action items, and agent notes.
 
-Defaults to markdown format. Use --format json for structured output.`,
-		Example: "  acai meeting export meeting-001\n  acai meeting export meeting-001 --format json",
+Defaults to markdown format. Use --format json for structured output.
+With --transcript, markdown and text exports also include the transcript,
+with notes inline after the utterances they are anchored to. If the
+transcript cannot be fetched, the meeting is exported without it.`,
+		Example: "  acai meeting export meeting-001\n  acai meeting export meeting-001 --transcript\n  acai meeting export meeting-001 --format json",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			format := exportapp.Format(flagFormat)
@@ -157,17 +170,78 @@ Defaults to markdown format. Use --format json for structured output.`,
 			}
 
 			out, err := deps.ExportMeeting.Execute(cmd.Context(), exportapp.ExportMeetingInput{
-				MeetingID: domain.MeetingID(args[0]),
-				Format:    format,
+				MeetingID:  domain.MeetingID(args[0]),
+				Format:     format,
+				Transcript: transcript,
 			})
 			if err != nil {
 				return fmt.Errorf("export failed: %w", err)
 			}
+			if out.TranscriptErr != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: exported without the transcript: %v\n", out.TranscriptErr)
+			}
 
 			_, _ = fmt.Fprint(deps.Out, out.Content)
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&transcript, "transcript", false, "Include the transcript in markdown and text exports")
+	return cmd
+}
+
+func newMeetingSummarizeCmd(deps *Dependencies) *cobra.Command {
+	var (
+		strategy  string
+		maxTokens int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "summarize <meeting_id>",
+		Short: "Generate a summary for a meeting Granola has none for",
+		Long: `Summarize a meeting's transcript with the language model configured by
+ACAI_LLM_URL and ACAI_LLM_MODEL, e.g. a local Ollama or llama.cpp server.
+
+The transcript is chunked, each part summarized, and the parts merged into one
+summary. It is stored locally and shown wherever the meeting's summary is;
+running the command again replaces it. Meetings with a summary from Granola
+are left alone.`,
+		Example: "  acai meeting summarize meeting-001\n  acai meeting summarize meeting-001 --strategy token_limit --max-tokens 4000",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.GenerateSummary == nil {
+				return errors.New("summary generation is not configured: set ACAI_LLM_URL and ACAI_LLM_MODEL (requires local storage)")
+			}
+
+			out, err := deps.GenerateSummary.Execute(cmd.Context(), meetingapp.GenerateSummaryInput{
+				MeetingID: domain.MeetingID(args[0]),
+				Strategy:  strategy,
+				MaxTokens: maxTokens,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate summary: %w", err)
+			}
+
+			switch flagFormat {
+			case "json":
+				return printJSON(deps, map[string]any{
+					"meeting_id":  out.Summary.MeetingID(),
+					"content":     out.Summary.Content(),
+					"kind":        out.Summary.Kind(),
+					"model":       out.Model,
+					"chunk_count": out.ChunkCount,
+				})
+			default:
+				_, _ = fmt.Fprintln(deps.Out, out.Summary.Content())
+				_, _ = fmt.Fprintf(deps.Out, "\nGenerated by %s from %d transcript chunks.\n", out.Model, out.ChunkCount)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&strategy, "strategy", "speaker_turn", "Chunking strategy: speaker_turn, time_window, token_limit")
+	cmd.Flags().IntVar(&maxTokens, "max-tokens", meetingapp.DefaultSummaryMaxTokens, "Max tokens of transcript per prompt to the model")
+	return cmd
 }
 
 func printMeetingsTable(deps *Dependencies, meetings []*domain.Meeting) error {
@@ -179,3 +253,65 @@ func printMeetingsTable(deps *Dependencies, meetings []*domain.Meeting) error {
 	}
 	return w.Flush()
 }
+
+type historyItemJSON struct {
+	EventID     string `json:"event_id"`
+	Type        string `json:"type"`
+	OccurredAt  string `json:"occurred_at"`
+	Description string `json:"description"`
+}
+
+func newMeetingHistoryCmd(deps *Dependencies) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history <meeting_id>",
+		Short: "Show a meeting's activity timeline",
+		Long: `List the recorded events for a meeting, oldest first: creation, transcript
+and summary updates, notes added or deleted, and action item changes.`,
+		Example: "  acai meeting history meeting-001\n  acai meeting history meeting-001 --limit 10 --format json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.GetMeetingHistory == nil {
+				return errLocalDBRequired
+			}
+
+			out, err := deps.GetMeetingHistory.Execute(cmd.Context(), meetingapp.GetMeetingHistoryInput{
+				MeetingID: domain.MeetingID(args[0]),
+				Limit:     limit,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get meeting history: %w", err)
+			}
+
+			switch flagFormat {
+			case "json":
+				result := make([]historyItemJSON, len(out.Items))
+				for i, item := range out.Items {
+					result[i] = historyItemJSON{
+						EventID:     item.EventID,
+						Type:        item.Type,
+						OccurredAt:  item.OccurredAt.Format(time.RFC3339),
+						Description: item.Description,
+					}
+				}
+				return printJSON(deps, result)
+			default:
+				if len(out.Items) == 0 {
+					_, _ = fmt.Fprintln(deps.Out, "No recorded activity for this meeting.")
+					return nil
+				}
+				w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "TIME\tEVENT\tDETAILS")
+				for _, item := range out.Items {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n",
+						item.OccurredAt.Local().Format("2006-01-02 15:04"), item.Type, item.Description)
+				}
+				return w.Flush()
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 0, "Show only the N most recent events (0 for all)")
+	return cmd
+}
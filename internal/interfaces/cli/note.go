@@ -1,10 +1,13 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"text/tabwriter"
+	"time"
 
 	annotationapp "github.com/felixgeelhaar/acai/internal/application/annotation"
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +19,8 @@ func newNoteCmd(deps *Dependencies) *cobra.Command {
 
 	cmd.AddCommand(
 		newNoteAddCmd(deps),
+		newNoteEditCmd(deps),
+		newNoteHistoryCmd(deps),
 		newNoteListCmd(deps),
 		newNoteDeleteCmd(deps),
 	)
@@ -23,52 +28,204 @@ func newNoteCmd(deps *Dependencies) *cobra.Command {
 }
 
 func newNoteAddCmd(deps *Dependencies) *cobra.Command {
-	var author string
+	var (
+		author         string
+		kind           string
+		anchorStart    string
+		anchorEnd      string
+		utterance      int
+		replyTo        string
+		idempotencyKey string
+	)
 
 	cmd := &cobra.Command{
-		Use:   "add <meeting_id> <text>",
+		Use:   "add [meeting_id] <text>",
 		Short: "Add an agent note to a meeting",
-		Args:  cobra.ExactArgs(2),
+		Long: `Add a note to a meeting. A note can be typed with --kind, anchored to part of
+the transcript with --anchor-start/--anchor-end (utterance timestamps) or
+--utterance (0-based index), and posted as a reply with --reply-to, in which
+case the meeting ID may be omitted. Scripts that may retry should pass
+--idempotency-key: repeating the command with the same key prints the note
+added first instead of adding another.`,
+		Example: "  acai note add meeting-001 \"Budget not approved\" --kind risk --utterance 12\n  acai note add --reply-to note-123 \"Approved on Friday\"",
+		Args:    cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if deps.AddNote == nil {
 				return errLocalDBRequired
 			}
-			out, err := deps.AddNote.Execute(cmd.Context(), annotationapp.AddNoteInput{
-				MeetingID: args[0],
-				Author:    author,
-				Content:   args[1],
-			})
+			input := annotationapp.AddNoteInput{Author: author, Kind: kind, ParentID: replyTo, IdempotencyKey: idempotencyKey}
+			switch {
+			case len(args) == 2:
+				input.MeetingID, input.Content = args[0], args[1]
+			case replyTo != "":
+				input.Content = args[0]
+			default:
+				return fmt.Errorf("specify a meeting ID and the note text")
+			}
+
+			var err error
+			if input.AnchorStart, err = parseDateFlag("--anchor-start", anchorStart); err != nil {
+				return err
+			}
+			if input.AnchorEnd, err = parseDateFlag("--anchor-end", anchorEnd); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("utterance") {
+				input.UtteranceIndex = &utterance
+			}
+
+			out, err := deps.AddNote.Execute(cmd.Context(), input)
 			if err != nil {
 				return fmt.Errorf("failed to add note: %w", err)
 			}
+			if out.Replayed {
+				_, _ = fmt.Fprintf(deps.Out, "Note %s was already added to meeting %s\n", out.Note.ID(), out.Note.MeetingID())
+				return nil
+			}
 			_, _ = fmt.Fprintf(deps.Out, "Note %s added to meeting %s\n", out.Note.ID(), out.Note.MeetingID())
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&author, "author", "cli", "Note author")
+	cmd.Flags().StringVar(&kind, "kind", "note", "Note kind: note, insight, risk, decision, question")
+	cmd.Flags().StringVar(&anchorStart, "anchor-start", "", "Anchor to utterances from this time (RFC3339)")
+	cmd.Flags().StringVar(&anchorEnd, "anchor-end", "", "Anchor to utterances until this time (RFC3339)")
+	cmd.Flags().IntVar(&utterance, "utterance", 0, "Anchor to the utterance at this index (0-based)")
+	cmd.Flags().StringVar(&replyTo, "reply-to", "", "Reply to this note ID")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Key that makes retries safe: a repeated add with the same key returns the first note")
 	return cmd
 }
 
-func newNoteListCmd(deps *Dependencies) *cobra.Command {
+func newNoteEditCmd(deps *Dependencies) *cobra.Command {
+	var (
+		author   string
+		revision int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "edit <note_id> <text>",
+		Short: "Replace the content of an agent note",
+		Long: `Replace a note's content, keeping the previous content in its history.
+--revision must be the note's current revision (see "acai note list"); the
+edit is rejected if the note was edited since.`,
+		Example: "  acai note edit note-123 \"Budget approved on Friday\" --revision 1",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.UpdateNote == nil {
+				return errLocalDBRequired
+			}
+			out, err := deps.UpdateNote.Execute(cmd.Context(), annotationapp.UpdateNoteInput{
+				NoteID:           args[0],
+				Author:           author,
+				Content:          args[1],
+				ExpectedRevision: revision,
+			})
+			if errors.Is(err, annotation.ErrRevisionConflict) {
+				return fmt.Errorf("failed to edit note: %w; run \"acai note history %s\" to see the latest revision", err, args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("failed to edit note: %w", err)
+			}
+			_, _ = fmt.Fprintf(deps.Out, "Note %s updated to revision %d\n", out.Note.ID(), out.Note.Revision())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&author, "author", "cli", "Editor recorded in the note's history")
+	cmd.Flags().IntVar(&revision, "revision", 0, "Current revision of the note (required)")
+	_ = cmd.MarkFlagRequired("revision")
+	return cmd
+}
+
+// noteRevisionJSON is the JSON form of a note revision.
+type noteRevisionJSON struct {
+	Revision  int       `json:"revision"`
+	Author    string    `json:"author"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newNoteHistoryCmd(deps *Dependencies) *cobra.Command {
 	return &cobra.Command{
-		Use:     "list [meeting_id]",
-		Short:   "List agent notes (all or for a specific meeting)",
-		Example: "  acai note list\n  acai note list meeting-001",
+		Use:     "history <note_id>",
+		Short:   "Show every revision of an agent note",
+		Example: "  acai note history note-123",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.GetNoteHistory == nil {
+				return errLocalDBRequired
+			}
+			out, err := deps.GetNoteHistory.Execute(cmd.Context(), annotationapp.GetNoteHistoryInput{
+				NoteID: args[0],
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get note history: %w", err)
+			}
+
+			switch flagFormat {
+			case "json":
+				revisions := make([]noteRevisionJSON, len(out.Revisions))
+				for i, r := range out.Revisions {
+					revisions[i] = noteRevisionJSON{Revision: r.Number(), Author: r.Author(), Content: r.Content(), CreatedAt: r.CreatedAt()}
+				}
+				return printJSON(deps, revisions)
+			default:
+				w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "REVISION\tAUTHOR\tDATE\tCONTENT")
+				for _, r := range out.Revisions {
+					_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n",
+						r.Number(), r.Author(), r.CreatedAt().Format("2006-01-02 15:04"), r.Content())
+				}
+				return w.Flush()
+			}
+		},
+	}
+}
+
+func newNoteListCmd(deps *Dependencies) *cobra.Command {
+	var (
+		author string
+		kind   string
+		since  string
+		until  string
+		query  string
+		limit  int
+		offset int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [meeting_id]",
+		Short: "List agent notes (all or for a specific meeting)",
+		Long: `List agent notes. Filtering by author, kind, creation date or a full-text
+--query searches notes across all meetings (or within the given meeting),
+newest first.`,
+		Example: "  acai note list\n  acai note list meeting-001\n  acai note list --query \"budget approval\" --kind decision --since 2026-01-01",
 		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if deps.ListNotes == nil {
 				return errLocalDBRequired
 			}
 
-			var meetingID string
+			input := annotationapp.ListNotesInput{
+				Author: author,
+				Kind:   kind,
+				Query:  query,
+				Limit:  limit,
+				Offset: offset,
+			}
 			if len(args) > 0 {
-				meetingID = args[0]
+				input.MeetingID = args[0]
+			}
+			var err error
+			if input.Since, err = parseDateFlag("--since", since); err != nil {
+				return err
+			}
+			if input.Until, err = parseUntilFlag("--until", until); err != nil {
+				return err
 			}
 
-			out, err := deps.ListNotes.Execute(cmd.Context(), annotationapp.ListNotesInput{
-				MeetingID: meetingID,
-			})
+			out, err := deps.ListNotes.Execute(cmd.Context(), input)
 			if err != nil {
 				return fmt.Errorf("failed to list notes: %w", err)
 			}
@@ -78,15 +235,37 @@ func newNoteListCmd(deps *Dependencies) *cobra.Command {
 				return printJSON(deps, out.Notes)
 			default:
 				w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
-				_, _ = fmt.Fprintln(w, "ID\tMEETING\tAUTHOR\tCONTENT\tCREATED")
+				_, _ = fmt.Fprintln(w, "ID\tMEETING\tKIND\tAUTHOR\tCONTENT\tREPLY_TO\tREV\tCREATED")
 				for _, n := range out.Notes {
-					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-						n.ID(), n.MeetingID(), n.Author(), n.Content(), n.CreatedAt().Format("2006-01-02 15:04"))
+					replyTo := string(n.ParentID())
+					if replyTo == "" {
+						replyTo = "-"
+					}
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+						n.ID(), n.MeetingID(), n.Kind(), n.Author(), n.Content(), replyTo, n.Revision(), n.CreatedAt().Format("2006-01-02 15:04"))
 				}
-				return w.Flush()
+				if err := w.Flush(); err != nil {
+					return err
+				}
+				for _, n := range out.Notes {
+					if at, ok := out.PushConflicts[n.ID()]; ok {
+						_, _ = fmt.Fprintf(deps.Out, "Note %s was also edited in Granola on %s; Granola keeps that version.\n",
+							n.ID(), at.Format("2006-01-02 15:04"))
+					}
+				}
+				return nil
 			}
 		},
 	}
+
+	cmd.Flags().StringVar(&author, "author", "", "Only notes by this author")
+	cmd.Flags().StringVar(&kind, "kind", "", "Only notes of this kind: note, insight, risk, decision, question")
+	cmd.Flags().StringVar(&since, "since", "", "Only notes created on or after this date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&until, "until", "", "Only notes created on or before this date (YYYY-MM-DD includes the whole day, or RFC3339)")
+	cmd.Flags().StringVarP(&query, "query", "q", "", "Only notes containing every word of this text")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Max results (0: no limit)")
+	cmd.Flags().IntVar(&offset, "offset", 0, "Pagination offset")
+	return cmd
 }
 
 func newNoteDeleteCmd(deps *Dependencies) *cobra.Command {
@@ -0,0 +1,327 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+	"github.com/spf13/cobra"
+)
+
+func newOutboxCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outbox",
+		Short: "Inspect and deliver the local event outbox",
+		Long:  "Write events (notes, action item changes) are stored in a local outbox and delivered to configured sinks by the relay.",
+	}
+
+	cmd.AddCommand(
+		newOutboxListCmd(deps),
+		newOutboxShowCmd(deps),
+		newOutboxRetryCmd(deps),
+		newOutboxPurgeCmd(deps),
+		newOutboxRelayCmd(deps),
+	)
+	return cmd
+}
+
+func newOutboxRelayCmd(deps *Dependencies) *cobra.Command {
+	var once bool
+
+	cmd := &cobra.Command{
+		Use:   "relay",
+		Short: "Deliver pending outbox entries to configured sinks",
+		Long: `Poll the outbox and deliver pending entries to the configured sinks
+(ACAI_OUTBOX_WEBHOOK_URL, ACAI_OUTBOX_FILE, ACAI_OUTBOX_GRANOLA_PATH).
+
+Failed deliveries are retried with exponential backoff; entries that exhaust
+their attempts move to the "failed" (dead-letter) status. With --once, a single
+pass is made and the command exits. "acai serve" runs the relay automatically.`,
+		Example: "  acai outbox relay --once\n  ACAI_OUTBOX_FILE=~/acai-events.ndjson acai outbox relay",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.OutboxRelay == nil {
+				return errLocalDBRequired
+			}
+			if deps.OutboxRelay.Sinks() == 0 {
+				return fmt.Errorf("no outbox sinks configured")
+			}
+
+			if once {
+				result, err := deps.OutboxRelay.RunOnce(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("relay failed: %w", err)
+				}
+				_, _ = fmt.Fprintf(deps.Out, "Delivered %d, retrying %d, dead-lettered %d\n",
+					result.Delivered, result.Retried, result.DeadLettered)
+				return nil
+			}
+
+			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+
+			_, _ = fmt.Fprintln(deps.Out, "Outbox relay running (Ctrl+C to stop)...")
+			deps.OutboxRelay.Start(ctx)
+			<-ctx.Done()
+			deps.OutboxRelay.Stop()
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&once, "once", false, "Run a single delivery pass and exit")
+	return cmd
+}
+
+type outboxEntryJSON struct {
+	ID            string          `json:"id"`
+	EventType     string          `json:"event_type"`
+	Status        string          `json:"status"`
+	CreatedAt     string          `json:"created_at"`
+	SyncedAt      *string         `json:"synced_at,omitempty"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *string         `json:"next_attempt_at,omitempty"`
+	LastError     string          `json:"last_error,omitempty"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+func toOutboxEntryJSON(e outbox.Entry, withPayload bool) outboxEntryJSON {
+	out := outboxEntryJSON{
+		ID:        e.ID,
+		EventType: e.EventType,
+		Status:    e.Status,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+		Attempts:  e.Attempts,
+		LastError: e.LastError,
+	}
+	if e.SyncedAt != nil {
+		t := e.SyncedAt.Format(time.RFC3339)
+		out.SyncedAt = &t
+	}
+	if e.NextAttemptAt != nil {
+		t := e.NextAttemptAt.Format(time.RFC3339)
+		out.NextAttemptAt = &t
+	}
+	if withPayload && json.Valid(e.Payload) {
+		out.Payload = e.Payload
+	}
+	return out
+}
+
+func newOutboxListCmd(deps *Dependencies) *cobra.Command {
+	var (
+		status string
+		limit  int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List outbox entries",
+		Example: "  acai outbox list --status failed\n  acai outbox list --status pending --format json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Outbox == nil {
+				return errLocalDBRequired
+			}
+			if status != "" && !outbox.ValidStatus(status) {
+				return fmt.Errorf("invalid --status %q (use pending, failed, or synced)", status)
+			}
+
+			entries, err := deps.Outbox.List(status, limit)
+			if err != nil {
+				return fmt.Errorf("failed to list outbox entries: %w", err)
+			}
+
+			if len(entries) == 0 {
+				_, _ = fmt.Fprintln(deps.Out, "No outbox entries found.")
+				return nil
+			}
+
+			switch flagFormat {
+			case "json":
+				result := make([]outboxEntryJSON, len(entries))
+				for i, e := range entries {
+					result[i] = toOutboxEntryJSON(e, false)
+				}
+				return printJSON(deps, result)
+			default:
+				w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "ID\tTYPE\tSTATUS\tATTEMPTS\tCREATED\tLAST_ERROR")
+				for _, e := range entries {
+					lastErr := e.LastError
+					if lastErr == "" {
+						lastErr = "-"
+					}
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+						e.ID, e.EventType, e.Status, e.Attempts, e.CreatedAt.Format("2006-01-02 15:04"), lastErr)
+				}
+				return w.Flush()
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&status, "status", "", "Filter by status: pending, failed, synced")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of entries (0 for all)")
+	return cmd
+}
+
+func newOutboxShowCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:     "show <id>",
+		Short:   "Show an outbox entry with its payload",
+		Example: "  acai outbox show 3f1c2a4e-...",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Outbox == nil {
+				return errLocalDBRequired
+			}
+
+			entry, err := deps.Outbox.Get(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get outbox entry: %w", err)
+			}
+
+			if flagFormat == "json" {
+				return printJSON(deps, toOutboxEntryJSON(*entry, true))
+			}
+
+			_, _ = fmt.Fprintf(deps.Out, "ID:        %s\n", entry.ID)
+			_, _ = fmt.Fprintf(deps.Out, "Type:      %s\n", entry.EventType)
+			_, _ = fmt.Fprintf(deps.Out, "Status:    %s\n", entry.Status)
+			_, _ = fmt.Fprintf(deps.Out, "Created:   %s\n", entry.CreatedAt.Format(time.RFC3339))
+			if entry.SyncedAt != nil {
+				_, _ = fmt.Fprintf(deps.Out, "Synced:    %s\n", entry.SyncedAt.Format(time.RFC3339))
+			}
+			_, _ = fmt.Fprintf(deps.Out, "Attempts:  %d\n", entry.Attempts)
+			if entry.NextAttemptAt != nil {
+				_, _ = fmt.Fprintf(deps.Out, "Next try:  %s\n", entry.NextAttemptAt.Format(time.RFC3339))
+			}
+			if entry.LastError != "" {
+				_, _ = fmt.Fprintf(deps.Out, "Error:     %s\n", entry.LastError)
+			}
+			_, _ = fmt.Fprintln(deps.Out, "Payload:")
+			var pretty bytes.Buffer
+			if err := json.Indent(&pretty, entry.Payload, "", "  "); err != nil {
+				pretty.Reset()
+				pretty.Write(entry.Payload)
+			}
+			_, _ = fmt.Fprintln(deps.Out, pretty.String())
+			return nil
+		},
+	}
+}
+
+func newOutboxRetryCmd(deps *Dependencies) *cobra.Command {
+	var allFailed bool
+
+	cmd := &cobra.Command{
+		Use:   "retry [id]",
+		Short: "Requeue an outbox entry, or all failed entries, for delivery",
+		Long: `Reset an entry to pending with a fresh attempt budget so the relay delivers
+it on its next pass. Retrying a synced entry replays it.`,
+		Example: "  acai outbox retry 3f1c2a4e-...\n  acai outbox retry --all-failed",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Outbox == nil {
+				return errLocalDBRequired
+			}
+			if allFailed == (len(args) == 1) {
+				return errors.New("specify either an entry ID or --all-failed")
+			}
+
+			if allFailed {
+				n, err := deps.Outbox.RetryAllFailed()
+				if err != nil {
+					return fmt.Errorf("failed to retry entries: %w", err)
+				}
+				_, _ = fmt.Fprintf(deps.Out, "Requeued %d failed entries\n", n)
+				return nil
+			}
+
+			if err := deps.Outbox.Retry(args[0]); err != nil {
+				return fmt.Errorf("failed to retry entry: %w", err)
+			}
+			_, _ = fmt.Fprintf(deps.Out, "Requeued %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&allFailed, "all-failed", false, "Requeue every failed (dead-lettered) entry")
+	return cmd
+}
+
+func newOutboxPurgeCmd(deps *Dependencies) *cobra.Command {
+	var (
+		synced    bool
+		failed    bool
+		olderThan string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "purge",
+		Short:   "Delete old synced or failed outbox entries",
+		Example: "  acai outbox purge --synced --older-than 30d",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Outbox == nil {
+				return errLocalDBRequired
+			}
+			if !synced && !failed {
+				return errors.New("specify --synced and/or --failed")
+			}
+
+			age, err := parseAge(olderThan)
+			if err != nil {
+				return fmt.Errorf("invalid --older-than: %w", err)
+			}
+			cutoff := time.Now().Add(-age)
+
+			var statuses []string
+			if synced {
+				statuses = append(statuses, outbox.StatusSynced)
+			}
+			if failed {
+				statuses = append(statuses, outbox.StatusFailed)
+			}
+
+			total := 0
+			for _, status := range statuses {
+				n, err := deps.Outbox.Purge(status, cutoff)
+				if err != nil {
+					return fmt.Errorf("failed to purge %s entries: %w", status, err)
+				}
+				total += n
+			}
+			_, _ = fmt.Fprintf(deps.Out, "Purged %d entries older than %s\n", total, olderThan)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&synced, "synced", false, "Purge synced entries")
+	cmd.Flags().BoolVar(&failed, "failed", false, "Purge failed (dead-lettered) entries")
+	cmd.Flags().StringVar(&olderThan, "older-than", "30d", "Minimum age, e.g. 30d or 12h")
+	return cmd
+}
+
+// parseAge parses a Go duration, additionally accepting a whole number of days ("30d").
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid day count %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative age %q", value)
+	}
+	return d, nil
+}
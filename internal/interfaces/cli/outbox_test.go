@@ -0,0 +1,275 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
+	"github.com/felixgeelhaar/acai/internal/interfaces/cli"
+)
+
+// memOutboxStore is a minimal in-memory outbox.Store and outbox.Inspector for CLI tests.
+type memOutboxStore struct {
+	entries []outbox.Entry
+}
+
+func (s *memOutboxStore) Append(e outbox.Entry) error {
+	e.Status = outbox.StatusPending
+	s.entries = append(s.entries, e)
+	return nil
+}
+
+func (s *memOutboxStore) ListPending() ([]outbox.Entry, error) {
+	var out []outbox.Entry
+	for _, e := range s.entries {
+		if e.Status == outbox.StatusPending {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (s *memOutboxStore) ListDue(_ time.Time, _ int, _ []string) ([]outbox.Entry, error) {
+	return s.ListPending()
+}
+
+func (s *memOutboxStore) set(id string, fn func(*outbox.Entry)) error {
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			fn(&s.entries[i])
+		}
+	}
+	return nil
+}
+
+func (s *memOutboxStore) MarkSynced(id string) error {
+	return s.set(id, func(e *outbox.Entry) { e.Status = outbox.StatusSynced })
+}
+
+func (s *memOutboxStore) MarkRetry(id string, next time.Time, lastErr string) error {
+	return s.set(id, func(e *outbox.Entry) { e.Attempts++; e.NextAttemptAt = &next; e.LastError = lastErr })
+}
+
+func (s *memOutboxStore) MarkFailed(id string, lastErr string) error {
+	return s.set(id, func(e *outbox.Entry) { e.Attempts++; e.Status = outbox.StatusFailed; e.LastError = lastErr })
+}
+
+func (s *memOutboxStore) List(status string, _ int) ([]outbox.Entry, error) {
+	var out []outbox.Entry
+	for _, e := range s.entries {
+		if status == "" || e.Status == status {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (s *memOutboxStore) Get(id string) (*outbox.Entry, error) {
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			return &s.entries[i], nil
+		}
+	}
+	return nil, outbox.ErrEntryNotFound
+}
+
+func (s *memOutboxStore) Retry(id string) error {
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+	return s.set(id, func(e *outbox.Entry) { e.Status = outbox.StatusPending; e.Attempts = 0 })
+}
+
+func (s *memOutboxStore) RetryAllFailed() (int, error) {
+	n := 0
+	for i := range s.entries {
+		if s.entries[i].Status == outbox.StatusFailed {
+			s.entries[i].Status = outbox.StatusPending
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memOutboxStore) Purge(status string, before time.Time) (int, error) {
+	kept := s.entries[:0]
+	n := 0
+	for _, e := range s.entries {
+		if e.Status == status && e.CreatedAt.Before(before) {
+			n++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.entries = kept
+	return n, nil
+}
+
+func (s *memOutboxStore) Stats() ([]outbox.TypeStats, error) { return nil, nil }
+
+func seededOutbox() *memOutboxStore {
+	store := &memOutboxStore{}
+	old := time.Now().Add(-40 * 24 * time.Hour)
+	_ = store.Append(outbox.Entry{ID: "evt-1", EventType: "note.added", Payload: []byte(`{"note_id":"n-1"}`), CreatedAt: old})
+	_ = store.Append(outbox.Entry{ID: "evt-2", EventType: "action_item.completed", Payload: []byte(`{}`), CreatedAt: time.Now()})
+	_ = store.MarkSynced("evt-1")
+	_ = store.MarkFailed("evt-2", "webhook: status 500")
+	return store
+}
+
+func TestOutboxListCmd_FilterByStatus(t *testing.T) {
+	deps := testDeps(t)
+	deps.Outbox = seededOutbox()
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "list", "--status", "failed"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "evt-2") || strings.Contains(output, "evt-1") {
+		t.Errorf("expected only failed entry, got: %q", output)
+	}
+	if !strings.Contains(output, "webhook: status 500") {
+		t.Errorf("expected last error column, got: %q", output)
+	}
+}
+
+func TestOutboxListCmd_InvalidStatus(t *testing.T) {
+	deps := testDeps(t)
+	deps.Outbox = seededOutbox()
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "list", "--status", "stuck"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for invalid status")
+	}
+}
+
+func TestOutboxShowCmd_PrettyPrintsPayload(t *testing.T) {
+	deps := testDeps(t)
+	deps.Outbox = seededOutbox()
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "show", "evt-1"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "\"note_id\": \"n-1\"") {
+		t.Errorf("expected indented payload, got: %q", output)
+	}
+}
+
+func TestOutboxRetryCmd(t *testing.T) {
+	deps := testDeps(t)
+	store := seededOutbox()
+	deps.Outbox = store
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "retry", "--all-failed"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.entries[1].Status != outbox.StatusPending {
+		t.Errorf("expected failed entry requeued, got %q", store.entries[1].Status)
+	}
+	if !strings.Contains(deps.Out.(*bytes.Buffer).String(), "Requeued 1 failed entries") {
+		t.Errorf("unexpected output: %q", deps.Out.(*bytes.Buffer).String())
+	}
+}
+
+func TestOutboxRetryCmd_RequiresIDOrAllFailed(t *testing.T) {
+	deps := testDeps(t)
+	deps.Outbox = seededOutbox()
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "retry"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error without ID or --all-failed")
+	}
+}
+
+func TestOutboxPurgeCmd_OlderThan(t *testing.T) {
+	deps := testDeps(t)
+	store := seededOutbox()
+	deps.Outbox = store
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "purge", "--synced", "--older-than", "30d"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.entries) != 1 || store.entries[0].ID != "evt-2" {
+		t.Errorf("expected only old synced entry purged, got %+v", store.entries)
+	}
+}
+
+func TestOutboxPurgeCmd_InvalidAge(t *testing.T) {
+	deps := testDeps(t)
+	deps.Outbox = seededOutbox()
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "purge", "--synced", "--older-than", "a month"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for invalid --older-than")
+	}
+}
+
+func TestOutboxRelayCmd_RequiresLocalDB(t *testing.T) {
+	deps := testDeps(t)
+	deps.OutboxRelay = nil
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "relay", "--once"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "local storage") {
+		t.Errorf("expected local storage error, got: %v", err)
+	}
+}
+
+func TestOutboxRelayCmd_NoSinksConfigured(t *testing.T) {
+	deps := testDeps(t)
+	deps.OutboxRelay = outbox.NewRelay(&memOutboxStore{}, nil, outbox.DefaultRelayConfig())
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "relay", "--once"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "no outbox sinks configured") {
+		t.Errorf("expected missing sink error, got: %v", err)
+	}
+}
+
+func TestOutboxRelayCmd_Once(t *testing.T) {
+	store := &memOutboxStore{}
+	_ = store.Append(outbox.Entry{ID: "evt-1", EventType: "note.added", Payload: []byte(`{}`), CreatedAt: time.Now()})
+
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	deps := testDeps(t)
+	deps.OutboxRelay = outbox.NewRelay(store, []outbox.Sink{outbox.NewFileSink(path)}, outbox.DefaultRelayConfig())
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"outbox", "relay", "--once"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "Delivered 1") {
+		t.Errorf("expected delivery summary, got: %q", output)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read sink file: %v", err)
+	}
+	if !strings.Contains(string(data), "evt-1") {
+		t.Errorf("expected entry in sink file, got: %q", string(data))
+	}
+	if store.entries[0].Status != outbox.StatusSynced {
+		t.Errorf("expected entry synced, got %q", store.entries[0].Status)
+	}
+}
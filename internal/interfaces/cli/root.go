@@ -31,6 +31,10 @@ func NewRootCmd(deps *Dependencies) *cobra.Command {
 		newStatsCmd(deps),
 		newExportCmd(deps),
 		newSyncCmd(deps),
+		newOutboxCmd(deps),
+		newWebhookCmd(deps),
+		newDBCmd(deps),
+		newBackupCmd(deps),
 		newServeCmd(deps),
 		newVersionCmd(),
 	)
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -25,13 +26,39 @@ func newServeCmd(deps *Dependencies) *cobra.Command {
 			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
 			defer cancel()
 
+			// Deliver outbox entries in the background while serving
+			if deps.OutboxRelay != nil && deps.OutboxRelay.Sinks() > 0 {
+				deps.OutboxRelay.Start(ctx)
+				defer deps.OutboxRelay.Stop()
+			}
+
+			// Pick up meetings recorded in the desktop app while serving
+			if deps.CacheWatcher != nil {
+				deps.CacheWatcher.Start(ctx)
+				defer deps.CacheWatcher.Stop()
+			}
+
+			// Sync periodically so reads keep coming from the projection
+			if deps.SyncManager != nil {
+				deps.SyncManager.Start(ctx)
+				defer deps.SyncManager.Stop()
+			}
+
 			switch transport {
 			case "http":
 				addr := fmt.Sprintf(":%d", port)
 				_, _ = fmt.Fprintf(deps.Out, "Starting %s v%s MCP server (http on %s)...\n",
 					deps.MCPServer.Name(), deps.MCPServer.Version(), addr)
 
-				err := deps.MCPServer.ServeHTTP(ctx, addr, nil)
+				var extraRoutes func(mux *http.ServeMux)
+				if deps.EventStream != nil {
+					extraRoutes = func(mux *http.ServeMux) {
+						mux.Handle("/events", deps.EventStream)
+					}
+					_, _ = fmt.Fprintf(deps.Out, "Streaming events at http://localhost%s/events\n", addr)
+				}
+
+				err := deps.MCPServer.ServeHTTP(ctx, addr, extraRoutes)
 				if err != nil {
 					if ctx.Err() != nil {
 						_, _ = fmt.Fprintln(os.Stderr, "MCP server stopped.")
@@ -42,6 +42,10 @@ func newSyncCmd(deps *Dependencies) *cobra.Command {
 			}
 
 			_, _ = fmt.Fprintf(deps.Out, "Synced %d meeting event(s)\n", len(out.Events))
+			for _, o := range out.Orphans {
+				_, _ = fmt.Fprintf(deps.Out, "Warning: meeting %s was deleted upstream; kept %d local note(s) and %d action item override(s)\n",
+					o.MeetingID, o.State.Notes, o.State.ActionItemOverrides)
+			}
 			return nil
 		},
 	}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"text/tabwriter"
 
+	annotationapp "github.com/felixgeelhaar/acai/internal/application/annotation"
+	exportapp "github.com/felixgeelhaar/acai/internal/application/export"
 	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	"github.com/felixgeelhaar/acai/internal/domain/annotation"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 	"github.com/spf13/cobra"
 )
@@ -27,7 +30,7 @@ func newTranscriptShowCmd(deps *Dependencies) *cobra.Command {
 	return &cobra.Command{
 		Use:   "show <meeting_id>",
 		Short: "Show the transcript for a meeting",
-		Long:  "Display the full transcript with speaker names and timestamps.",
+		Long:  "Display the full transcript with speaker names and timestamps, and agent notes after the utterances they are anchored to.",
 		Example: "  acai transcript show meeting-001\n  acai transcript show meeting-001 --format json",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -47,10 +50,16 @@ func newTranscriptShowCmd(deps *Dependencies) *cobra.Command {
 			case "json":
 				return printJSON(deps, out.Transcript)
 			default:
-				for _, u := range out.Transcript.Utterances() {
-					ts := u.Timestamp().Format("15:04:05")
-					_, _ = fmt.Fprintf(deps.Out, "[%s] %s: %s\n", ts, u.Speaker(), u.Text())
+				// Agent notes are shown after the utterances they are anchored to
+				var notes []*annotation.AgentNote
+				if deps.ListNotes != nil {
+					listed, err := deps.ListNotes.Execute(cmd.Context(), annotationapp.ListNotesInput{MeetingID: args[0]})
+					if err != nil {
+						return fmt.Errorf("failed to list notes: %w", err)
+					}
+					notes = listed.Notes
 				}
+				_, _ = fmt.Fprint(deps.Out, exportapp.TranscriptText(out.Transcript, notes))
 				return nil
 			}
 		},
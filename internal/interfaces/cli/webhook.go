@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
+	"github.com/spf13/cobra"
+)
+
+func newWebhookCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Manage outbound webhook subscriptions",
+		Long: `Subscribe HTTP endpoints to domain events. Deliveries are queued in the
+outbox, signed with HMAC-SHA256 (X-Acai-Signature: sha256=<hex>), and retried
+by the outbox relay.`,
+	}
+
+	subs := &cobra.Command{
+		Use:   "subscriptions",
+		Short: "Add, list, test, and remove webhook subscriptions",
+	}
+	subs.AddCommand(
+		newWebhookAddCmd(deps),
+		newWebhookListCmd(deps),
+		newWebhookTestCmd(deps),
+		newWebhookRemoveCmd(deps),
+	)
+
+	cmd.AddCommand(subs)
+	return cmd
+}
+
+type webhookSubscriptionJSON struct {
+	ID           string   `json:"id"`
+	URL          string   `json:"url"`
+	EventTypes   []string `json:"event_types"`
+	CreatedAt    string   `json:"created_at"`
+	Secret       string   `json:"secret,omitempty"`
+	LastStatus   int      `json:"last_status,omitempty"`
+	LastError    string   `json:"last_error,omitempty"`
+	LastDelivery string   `json:"last_delivery,omitempty"`
+}
+
+func newWebhookAddCmd(deps *Dependencies) *cobra.Command {
+	var (
+		url    string
+		events []string
+		secret string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Subscribe a URL to events",
+		Long: `Subscribe a URL to one or more event types. Types may be exact
+("note.added"), a prefix wildcard ("action_item.*"), or "*" for all events.
+
+A signing secret is generated unless --secret is given. It is shown only once.`,
+		Example: "  acai webhook subscriptions add --url https://example.com/hooks --events 'action_item.*,note.added'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.WebhookSubscriptions == nil {
+				return errLocalDBRequired
+			}
+			if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+				return fmt.Errorf("--url must be an http(s) URL")
+			}
+			var types []string
+			for _, t := range events {
+				if t = strings.TrimSpace(t); t != "" {
+					types = append(types, t)
+				}
+			}
+			if len(types) == 0 {
+				return fmt.Errorf("--events is required")
+			}
+			if secret == "" {
+				secret = webhook.NewSecret()
+			}
+
+			sub := webhook.Subscription{
+				ID:         webhook.NewSubscriptionID(),
+				URL:        url,
+				EventTypes: types,
+				Secret:     secret,
+				CreatedAt:  time.Now().UTC(),
+			}
+			if err := deps.WebhookSubscriptions.Add(cmd.Context(), sub); err != nil {
+				return fmt.Errorf("failed to add subscription: %w", err)
+			}
+
+			switch flagFormat {
+			case "json":
+				return printJSON(deps, webhookSubscriptionJSON{
+					ID:         sub.ID,
+					URL:        sub.URL,
+					EventTypes: sub.EventTypes,
+					CreatedAt:  sub.CreatedAt.Format(time.RFC3339),
+					Secret:     sub.Secret,
+				})
+			default:
+				_, _ = fmt.Fprintf(deps.Out, "Subscription %s created.\n", sub.ID)
+				_, _ = fmt.Fprintf(deps.Out, "Signing secret (shown once): %s\n", sub.Secret)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&url, "url", "", "Endpoint URL (required)")
+	cmd.Flags().StringSliceVar(&events, "events", nil, "Comma-separated event types (required)")
+	cmd.Flags().StringVar(&secret, "secret", "", "Signing secret (generated if empty)")
+	_ = cmd.MarkFlagRequired("url")
+	_ = cmd.MarkFlagRequired("events")
+	return cmd
+}
+
+func newWebhookListCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List webhook subscriptions and their last delivery",
+		Example: "  acai webhook subscriptions list\n  acai webhook subscriptions list --format json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.WebhookSubscriptions == nil {
+				return errLocalDBRequired
+			}
+			subs, err := deps.WebhookSubscriptions.List(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list subscriptions: %w", err)
+			}
+
+			result := make([]webhookSubscriptionJSON, len(subs))
+			for i, sub := range subs {
+				result[i] = webhookSubscriptionJSON{
+					ID:         sub.ID,
+					URL:        sub.URL,
+					EventTypes: sub.EventTypes,
+					CreatedAt:  sub.CreatedAt.Format(time.RFC3339),
+				}
+				last, err := deps.WebhookSubscriptions.ListAttempts(cmd.Context(), sub.ID, 1)
+				if err != nil {
+					return fmt.Errorf("failed to load deliveries for %s: %w", sub.ID, err)
+				}
+				if len(last) == 1 {
+					result[i].LastStatus = last[0].StatusCode
+					result[i].LastError = last[0].Error
+					result[i].LastDelivery = last[0].AttemptedAt.Format(time.RFC3339)
+				}
+			}
+
+			switch flagFormat {
+			case "json":
+				return printJSON(deps, result)
+			default:
+				if len(result) == 0 {
+					_, _ = fmt.Fprintln(deps.Out, "No webhook subscriptions.")
+					return nil
+				}
+				w := tabwriter.NewWriter(deps.Out, 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "ID\tURL\tEVENTS\tLAST DELIVERY")
+				for _, s := range result {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.ID, s.URL, strings.Join(s.EventTypes, ","), describeLastDelivery(s))
+				}
+				return w.Flush()
+			}
+		},
+	}
+}
+
+func describeLastDelivery(s webhookSubscriptionJSON) string {
+	switch {
+	case s.LastDelivery == "":
+		return "-"
+	case s.LastStatus == 0:
+		return fmt.Sprintf("error (%s)", s.LastDelivery)
+	default:
+		return fmt.Sprintf("%d (%s)", s.LastStatus, s.LastDelivery)
+	}
+}
+
+func newWebhookTestCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:     "test <subscription_id>",
+		Short:   "Send a signed test event to a subscription",
+		Long:    "Send a synthetic \"webhook.test\" event immediately, bypassing the outbox, and report the response.",
+		Example: "  acai webhook subscriptions test sub_1a2b3c4d5e6f7a8b",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.WebhookSubscriptions == nil || deps.WebhookSink == nil {
+				return errLocalDBRequired
+			}
+			sub, err := deps.WebhookSubscriptions.Get(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get subscription: %w", err)
+			}
+
+			attempt, err := deps.WebhookSink.SendTest(cmd.Context(), *sub)
+			if err != nil {
+				return fmt.Errorf("failed to send test event: %w", err)
+			}
+
+			if flagFormat == "json" {
+				return printJSON(deps, map[string]any{
+					"subscription_id": sub.ID,
+					"delivery_id":     attempt.DeliveryID,
+					"status_code":     attempt.StatusCode,
+					"error":           attempt.Error,
+					"duration_ms":     attempt.Duration.Milliseconds(),
+					"succeeded":       attempt.Succeeded(),
+				})
+			}
+			if attempt.StatusCode == 0 {
+				return fmt.Errorf("test delivery failed: %s", attempt.Error)
+			}
+			_, _ = fmt.Fprintf(deps.Out, "%s responded %d in %s\n", sub.URL, attempt.StatusCode, attempt.Duration.Round(time.Millisecond))
+			if !attempt.Succeeded() {
+				return fmt.Errorf("test delivery rejected with status %d", attempt.StatusCode)
+			}
+			return nil
+		},
+	}
+}
+
+func newWebhookRemoveCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <subscription_id>",
+		Short:   "Remove a webhook subscription",
+		Long:    "Remove a subscription. Queued deliveries for it are dead-lettered by the relay.",
+		Example: "  acai webhook subscriptions remove sub_1a2b3c4d5e6f7a8b",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.WebhookSubscriptions == nil {
+				return errLocalDBRequired
+			}
+			if err := deps.WebhookSubscriptions.Remove(cmd.Context(), args[0]); err != nil {
+				return fmt.Errorf("failed to remove subscription: %w", err)
+			}
+			_, _ = fmt.Fprintf(deps.Out, "Subscription %s removed.\n", args[0])
+			return nil
+		},
+	}
+}
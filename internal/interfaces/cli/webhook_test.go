@@ -0,0 +1,114 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/acai/internal/infrastructure/localstore"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/webhook"
+	"github.com/felixgeelhaar/acai/internal/interfaces/cli"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func webhookDeps(t *testing.T) (*cli.Dependencies, *webhook.SQLiteRepository) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	if err := localstore.InitSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+
+	repo := webhook.NewSQLiteRepository(db)
+	deps := testDeps(t)
+	deps.WebhookSubscriptions = repo
+	deps.WebhookSink = webhook.NewSink(repo, nil)
+	return deps, repo
+}
+
+func TestWebhookAddCmd_GeneratesSecret(t *testing.T) {
+	deps, repo := webhookDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"webhook", "subscriptions", "add", "--url", "https://example.com/hook", "--events", "note.added,action_item.*"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	subs, err := repo.List(context.Background())
+	if err != nil || len(subs) != 1 {
+		t.Fatalf("got %v (%v), want 1 subscription", subs, err)
+	}
+	if len(subs[0].EventTypes) != 2 || !strings.HasPrefix(subs[0].Secret, "whsec_") {
+		t.Errorf("unexpected subscription %+v", subs[0])
+	}
+	if output := deps.Out.(*bytes.Buffer).String(); !strings.Contains(output, subs[0].Secret) {
+		t.Errorf("expected secret to be shown once, got: %q", output)
+	}
+}
+
+func TestWebhookAddCmd_RejectsNonHTTPURL(t *testing.T) {
+	deps, _ := webhookDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"webhook", "subscriptions", "add", "--url", "ftp://example.com", "--events", "*"})
+	if err := root.Execute(); err == nil {
+		t.Fatal("expected error for non-http url")
+	}
+}
+
+func TestWebhookTestAndListCmd(t *testing.T) {
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(webhook.SignatureHeader)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	deps, repo := webhookDeps(t)
+	sub := webhook.Subscription{ID: "sub_1", URL: srv.URL, EventTypes: []string{"*"}, Secret: "s3cret"}
+	if err := repo.Add(context.Background(), sub); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	root := cli.NewRootCmd(deps)
+	root.SetArgs([]string{"webhook", "subscriptions", "test", "sub_1"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("test: %v", err)
+	}
+	if !strings.HasPrefix(signature, "sha256=") {
+		t.Errorf("expected signed request, got signature %q", signature)
+	}
+	if output := deps.Out.(*bytes.Buffer).String(); !strings.Contains(output, "responded 202") {
+		t.Errorf("expected status in output, got: %q", output)
+	}
+
+	deps.Out.(*bytes.Buffer).Reset()
+	root = cli.NewRootCmd(deps)
+	root.SetArgs([]string{"webhook", "subscriptions", "list"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	output := deps.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "sub_1") || !strings.Contains(output, "202 (") {
+		t.Errorf("expected last delivery status in list, got: %q", output)
+	}
+}
+
+func TestWebhookCmd_RequiresLocalDB(t *testing.T) {
+	deps := testDeps(t)
+	root := cli.NewRootCmd(deps)
+
+	root.SetArgs([]string{"webhook", "subscriptions", "list"})
+	if err := root.Execute(); err == nil {
+		t.Fatal("expected error without local storage")
+	}
+}
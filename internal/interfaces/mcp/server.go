@@ -5,6 +5,7 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -15,10 +16,12 @@ import (
 
 	annotationapp "github.com/felixgeelhaar/acai/internal/application/annotation"
 	embeddingapp "github.com/felixgeelhaar/acai/internal/application/embedding"
+	exportapp "github.com/felixgeelhaar/acai/internal/application/export"
 	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
 	"github.com/felixgeelhaar/acai/internal/domain/annotation"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
 	domainpolicy "github.com/felixgeelhaar/acai/internal/domain/policy"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
 	policy "github.com/felixgeelhaar/acai/internal/infrastructure/policy"
 )
 
@@ -35,10 +38,14 @@ type ServerOptions struct {
 	GetTranscript     *meetingapp.GetTranscript
 	SearchTranscripts *meetingapp.SearchTranscripts
 	GetActionItems    *meetingapp.GetActionItems
+	ListActionItems   *meetingapp.ListActionItems
+	ExportActionItems *exportapp.ExportActionItems
 	GetMeetingStats   *meetingapp.GetMeetingStats
+	GetMeetingHistory *meetingapp.GetMeetingHistory
 
 	// Write use cases
 	AddNote            *annotationapp.AddNote
+	UpdateNote         *annotationapp.UpdateNote
 	ListNotes          *annotationapp.ListNotes
 	DeleteNote         *annotationapp.DeleteNote
 	CompleteActionItem *meetingapp.CompleteActionItem
@@ -47,6 +54,12 @@ type ServerOptions struct {
 	// Embedding export
 	ExportEmbeddings *embeddingapp.ExportEmbeddings
 
+	// Summary generation by a language model (optional)
+	GenerateSummary *meetingapp.GenerateSummary
+
+	// Outbox inspection (optional)
+	Outbox outbox.Inspector
+
 	// Policy engine (optional)
 	PolicyEngine *policy.Engine
 }
@@ -61,10 +74,14 @@ type Server struct {
 	getTranscript     *meetingapp.GetTranscript
 	searchTranscripts *meetingapp.SearchTranscripts
 	getActionItems    *meetingapp.GetActionItems
+	listActionItems   *meetingapp.ListActionItems
+	exportActionItems *exportapp.ExportActionItems
 	getMeetingStats   *meetingapp.GetMeetingStats
+	getMeetingHistory *meetingapp.GetMeetingHistory
 
 	// Write use cases
 	addNote            *annotationapp.AddNote
+	updateNote         *annotationapp.UpdateNote
 	listNotes          *annotationapp.ListNotes
 	deleteNote         *annotationapp.DeleteNote
 	completeActionItem *meetingapp.CompleteActionItem
@@ -73,6 +90,12 @@ type Server struct {
 	// Embedding export
 	exportEmbeddings *embeddingapp.ExportEmbeddings
 
+	// Summary generation by a language model (optional)
+	generateSummary *meetingapp.GenerateSummary
+
+	// Outbox inspection (optional)
+	outbox outbox.Inspector
+
 	// Policy engine (optional)
 	policyEngine *policy.Engine
 
@@ -90,13 +113,19 @@ func NewServer(name, version string, opts ServerOptions) *Server {
 		getTranscript:      opts.GetTranscript,
 		searchTranscripts:  opts.SearchTranscripts,
 		getActionItems:     opts.GetActionItems,
+		listActionItems:    opts.ListActionItems,
+		exportActionItems:  opts.ExportActionItems,
 		getMeetingStats:    opts.GetMeetingStats,
+		getMeetingHistory:  opts.GetMeetingHistory,
 		addNote:            opts.AddNote,
+		updateNote:         opts.UpdateNote,
 		listNotes:          opts.ListNotes,
 		deleteNote:         opts.DeleteNote,
 		completeActionItem: opts.CompleteActionItem,
 		updateActionItem:   opts.UpdateActionItem,
 		exportEmbeddings:   opts.ExportEmbeddings,
+		generateSummary:    opts.GenerateSummary,
+		outbox:             opts.Outbox,
 		policyEngine:       opts.PolicyEngine,
 	}
 
@@ -186,7 +215,7 @@ func (s *Server) registerTools(srv *mcpfw.Server) {
 		Handler(s.HandleGetMeeting)
 
 	srv.Tool("get_transcript").
-		Description("Get the transcript for a meeting").
+		Description("Get the transcript for a meeting, with agent notes attached to the utterances they are anchored to").
 		Handler(s.HandleGetTranscript)
 
 	// NOTE: SearchTranscripts currently falls back to local filtering because
@@ -199,21 +228,47 @@ func (s *Server) registerTools(srv *mcpfw.Server) {
 		Description("Get action items from a meeting").
 		Handler(s.HandleGetActionItems)
 
+	if s.listActionItems != nil {
+		srv.Tool("action_items_inbox").
+			Description("List action items across meetings, filtered by owner (\"me\" for the configured user), status, due date, meeting date range, and tag").
+			Handler(s.HandleActionItemsInbox)
+	}
+
+	if s.exportActionItems != nil {
+		srv.Tool("export_action_items").
+			Description("Export action items across meetings as an iCalendar (VTODO) file or CSV for external task trackers").
+			Handler(s.HandleExportActionItems)
+	}
+
 	srv.Tool("meeting_stats").
 		Description("Get aggregated meeting statistics with visual dashboard").
 		UIResource("ui://meeting-stats").
 		Handler(s.HandleMeetingStats)
 
+	if s.getMeetingHistory != nil {
+		srv.Tool("meeting_history").
+			Description("Get a meeting's activity timeline: creation, transcript and summary updates, notes, and action item changes").
+			Handler(s.HandleMeetingHistory)
+	}
+
 	// Write tools
 	if s.addNote != nil {
 		srv.Tool("add_note").
-			Description("Add an agent note to a meeting").
+			Description("Add an agent note to a meeting: optionally typed (insight, risk, decision, question), anchored to a transcript time range or utterance index, or a reply to another note (parent_id). Pass an idempotency_key to make retries safe: a repeated call returns the original note").
 			Handler(s.HandleAddNote)
 	}
+	if s.updateNote != nil {
+		srv.Tool("update_note").
+			Description("Edit an agent note's content. Pass the note's current revision as expected_revision; the edit is rejected if the note changed since. Prior content is kept as revision history").
+			Handler(s.HandleUpdateNote)
+	}
 	if s.listNotes != nil {
 		srv.Tool("list_notes").
 			Description("List agent notes for a meeting").
 			Handler(s.HandleListNotes)
+		srv.Tool("search_notes").
+			Description("Search agent notes across all meetings by full-text query, author, kind and creation date range, newest first, to find what other agents already concluded").
+			Handler(s.HandleSearchNotes)
 	}
 	if s.deleteNote != nil {
 		srv.Tool("delete_note").
@@ -235,6 +290,16 @@ func (s *Server) registerTools(srv *mcpfw.Server) {
 			Description("Export meeting content as chunks for embedding generation (JSONL format)").
 			Handler(s.HandleExportEmbeddings)
 	}
+	if s.generateSummary != nil {
+		srv.Tool("generate_summary").
+			Description("Generate a summary with the configured language model for a meeting Granola has no summary for").
+			Handler(s.HandleGenerateSummary)
+	}
+	if s.outbox != nil {
+		srv.Tool("outbox_status").
+			Description("Summarise outbox entries (pending, failed, synced) per event type").
+			Handler(s.HandleOutboxStatus)
+	}
 }
 
 // --- Resource registration ---
@@ -288,7 +353,10 @@ func (s *Server) registerResources(srv *mcpfw.Server) {
 			if err != nil {
 				return nil, err
 			}
-			result := toTranscriptResult(out.Transcript)
+			result, err := s.transcriptResult(ctx, out.Transcript)
+			if err != nil {
+				return nil, err
+			}
 			data, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("marshal transcript resource: %w", err)
@@ -300,10 +368,32 @@ func (s *Server) registerResources(srv *mcpfw.Server) {
 			}, nil
 		})
 
+	if s.getMeetingHistory != nil {
+		srv.Resource("history://{meeting_id}").
+			Name("Meeting History").
+			Description("Activity timeline of recorded events for a meeting").
+			MimeType("application/json").
+			Handler(func(ctx context.Context, uri string, params map[string]string) (*mcpfw.ResourceContent, error) {
+				results, err := s.HandleMeetingHistory(ctx, MeetingHistoryToolInput{MeetingID: params["meeting_id"]})
+				if err != nil {
+					return nil, err
+				}
+				data, err := json.Marshal(results)
+				if err != nil {
+					return nil, fmt.Errorf("marshal history resource: %w", err)
+				}
+				return &mcpfw.ResourceContent{
+					URI:      uri,
+					MimeType: "application/json",
+					Text:     string(data),
+				}, nil
+			})
+	}
+
 	if s.listNotes != nil {
 		srv.Resource("note://{meeting_id}").
 			Name("Agent Notes").
-			Description("Agent notes for a meeting").
+			Description("Agent notes for a meeting, with kind, transcript anchor and parent note ID for replies").
 			MimeType("application/json").
 			Handler(func(ctx context.Context, uri string, params map[string]string) (*mcpfw.ResourceContent, error) {
 				meetingID := params["meeting_id"]
@@ -313,11 +403,7 @@ func (s *Server) registerResources(srv *mcpfw.Server) {
 				if err != nil {
 					return nil, err
 				}
-				results := make([]NoteResult, len(out.Notes))
-				for i, n := range out.Notes {
-					results[i] = toNoteResult(n)
-				}
-				data, err := json.Marshal(results)
+				data, err := json.Marshal(toNoteResults(out))
 				if err != nil {
 					return nil, fmt.Errorf("marshal notes resource: %w", err)
 				}
@@ -362,6 +448,35 @@ type GetActionItemsToolInput struct {
 	MeetingID string `json:"meeting_id"`
 }
 
+type ActionItemsInboxToolInput struct {
+	Owner     *string `json:"owner,omitempty"` // substring match, or "me" for the configured user
+	Status    *string `json:"status,omitempty"`
+	DueBefore *string `json:"due_before,omitempty"`
+	DueAfter  *string `json:"due_after,omitempty"`
+	Overdue   bool    `json:"overdue,omitempty"`
+	Since     *string `json:"since,omitempty"`
+	Until     *string `json:"until,omitempty"`
+	Tag       *string `json:"tag,omitempty"`
+	Limit     *int    `json:"limit,omitempty"`
+}
+
+type ExportActionItemsToolInput struct {
+	Format    *string `json:"format,omitempty"` // "ics" (default) or "csv"
+	Owner     *string `json:"owner,omitempty"`  // substring match, or "me" for the configured user
+	Status    *string `json:"status,omitempty"`
+	DueBefore *string `json:"due_before,omitempty"`
+	DueAfter  *string `json:"due_after,omitempty"`
+	Overdue   bool    `json:"overdue,omitempty"`
+	Since     *string `json:"since,omitempty"`
+	Until     *string `json:"until,omitempty"`
+	Tag       *string `json:"tag,omitempty"`
+}
+
+type MeetingHistoryToolInput struct {
+	MeetingID string `json:"meeting_id"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
 type MeetingStatsToolInput struct {
 	Since *string `json:"since,omitempty"`
 	Until *string `json:"until,omitempty"`
@@ -397,6 +512,8 @@ type SummaryResult struct {
 type TranscriptResult struct {
 	MeetingID  string            `json:"meeting_id"`
 	Utterances []UtteranceResult `json:"utterances"`
+	// Notes are the agent notes anchored to no utterance.
+	Notes []NoteResult `json:"notes,omitempty"`
 }
 
 type UtteranceResult struct {
@@ -404,6 +521,9 @@ type UtteranceResult struct {
 	Text       string  `json:"text"`
 	Timestamp  string  `json:"timestamp"`
 	Confidence float64 `json:"confidence"`
+	// Notes are the agent notes anchored to the utterance, each followed
+	// by its replies.
+	Notes []NoteResult `json:"notes,omitempty"`
 }
 
 type ActionItemResult struct {
@@ -414,6 +534,26 @@ type ActionItemResult struct {
 	Completed bool    `json:"completed"`
 }
 
+type InboxItemResult struct {
+	ActionItemResult
+	MeetingID       string `json:"meeting_id"`
+	MeetingTitle    string `json:"meeting_title"`
+	MeetingDatetime string `json:"meeting_datetime"`
+}
+
+type ExportActionItemsResult struct {
+	Format  string `json:"format"`
+	Count   int    `json:"count"`
+	Content string `json:"content"`
+}
+
+type HistoryItemResult struct {
+	EventID     string `json:"event_id"`
+	Type        string `json:"type"`
+	OccurredAt  string `json:"occurred_at"`
+	Description string `json:"description"`
+}
+
 type MeetingStatsResult struct {
 	GeneratedAt          string                              `json:"generated_at"`
 	TotalMeetings        int                                 `json:"total_meetings"`
@@ -491,10 +631,34 @@ func (s *Server) HandleGetTranscript(ctx context.Context, input GetTranscriptToo
 		return nil, err
 	}
 
-	result := toTranscriptResult(out.Transcript)
+	result, err := s.transcriptResult(ctx, out.Transcript)
+	if err != nil {
+		return nil, err
+	}
 	return &result, nil
 }
 
+// transcriptResult converts a transcript and attaches the meeting's agent
+// notes: to the utterances they are anchored to, the rest to the transcript.
+func (s *Server) transcriptResult(ctx context.Context, t *domain.Transcript) (TranscriptResult, error) {
+	result := toTranscriptResult(t)
+	if s.listNotes == nil {
+		return result, nil
+	}
+	out, err := s.listNotes.Execute(ctx, annotationapp.ListNotesInput{MeetingID: string(t.MeetingID())})
+	if err != nil {
+		return TranscriptResult{}, err
+	}
+	threads := exportapp.PlaceNotes(t, out.Notes)
+	for i, notes := range threads.Inline {
+		result.Utterances[i].Notes = toNoteResults(&annotationapp.ListNotesOutput{Notes: notes, PushConflicts: out.PushConflicts})
+	}
+	if len(threads.Unplaced) > 0 {
+		result.Notes = toNoteResults(&annotationapp.ListNotesOutput{Notes: threads.Unplaced, PushConflicts: out.PushConflicts})
+	}
+	return result, nil
+}
+
 func (s *Server) HandleSearchTranscripts(ctx context.Context, input SearchTranscriptsToolInput) ([]MeetingResult, error) {
 	appInput := meetingapp.SearchTranscriptsInput{
 		Query: input.Query,
@@ -537,6 +701,124 @@ func (s *Server) HandleGetActionItems(ctx context.Context, input GetActionItemsT
 	return results, nil
 }
 
+func (s *Server) HandleActionItemsInbox(ctx context.Context, input ActionItemsInboxToolInput) ([]InboxItemResult, error) {
+	if s.listActionItems == nil {
+		return nil, fmt.Errorf("tool not available: action item inbox is not configured")
+	}
+
+	appInput, err := toListActionItemsInput(input)
+	if err != nil {
+		return nil, err
+	}
+	appInput.Limit = 50
+	if input.Limit != nil {
+		appInput.Limit = *input.Limit
+	}
+
+	out, err := s.listActionItems.Execute(ctx, appInput)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]InboxItemResult, len(out.Items))
+	for i, entry := range out.Items {
+		results[i] = InboxItemResult{
+			ActionItemResult: toActionItemResult(entry.Item),
+			MeetingID:        string(entry.Item.MeetingID()),
+			MeetingTitle:     entry.MeetingTitle,
+			MeetingDatetime:  entry.MeetingDatetime.Format(time.RFC3339),
+		}
+	}
+	return results, nil
+}
+
+func (s *Server) HandleExportActionItems(ctx context.Context, input ExportActionItemsToolInput) (*ExportActionItemsResult, error) {
+	if s.exportActionItems == nil {
+		return nil, fmt.Errorf("tool not available: action item export is not configured")
+	}
+
+	query, err := toListActionItemsInput(ActionItemsInboxToolInput{
+		Owner:     input.Owner,
+		Status:    input.Status,
+		DueBefore: input.DueBefore,
+		DueAfter:  input.DueAfter,
+		Overdue:   input.Overdue,
+		Since:     input.Since,
+		Until:     input.Until,
+		Tag:       input.Tag,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	appInput := exportapp.ExportActionItemsInput{Query: query}
+	if input.Format != nil {
+		appInput.Format = exportapp.Format(*input.Format)
+	}
+
+	out, err := s.exportActionItems.Execute(ctx, appInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportActionItemsResult{
+		Format:  string(out.Format),
+		Count:   out.Count,
+		Content: out.Content,
+	}, nil
+}
+
+// toListActionItemsInput converts inbox tool filters into the application query.
+// Limit is left to the caller.
+func toListActionItemsInput(input ActionItemsInboxToolInput) (meetingapp.ListActionItemsInput, error) {
+	appInput := meetingapp.ListActionItemsInput{
+		Owner:   input.Owner,
+		Overdue: input.Overdue,
+		Tag:     input.Tag,
+	}
+	if input.Status != nil {
+		appInput.Status = meetingapp.ActionItemStatus(*input.Status)
+	}
+
+	var err error
+	if appInput.DueBefore, err = parseOptionalTime("due_before", input.DueBefore); err != nil {
+		return appInput, err
+	}
+	if appInput.DueAfter, err = parseOptionalTime("due_after", input.DueAfter); err != nil {
+		return appInput, err
+	}
+	if appInput.Since, err = parseOptionalTime("since", input.Since); err != nil {
+		return appInput, err
+	}
+	if appInput.Until, err = parseOptionalTime("until", input.Until); err != nil {
+		return appInput, err
+	}
+	return appInput, nil
+}
+
+func (s *Server) HandleMeetingHistory(ctx context.Context, input MeetingHistoryToolInput) ([]HistoryItemResult, error) {
+	if s.getMeetingHistory == nil {
+		return nil, errToolNotAvailable
+	}
+	out, err := s.getMeetingHistory.Execute(ctx, meetingapp.GetMeetingHistoryInput{
+		MeetingID: domain.MeetingID(input.MeetingID),
+		Limit:     input.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]HistoryItemResult, len(out.Items))
+	for i, item := range out.Items {
+		results[i] = HistoryItemResult{
+			EventID:     item.EventID,
+			Type:        item.Type,
+			OccurredAt:  item.OccurredAt.Format(time.RFC3339),
+			Description: item.Description,
+		}
+	}
+	return results, nil
+}
+
 func (s *Server) HandleMeetingStats(ctx context.Context, input MeetingStatsToolInput) (*MeetingStatsResult, error) {
 	appInput := meetingapp.GetMeetingStatsInput{}
 
@@ -641,6 +923,28 @@ func (s *Server) HandleToolJSON(ctx context.Context, tool string, rawInput json.
 		}
 		return json.Marshal(result)
 
+	case "action_items_inbox":
+		var input ActionItemsInboxToolInput
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		result, err := s.HandleActionItemsInbox(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+
+	case "export_action_items":
+		var input ExportActionItemsToolInput
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		result, err := s.HandleExportActionItems(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+
 	case "meeting_stats":
 		var input MeetingStatsToolInput
 		if err := json.Unmarshal(rawInput, &input); err != nil {
@@ -663,6 +967,17 @@ func (s *Server) HandleToolJSON(ctx context.Context, tool string, rawInput json.
 		}
 		return json.Marshal(result)
 
+	case "update_note":
+		var input UpdateNoteToolInput
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		result, err := s.HandleUpdateNote(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+
 	case "list_notes":
 		var input ListNotesToolInput
 		if err := json.Unmarshal(rawInput, &input); err != nil {
@@ -674,6 +989,17 @@ func (s *Server) HandleToolJSON(ctx context.Context, tool string, rawInput json.
 		}
 		return json.Marshal(result)
 
+	case "search_notes":
+		var input SearchNotesToolInput
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		result, err := s.HandleSearchNotes(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+
 	case "delete_note":
 		var input DeleteNoteToolInput
 		if err := json.Unmarshal(rawInput, &input); err != nil {
@@ -707,6 +1033,17 @@ func (s *Server) HandleToolJSON(ctx context.Context, tool string, rawInput json.
 		}
 		return json.Marshal(result)
 
+	case "meeting_history":
+		var input MeetingHistoryToolInput
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		result, err := s.HandleMeetingHistory(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+
 	case "export_embeddings":
 		var input ExportEmbeddingsToolInput
 		if err := json.Unmarshal(rawInput, &input); err != nil {
@@ -718,6 +1055,28 @@ func (s *Server) HandleToolJSON(ctx context.Context, tool string, rawInput json.
 		}
 		return json.Marshal(result)
 
+	case "generate_summary":
+		var input GenerateSummaryToolInput
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		result, err := s.HandleGenerateSummary(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+
+	case "outbox_status":
+		var input OutboxStatusToolInput
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		result, err := s.HandleOutboxStatus(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+
 	default:
 		return nil, fmt.Errorf("unknown tool: %s", tool)
 	}
@@ -794,6 +1153,18 @@ func toActionItemResult(item *domain.ActionItem) ActionItemResult {
 	return r
 }
 
+// parseOptionalTime parses an optional RFC3339 tool input field.
+func parseOptionalTime(name string, raw *string) (*time.Time, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' date: %w", name, err)
+	}
+	return &t, nil
+}
+
 // --- Policy Helpers ---
 
 // extractMeetingContext pulls meeting metadata from tool input for policy evaluation.
@@ -832,18 +1203,64 @@ type ExportEmbeddingsResult struct {
 	Format     string `json:"format"`
 }
 
+// --- Summary Generation Tool Input Type ---
+
+type GenerateSummaryToolInput struct {
+	MeetingID string `json:"meeting_id"`
+	Strategy  string `json:"strategy,omitempty"`   // chunking: speaker_turn (default), time_window, token_limit
+	MaxTokens int    `json:"max_tokens,omitempty"` // per prompt to the model
+}
+
+type GenerateSummaryResult struct {
+	MeetingID  string        `json:"meeting_id"`
+	Summary    SummaryResult `json:"summary"`
+	Model      string        `json:"model"`
+	ChunkCount int           `json:"chunk_count"`
+}
+
 // --- Write Tool Input Types ---
 
 type AddNoteToolInput struct {
-	MeetingID string `json:"meeting_id"`
+	MeetingID string `json:"meeting_id,omitempty"` // optional for replies
 	Author    string `json:"author"`
 	Content   string `json:"content"`
+	Kind      string `json:"kind,omitempty"` // note (default), insight, risk, decision, question
+
+	// Optional transcript anchor: an RFC3339 time range of utterance
+	// timestamps, or the index of one utterance in get_transcript.
+	AnchorStart    *string `json:"anchor_start,omitempty"`
+	AnchorEnd      *string `json:"anchor_end,omitempty"`
+	UtteranceIndex *int    `json:"utterance_index,omitempty"`
+
+	ParentID string `json:"parent_id,omitempty"` // reply to this note
+
+	// Optional client-chosen key; retrying with the same key returns the
+	// note created first instead of adding a duplicate.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+type UpdateNoteToolInput struct {
+	NoteID           string `json:"note_id"`
+	Author           string `json:"author"`
+	Content          string `json:"content"`
+	ExpectedRevision int    `json:"expected_revision"`
 }
 
 type ListNotesToolInput struct {
 	MeetingID string `json:"meeting_id"`
 }
 
+type SearchNotesToolInput struct {
+	Query     string  `json:"query,omitempty"` // every word must occur in the note
+	MeetingID string  `json:"meeting_id,omitempty"`
+	Author    string  `json:"author,omitempty"`
+	Kind      string  `json:"kind,omitempty"`
+	Since     *string `json:"since,omitempty"` // RFC3339, on note creation time
+	Until     *string `json:"until,omitempty"`
+	Limit     *int    `json:"limit,omitempty"`
+	Offset    *int    `json:"offset,omitempty"`
+}
+
 type DeleteNoteToolInput struct {
 	NoteID string `json:"note_id"`
 }
@@ -859,24 +1276,67 @@ type UpdateActionItemToolInput struct {
 	Text         string `json:"text"`
 }
 
+type OutboxStatusToolInput struct {
+	EventType *string `json:"event_type,omitempty"`
+}
+
 // --- Write Tool Output Types ---
 
 type NoteResult struct {
-	ID        string `json:"id"`
-	MeetingID string `json:"meeting_id"`
-	Author    string `json:"author"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"created_at"`
+	ID        string            `json:"id"`
+	MeetingID string            `json:"meeting_id"`
+	Author    string            `json:"author"`
+	Content   string            `json:"content"`
+	CreatedAt string            `json:"created_at"`
+	Kind      string            `json:"kind"`
+	Anchor    *NoteAnchorResult `json:"anchor,omitempty"`
+	ParentID  string            `json:"parent_id,omitempty"`
+	Revision  int               `json:"revision"`
+	UpdatedBy string            `json:"updated_by"`
+	UpdatedAt string            `json:"updated_at"`
+	// PushConflictAt is set when a human edit in Granola won over the
+	// note's local edits; Granola keeps the human's version.
+	PushConflictAt string `json:"push_conflict_at,omitempty"`
+}
+
+// NoteAnchorResult is either a time range or an utterance index.
+type NoteAnchorResult struct {
+	Start          string `json:"start,omitempty"`
+	End            string `json:"end,omitempty"`
+	UtteranceIndex *int   `json:"utterance_index,omitempty"`
+}
+
+func toNoteResults(out *annotationapp.ListNotesOutput) []NoteResult {
+	results := make([]NoteResult, len(out.Notes))
+	for i, n := range out.Notes {
+		results[i] = toNoteResult(n)
+		if at, ok := out.PushConflicts[n.ID()]; ok {
+			results[i].PushConflictAt = at.Format(time.RFC3339)
+		}
+	}
+	return results
 }
 
 func toNoteResult(n *annotation.AgentNote) NoteResult {
-	return NoteResult{
+	r := NoteResult{
 		ID:        string(n.ID()),
 		MeetingID: n.MeetingID(),
 		Author:    n.Author(),
 		Content:   n.Content(),
 		CreatedAt: n.CreatedAt().Format(time.RFC3339),
+		Kind:      string(n.Kind()),
+		ParentID:  string(n.ParentID()),
+		Revision:  n.Revision(),
+		UpdatedBy: n.UpdatedBy(),
+		UpdatedAt: n.UpdatedAt().Format(time.RFC3339),
+	}
+	if start, end, ok := n.Anchor().TimeRange(); ok {
+		r.Anchor = &NoteAnchorResult{Start: start.Format(time.RFC3339), End: end.Format(time.RFC3339)}
 	}
+	if index, ok := n.Anchor().UtteranceIndex(); ok {
+		r.Anchor = &NoteAnchorResult{UtteranceIndex: &index}
+	}
+	return r
 }
 
 // errToolNotAvailable is returned when a write tool is invoked but
@@ -889,10 +1349,41 @@ func (s *Server) HandleAddNote(ctx context.Context, input AddNoteToolInput) (*No
 	if s.addNote == nil {
 		return nil, errToolNotAvailable
 	}
+	anchorStart, err := parseOptionalTime("anchor_start", input.AnchorStart)
+	if err != nil {
+		return nil, err
+	}
+	anchorEnd, err := parseOptionalTime("anchor_end", input.AnchorEnd)
+	if err != nil {
+		return nil, err
+	}
 	out, err := s.addNote.Execute(ctx, annotationapp.AddNoteInput{
-		MeetingID: input.MeetingID,
-		Author:    input.Author,
-		Content:   input.Content,
+		MeetingID:      input.MeetingID,
+		Author:         input.Author,
+		Content:        input.Content,
+		Kind:           input.Kind,
+		AnchorStart:    anchorStart,
+		AnchorEnd:      anchorEnd,
+		UtteranceIndex: input.UtteranceIndex,
+		ParentID:       input.ParentID,
+		IdempotencyKey: input.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := toNoteResult(out.Note)
+	return &result, nil
+}
+
+func (s *Server) HandleUpdateNote(ctx context.Context, input UpdateNoteToolInput) (*NoteResult, error) {
+	if s.updateNote == nil {
+		return nil, errToolNotAvailable
+	}
+	out, err := s.updateNote.Execute(ctx, annotationapp.UpdateNoteInput{
+		NoteID:           input.NoteID,
+		Author:           input.Author,
+		Content:          input.Content,
+		ExpectedRevision: input.ExpectedRevision,
 	})
 	if err != nil {
 		return nil, err
@@ -911,11 +1402,42 @@ func (s *Server) HandleListNotes(ctx context.Context, input ListNotesToolInput)
 	if err != nil {
 		return nil, err
 	}
-	results := make([]NoteResult, len(out.Notes))
-	for i, n := range out.Notes {
-		results[i] = toNoteResult(n)
+	return toNoteResults(out), nil
+}
+
+func (s *Server) HandleSearchNotes(ctx context.Context, input SearchNotesToolInput) ([]NoteResult, error) {
+	if s.listNotes == nil {
+		return nil, errToolNotAvailable
 	}
-	return results, nil
+	since, err := parseOptionalTime("since", input.Since)
+	if err != nil {
+		return nil, err
+	}
+	until, err := parseOptionalTime("until", input.Until)
+	if err != nil {
+		return nil, err
+	}
+	appInput := annotationapp.ListNotesInput{
+		MeetingID: input.MeetingID,
+		Author:    input.Author,
+		Kind:      input.Kind,
+		Since:     since,
+		Until:     until,
+		Query:     input.Query,
+		Limit:     20,
+	}
+	if input.Limit != nil {
+		appInput.Limit = *input.Limit
+	}
+	if input.Offset != nil {
+		appInput.Offset = *input.Offset
+	}
+
+	out, err := s.listNotes.Execute(ctx, appInput)
+	if err != nil {
+		return nil, err
+	}
+	return toNoteResults(out), nil
 }
 
 func (s *Server) HandleDeleteNote(ctx context.Context, input DeleteNoteToolInput) (*struct{}, error) {
@@ -986,3 +1508,56 @@ func (s *Server) HandleExportEmbeddings(ctx context.Context, input ExportEmbeddi
 		Format:     "jsonl",
 	}, nil
 }
+
+var errSummaryNotAvailable = errors.New("tool not available: set ACAI_LLM_URL and ACAI_LLM_MODEL to generate summaries")
+
+func (s *Server) HandleGenerateSummary(ctx context.Context, input GenerateSummaryToolInput) (*GenerateSummaryResult, error) {
+	if s.generateSummary == nil {
+		return nil, errSummaryNotAvailable
+	}
+	out, err := s.generateSummary.Execute(ctx, meetingapp.GenerateSummaryInput{
+		MeetingID: domain.MeetingID(input.MeetingID),
+		Strategy:  input.Strategy,
+		MaxTokens: input.MaxTokens,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GenerateSummaryResult{
+		MeetingID:  string(out.Summary.MeetingID()),
+		Summary:    SummaryResult{Content: out.Summary.Content(), Kind: string(out.Summary.Kind())},
+		Model:      out.Model,
+		ChunkCount: out.ChunkCount,
+	}, nil
+}
+
+// --- Outbox Status Tool ---
+
+type OutboxStatusResult struct {
+	Pending int                `json:"pending"`
+	Failed  int                `json:"failed"`
+	Synced  int                `json:"synced"`
+	ByType  []outbox.TypeStats `json:"by_type"`
+}
+
+func (s *Server) HandleOutboxStatus(_ context.Context, input OutboxStatusToolInput) (*OutboxStatusResult, error) {
+	if s.outbox == nil {
+		return nil, errToolNotAvailable
+	}
+	stats, err := s.outbox.Stats()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OutboxStatusResult{ByType: []outbox.TypeStats{}}
+	for _, st := range stats {
+		if input.EventType != nil && *input.EventType != st.EventType {
+			continue
+		}
+		result.Pending += st.Pending
+		result.Failed += st.Failed
+		result.Synced += st.Synced
+		result.ByType = append(result.ByType, st)
+	}
+	return result, nil
+}
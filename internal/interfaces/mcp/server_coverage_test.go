@@ -3,6 +3,7 @@ package mcp_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,7 +11,9 @@ import (
 	"time"
 
 	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
+	annotatn "github.com/felixgeelhaar/acai/internal/domain/annotation"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/llm"
 	mcpiface "github.com/felixgeelhaar/acai/internal/interfaces/mcp"
 )
 
@@ -262,7 +265,7 @@ func TestServer_HandleToolJSON_InvalidJSON_AllTools(t *testing.T) {
 	repo := newMockRepo()
 	srv := newTestServer(repo)
 
-	tools := []string{"list_meetings", "get_meeting", "get_transcript", "search_transcripts", "get_action_items", "meeting_stats", "add_note", "list_notes", "delete_note", "complete_action_item", "update_action_item", "export_embeddings"}
+	tools := []string{"list_meetings", "get_meeting", "get_transcript", "search_transcripts", "get_action_items", "action_items_inbox", "export_action_items", "meeting_stats", "add_note", "update_note", "list_notes", "search_notes", "delete_note", "complete_action_item", "update_action_item", "export_embeddings", "generate_summary", "outbox_status", "meeting_history"}
 	for _, tool := range tools {
 		_, err := srv.HandleToolJSON(context.Background(), tool, json.RawMessage(`{invalid`))
 		if err == nil {
@@ -393,6 +396,66 @@ func TestServer_HandleAddNote(t *testing.T) {
 	}
 }
 
+func TestServer_HandleAddNote_KindAnchorAndReply(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Sprint Planning"))
+	srv := newTestServer(repo)
+
+	start, end := "2026-01-15T10:05:00Z", "2026-01-15T10:07:00Z"
+	risk, err := srv.HandleAddNote(context.Background(), mcpiface.AddNoteToolInput{
+		MeetingID:   "m-1",
+		Author:      "claude",
+		Content:     "Timeline is at risk",
+		Kind:        "risk",
+		AnchorStart: &start,
+		AnchorEnd:   &end,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if risk.Kind != "risk" || risk.Anchor == nil || risk.Anchor.Start != start || risk.Anchor.End != end {
+		t.Errorf("got kind %q, anchor %+v", risk.Kind, risk.Anchor)
+	}
+
+	reply, err := srv.HandleAddNote(context.Background(), mcpiface.AddNoteToolInput{
+		Author:   "gpt",
+		Content:  "Agreed, two weeks behind",
+		ParentID: risk.ID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reply.ParentID != risk.ID || reply.MeetingID != "m-1" || reply.Kind != "note" {
+		t.Errorf("got reply %+v", reply)
+	}
+
+	bad := "yesterday"
+	if _, err := srv.HandleAddNote(context.Background(), mcpiface.AddNoteToolInput{
+		MeetingID: "m-1", Author: "claude", Content: "x", AnchorStart: &bad,
+	}); err == nil {
+		t.Error("expected error for a non-RFC3339 anchor")
+	}
+}
+
+func TestServer_HandleAddNote_IdempotencyKey(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Sprint Planning"))
+	srv := newTestServer(repo)
+
+	input := mcpiface.AddNoteToolInput{MeetingID: "m-1", Author: "claude", Content: "Budget at risk", IdempotencyKey: "call-42"}
+	first, err := srv.HandleAddNote(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	retry, err := srv.HandleAddNote(context.Background(), input)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if retry.ID != first.ID {
+		t.Errorf("retry created note %s, want the original %s", retry.ID, first.ID)
+	}
+}
+
 func TestServer_HandleAddNote_MeetingNotFound(t *testing.T) {
 	repo := newMockRepo()
 	srv := newTestServer(repo)
@@ -449,6 +512,79 @@ func TestServer_HandleListNotes_Empty(t *testing.T) {
 	}
 }
 
+func TestServer_HandleUpdateNote(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Meeting"))
+	srv := newTestServer(repo)
+
+	added, _ := srv.HandleAddNote(context.Background(), mcpiface.AddNoteToolInput{
+		MeetingID: "m-1",
+		Author:    "claude",
+		Content:   "Budget is blocked",
+	})
+	if added.Revision != 1 {
+		t.Fatalf("new note revision = %d, want 1", added.Revision)
+	}
+
+	result, err := srv.HandleUpdateNote(context.Background(), mcpiface.UpdateNoteToolInput{
+		NoteID:           added.ID,
+		Author:           "alice",
+		Content:          "Budget approved on Friday",
+		ExpectedRevision: 1,
+	})
+	if err != nil {
+		t.Fatalf("update note: %v", err)
+	}
+	if result.Content != "Budget approved on Friday" || result.Revision != 2 || result.UpdatedBy != "alice" || result.Author != "claude" {
+		t.Errorf("got %+v", result)
+	}
+
+	_, err = srv.HandleUpdateNote(context.Background(), mcpiface.UpdateNoteToolInput{
+		NoteID:           added.ID,
+		Author:           "bob",
+		Content:          "Stale edit",
+		ExpectedRevision: 1,
+	})
+	if !errors.Is(err, annotatn.ErrRevisionConflict) {
+		t.Errorf("stale update: got %v, want ErrRevisionConflict", err)
+	}
+}
+
+func TestServer_HandleSearchNotes(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Planning"))
+	repo.addMeeting(mustMeeting(t, "m-2", "Review"))
+	srv := newTestServer(repo)
+
+	for _, in := range []mcpiface.AddNoteToolInput{
+		{MeetingID: "m-1", Author: "claude", Content: "Budget is blocked", Kind: "risk"},
+		{MeetingID: "m-2", Author: "gpt", Content: "Budget approved", Kind: "decision"},
+		{MeetingID: "m-2", Author: "claude", Content: "Ship the beta", Kind: "decision"},
+	} {
+		if _, err := srv.HandleAddNote(context.Background(), in); err != nil {
+			t.Fatalf("add note: %v", err)
+		}
+	}
+
+	results, err := srv.HandleSearchNotes(context.Background(), mcpiface.SearchNotesToolInput{Query: "budget"})
+	if err != nil {
+		t.Fatalf("search notes: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("got %d notes across meetings, want 2", len(results))
+	}
+
+	results, _ = srv.HandleSearchNotes(context.Background(), mcpiface.SearchNotesToolInput{Author: "claude", Kind: "decision"})
+	if len(results) != 1 || results[0].Content != "Ship the beta" {
+		t.Errorf("got %+v, want claude's decision only", results)
+	}
+
+	bad := "yesterday"
+	if _, err := srv.HandleSearchNotes(context.Background(), mcpiface.SearchNotesToolInput{Since: &bad}); err == nil {
+		t.Error("expected error for invalid since date")
+	}
+}
+
 func TestServer_HandleDeleteNote(t *testing.T) {
 	repo := newMockRepo()
 	repo.addMeeting(mustMeeting(t, "m-1", "Meeting"))
@@ -610,6 +746,59 @@ func TestServer_HandleToolJSON_ExportEmbeddings(t *testing.T) {
 	}
 }
 
+// memorySummaryStore implements domain.SummaryStore in memory.
+type memorySummaryStore map[domain.MeetingID]domain.Summary
+
+func (m memorySummaryStore) SaveSummary(_ context.Context, s domain.Summary) error {
+	m[s.MeetingID()] = s
+	return nil
+}
+
+func (m memorySummaryStore) FindSummaries(_ context.Context, ids []domain.MeetingID) (map[domain.MeetingID]domain.Summary, error) {
+	found := make(map[domain.MeetingID]domain.Summary)
+	for _, id := range ids {
+		if s, ok := m[id]; ok {
+			found[id] = s
+		}
+	}
+	return found, nil
+}
+
+func TestServer_HandleToolJSON_GenerateSummary(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Meeting"))
+	transcript := domain.NewTranscript("m-1", []domain.Utterance{
+		domain.NewUtterance("Alice", "We ship on Friday", time.Now().UTC(), 0.9),
+	})
+	repo.addTranscript("m-1", &transcript)
+
+	opts, _, _ := testDeps(repo)
+	store := memorySummaryStore{}
+	opts.GenerateSummary = meetingapp.NewGenerateSummary(repo, store, llm.NewFake(), nil)
+	srv := mcpiface.NewServer("acai", "test", opts)
+
+	raw, err := srv.HandleToolJSON(context.Background(), "generate_summary", json.RawMessage(`{"meeting_id":"m-1"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var result mcpiface.GenerateSummaryResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if result.MeetingID != "m-1" || result.Summary.Kind != "generated" || result.Summary.Content != "summary 1" || result.Model != "fake" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if _, ok := store["m-1"]; !ok {
+		t.Error("summary not stored")
+	}
+
+	// Without a configured model the tool is unavailable.
+	_, err = newTestServer(repo).HandleToolJSON(context.Background(), "generate_summary", json.RawMessage(`{"meeting_id":"m-1"}`))
+	if err == nil {
+		t.Error("expected an error without a language model")
+	}
+}
+
 func TestServer_HandleToolJSON_WriteTools_NilUseCases(t *testing.T) {
 	repo := newMockRepo()
 	repo.addMeeting(mustMeeting(t, "m-1", "Meeting"))
@@ -629,7 +818,9 @@ func TestServer_HandleToolJSON_WriteTools_NilUseCases(t *testing.T) {
 		input string
 	}{
 		{"add_note", `{"meeting_id":"m-1","author":"claude","content":"note"}`},
+		{"update_note", `{"note_id":"n-1","author":"claude","content":"edited","expected_revision":1}`},
 		{"list_notes", `{"meeting_id":"m-1"}`},
+		{"search_notes", `{"query":"budget"}`},
 		{"delete_note", `{"note_id":"n-1"}`},
 		{"complete_action_item", `{"meeting_id":"m-1","action_item_id":"ai-1"}`},
 		{"update_action_item", `{"meeting_id":"m-1","action_item_id":"ai-1","text":"new"}`},
@@ -3,14 +3,18 @@ package mcp_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	annotationapp "github.com/felixgeelhaar/acai/internal/application/annotation"
 	embeddingapp "github.com/felixgeelhaar/acai/internal/application/embedding"
+	exportapp "github.com/felixgeelhaar/acai/internal/application/export"
 	meetingapp "github.com/felixgeelhaar/acai/internal/application/meeting"
 	annotatn "github.com/felixgeelhaar/acai/internal/domain/annotation"
 	domain "github.com/felixgeelhaar/acai/internal/domain/meeting"
+	"github.com/felixgeelhaar/acai/internal/infrastructure/outbox"
 	mcpiface "github.com/felixgeelhaar/acai/internal/interfaces/mcp"
 )
 
@@ -85,6 +89,42 @@ func TestServer_HandleGetTranscript(t *testing.T) {
 	}
 }
 
+func TestServer_HandleGetTranscript_AttachesNotes(t *testing.T) {
+	repo := newMockRepo()
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	transcript := domain.NewTranscript("m-1", []domain.Utterance{
+		domain.NewUtterance("Alice", "Let's review the budget.", start, 0.9),
+		domain.NewUtterance("Bob", "We're over by ten percent.", start.Add(time.Minute), 0.9),
+	})
+	repo.addTranscript("m-1", &transcript)
+
+	opts, noteRepo, _ := testDeps(repo)
+	srv := mcpiface.NewServer("acai", "test", opts)
+	risk, _ := annotatn.NewAgentNote("n-1", "m-1", "claude", "Budget overrun")
+	anchor, _ := annotatn.NewUtteranceAnchor(1)
+	risk.AnchorTo(anchor)
+	reply, _ := annotatn.NewAgentNote("n-2", "m-1", "alice", "Finance is aware")
+	_ = reply.ReplyTo(risk)
+	loose, _ := annotatn.NewAgentNote("n-3", "m-1", "claude", "Follow up next week")
+	for _, n := range []*annotatn.AgentNote{risk, reply, loose} {
+		_ = noteRepo.Save(context.Background(), n)
+	}
+
+	result, err := srv.HandleGetTranscript(context.Background(), mcpiface.GetTranscriptToolInput{MeetingID: "m-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Utterances[0].Notes) != 0 {
+		t.Errorf("got notes %+v on the first utterance, want none", result.Utterances[0].Notes)
+	}
+	if got := result.Utterances[1].Notes; len(got) != 2 || got[0].ID != "n-1" || got[1].ParentID != "n-1" {
+		t.Errorf("got notes %+v on the second utterance, want n-1 and its reply", got)
+	}
+	if len(result.Notes) != 1 || result.Notes[0].ID != "n-3" {
+		t.Errorf("got unanchored notes %+v, want n-3", result.Notes)
+	}
+}
+
 func TestServer_HandleGetActionItems(t *testing.T) {
 	repo := newMockRepo()
 	item, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Write report", nil)
@@ -199,6 +239,212 @@ func TestServer_HandleToolJSON_MeetingStats(t *testing.T) {
 	}
 }
 
+func TestServer_HandleActionItemsInbox(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Standup"))
+	past := time.Now().UTC().Add(-24 * time.Hour)
+	overdue, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Ship release", &past)
+	other, _ := domain.NewActionItem("ai-2", "m-1", "Bob", "Write docs", nil)
+	repo.addActionItems("m-1", []*domain.ActionItem{overdue, other})
+
+	srv := newTestServer(repo)
+
+	owner := "alice"
+	status := "open"
+	results, err := srv.HandleActionItemsInbox(context.Background(), mcpiface.ActionItemsInboxToolInput{
+		Owner:   &owner,
+		Status:  &status,
+		Overdue: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d items, want 1", len(results))
+	}
+	if results[0].ID != "ai-1" || results[0].MeetingTitle != "Standup" {
+		t.Errorf("unexpected result %+v", results[0])
+	}
+}
+
+func TestServer_HandleActionItemsInbox_OwnerMe(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Standup"))
+	mine, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Ship release", nil)
+	other, _ := domain.NewActionItem("ai-2", "m-1", "Bob", "Write docs", nil)
+	repo.addActionItems("m-1", []*domain.ActionItem{mine, other})
+
+	opts, _, writeRepo := testDeps(repo)
+	opts.ListActionItems = meetingapp.NewListActionItems(repo, writeRepo, "Alice")
+	srv := mcpiface.NewServer("acai", "test", opts)
+
+	me := "me"
+	results, err := srv.HandleActionItemsInbox(context.Background(), mcpiface.ActionItemsInboxToolInput{Owner: &me})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "ai-1" {
+		t.Errorf("got %+v, want Alice's ai-1 only", results)
+	}
+
+	// Without a configured user, "me" is an error rather than a substring.
+	_, err = newTestServer(repo).HandleActionItemsInbox(context.Background(), mcpiface.ActionItemsInboxToolInput{Owner: &me})
+	if !errors.Is(err, meetingapp.ErrCurrentUserUnknown) {
+		t.Errorf("got error %v, want ErrCurrentUserUnknown", err)
+	}
+}
+
+func TestServer_HandleActionItemsInbox_InvalidDate(t *testing.T) {
+	srv := newTestServer(newMockRepo())
+
+	bad := "tomorrow"
+	_, err := srv.HandleActionItemsInbox(context.Background(), mcpiface.ActionItemsInboxToolInput{DueBefore: &bad})
+	if err == nil {
+		t.Fatal("expected error for invalid due_before date")
+	}
+}
+
+func TestServer_HandleToolJSON_ActionItemsInbox(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Standup"))
+	item, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Ship release", nil)
+	repo.addActionItems("m-1", []*domain.ActionItem{item})
+
+	srv := newTestServer(repo)
+
+	raw, err := srv.HandleToolJSON(context.Background(), "action_items_inbox", json.RawMessage(`{"owner":"Alice"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var results []mcpiface.InboxItemResult
+	if err := json.Unmarshal(raw, &results); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if len(results) != 1 || results[0].MeetingID != "m-1" {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestServer_HandleExportActionItems(t *testing.T) {
+	repo := newMockRepo()
+	repo.addMeeting(mustMeeting(t, "m-1", "Standup"))
+	item, _ := domain.NewActionItem("ai-1", "m-1", "Alice", "Ship release", nil)
+	repo.addActionItems("m-1", []*domain.ActionItem{item})
+
+	srv := newTestServer(repo)
+
+	result, err := srv.HandleExportActionItems(context.Background(), mcpiface.ExportActionItemsToolInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Format != "ics" || result.Count != 1 {
+		t.Errorf("unexpected result format=%q count=%d", result.Format, result.Count)
+	}
+	if !strings.Contains(result.Content, "BEGIN:VTODO") {
+		t.Errorf("expected VTODO in content, got: %q", result.Content)
+	}
+
+	raw, err := srv.HandleToolJSON(context.Background(), "export_action_items", json.RawMessage(`{"format":"csv"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var csvResult mcpiface.ExportActionItemsResult
+	if err := json.Unmarshal(raw, &csvResult); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if !strings.HasPrefix(csvResult.Content, "id,meeting_id") {
+		t.Errorf("expected CSV header, got: %q", csvResult.Content)
+	}
+}
+
+func TestServer_HandleExportActionItems_UnsupportedFormat(t *testing.T) {
+	srv := newTestServer(newMockRepo())
+
+	format := "pdf"
+	_, err := srv.HandleExportActionItems(context.Background(), mcpiface.ExportActionItemsToolInput{Format: &format})
+	if err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
+
+func TestServer_HandleOutboxStatus(t *testing.T) {
+	opts, _, _ := testDeps(newMockRepo())
+	opts.Outbox = &mockOutbox{stats: []outbox.TypeStats{
+		{EventType: "action_item.completed", Failed: 1},
+		{EventType: "note.added", Pending: 2, Synced: 3},
+	}}
+	srv := mcpiface.NewServer("acai", "test", opts)
+
+	result, err := srv.HandleOutboxStatus(context.Background(), mcpiface.OutboxStatusToolInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pending != 2 || result.Failed != 1 || result.Synced != 3 || len(result.ByType) != 2 {
+		t.Errorf("unexpected totals: %+v", result)
+	}
+
+	raw, err := srv.HandleToolJSON(context.Background(), "outbox_status", json.RawMessage(`{"event_type":"note.added"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var filtered mcpiface.OutboxStatusResult
+	if err := json.Unmarshal(raw, &filtered); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if filtered.Failed != 0 || filtered.Pending != 2 || len(filtered.ByType) != 1 {
+		t.Errorf("unexpected filtered result: %+v", filtered)
+	}
+}
+
+func TestServer_HandleOutboxStatus_NotConfigured(t *testing.T) {
+	opts, _, _ := testDeps(newMockRepo())
+	opts.Outbox = nil
+	srv := mcpiface.NewServer("acai", "test", opts)
+
+	if _, err := srv.HandleOutboxStatus(context.Background(), mcpiface.OutboxStatusToolInput{}); err == nil {
+		t.Fatal("expected error when outbox is not configured")
+	}
+}
+
+func TestServer_HandleMeetingHistory(t *testing.T) {
+	opts, _, _ := testDeps(newMockRepo())
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	opts.GetMeetingHistory = meetingapp.NewGetMeetingHistory(&mockEventHistory{entries: []domain.HistoryEntry{
+		{EventID: "evt-1", OccurredAt: now, Event: domain.NewTranscriptUpdatedEvent("m-1", 3)},
+		{EventID: "evt-2", OccurredAt: now, Event: annotatn.NewNoteDeletedEvent("n-1", "m-1")},
+	}})
+	srv := mcpiface.NewServer("acai", "test", opts)
+
+	raw, err := srv.HandleToolJSON(context.Background(), "meeting_history", json.RawMessage(`{"meeting_id":"m-1"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var results []mcpiface.HistoryItemResult
+	if err := json.Unmarshal(raw, &results); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Type != "transcript.updated" || results[0].OccurredAt != "2025-03-01T09:00:00Z" {
+		t.Errorf("unexpected first result %+v", results[0])
+	}
+	if results[1].Description != "Note n-1 deleted" {
+		t.Errorf("unexpected description %q", results[1].Description)
+	}
+}
+
+func TestServer_HandleMeetingHistory_NotConfigured(t *testing.T) {
+	opts, _, _ := testDeps(newMockRepo())
+	opts.GetMeetingHistory = nil
+	srv := mcpiface.NewServer("acai", "test", opts)
+
+	if _, err := srv.HandleMeetingHistory(context.Background(), mcpiface.MeetingHistoryToolInput{MeetingID: "m-1"}); err == nil {
+		t.Fatal("expected error when history is not configured")
+	}
+}
+
 // --- Test Helpers ---
 
 type mockRepo struct {
@@ -279,7 +525,8 @@ func mustMeeting(t *testing.T, id domain.MeetingID, title string) *domain.Meetin
 
 // mockNoteRepo implements annotation.NoteRepository for tests.
 type mockNoteRepo struct {
-	notes map[annotatn.NoteID]*annotatn.AgentNote
+	notes     map[annotatn.NoteID]*annotatn.AgentNote
+	revisions map[annotatn.NoteID][]annotatn.NoteRevision
 }
 
 func newMockNoteRepo() *mockNoteRepo {
@@ -299,6 +546,15 @@ func (m *mockNoteRepo) FindByID(_ context.Context, id annotatn.NoteID) (*annotat
 	return note, nil
 }
 
+func (m *mockNoteRepo) FindByIdempotencyKey(_ context.Context, author, key string) (*annotatn.AgentNote, error) {
+	for _, note := range m.notes {
+		if note.Author() == author && note.IdempotencyKey() == key {
+			return note, nil
+		}
+	}
+	return nil, annotatn.ErrNoteNotFound
+}
+
 func (m *mockNoteRepo) ListByMeeting(_ context.Context, meetingID string) ([]*annotatn.AgentNote, error) {
 	var result []*annotatn.AgentNote
 	for _, note := range m.notes {
@@ -323,6 +579,19 @@ func (m *mockNoteRepo) ListAll(_ context.Context) ([]*annotatn.AgentNote, error)
 	return result, nil
 }
 
+func (m *mockNoteRepo) Search(_ context.Context, filter annotatn.NoteFilter) ([]*annotatn.AgentNote, error) {
+	result := []*annotatn.AgentNote{}
+	for _, note := range m.notes {
+		if (filter.MeetingID == "" || note.MeetingID() == filter.MeetingID) &&
+			(filter.Author == "" || note.Author() == filter.Author) &&
+			(filter.Kind == "" || note.Kind() == filter.Kind) &&
+			strings.Contains(strings.ToLower(note.Content()), strings.ToLower(filter.Query)) {
+			result = append(result, note)
+		}
+	}
+	return result, nil
+}
+
 func (m *mockNoteRepo) Delete(_ context.Context, id annotatn.NoteID) error {
 	if _, ok := m.notes[id]; !ok {
 		return annotatn.ErrNoteNotFound
@@ -331,6 +600,22 @@ func (m *mockNoteRepo) Delete(_ context.Context, id annotatn.NoteID) error {
 	return nil
 }
 
+func (m *mockNoteRepo) Update(_ context.Context, note *annotatn.AgentNote, prior annotatn.NoteRevision) error {
+	if _, ok := m.notes[note.ID()]; !ok {
+		return annotatn.ErrNoteNotFound
+	}
+	if m.revisions == nil {
+		m.revisions = make(map[annotatn.NoteID][]annotatn.NoteRevision)
+	}
+	m.revisions[note.ID()] = append(m.revisions[note.ID()], prior)
+	m.notes[note.ID()] = note
+	return nil
+}
+
+func (m *mockNoteRepo) ListRevisions(_ context.Context, id annotatn.NoteID) ([]annotatn.NoteRevision, error) {
+	return m.revisions[id], nil
+}
+
 // mockWriteRepo implements domain.WriteRepository for tests.
 type mockWriteRepo struct {
 	items map[domain.ActionItemID]*domain.ActionItem
@@ -374,18 +659,43 @@ func testDeps(repo *mockRepo) (mcpiface.ServerOptions, *mockNoteRepo, *mockWrite
 		GetTranscript:      meetingapp.NewGetTranscript(repo),
 		SearchTranscripts:  meetingapp.NewSearchTranscripts(repo),
 		GetActionItems:     meetingapp.NewGetActionItems(repo),
+		ListActionItems:    meetingapp.NewListActionItems(repo, writeRepo, ""),
+		ExportActionItems:  exportapp.NewExportActionItems(meetingapp.NewListActionItems(repo, writeRepo, "")),
 		GetMeetingStats:    meetingapp.NewGetMeetingStats(repo),
 		AddNote:            annotationapp.NewAddNote(noteRepo, repo, dispatcher),
-		ListNotes:          annotationapp.NewListNotes(noteRepo),
+		UpdateNote:         annotationapp.NewUpdateNote(noteRepo, dispatcher),
+		ListNotes:          annotationapp.NewListNotes(noteRepo, nil),
 		DeleteNote:         annotationapp.NewDeleteNote(noteRepo, dispatcher),
 		CompleteActionItem: meetingapp.NewCompleteActionItem(repo, writeRepo, dispatcher),
 		UpdateActionItem:   meetingapp.NewUpdateActionItem(repo, writeRepo, dispatcher),
 		ExportEmbeddings:   embeddingapp.NewExportEmbeddings(repo, noteRepo),
+		Outbox:             &mockOutbox{},
+		GetMeetingHistory:  meetingapp.NewGetMeetingHistory(&mockEventHistory{}),
 	}, noteRepo, writeRepo
 }
 
+// mockEventHistory implements domain.EventHistory with canned entries.
+type mockEventHistory struct {
+	entries []domain.HistoryEntry
+}
+
+func (m *mockEventHistory) MeetingHistory(_ context.Context, _ domain.MeetingID, _ int) ([]domain.HistoryEntry, error) {
+	return m.entries, nil
+}
+
+// mockOutbox implements outbox.Inspector with canned stats.
+type mockOutbox struct {
+	stats []outbox.TypeStats
+}
+
+func (m *mockOutbox) List(string, int) ([]outbox.Entry, error) { return nil, nil }
+func (m *mockOutbox) Get(string) (*outbox.Entry, error)        { return nil, outbox.ErrEntryNotFound }
+func (m *mockOutbox) Retry(string) error                       { return nil }
+func (m *mockOutbox) RetryAllFailed() (int, error)             { return 0, nil }
+func (m *mockOutbox) Purge(string, time.Time) (int, error)     { return 0, nil }
+func (m *mockOutbox) Stats() ([]outbox.TypeStats, error)       { return m.stats, nil }
+
 func newTestServer(repo *mockRepo) *mcpiface.Server {
 	opts, _, _ := testDeps(repo)
 	return mcpiface.NewServer("acai", "test", opts)
 }
-